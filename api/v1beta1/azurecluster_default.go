@@ -39,8 +39,22 @@ const (
 	DefaultAzureBastionSubnetRole = SubnetBastion
 	// DefaultInternalLBIPAddress is the default internal load balancer ip address.
 	DefaultInternalLBIPAddress = "10.0.0.100"
+	// DefaultAPIServerProbeRequestPath is the default request path of the API server load balancer health probe.
+	DefaultAPIServerProbeRequestPath = "/readyz"
+	// DefaultAPIServerProbeIntervalInSeconds is the default interval of the API server load balancer health probe.
+	DefaultAPIServerProbeIntervalInSeconds = 15
+	// DefaultAPIServerProbeNumberOfProbes is the default number of failed API server load balancer health probes
+	// after which a control plane machine is taken out of rotation.
+	DefaultAPIServerProbeNumberOfProbes = 4
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
 	DefaultOutboundRuleIdleTimeoutInMinutes = 4
+	// DefaultPublicIPPrefixLength is the default length of the public IP prefix created by CAPZ.
+	DefaultPublicIPPrefixLength = 28
+	// UserDefinedRoutingRouteName is the name of the route that sends egress traffic from the control plane and
+	// node subnets to the next hop when the cluster uses user-defined routing.
+	UserDefinedRoutingRouteName = "default-egress"
+	// DefaultAPIServerDNSTTL is the default time to live in seconds of the API server records in a public DNS zone.
+	DefaultAPIServerDNSTTL = 300
 	// DefaultAzureCloud is the public cloud that will be used by most users.
 	DefaultAzureCloud = "AzurePublicCloud"
 )
@@ -59,6 +73,29 @@ func (c *AzureCluster) setNetworkSpecDefaults() {
 	c.setAPIServerLBDefaults()
 	c.SetNodeOutboundLBDefaults()
 	c.SetControlPlaneOutboundLBDefaults()
+	c.setPublicIPPrefixDefaults()
+	c.setAPIServerDNSDefaults()
+}
+
+func (c *AzureCluster) setPublicIPPrefixDefaults() {
+	prefix := c.Spec.NetworkSpec.PublicIPPrefix
+	if prefix == nil || prefix.ID != "" {
+		return
+	}
+
+	if prefix.Name == "" {
+		prefix.Name = generatePublicIPPrefixName(c.ObjectMeta.Name)
+	}
+	if prefix.PrefixLength == nil {
+		prefix.PrefixLength = pointer.Int32(DefaultPublicIPPrefixLength)
+	}
+}
+
+func (c *AzureCluster) setAPIServerDNSDefaults() {
+	dns := c.Spec.NetworkSpec.APIServerDNS
+	if dns != nil && dns.TTL == nil {
+		dns.TTL = pointer.Int64(DefaultAPIServerDNSTTL)
+	}
 }
 
 func (c *AzureCluster) setResourceGroupDefault() {
@@ -101,6 +138,11 @@ func (c *AzureCluster) setSubnetDefaults() {
 	}
 	cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults()
 
+	// With user-defined routing or custom routes, the control plane subnet also needs a route table.
+	if (c.Spec.NetworkSpec.IsUserDefinedRouting() || len(cpSubnet.RouteTable.Routes) > 0) && cpSubnet.RouteTable.Name == "" {
+		cpSubnet.RouteTable.Name = generateControlPlaneRouteTableName(c.ObjectMeta.Name)
+	}
+
 	c.Spec.NetworkSpec.UpdateControlPlaneSubnet(cpSubnet)
 
 	var nodeSubnetFound bool
@@ -125,12 +167,14 @@ func (c *AzureCluster) setSubnetDefaults() {
 			subnet.RouteTable.Name = generateNodeRouteTableName(c.ObjectMeta.Name)
 		}
 
-		if !subnet.IsIPv6Enabled() {
+		if !subnet.IsIPv6Enabled() && !c.Spec.NetworkSpec.IsUserDefinedRouting() {
 			// NAT gateway supports the use of IPv4 public IP addresses for outbound connectivity.
 			// So default use the NAT gateway for outbound traffic in IPv4 cluster instead of loadbalancer.
 			if subnet.NatGateway.Name == "" {
 				subnet.NatGateway.Name = withIndex(generateNatGatewayName(c.ObjectMeta.Name), nodeSubnetCounter)
 			}
+		}
+		if subnet.IsNatGatewayEnabled() {
 			if subnet.NatGateway.NatGatewayIP.Name == "" {
 				subnet.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(subnet.NatGateway.Name)
 			}
@@ -152,11 +196,13 @@ func (c *AzureCluster) setSubnetDefaults() {
 			RouteTable: RouteTable{
 				Name: generateNodeRouteTableName(c.ObjectMeta.Name),
 			},
-			NatGateway: NatGateway{
+		}
+		if !c.Spec.NetworkSpec.IsUserDefinedRouting() {
+			nodeSubnet.NatGateway = NatGateway{
 				NatGatewayClassSpec: NatGatewayClassSpec{
 					Name: generateNatGatewayName(c.ObjectMeta.Name),
 				},
-			},
+			}
 		}
 		c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, nodeSubnet)
 	}
@@ -173,6 +219,10 @@ func (c *AzureCluster) setVnetPeeringDefaults() {
 func (c *AzureCluster) setAPIServerLBDefaults() {
 	lb := &c.Spec.NetworkSpec.APIServerLB
 
+	// A public API server load balancer would provide outbound connectivity, so user-defined routing defaults to an internal one.
+	if lb.Type == "" && c.Spec.NetworkSpec.IsUserDefinedRouting() {
+		lb.Type = Internal
+	}
 	lb.LoadBalancerClassSpec.setAPIServerLBDefaults()
 
 	if lb.Type == Public {
@@ -189,6 +239,14 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 				},
 			}
 		}
+		if lb.InternalLB != nil {
+			if lb.InternalLB.Name == "" {
+				lb.InternalLB.Name = generateInternalLBName(c.ObjectMeta.Name)
+			}
+			if lb.InternalLB.PrivateIPAddress == "" {
+				lb.InternalLB.PrivateIPAddress = DefaultInternalLBIPAddress
+			}
+		}
 	} else if lb.Type == Internal {
 		if lb.Name == "" {
 			lb.Name = generateInternalLBName(c.ObjectMeta.Name)
@@ -203,29 +261,66 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 				},
 			}
 		}
+		c.setPrivateLinkServiceDefaults()
+	}
+
+	if lb.HealthProbe != nil {
+		if lb.HealthProbe.RequestPath == "" {
+			lb.HealthProbe.RequestPath = DefaultAPIServerProbeRequestPath
+		}
+		if lb.HealthProbe.IntervalInSeconds == nil {
+			lb.HealthProbe.IntervalInSeconds = pointer.Int32(DefaultAPIServerProbeIntervalInSeconds)
+		}
+		if lb.HealthProbe.NumberOfProbes == nil {
+			lb.HealthProbe.NumberOfProbes = pointer.Int32(DefaultAPIServerProbeNumberOfProbes)
+		}
+	}
+	for i, rule := range lb.AdditionalRules {
+		if rule.Protocol == "" {
+			lb.AdditionalRules[i].Protocol = LoadBalancingRuleProtocolTCP
+		}
+		if rule.BackendPort == 0 {
+			lb.AdditionalRules[i].BackendPort = rule.FrontendPort
+		}
 	}
 	c.SetAPIServerLBBackendPoolNameDefault()
 }
 
+func (c *AzureCluster) setPrivateLinkServiceDefaults() {
+	lb := &c.Spec.NetworkSpec.APIServerLB
+	pls := lb.PrivateLinkService
+	if pls == nil {
+		return
+	}
+
+	if pls.Name == "" {
+		pls.Name = generatePrivateLinkServiceName(lb.Name)
+	}
+	if pls.NATSubnetName == "" {
+		if subnet, err := c.Spec.NetworkSpec.GetControlPlaneSubnet(); err == nil {
+			pls.NATSubnetName = subnet.Name
+		}
+	}
+	if len(pls.NATIPConfigurations) == 0 {
+		pls.NATIPConfigurations = []PrivateLinkServiceNATIPConfiguration{
+			{
+				Name: withIndex(generatePrivateLinkServiceNATIPConfigName(pls.Name), 0),
+			},
+		}
+	}
+}
+
 // SetNodeOutboundLBDefaults sets the default values for the NodeOutboundLB.
 func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	if c.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.NetworkSpec.IsUserDefinedRouting() {
 			return
 		}
 
-		var needsOutboundLB bool
-		for _, subnet := range c.Spec.NetworkSpec.Subnets {
-			if subnet.Role == SubnetNode && subnet.IsIPv6Enabled() {
-				needsOutboundLB = true
-				break
-			}
-		}
-
 		// If we don't default the outbound LB when there are some subnets with NAT gateway,
 		// and some without, those without wouldn't have outbound traffic. So taking the
 		// safer route, we configure the outbound LB in that scenario.
-		if !needsOutboundLB {
+		if !hasIPv6NodeSubnet(c.Spec.NetworkSpec.Subnets) {
 			return
 		}
 
@@ -243,10 +338,37 @@ func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 		lb.FrontendIPsCount = pointer.Int32(1)
 	}
 
-	c.setOutboundLBFrontendIPs(lb, generateNodeOutboundIPName)
+	if lb.SNATPortsPerNode != nil {
+		c.appendOutboundLBFrontendIPs(lb, generateNodeOutboundIPName)
+	} else {
+		c.setOutboundLBFrontendIPs(lb, generateNodeOutboundIPName)
+	}
+
+	// The IPv6 addresses of dual-stack nodes egress through an IPv6 frontend IP. It is only defaulted when the cluster is
+	// created, as the machines of an existing cluster would never join the IPv6 backend pool of the load balancer.
+	if len(lb.IPv6FrontendIPs) == 0 && c.CreationTimestamp.IsZero() && hasIPv6NodeSubnet(c.Spec.NetworkSpec.Subnets) {
+		lb.IPv6FrontendIPs = []FrontendIP{
+			{
+				Name: generateIPv6FrontendIPConfigName(lb.Name),
+				PublicIP: &PublicIPSpec{
+					Name: generateNodeOutboundIPv6Name(c.ObjectMeta.Name),
+				},
+			},
+		}
+	}
 	c.SetNodeOutboundLBBackendPoolNameDefault()
 }
 
+// hasIPv6NodeSubnet returns true if one of the node subnets is dual-stack.
+func hasIPv6NodeSubnet(subnets Subnets) bool {
+	for _, subnet := range subnets {
+		if subnet.Role == SubnetNode && subnet.IsIPv6Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
 // SetControlPlaneOutboundLBDefaults sets the default values for the control plane's outbound LB.
 func (c *AzureCluster) SetControlPlaneOutboundLBDefaults() {
 	lb := c.Spec.NetworkSpec.ControlPlaneOutboundLB
@@ -325,6 +447,24 @@ func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePu
 	}
 }
 
+// appendOutboundLBFrontendIPs adds frontend IPs to the load balancer until it has FrontendIPsCount of them.
+// The existing frontend IPs are kept, so the frontend IPs of an automatically sized load balancer don't change as it grows.
+func (c *AzureCluster) appendOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePublicIPName func(string) string) {
+	for i := len(lb.FrontendIPs); i < int(*lb.FrontendIPsCount); i++ {
+		frontendIP := FrontendIP{
+			Name: generateFrontendIPConfigName(lb.Name),
+			PublicIP: &PublicIPSpec{
+				Name: generatePublicIPName(c.ObjectMeta.Name),
+			},
+		}
+		if i > 0 {
+			frontendIP.Name = withIndex(frontendIP.Name, i+1)
+			frontendIP.PublicIP.Name = withIndex(frontendIP.PublicIP.Name, i+1)
+		}
+		lb.FrontendIPs = append(lb.FrontendIPs, frontendIP)
+	}
+}
+
 func (c *AzureCluster) setBastionDefaults() {
 	if c.Spec.BastionSpec.AzureBastion != nil {
 		if c.Spec.BastionSpec.AzureBastion.Name == "" {
@@ -434,6 +574,11 @@ func generateNodeRouteTableName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-routetable")
 }
 
+// generateControlPlaneRouteTableName generates a control plane route table name, based on the cluster name.
+func generateControlPlaneRouteTableName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "controlplane-routetable")
+}
+
 // generateInternalLBName generates a internal load balancer name, based on the cluster name.
 func generateInternalLBName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "internal-lb")
@@ -454,16 +599,41 @@ func generatePublicIPName(clusterName string) string {
 	return fmt.Sprintf("pip-%s-apiserver", clusterName)
 }
 
+// generatePublicIPPrefixName generates a public IP prefix name, based on the cluster name.
+func generatePublicIPPrefixName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "pip-prefix")
+}
+
 // generateFrontendIPConfigName generates a load balancer frontend IP config name.
 func generateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
 }
 
+// generatePrivateLinkServiceName generates a private link service name, based on the load balancer name.
+func generatePrivateLinkServiceName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "pls")
+}
+
+// generatePrivateLinkServiceNATIPConfigName generates a private link service NAT IP configuration name.
+func generatePrivateLinkServiceNATIPConfigName(plsName string) string {
+	return fmt.Sprintf("%s-%s", plsName, "natip")
+}
+
 // generateNodeOutboundIPName generates a public IP name, based on the cluster name.
 func generateNodeOutboundIPName(clusterName string) string {
 	return fmt.Sprintf("pip-%s-node-outbound", clusterName)
 }
 
+// generateNodeOutboundIPv6Name generates an IPv6 public IP name, based on the cluster name.
+func generateNodeOutboundIPv6Name(clusterName string) string {
+	return fmt.Sprintf("pip-%s-node-outbound-ipv6", clusterName)
+}
+
+// generateIPv6FrontendIPConfigName generates a load balancer IPv6 frontend IP config name.
+func generateIPv6FrontendIPConfigName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "frontEnd-ipv6")
+}
+
 // generateControlPlaneOutboundIPName generates a public IP name, based on the cluster name.
 func generateControlPlaneOutboundIPName(clusterName string) string {
 	return fmt.Sprintf("pip-%s-controlplane-outbound", clusterName)
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -309,6 +310,108 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no subnets with user-defined routing",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: (*OutboundType)(pointer.String(string(OutboundTypeUserDefinedRouting))),
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: (*OutboundType)(pointer.String(string(OutboundTypeUserDefinedRouting))),
+						},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-controlplane-routetable"},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{DefaultNodeSubnetCIDR},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "node subnet with a prefix length",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:         SubnetNode,
+									Name:         "my-node-subnet",
+									PrefixLength: pointer.Int32(24),
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:         SubnetNode,
+									Name:         "my-node-subnet",
+									PrefixLength: pointer.Int32(24),
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+								NatGateway: NatGateway{
+									NatGatewayIP: PublicIPSpec{Name: "pip-cluster-test-node-natgw-1"},
+									NatGatewayClassSpec: NatGatewayClassSpec{
+										Name: "cluster-test-node-natgw-1",
+									},
+								},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "subnets with custom attributes",
 			cluster: &AzureCluster{
@@ -634,6 +737,72 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dual-stack subnet with a NAT gateway",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       "control-plane",
+									CIDRBlocks: []string{"10.0.0.0/16"},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"10.1.0.0/16", "2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								NatGateway: NatGateway{
+									NatGatewayClassSpec: NatGatewayClassSpec{Name: "cluster-test-node-natgw"},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{"10.0.0.0/16"},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{"10.1.0.0/16", "2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+								NatGateway: NatGateway{
+									NatGatewayIP: PublicIPSpec{
+										Name: "pip-cluster-test-node-natgw",
+									},
+									NatGatewayClassSpec: NatGatewayClassSpec{Name: "cluster-test-node-natgw"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "subnets with custom security group",
 			cluster: &AzureCluster{
@@ -864,6 +1033,103 @@ func TestVnetPeeringDefaults(t *testing.T) {
 	}
 }
 
+func TestPublicIPPrefixDefaults(t *testing.T) {
+	cases := []struct {
+		name   string
+		prefix *PublicIPPrefix
+		output *PublicIPPrefix
+	}{
+		{
+			name:   "no public IP prefix",
+			prefix: nil,
+			output: nil,
+		},
+		{
+			name:   "public IP prefix created by CAPZ",
+			prefix: &PublicIPPrefix{},
+			output: &PublicIPPrefix{Name: "cluster-test-pip-prefix", PrefixLength: pointer.Int32(28)},
+		},
+		{
+			name:   "public IP prefix created by CAPZ with custom name and length",
+			prefix: &PublicIPPrefix{Name: "my-prefix", PrefixLength: pointer.Int32(30)},
+			output: &PublicIPPrefix{Name: "my-prefix", PrefixLength: pointer.Int32(30)},
+		},
+		{
+			name:   "pre-existing public IP prefix",
+			prefix: &PublicIPPrefix{ID: "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress"},
+			output: &PublicIPPrefix{ID: "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress"},
+		},
+	}
+
+	for _, c := range cases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						PublicIPPrefix: tc.prefix,
+					},
+				},
+			}
+			cluster.setPublicIPPrefixDefaults()
+			if !reflect.DeepEqual(cluster.Spec.NetworkSpec.PublicIPPrefix, tc.output) {
+				expected, _ := json.MarshalIndent(tc.output, "", "\t")
+				actual, _ := json.MarshalIndent(cluster.Spec.NetworkSpec.PublicIPPrefix, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestAPIServerDNSDefaults(t *testing.T) {
+	zoneID := "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com"
+	cases := []struct {
+		name   string
+		dns    *APIServerDNS
+		output *APIServerDNS
+	}{
+		{
+			name:   "no API server DNS",
+			dns:    nil,
+			output: nil,
+		},
+		{
+			name:   "default TTL",
+			dns:    &APIServerDNS{ZoneID: zoneID, RecordName: "api"},
+			output: &APIServerDNS{ZoneID: zoneID, RecordName: "api", TTL: pointer.Int64(300)},
+		},
+		{
+			name:   "custom TTL",
+			dns:    &APIServerDNS{ZoneID: zoneID, RecordName: "api", TTL: pointer.Int64(60)},
+			output: &APIServerDNS{ZoneID: zoneID, RecordName: "api", TTL: pointer.Int64(60)},
+		},
+	}
+
+	for _, c := range cases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerDNS: tc.dns,
+					},
+				},
+			}
+			cluster.setAPIServerDNSDefaults()
+			if !reflect.DeepEqual(cluster.Spec.NetworkSpec.APIServerDNS, tc.output) {
+				expected, _ := json.MarshalIndent(tc.output, "", "\t")
+				actual, _ := json.MarshalIndent(cluster.Spec.NetworkSpec.APIServerDNS, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
 func TestAPIServerLBDefaults(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -956,20 +1222,15 @@ func TestAPIServerLBDefaults(t *testing.T) {
 			},
 		},
 		{
-			name: "with custom backend pool name",
+			name: "user-defined routing defaults to an internal lb",
 			cluster: &AzureCluster{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster-test",
 				},
 				Spec: AzureClusterSpec{
 					NetworkSpec: NetworkSpec{
-						APIServerLB: LoadBalancerSpec{
-							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								Type: Internal,
-							},
-							BackendPool: BackendPool{
-								Name: "custom-backend-pool",
-							},
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: (*OutboundType)(pointer.String(string(OutboundTypeUserDefinedRouting))),
 						},
 					},
 				},
@@ -980,6 +1241,9 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 				Spec: AzureClusterSpec{
 					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: (*OutboundType)(pointer.String(string(OutboundTypeUserDefinedRouting))),
+						},
 						APIServerLB: LoadBalancerSpec{
 							FrontendIPs: []FrontendIP{
 								{
@@ -990,7 +1254,7 @@ func TestAPIServerLBDefaults(t *testing.T) {
 								},
 							},
 							BackendPool: BackendPool{
-								Name: "custom-backend-pool",
+								Name: "cluster-test-internal-lb-backendPool",
 							},
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
 								SKU:                  SKUStandard,
@@ -1003,50 +1267,238 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for _, c := range cases {
-		tc := c
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-			tc.cluster.setAPIServerLBDefaults()
-			if !reflect.DeepEqual(tc.cluster, tc.output) {
-				expected, _ := json.MarshalIndent(tc.output, "", "\t")
-				actual, _ := json.MarshalIndent(tc.cluster, "", "\t")
-				t.Errorf("Expected %s, got %s", string(expected), string(actual))
-			}
-		})
-	}
-}
-
-func TestAzureEnviromentDefault(t *testing.T) {
-	cases := map[string]struct {
-		cluster *AzureCluster
-		output  *AzureCluster
-	}{
-		"default empty azure env": {
+		{
+			name: "internal lb with private link service",
 			cluster: &AzureCluster{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
-				Spec: AzureClusterSpec{},
-			},
-			output: &AzureCluster{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
+					Name: "cluster-test",
 				},
 				Spec: AzureClusterSpec{
-					AzureClusterClassSpec: AzureClusterClassSpec{
-						AzureEnvironment: DefaultAzureCloud,
-					},
-				},
-			},
-		},
-		"azure env set to AzurePublicCloud": {
-			cluster: &AzureCluster{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetControlPlane,
+									Name: "cluster-test-controlplane-subnet",
+								},
+							},
+						},
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+							PrivateLinkService: &PrivateLinkService{
+								VisibilitySubscriptions: []string{"*"},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetControlPlane,
+									Name: "cluster-test-controlplane-subnet",
+								},
+							},
+						},
+						APIServerLB: LoadBalancerSpec{
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-internal-lb-frontEnd",
+									FrontendIPClass: FrontendIPClass{
+										PrivateIPAddress: DefaultInternalLBIPAddress,
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "cluster-test-internal-lb-backendPool",
+							},
+							PrivateLinkService: &PrivateLinkService{
+								Name:          "cluster-test-internal-lb-pls",
+								NATSubnetName: "cluster-test-controlplane-subnet",
+								NATIPConfigurations: []PrivateLinkServiceNATIPConfiguration{
+									{
+										Name: "cluster-test-internal-lb-pls-natip-0",
+									},
+								},
+								VisibilitySubscriptions: []string{"*"},
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Internal,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							Name: "cluster-test-internal-lb",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with custom backend pool name",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+							BackendPool: BackendPool{
+								Name: "custom-backend-pool",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-internal-lb-frontEnd",
+									FrontendIPClass: FrontendIPClass{
+										PrivateIPAddress: DefaultInternalLBIPAddress,
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "custom-backend-pool",
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Internal,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							Name: "cluster-test-internal-lb",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "public lb with an internal lb, additional rules and a health probe",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							HealthProbe: &LoadBalancerHealthProbe{
+								IntervalInSeconds: pointer.Int32(5),
+							},
+							AdditionalRules: []LoadBalancingRule{
+								{Name: "konnectivity", FrontendPort: 8132},
+								{Name: "registration", Protocol: LoadBalancingRuleProtocolUDP, FrontendPort: 9345, BackendPort: 9346},
+							},
+							InternalLB: &InternalLoadBalancer{},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							Name: "cluster-test-public-lb",
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-public-lb-frontEnd",
+									PublicIP: &PublicIPSpec{
+										Name:    "pip-cluster-test-apiserver",
+										DNSName: "",
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "cluster-test-public-lb-backendPool",
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							HealthProbe: &LoadBalancerHealthProbe{
+								RequestPath:       DefaultAPIServerProbeRequestPath,
+								IntervalInSeconds: pointer.Int32(5),
+								NumberOfProbes:    pointer.Int32(DefaultAPIServerProbeNumberOfProbes),
+							},
+							AdditionalRules: []LoadBalancingRule{
+								{Name: "konnectivity", Protocol: LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8132},
+								{Name: "registration", Protocol: LoadBalancingRuleProtocolUDP, FrontendPort: 9345, BackendPort: 9346},
+							},
+							InternalLB: &InternalLoadBalancer{
+								Name: "cluster-test-internal-lb",
+								FrontendIPClass: FrontendIPClass{
+									PrivateIPAddress: DefaultInternalLBIPAddress,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cluster.setAPIServerLBDefaults()
+			if !reflect.DeepEqual(tc.cluster, tc.output) {
+				expected, _ := json.MarshalIndent(tc.output, "", "\t")
+				actual, _ := json.MarshalIndent(tc.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestAzureEnviromentDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"default empty azure env": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						AzureEnvironment: DefaultAzureCloud,
+					},
+				},
+			},
+		},
+		"azure env set to AzurePublicCloud": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
 				Spec: AzureClusterSpec{
 					AzureClusterClassSpec: AzureClusterClassSpec{
 						AzureEnvironment: DefaultAzureCloud,
@@ -1206,6 +1658,196 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster-test",
 				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetControlPlane,
+									Name: "control-plane-subnet",
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+						},
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Public,
+							},
+						},
+						NodeOutboundLB: &LoadBalancerSpec{
+							Name: "cluster-test",
+							FrontendIPs: []FrontendIP{{
+								Name: "cluster-test-frontEnd",
+								PublicIP: &PublicIPSpec{
+									Name: "pip-cluster-test-node-outbound",
+								},
+							}},
+							BackendPool: BackendPool{
+								Name: "cluster-test-outboundBackendPool",
+							},
+							FrontendIPsCount: pointer.Int32(1),
+							IPv6FrontendIPs: []FrontendIP{{
+								Name: "cluster-test-frontEnd-ipv6",
+								PublicIP: &PublicIPSpec{
+									Name: "pip-cluster-test-node-outbound-ipv6",
+								},
+							}},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IPv6 enabled on a node subnet with a NAT gateway",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetControlPlane,
+									Name: "control-plane-subnet",
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								NatGateway: NatGateway{
+									NatGatewayClassSpec: NatGatewayClassSpec{
+										Name: "cluster-test-node-natgw",
+									},
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetControlPlane,
+									Name: "control-plane-subnet",
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								NatGateway: NatGateway{
+									NatGatewayClassSpec: NatGatewayClassSpec{
+										Name: "cluster-test-node-natgw",
+									},
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+						},
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Public,
+							},
+						},
+						NodeOutboundLB: &LoadBalancerSpec{
+							Name: "cluster-test",
+							FrontendIPs: []FrontendIP{{
+								Name: "cluster-test-frontEnd",
+								PublicIP: &PublicIPSpec{
+									Name: "pip-cluster-test-node-outbound",
+								},
+							}},
+							BackendPool: BackendPool{
+								Name: "cluster-test-outboundBackendPool",
+							},
+							FrontendIPsCount: pointer.Int32(1),
+							IPv6FrontendIPs: []FrontendIP{{
+								Name: "cluster-test-frontEnd-ipv6",
+								PublicIP: &PublicIPSpec{
+									Name: "pip-cluster-test-node-outbound-ipv6",
+								},
+							}},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IPv6 enabled on an existing cluster",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "cluster-test",
+					CreationTimestamp: metav1.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetControlPlane,
+									Name: "control-plane-subnet",
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{},
+								RouteTable:    RouteTable{},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "cluster-test",
+					CreationTimestamp: metav1.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
 				Spec: AzureClusterSpec{
 					NetworkSpec: NetworkSpec{
 						Subnets: Subnets{
@@ -1343,6 +1985,12 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 								Name: "cluster-test-outboundBackendPool",
 							},
 							FrontendIPsCount: pointer.Int32(1),
+							IPv6FrontendIPs: []FrontendIP{{
+								Name: "cluster-test-frontEnd-ipv6",
+								PublicIP: &PublicIPSpec{
+									Name: "pip-cluster-test-node-outbound-ipv6",
+								},
+							}},
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
 								SKU:                  SKUStandard,
 								Type:                 Public,
@@ -1557,6 +2205,12 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 								Name: "cluster-test-outboundBackendPool",
 							},
 							FrontendIPsCount: pointer.Int32(1),
+							IPv6FrontendIPs: []FrontendIP{{
+								Name: "cluster-test-frontEnd-ipv6",
+								PublicIP: &PublicIPSpec{
+									Name: "pip-cluster-test-node-outbound-ipv6",
+								},
+							}},
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
 								SKU:                  SKUStandard,
 								Type:                 Public,
@@ -1656,6 +2310,73 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "NodeOutboundLB with automatically sized SNAT ports keeps its existing frontend IPs",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						NodeOutboundLB: &LoadBalancerSpec{
+							Name: "cluster-test",
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-frontEnd",
+									PublicIP: &PublicIPSpec{
+										Name: "pip-cluster-test-node-outbound",
+									},
+								},
+							},
+							FrontendIPsCount: pointer.Int32(2),
+							SNATPortsPerNode: pointer.Int32(1024),
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Public,
+							},
+						},
+						NodeOutboundLB: &LoadBalancerSpec{
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-frontEnd",
+									PublicIP: &PublicIPSpec{
+										Name: "pip-cluster-test-node-outbound",
+									},
+								},
+								{
+									Name: "cluster-test-frontEnd-2",
+									PublicIP: &PublicIPSpec{
+										Name: "pip-cluster-test-node-outbound-2",
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "cluster-test-outboundBackendPool",
+							},
+							FrontendIPsCount: pointer.Int32(2),
+							SNATPortsPerNode: pointer.Int32(1024),
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							Name: "cluster-test",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ensure that existing lb names are not overwritten",
 			cluster: &AzureCluster{
@@ -74,6 +74,24 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// PrivateLinkService is the observed state of the private link service fronting the API server load balancer.
+	// +optional
+	PrivateLinkService *PrivateLinkServiceStatus `json:"privateLinkService,omitempty"`
+
+	// PublicIPPrefix is the observed state of the public IP prefix the cluster's public IPs are allocated from.
+	// +optional
+	PublicIPPrefix *PublicIPPrefixStatus `json:"publicIPPrefix,omitempty"`
+
+	// NodeOutboundSNAT is the SNAT port allocation of the node outbound load balancer when it is sized automatically.
+	// +optional
+	NodeOutboundSNAT *SNATPortsStatus `json:"nodeOutboundSNAT,omitempty"`
+
+	// VnetPeerings is the observed state of the virtual network peerings, in both directions.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VnetPeerings []VnetPeeringStatus `json:"vnetPeerings,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,8 +19,10 @@ package v1beta1
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"reflect"
 	"regexp"
+	"strings"
 
 	valid "github.com/asaskevich/govalidator"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -60,6 +62,27 @@ const (
 	privateEndpointRegex = `^[-\w\._]+$`
 	// resource ID Pattern.
 	resourceIDPattern = `(?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	applicationSecurityGroupRegex = `^[a-zA-Z0-9]([-\w\.]{0,78}\w)?$`
+	// routeRegex is the regex used to validate the name of a route.
+	// Route names must be 1-80 characters, start with an alphanumeric character and end with an alphanumeric character or an underscore.
+	routeRegex = `^[a-zA-Z0-9]([-\w\.]{0,78}\w)?$`
+	// Public IP prefix resource ID pattern.
+	publicIPPrefixIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/publicIPPrefixes/[^/]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	publicIPPrefixRegex = `^[-\w\._]+$`
+	// DDoS protection plan resource ID pattern.
+	ddosProtectionPlanIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/ddosProtectionPlans/[^/]+$`
+	// Public DNS zone resource ID pattern.
+	dnsZoneIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/dnszones/[^/]+$`
+	// private DNS zone resource ID Pattern.
+	privateDNSZoneIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/privateDnsZones/[^/]+$`
+	// Storage account resource ID pattern.
+	storageAccountIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Storage/storageAccounts/[^/]+$`
+	// Log Analytics workspace resource ID pattern.
+	logAnalyticsWorkspaceIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.OperationalInsights/workspaces/[^/]+$`
+	// Name of a record set relative to its DNS zone.
+	dnsRecordNameRegex = `^[a-zA-Z0-9_]([-a-zA-Z0-9_]{0,61}[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([-a-zA-Z0-9_]{0,61}[a-zA-Z0-9_])?)*$`
 )
 
 var (
@@ -90,6 +113,11 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
 
+	allErrs = append(allErrs, validatePublicIPPrefix(c.Spec.NetworkSpec.PublicIPPrefix, oldNetworkSpec.PublicIPPrefix, c.Spec.SubscriptionID,
+		field.NewPath("spec").Child("networkSpec").Child("publicIPPrefix"))...)
+
+	allErrs = append(allErrs, validateSharedVnet(c.Spec.NetworkSpec.Vnet, c.Spec.ResourceGroup, field.NewPath("spec").Child("networkSpec").Child("vnet"))...)
+
 	var oldCloudProviderConfigOverrides *CloudProviderConfigOverrides
 	if old != nil {
 		oldCloudProviderConfigOverrides = old.Spec.CloudProviderConfigOverrides
@@ -102,9 +130,7 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ExtendedLocation"), "can be set only if the EdgeZone feature flag is enabled"))
 	}
 
-	if err := validateBastionSpec(c.Spec.BastionSpec, field.NewPath("spec").Child("azureBastion").Child("bastionSpec")); err != nil {
-		allErrs = append(allErrs, err)
-	}
+	allErrs = append(allErrs, validateBastionSpec(c.Spec.BastionSpec, field.NewPath("spec").Child("azureBastion").Child("bastionSpec"))...)
 
 	return allErrs
 }
@@ -128,12 +154,43 @@ func (c *AzureCluster) validateClusterName() field.ErrorList {
 }
 
 // validateBastionSpec validates a BastionSpec.
-func validateBastionSpec(bastionSpec BastionSpec, fldPath *field.Path) *field.Error {
-	if bastionSpec.AzureBastion != nil && bastionSpec.AzureBastion.Sku != StandardBastionHostSku && bastionSpec.AzureBastion.EnableTunneling {
-		return field.Invalid(fldPath.Child("sku"), bastionSpec.AzureBastion.Sku,
-			"sku must be Standard if tunneling is enabled")
+func validateBastionSpec(bastionSpec BastionSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	bastion := bastionSpec.AzureBastion
+	if bastion == nil {
+		return allErrs
 	}
-	return nil
+
+	allErrs = append(allErrs, validatePublicIPZones(bastion.PublicIP.Zones, fldPath.Child("publicIP", "zones"))...)
+
+	if bastion.Sku == StandardBastionHostSku {
+		return allErrs
+	}
+
+	// The following features are only available with the Standard SKU.
+	if bastion.EnableTunneling {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if tunneling is enabled"))
+	}
+	if bastion.ScaleUnits != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if scale units are set"))
+	}
+	if bastion.EnableIPConnect {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if IP connect is enabled"))
+	}
+	if bastion.EnableShareableLink {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if shareable links are enabled"))
+	}
+	if bastion.EnableFileCopy {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if file copy is enabled"))
+	}
+
+	return allErrs
 }
 
 // validateNetworkSpec validates a NetworkSpec.
@@ -155,6 +212,8 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 		allErrs = append(allErrs, validateVnetPeerings(networkSpec.Vnet.Peerings, fldPath.Child("peerings"))...)
 	}
 
+	allErrs = append(allErrs, validateVnetSettings(networkSpec.Vnet.VnetClassSpec, fldPath.Child("vnet"))...)
+
 	var cidrBlocks []string
 	controlPlaneSubnet, err := networkSpec.GetControlPlaneSubnet()
 	if err != nil {
@@ -165,27 +224,312 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 	allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
 
-	var needOutboundLB bool
-	for _, subnet := range networkSpec.Subnets {
-		if subnet.Role == SubnetNode && subnet.IsIPv6Enabled() {
-			needOutboundLB = true
-			break
-		}
-	}
-	if needOutboundLB {
+	if hasIPv6NodeSubnet(networkSpec.Subnets) {
 		allErrs = append(allErrs, validateNodeOutboundLB(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
 	}
 
+	allErrs = append(allErrs, validateDualStackSubnets(networkSpec, old, fldPath.Child("subnets"))...)
+
 	allErrs = append(allErrs, validateControlPlaneOutboundLB(networkSpec.ControlPlaneOutboundLB, networkSpec.APIServerLB, fldPath.Child("controlPlaneOutboundLB"))...)
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validatePrivateDNSZoneID(networkSpec.PrivateDNSZoneID, networkSpec.PrivateDNSZoneName, fldPath.Child("privateDNSZoneID"))...)
+
+	allErrs = append(allErrs, validatePrivateDNSNodeRecords(networkSpec.PrivateDNSNodeRecords, networkSpec.PrivateDNSZoneID, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSNodeRecords"))...)
+
+	allErrs = append(allErrs, validateUserDefinedRouting(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validatePrivateLinkService(networkSpec, old, fldPath.Child("apiServerLB", "privateLinkService"))...)
+
+	allErrs = append(allErrs, validateAPIServerDNS(networkSpec.APIServerDNS, networkSpec.APIServerLB, fldPath.Child("apiServerDNS"))...)
+
+	allErrs = append(allErrs, validateNSGFlowLogs(networkSpec.FlowLogs, fldPath.Child("flowLogs"))...)
+
+	allErrs = append(allErrs, validateSubnetZones(networkSpec.Subnets, old.Subnets, fldPath.Child("subnets"))...)
+
+	allErrs = append(allErrs, validateNetworkPublicIPZones(networkSpec, old, fldPath)...)
+
+	allErrs = append(allErrs, validateRouteTables(networkSpec, fldPath.Child("subnets"))...)
+
+	allErrs = append(allErrs, validateApplicationSecurityGroups(networkSpec.ApplicationSecurityGroups, fldPath.Child("applicationSecurityGroups"))...)
+	for i, subnet := range networkSpec.Subnets {
+		for j, rule := range subnet.SecurityGroup.SecurityRules {
+			allErrs = append(allErrs, validateSecurityRuleApplicationSecurityGroups(rule, networkSpec.ApplicationSecurityGroups,
+				fldPath.Child("subnets").Index(i).Child("securityGroup", "securityRules").Index(j))...)
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs
 }
 
+// validateUserDefinedRouting validates that user-defined routing is configured with a next hop and
+// that no CAPZ-managed outbound connectivity conflicts with it.
+func validateUserDefinedRouting(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !networkSpec.IsUserDefinedRouting() {
+		if networkSpec.UserDefinedRouting != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("userDefinedRouting"), "can only be set when outboundType is userDefinedRouting"))
+		}
+		return allErrs
+	}
+
+	if networkSpec.UserDefinedRouting == nil || networkSpec.UserDefinedRouting.NextHopIPAddress == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("userDefinedRouting", "nextHopIPAddress"), "is required when outboundType is userDefinedRouting"))
+	} else if ip := net.ParseIP(networkSpec.UserDefinedRouting.NextHopIPAddress); ip == nil || ip.To4() == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("userDefinedRouting", "nextHopIPAddress"), networkSpec.UserDefinedRouting.NextHopIPAddress, "must be a valid IPv4 address"))
+	}
+
+	if networkSpec.APIServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiServerLB", "type"), networkSpec.APIServerLB.Type, "must be Internal when outboundType is userDefinedRouting"))
+	}
+	if networkSpec.NodeOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB"), "cannot be set when outboundType is userDefinedRouting"))
+	}
+	if networkSpec.ControlPlaneOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneOutboundLB"), "cannot be set when outboundType is userDefinedRouting"))
+	}
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("natGateway"), "cannot be set when outboundType is userDefinedRouting"))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePublicIPPrefix validates the public IP prefix the cluster's public IPs are allocated from.
+func validatePublicIPPrefix(prefix *PublicIPPrefix, old *PublicIPPrefix, subscriptionID string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if prefix == nil {
+		return allErrs
+	}
+
+	if old != nil && !reflect.DeepEqual(prefix, old) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "public IP prefix should not be modified once set"))
+	}
+
+	switch {
+	case prefix.ID != "" && prefix.Name != "":
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "cannot be set together with id"))
+	case prefix.ID != "":
+		if success, _ := regexp.MatchString(publicIPPrefixIDPattern, prefix.ID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), prefix.ID,
+				fmt.Sprintf("public IP prefix ID doesn't match regex %s", publicIPPrefixIDPattern)))
+		} else if subscriptionID != "" && !strings.EqualFold(strings.Split(prefix.ID, "/")[2], subscriptionID) {
+			// Public IPs can only be allocated from a prefix in their own subscription.
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), prefix.ID, "public IP prefix must be in the subscription of the cluster"))
+		}
+		if prefix.PrefixLength != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("prefixLength"), "cannot be set together with id"))
+		}
+	case prefix.Name != "":
+		if success, _ := regexp.MatchString(publicIPPrefixRegex, prefix.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), prefix.Name,
+				fmt.Sprintf("name of public IP prefix doesn't match regex %s", publicIPPrefixRegex)))
+		}
+	default:
+		allErrs = append(allErrs, field.Required(fldPath, "one of id or name must be set"))
+	}
+
+	return allErrs
+}
+
+// validateAPIServerDNS validates the API server records of a public DNS zone.
+// "@" is accepted as the API server record name to use the apex of the zone, but not as a CNAME name.
+func validateAPIServerDNS(dns *APIServerDNS, lb LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if dns == nil {
+		return allErrs
+	}
+
+	if lb.Type != Public {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can only be set for a public API server load balancer"))
+	}
+
+	if success, _ := regexp.MatchString(dnsZoneIDPattern, dns.ZoneID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("zoneID"), dns.ZoneID,
+			fmt.Sprintf("DNS zone ID doesn't match regex %s", dnsZoneIDPattern)))
+	}
+
+	if success, _ := regexp.MatchString(dnsRecordNameRegex, dns.RecordName); dns.RecordName != "@" && !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("recordName"), dns.RecordName,
+			fmt.Sprintf("record name doesn't match regex %s", dnsRecordNameRegex)))
+	}
+
+	names := map[string]bool{strings.ToLower(dns.RecordName): true}
+	for i, name := range dns.CNAMERecordNames {
+		if success, _ := regexp.MatchString(dnsRecordNameRegex, name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cnameRecordNames").Index(i), name,
+				fmt.Sprintf("record name doesn't match regex %s", dnsRecordNameRegex)))
+		}
+		if names[strings.ToLower(name)] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("cnameRecordNames").Index(i), name))
+		}
+		names[strings.ToLower(name)] = true
+	}
+
+	return allErrs
+}
+
+// validateNSGFlowLogs validates the flow logs of the cluster's network security groups.
+func validateNSGFlowLogs(flowLogs *NSGFlowLogs, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if flowLogs == nil {
+		return allErrs
+	}
+
+	if success, _ := regexp.MatchString(storageAccountIDPattern, flowLogs.StorageAccountID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountID"), flowLogs.StorageAccountID,
+			fmt.Sprintf("storage account ID doesn't match regex %s", storageAccountIDPattern)))
+	}
+
+	if analytics := flowLogs.TrafficAnalytics; analytics != nil {
+		if success, _ := regexp.MatchString(logAnalyticsWorkspaceIDPattern, analytics.WorkspaceResourceID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("trafficAnalytics", "workspaceResourceID"), analytics.WorkspaceResourceID,
+				fmt.Sprintf("Log Analytics workspace ID doesn't match regex %s", logAnalyticsWorkspaceIDPattern)))
+		}
+		if !valid.IsUUID(analytics.WorkspaceID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("trafficAnalytics", "workspaceID"), analytics.WorkspaceID,
+				"workspace ID must be a GUID"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateApplicationSecurityGroups validates the names of the cluster's application security groups.
+func validateApplicationSecurityGroups(asgs []ApplicationSecurityGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := make(map[string]bool, len(asgs))
+	for i, asg := range asgs {
+		if success, _ := regexp.MatchString(applicationSecurityGroupRegex, asg.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), asg.Name,
+				fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex)))
+		}
+		if names[asg.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), asg.Name))
+		}
+		names[asg.Name] = true
+	}
+
+	return allErrs
+}
+
+// validateSecurityRuleApplicationSecurityGroups validates that a security rule only references application security groups
+// of the cluster, and does not combine them with an address prefix on the same side of the rule.
+func validateSecurityRuleApplicationSecurityGroups(rule SecurityRule, asgs []ApplicationSecurityGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if rule.Source != nil && len(rule.SourceApplicationSecurityGroups) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sourceApplicationSecurityGroups"), "cannot be set together with source"))
+	}
+	if rule.Destination != nil && len(rule.DestinationApplicationSecurityGroups) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("destinationApplicationSecurityGroups"), "cannot be set together with destination"))
+	}
+
+	names := make(map[string]bool, len(asgs))
+	for _, asg := range asgs {
+		names[asg.Name] = true
+	}
+	for i, name := range rule.SourceApplicationSecurityGroups {
+		if !names[name] {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("sourceApplicationSecurityGroups").Index(i), name))
+		}
+	}
+	for i, name := range rule.DestinationApplicationSecurityGroups {
+		if !names[name] {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("destinationApplicationSecurityGroups").Index(i), name))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePrivateLinkService validates the private link service fronting the API server load balancer.
+func validatePrivateLinkService(networkSpec NetworkSpec, old NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	pls := networkSpec.APIServerLB.PrivateLinkService
+	if pls == nil {
+		return allErrs
+	}
+
+	if networkSpec.APIServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can only be set when the API server load balancer type is Internal"))
+	}
+
+	if oldPLS := old.APIServerLB.PrivateLinkService; oldPLS != nil && oldPLS.Name != "" && oldPLS.Name != pls.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "private link service name should not be modified after AzureCluster creation."))
+	}
+
+	var natSubnetCIDRs []string
+	if pls.NATSubnetName != "" {
+		var found bool
+		for _, subnet := range networkSpec.Subnets {
+			if subnet.Name == pls.NATSubnetName {
+				natSubnetCIDRs = subnet.CIDRBlocks
+				found = true
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("natSubnetName"), pls.NATSubnetName, "must be the name of a subnet of the cluster"))
+		}
+	}
+
+	names := make(map[string]bool, len(pls.NATIPConfigurations))
+	for i, ipConfig := range pls.NATIPConfigurations {
+		if ipConfig.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("natIPConfigurations").Index(i).Child("name"), "name is required"))
+		} else if names[ipConfig.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("natIPConfigurations").Index(i).Child("name"), ipConfig.Name))
+		}
+		names[ipConfig.Name] = true
+
+		if ipConfig.PrivateIPAddress == "" {
+			continue
+		}
+		ip := net.ParseIP(ipConfig.PrivateIPAddress)
+		if ip == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("natIPConfigurations").Index(i).Child("privateIPAddress"), ipConfig.PrivateIPAddress, "must be a valid IP address"))
+			continue
+		}
+		if len(natSubnetCIDRs) > 0 && !cidrsContain(natSubnetCIDRs, ip) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("natIPConfigurations").Index(i).Child("privateIPAddress"), ipConfig.PrivateIPAddress,
+				fmt.Sprintf("must be in the NAT subnet range (%s)", natSubnetCIDRs)))
+		}
+	}
+
+	// Azure only auto-approves connections from subscriptions the service is visible to.
+	visible := make(map[string]bool, len(pls.VisibilitySubscriptions))
+	for _, sub := range pls.VisibilitySubscriptions {
+		visible[sub] = true
+	}
+	for i, sub := range pls.AutoApprovalSubscriptions {
+		if !visible[sub] && !visible["*"] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoApprovalSubscriptions").Index(i), sub, "must also be listed in visibilitySubscriptions"))
+		}
+	}
+
+	return allErrs
+}
+
+// cidrsContain returns true if any of the CIDR blocks contains the IP address.
+func cidrsContain(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if _, subnet, err := net.ParseCIDR(cidr); err == nil && subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // validateResourceGroup validates a ResourceGroup.
 func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.MatchString(resourceGroupRegex, resourceGroup); !success {
@@ -227,6 +571,10 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
 
+		if subnet.NeedsCIDRAllocation() {
+			allErrs = append(allErrs, validateSubnetPrefixLengths(subnet.SubnetClassSpec, vnet.CIDRBlocks, fldPath.Index(i))...)
+		}
+
 		if len(subnet.ServiceEndpoints) > 0 {
 			allErrs = append(allErrs, validateServiceEndpoints(subnet.ServiceEndpoints, fldPath.Index(i).Child("serviceEndpoints"))...)
 		}
@@ -234,6 +582,8 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 		if len(subnet.PrivateEndpoints) > 0 {
 			allErrs = append(allErrs, validatePrivateEndpoints(subnet.PrivateEndpoints, subnet.CIDRBlocks, fldPath.Index(i).Child("privateEndpoints"))...)
 		}
+
+		allErrs = append(allErrs, validateSubnetStaticIPPool(subnet.StaticIPPool, subnet.CIDRBlocks, fldPath.Index(i).Child("staticIPPool"))...)
 	}
 	for k, v := range requiredSubnetRoles {
 		if !v {
@@ -244,6 +594,264 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 	return allErrs
 }
 
+// validateSubnetStaticIPPool validates the static IP pool of a subnet, which must be a range of addresses of the subnet.
+func validateSubnetStaticIPPool(pool *PrivateIPAddressRange, cidrs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if pool == nil {
+		return allErrs
+	}
+
+	start, startErr := netip.ParseAddr(pool.Start)
+	if startErr != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("start"), pool.Start, "must be a valid IP address"))
+	}
+	end, endErr := netip.ParseAddr(pool.End)
+	if endErr != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), pool.End, "must be a valid IP address"))
+	}
+	if startErr != nil || endErr != nil {
+		return allErrs
+	}
+
+	if start.Is4() != end.Is4() || end.Less(start) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), pool.End, "must be an address of the same family as start and not lower than it"))
+		return allErrs
+	}
+
+	// The CIDR blocks of the subnet may not be known yet when they are allocated automatically.
+	if len(cidrs) == 0 {
+		return allErrs
+	}
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(start) && prefix.Contains(end) {
+			// Azure reserves the first four addresses and the last address of each subnet.
+			first, last := prefix.Masked().Addr(), lastAddr(prefix)
+			for i := 0; i < 3; i++ {
+				first = first.Next()
+			}
+			if !first.Less(start) || !end.Less(last) {
+				allErrs = append(allErrs, field.Invalid(fldPath, pool,
+					fmt.Sprintf("must not include the first four and the last addresses of the subnet CIDR block %s, which are reserved by Azure", cidr)))
+			}
+			return allErrs
+		}
+	}
+	allErrs = append(allErrs, field.Invalid(fldPath, pool, fmt.Sprintf("must be a range of addresses of one of the subnet CIDR blocks (%s)", cidrs)))
+	return allErrs
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr()
+	bytes := addr.AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+	last, _ := netip.AddrFromSlice(bytes)
+	return last
+}
+
+// validateRouteTables validates the routes of the route tables of the subnets.
+// The routes of a route table shared by several subnets are merged, so they must not conflict.
+func validateRouteTables(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	tableRoutes := make(map[string]map[string]Route)
+	for i, subnet := range networkSpec.Subnets {
+		routesPath := fldPath.Index(i).Child("routeTable", "routes")
+		allErrs = append(allErrs, validateRoutes(subnet.RouteTable.Routes, routesPath)...)
+
+		if tableRoutes[subnet.RouteTable.Name] == nil {
+			tableRoutes[subnet.RouteTable.Name] = make(map[string]Route)
+		}
+		subnetRoutes := make(map[string]Route, len(subnet.RouteTable.Routes))
+		for j, route := range subnet.RouteTable.Routes {
+			if networkSpec.IsUserDefinedRouting() && route.Name == UserDefinedRoutingRouteName {
+				allErrs = append(allErrs, field.Invalid(routesPath.Index(j).Child("name"), route.Name,
+					"name is reserved for the default route of user-defined routing"))
+			}
+			if other, ok := tableRoutes[subnet.RouteTable.Name][route.Name]; ok && other != route {
+				allErrs = append(allErrs, field.Invalid(routesPath.Index(j), route,
+					fmt.Sprintf("conflicts with route %s of another subnet using route table %s", route.Name, subnet.RouteTable.Name)))
+			}
+			subnetRoutes[route.Name] = route
+		}
+		// Routes are only compared across subnets, duplicates within a subnet are reported by validateRoutes.
+		for name, route := range subnetRoutes {
+			if _, ok := tableRoutes[subnet.RouteTable.Name][name]; !ok {
+				tableRoutes[subnet.RouteTable.Name][name] = route
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateRoutes validates the routes of a route table.
+func validateRoutes(routes []Route, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(routes))
+	for i, route := range routes {
+		if success, _ := regexp.MatchString(routeRegex, route.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), route.Name,
+				fmt.Sprintf("name of route doesn't match regex %s", routeRegex)))
+		}
+		if names[route.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), route.Name))
+		}
+		names[route.Name] = true
+
+		if _, _, err := net.ParseCIDR(route.AddressPrefix); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("addressPrefix"), route.AddressPrefix, "must be a valid CIDR"))
+		}
+
+		switch {
+		case route.NextHopType == RouteNextHopVirtualAppliance && route.NextHopIPAddress == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("nextHopIPAddress"),
+				"nextHopIPAddress is required when nextHopType is VirtualAppliance"))
+		case route.NextHopType == RouteNextHopVirtualAppliance && net.ParseIP(route.NextHopIPAddress) == nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("nextHopIPAddress"), route.NextHopIPAddress, "must be a valid IP address"))
+		case route.NextHopType != RouteNextHopVirtualAppliance && route.NextHopIPAddress != "":
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("nextHopIPAddress"),
+				"nextHopIPAddress can only be set when nextHopType is VirtualAppliance"))
+		}
+	}
+	return allErrs
+}
+
+// validateSubnetZones validates the availability zones of the subnets.
+func validateSubnetZones(subnets Subnets, oldSubnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	natGatewayZones := make(map[string]string, len(subnets))
+	for i, subnet := range subnets {
+		if err := validateSubnetZone(subnet.SubnetClassSpec, fldPath.Index(i).Child("zone")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		for _, oldSubnet := range oldSubnets {
+			if oldSubnet.Name == subnet.Name && oldSubnet.Zone != subnet.Zone {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("zone"), "the zone of a subnet cannot be changed"))
+			}
+		}
+		if !subnet.IsNatGatewayEnabled() {
+			continue
+		}
+		if zone, ok := natGatewayZones[subnet.NatGateway.Name]; ok && zone != subnet.Zone {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("natGateway", "name"), subnet.NatGateway.Name,
+				"a NAT gateway cannot be shared by subnets in different zones"))
+		}
+		natGatewayZones[subnet.NatGateway.Name] = subnet.Zone
+		if err := validateNatGatewayIPZones(subnet, fldPath.Index(i).Child("natGateway", "ip", "zones")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// validateNatGatewayIPZones validates that the public IP of the NAT gateway of a subnet is in the zone of the NAT gateway.
+func validateNatGatewayIPZones(subnet SubnetSpec, fldPath *field.Path) *field.Error {
+	zones := subnet.NatGateway.NatGatewayIP.Zones
+	if zones == nil {
+		return nil
+	}
+	if subnet.Zone != "" && (zones.Placement != PublicIPZonal || zones.Zone != subnet.Zone) {
+		return field.Invalid(fldPath, zones,
+			fmt.Sprintf("the public IP of a NAT gateway in zone %s must be Zonal in the same zone", subnet.Zone))
+	}
+	if subnet.Zone == "" && zones.Placement == PublicIPZonal {
+		return field.Invalid(fldPath, zones,
+			"the public IP of a NAT gateway without a zone cannot be Zonal, set the zone of the subnet instead")
+	}
+	return nil
+}
+
+// publicIPField is a public IP of the network spec along with its field path.
+type publicIPField struct {
+	publicIP *PublicIPSpec
+	fldPath  *field.Path
+}
+
+// networkPublicIPs returns the public IPs of the load balancers and NAT gateways of a network spec.
+func networkPublicIPs(networkSpec NetworkSpec, fldPath *field.Path) []publicIPField {
+	var publicIPs []publicIPField
+	lbs := []struct {
+		field string
+		lb    *LoadBalancerSpec
+	}{
+		{field: "apiServerLB", lb: &networkSpec.APIServerLB},
+		{field: "nodeOutboundLB", lb: networkSpec.NodeOutboundLB},
+		{field: "controlPlaneOutboundLB", lb: networkSpec.ControlPlaneOutboundLB},
+	}
+	for _, lb := range lbs {
+		if lb.lb == nil {
+			continue
+		}
+		for i := range lb.lb.FrontendIPs {
+			if lb.lb.FrontendIPs[i].PublicIP != nil {
+				publicIPs = append(publicIPs, publicIPField{
+					publicIP: lb.lb.FrontendIPs[i].PublicIP,
+					fldPath:  fldPath.Child(lb.field, "frontendIPs").Index(i).Child("publicIP"),
+				})
+			}
+		}
+		for i := range lb.lb.IPv6FrontendIPs {
+			if lb.lb.IPv6FrontendIPs[i].PublicIP != nil {
+				publicIPs = append(publicIPs, publicIPField{
+					publicIP: lb.lb.IPv6FrontendIPs[i].PublicIP,
+					fldPath:  fldPath.Child(lb.field, "ipv6FrontendIPs").Index(i).Child("publicIP"),
+				})
+			}
+		}
+	}
+	for i := range networkSpec.Subnets {
+		if networkSpec.Subnets[i].IsNatGatewayEnabled() {
+			publicIPs = append(publicIPs, publicIPField{
+				publicIP: &networkSpec.Subnets[i].NatGateway.NatGatewayIP,
+				fldPath:  fldPath.Child("subnets").Index(i).Child("natGateway", "ip"),
+			})
+		}
+	}
+	return publicIPs
+}
+
+// validateNetworkPublicIPZones validates the availability zones of the public IPs of a network spec,
+// which cannot be changed once the public IPs are created.
+func validateNetworkPublicIPZones(networkSpec NetworkSpec, old NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	oldPublicIPs := make(map[string]*PublicIPSpec)
+	for _, ip := range networkPublicIPs(old, fldPath) {
+		oldPublicIPs[ip.publicIP.Name] = ip.publicIP
+	}
+	for _, ip := range networkPublicIPs(networkSpec, fldPath) {
+		allErrs = append(allErrs, validatePublicIPZones(ip.publicIP.Zones, ip.fldPath.Child("zones"))...)
+		if oldIP, ok := oldPublicIPs[ip.publicIP.Name]; ok && !reflect.DeepEqual(oldIP.Zones, ip.publicIP.Zones) {
+			allErrs = append(allErrs, field.Forbidden(ip.fldPath.Child("zones"), "the zones of a public IP cannot be changed"))
+		}
+	}
+	return allErrs
+}
+
+// validatePublicIPZones validates the availability zones of a public IP.
+func validatePublicIPZones(zones *PublicIPZones, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if zones == nil {
+		return allErrs
+	}
+	if zones.Placement == PublicIPZonal && zones.Zone == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("zone"), "zone is required for a Zonal public IP"))
+	}
+	if zones.Placement != PublicIPZonal && zones.Zone != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zone"), "zone can only be set for a Zonal public IP"))
+	}
+	return allErrs
+}
+
+// validateSubnetZone validates that only node subnets are pinned to an availability zone.
+func validateSubnetZone(subnet SubnetClassSpec, fldPath *field.Path) *field.Error {
+	if subnet.Zone != "" && subnet.Role != SubnetNode {
+		return field.Forbidden(fldPath, "only subnets with role node can be pinned to a zone")
+	}
+	return nil
+}
+
 // validateSubnetName validates the Name of a Subnet.
 func validateSubnetName(name string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.Match(subnetRegex, []byte(name)); !success {
@@ -253,6 +861,36 @@ func validateSubnetName(name string, fldPath *field.Path) *field.Error {
 	return nil
 }
 
+// validateSubnetPrefixLengths validates that the virtual network's address space can fit
+// the CIDR blocks to allocate to a Subnet.
+func validateSubnetPrefixLengths(subnet SubnetClassSpec, vnetCidrBlocks []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	fits := func(prefixLength int32, ipv6 bool) bool {
+		for _, vnetCidr := range vnetCidrBlocks {
+			_, vnetNw, err := net.ParseCIDR(vnetCidr)
+			if err != nil || (vnetNw.IP.To4() == nil) != ipv6 {
+				continue
+			}
+			if ones, _ := vnetNw.Mask.Size(); int32(ones) <= prefixLength {
+				return true
+			}
+		}
+		return false
+	}
+
+	if subnet.PrefixLength != nil && !fits(*subnet.PrefixLength, false) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefixLength"), *subnet.PrefixLength,
+			fmt.Sprintf("no IPv4 address space of the virtual network (%s) can fit a subnet of this size", vnetCidrBlocks)))
+	}
+	if subnet.IPv6PrefixLength != nil && !fits(*subnet.IPv6PrefixLength, true) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ipv6PrefixLength"), *subnet.IPv6PrefixLength,
+			fmt.Sprintf("no IPv6 address space of the virtual network (%s) can fit a subnet of this size", vnetCidrBlocks)))
+	}
+
+	return allErrs
+}
+
 // validateSubnetCIDR validates the CIDR blocks of a Subnet.
 func validateSubnetCIDR(subnetCidrBlocks []string, vnetCidrBlocks []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -297,6 +935,36 @@ func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorL
 	return allErrs
 }
 
+// validateVnetSettings validates the DDoS protection plan and the DNS servers of a virtual network.
+func validateVnetSettings(vnet VnetClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if vnet.DDoSProtectionPlanID != "" {
+		if success, _ := regexp.MatchString(ddosProtectionPlanIDPattern, vnet.DDoSProtectionPlanID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ddosProtectionPlanID"), vnet.DDoSProtectionPlanID,
+				fmt.Sprintf("DDoS protection plan ID doesn't match regex %s", ddosProtectionPlanIDPattern)))
+		}
+	}
+
+	for i, server := range vnet.DNSServers {
+		if net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsServers").Index(i), server, "must be a valid IP address"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSharedVnet validates that a shared virtual network is not in the resource group of the cluster,
+// as the resource group is deleted with the cluster while the virtual network may still be used by other clusters.
+func validateSharedVnet(vnet VnetSpec, resourceGroup string, fldPath *field.Path) field.ErrorList {
+	if !vnet.Shared || resourceGroup == "" || !strings.EqualFold(vnet.ResourceGroup, resourceGroup) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath.Child("resourceGroup"), vnet.ResourceGroup,
+		"a shared vnet must be in another resource group than the cluster")}
+}
+
 // validateVnetPeerings validates a list of virtual network peerings.
 func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -304,6 +972,9 @@ func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.Erro
 
 	for _, peering := range peerings {
 		vnetIdentifier := peering.ResourceGroup + "/" + peering.RemoteVnetName
+		if peering.SubscriptionID != "" {
+			vnetIdentifier = peering.SubscriptionID + "/" + vnetIdentifier
+		}
 		if _, ok := vnetIdentifiers[vnetIdentifier]; ok {
 			allErrs = append(allErrs, field.Duplicate(fldPath, vnetIdentifier))
 		}
@@ -394,6 +1065,119 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 		}
 	}
 
+	allErrs = append(allErrs, validateLoadBalancerHealthProbe(lb.HealthProbe, fldPath.Child("healthProbe"))...)
+	allErrs = append(allErrs, validateAdditionalLBRules(lb.AdditionalRules, old.AdditionalRules, fldPath.Child("additionalRules"))...)
+	allErrs = append(allErrs, validateInternalLB(lb, old, cidrs, fldPath.Child("internalLB"))...)
+
+	return allErrs
+}
+
+// validateLoadBalancerHealthProbe validates the health probe of the API server load balancer.
+func validateLoadBalancerHealthProbe(probe *LoadBalancerHealthProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if probe == nil {
+		return allErrs
+	}
+	if probe.RequestPath != "" && !strings.HasPrefix(probe.RequestPath, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("requestPath"), probe.RequestPath, "request path must start with /"))
+	}
+	return allErrs
+}
+
+const (
+	// apiServerLBRuleName and apiServerLBProbeName are the names of the API server rule and probe of the API server load balancer.
+	apiServerLBRuleName  = "LBRuleHTTPS"
+	apiServerLBProbeName = "HTTPSProbe"
+	// lbRuleProbeSuffix is appended to the name of an additional load-balancing rule to name its probe.
+	lbRuleProbeSuffix = "Probe"
+)
+
+// validateAdditionalLBRules validates the additional load-balancing rules of the API server load balancer.
+// Rules can be added to an existing cluster, but not modified or removed.
+func validateAdditionalLBRules(rules []LoadBalancingRule, oldRules []LoadBalancingRule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Azure resource names are case-insensitive. The probe of a rule is named after the rule, so a rule named after the
+	// API server probe without its suffix would collide with it.
+	names := map[string]bool{
+		strings.ToLower(apiServerLBRuleName):                                         true,
+		strings.ToLower(strings.TrimSuffix(apiServerLBProbeName, lbRuleProbeSuffix)): true,
+	}
+	for i, rule := range rules {
+		name := strings.ToLower(rule.Name)
+		if names[name] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), rule.Name,
+				"must be unique and different from the names of the API server rule and probe"))
+		}
+		names[name] = true
+	}
+
+	frontendPorts := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		frontendPort := fmt.Sprintf("%s/%d", rule.Protocol, rule.FrontendPort)
+		if frontendPorts[frontendPort] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("frontendPort"), rule.FrontendPort))
+		}
+		frontendPorts[frontendPort] = true
+	}
+
+	for _, oldRule := range oldRules {
+		found := false
+		for i, rule := range rules {
+			if rule.Name != oldRule.Name {
+				continue
+			}
+			found = true
+			if !reflect.DeepEqual(rule, oldRule) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), "load-balancing rules cannot be modified after they are added"))
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("load-balancing rule %s cannot be removed", oldRule.Name)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateInternalLB validates the internal load balancer exposing the API server next to a public API server load balancer.
+func validateInternalLB(lb LoadBalancerSpec, oldLB LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	old := oldLB.InternalLB
+	internalLB := lb.InternalLB
+	if internalLB == nil {
+		if old != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "internal load balancer cannot be removed after it is added"))
+		}
+		return allErrs
+	}
+
+	// The control plane machines of an existing cluster never join the backend pool of an internal load balancer added later.
+	if old == nil && oldLB.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "internal load balancer can only be added when the cluster is created"))
+	}
+
+	if lb.Type != Public {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can only be set when the API server load balancer type is Public"))
+	}
+
+	if err := validateLoadBalancerName(internalLB.Name, fldPath.Child("name")); err != nil {
+		allErrs = append(allErrs, err)
+	} else if internalLB.Name == lb.Name {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), internalLB.Name, "must be different from the API server load balancer name"))
+	}
+
+	if internalLB.PrivateIPAddress != "" {
+		if err := validateInternalLBIPAddress(internalLB.PrivateIPAddress, cidrs, fldPath.Child("privateIP")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if old != nil && !reflect.DeepEqual(*old, *internalLB) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "internal load balancer should not be modified after it is added"))
+	}
+
 	return allErrs
 }
 
@@ -444,6 +1228,87 @@ func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserv
 			fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
 	}
 
+	allErrs = append(allErrs, validateIPv6FrontendIPs(lb.IPv6FrontendIPs, old, fldPath.Child("ipv6FrontendIPs"))...)
+
+	if lb.SNATPortsPerNode != nil {
+		if *lb.SNATPortsPerNode%8 != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("snatPortsPerNode"), *lb.SNATPortsPerNode, "must be a multiple of 8"))
+		}
+		// The frontend IPs of an automatically sized load balancer may be in use by the outbound rule, so they are never removed.
+		if old != nil && pointer.Int32Deref(lb.FrontendIPsCount, 1) < pointer.Int32Deref(old.FrontendIPsCount, 1) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPsCount"),
+				"frontend IPs count of an automatically sized node outbound load balancer cannot be decreased"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIPv6FrontendIPs validates the IPv6 frontend IPs of the node outbound LB.
+func validateIPv6FrontendIPs(frontendIPs []FrontendIP, oldLB *LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var old []FrontendIP
+	if oldLB != nil {
+		old = oldLB.IPv6FrontendIPs
+		// The machines of an existing cluster never join the IPv6 backend pool, which would leave them without IPv6 egress.
+		if len(old) == 0 && len(frontendIPs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "IPv6 frontend IPs can only be set when the node outbound load balancer is created"))
+		}
+	}
+
+	if len(frontendIPs) > MaxLoadBalancerOutboundIPs {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(frontendIPs), MaxLoadBalancerOutboundIPs))
+	}
+
+	for i, frontendIP := range frontendIPs {
+		if frontendIP.PublicIP == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("publicIP"), "an IPv6 frontend IP requires a public IP"))
+		}
+		if i < len(old) && !reflect.DeepEqual(old[i], frontendIP) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), "IPv6 frontend IPs cannot be modified after they are created"))
+		}
+	}
+
+	// The IPv6 frontend IPs are in use by the IPv6 outbound rule, so they are never removed.
+	if len(frontendIPs) < len(old) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "IPv6 frontend IPs cannot be removed after they are created"))
+	}
+
+	return allErrs
+}
+
+// validateDualStackSubnets validates that a dual-stack virtual network doesn't get single-stack node subnets,
+// whose machines wouldn't get any IPv6 address.
+func validateDualStackSubnets(networkSpec NetworkSpec, old NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var dualStackVnet bool
+	for _, cidr := range networkSpec.Vnet.CIDRBlocks {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil && ipNet.IP.To4() == nil {
+			dualStackVnet = true
+			break
+		}
+	}
+
+	for i, subnet := range networkSpec.Subnets {
+		if !dualStackVnet || subnet.Role != SubnetNode || subnet.IsIPv6Enabled() {
+			continue
+		}
+		// Existing single-stack subnets are left alone, only new subnets are required to be dual-stack.
+		var exists bool
+		for _, oldSubnet := range old.Subnets {
+			if oldSubnet.Name == subnet.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("cidrBlocks"), subnet.CIDRBlocks,
+				"a node subnet of a dual-stack virtual network must have an IPv6 CIDR block or an ipv6PrefixLength"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -487,6 +1352,36 @@ func validatePrivateDNSZoneName(privateDNSZoneName string, apiserverLBType LBTyp
 	return allErrs
 }
 
+// validatePrivateDNSZoneID validates the PrivateDNSZoneID.
+func validatePrivateDNSZoneID(privateDNSZoneID, privateDNSZoneName string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if privateDNSZoneID == "" {
+		return allErrs
+	}
+
+	if success, _ := regexp.MatchString(privateDNSZoneIDPattern, privateDNSZoneID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath, privateDNSZoneID,
+			fmt.Sprintf("private DNS zone ID doesn't match regex %s", privateDNSZoneIDPattern)))
+	}
+	if privateDNSZoneName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be set along with PrivateDNSZoneName"))
+	}
+
+	return allErrs
+}
+
+// validatePrivateDNSNodeRecords validates that the cluster has a private DNS zone for the node records.
+func validatePrivateDNSNodeRecords(privateDNSNodeRecords bool, privateDNSZoneID string, apiserverLBType LBType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if privateDNSNodeRecords && privateDNSZoneID == "" && apiserverLBType != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"PrivateDNSNodeRecords is available only if APIServerLB.Type is Internal or PrivateDNSZoneID is set"))
+	}
+
+	return allErrs
+}
+
 // validateCloudProviderConfigOverrides validates CloudProviderConfigOverrides.
 func validateCloudProviderConfigOverrides(oldConfig, newConfig *CloudProviderConfigOverrides, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -666,6 +1561,8 @@ func validatePrivateEndpoints(privateEndpointSpecs []PrivateEndpointSpec, subnet
 				allErrs = append(allErrs, err)
 			}
 		}
+
+		allErrs = append(allErrs, validatePrivateEndpointDNSZone(pe, fldPath.Index(i).Child("privateDNSZone"))...)
 	}
 
 	return allErrs
@@ -699,6 +1596,36 @@ func validatePrivateEndpointPrivateLinkServiceConnection(privateLinkServiceConne
 	return nil
 }
 
+// validatePrivateEndpointDNSZone validates the private DNS zone of a Private Endpoint.
+func validatePrivateEndpointDNSZone(pe PrivateEndpointSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	zone := pe.PrivateDNSZone
+	if zone == nil {
+		return allErrs
+	}
+
+	switch {
+	case zone.Name != "" && zone.ID != "":
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("id"), "cannot be set along with name"))
+	case zone.ID != "":
+		if success, _ := regexp.MatchString(privateDNSZoneIDPattern, zone.ID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), zone.ID,
+				fmt.Sprintf("private DNS zone ID doesn't match regex %s", privateDNSZoneIDPattern)))
+		}
+	case zone.Name != "":
+		if !valid.IsDNSName(zone.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), zone.Name,
+				"private DNS zone name can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character"))
+		}
+	case pe.GetPrivateDNSZoneName() == "":
+		allErrs = append(allErrs, field.Required(fldPath,
+			"name or id is required when the private DNS zone cannot be inferred from the group ID of the first private link service connection"))
+	}
+
+	return allErrs
+}
+
 // validatePrivateEndpointIPAddress validates a Private Endpoint IP Address.
 func validatePrivateEndpointIPAddress(address string, cidrs []string, fldPath *field.Path) *field.Error {
 	ip := net.ParseIP(address)
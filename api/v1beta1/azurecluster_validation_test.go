@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -409,6 +410,62 @@ func TestValidateVnetCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateVnetSettings(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		vnet        VnetClassSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid DDoS protection plan and DNS servers",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ddosProtectionPlans/my-plan",
+				DNSServers:           []string{"10.0.0.4", "fd00::4"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid DDoS protection plan ID",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "vnet.ddosProtectionPlanID",
+				BadValue: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				Detail:   fmt.Sprintf("DDoS protection plan ID doesn't match regex %s", ddosProtectionPlanIDPattern),
+			},
+		},
+		{
+			name: "invalid DNS server",
+			vnet: VnetClassSpec{
+				DNSServers: []string{"10.0.0.4", "my-dns"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "vnet.dnsServers[1]",
+				BadValue: "my-dns",
+				Detail:   "must be a valid IP address",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVnetSettings(testCase.vnet, field.NewPath("vnet"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestSubnetsValid(t *testing.T) {
 	g := NewWithT(t)
 
@@ -607,6 +664,51 @@ func TestValidateSubnetCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateSubnetPrefixLengths(t *testing.T) {
+	tests := []struct {
+		name           string
+		subnet         SubnetClassSpec
+		vnetCidrBlocks []string
+		wantErr        bool
+	}{
+		{
+			name:           "IPv4 prefix length fits",
+			subnet:         SubnetClassSpec{PrefixLength: pointer.Int32(24)},
+			vnetCidrBlocks: []string{"10.0.0.0/16"},
+		},
+		{
+			name:           "IPv4 prefix length larger than the virtual network",
+			subnet:         SubnetClassSpec{PrefixLength: pointer.Int32(12)},
+			vnetCidrBlocks: []string{"10.0.0.0/16"},
+			wantErr:        true,
+		},
+		{
+			name:           "IPv6 prefix length fits",
+			subnet:         SubnetClassSpec{PrefixLength: pointer.Int32(24), IPv6PrefixLength: pointer.Int32(64)},
+			vnetCidrBlocks: []string{"10.0.0.0/16", "2001:1234:5678:9a00::/56"},
+		},
+		{
+			name:           "IPv6 prefix length without IPv6 address space",
+			subnet:         SubnetClassSpec{IPv6PrefixLength: pointer.Int32(64)},
+			vnetCidrBlocks: []string{"10.0.0.0/16"},
+			wantErr:        true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateSubnetPrefixLengths(tc.subnet, tc.vnetCidrBlocks, field.NewPath("subnets").Index(0))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateSecurityRule(t *testing.T) {
 	g := NewWithT(t)
 
@@ -956,215 +1058,1379 @@ func TestPrivateDNSZoneName(t *testing.T) {
 	}
 }
 
-func TestValidateNodeOutboundLB(t *testing.T) {
-	g := NewWithT(t)
+func TestValidateUserDefinedRouting(t *testing.T) {
+	udr := OutboundTypeUserDefinedRouting
 
 	testcases := []struct {
 		name        string
-		lb          *LoadBalancerSpec
-		old         *LoadBalancerSpec
-		apiServerLB LoadBalancerSpec
-		wantErr     bool
-		expectedErr field.Error
+		network     NetworkSpec
+		expectedErr *field.Error
 	}{
 		{
-			name: "no lb for public clusters",
-			lb:   nil,
-			apiServerLB: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Public,
-				},
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueRequired",
-				Field:    "nodeOutboundLB",
-				BadValue: nil,
-				Detail:   "Node outbound load balancer cannot be nil for public clusters.",
+			name: "no outbound type",
+			network: NetworkSpec{
+				APIServerLB: createValidAPIServerLB(),
 			},
 		},
 		{
-			name: "no lb allowed for internal clusters",
-			lb:   nil,
-			apiServerLB: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
+			name: "valid user-defined routing",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType:       &udr,
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "10.2.0.4"},
 				},
+				APIServerLB: createValidAPIServerInternalLB(),
+				Subnets:     createValidSubnets(),
 			},
-			wantErr: false,
 		},
 		{
-			name: "invalid ID update",
-			lb: &LoadBalancerSpec{
-				ID: "some-id",
-			},
-			old: &LoadBalancerSpec{
-				ID: "old-id",
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueForbidden",
-				Field:    "nodeOutboundLB.id",
-				BadValue: "some-id",
-				Detail:   "Node outbound load balancer ID should not be modified after AzureCluster creation.",
+			name: "user-defined routing configured without outbound type",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "10.2.0.4"},
+				},
+				APIServerLB: createValidAPIServerLB(),
 			},
+			expectedErr: field.Forbidden(field.NewPath("spec", "networkSpec", "userDefinedRouting"), "can only be set when outboundType is userDefinedRouting"),
 		},
 		{
-			name: "invalid Name update",
-			lb: &LoadBalancerSpec{
-				Name: "some-name",
-			},
-			old: &LoadBalancerSpec{
-				Name: "old-name",
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueForbidden",
-				Field:    "nodeOutboundLB.name",
-				BadValue: "some-name",
-				Detail:   "Node outbound load balancer Name should not be modified after AzureCluster creation.",
+			name: "missing next hop",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType: &udr,
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
 			},
+			expectedErr: field.Required(field.NewPath("spec", "networkSpec", "userDefinedRouting", "nextHopIPAddress"), "is required when outboundType is userDefinedRouting"),
 		},
 		{
-			name: "invalid SKU update",
-			lb: &LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					SKU: "some-sku",
+			name: "invalid next hop",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType:       &udr,
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "fd00::4"},
 				},
+				APIServerLB: createValidAPIServerInternalLB(),
 			},
-			old: &LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					SKU: "old-sku",
+			expectedErr: field.Invalid(field.NewPath("spec", "networkSpec", "userDefinedRouting", "nextHopIPAddress"), "fd00::4", "must be a valid IPv4 address"),
+		},
+		{
+			name: "public API server load balancer",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType:       &udr,
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "10.2.0.4"},
 				},
+				APIServerLB: createValidAPIServerLB(),
 			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueForbidden",
-				Field:    "nodeOutboundLB.sku",
-				BadValue: "some-sku",
-				Detail:   "Node outbound load balancer SKU should not be modified after AzureCluster creation.",
-			},
+			expectedErr: field.Invalid(field.NewPath("spec", "networkSpec", "apiServerLB", "type"), Public, "must be Internal when outboundType is userDefinedRouting"),
 		},
 		{
-			name: "invalid FrontendIps update",
-			lb: &LoadBalancerSpec{
-				FrontendIPs: []FrontendIP{{
-					Name: "some-frontend-ip",
-				}},
-			},
-			old: &LoadBalancerSpec{
-				FrontendIPs: []FrontendIP{{
-					Name: "old-frontend-ip",
-				}},
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:  "FieldValueForbidden",
-				Field: "nodeOutboundLB.frontendIPs[0]",
-				BadValue: FrontendIP{
-					Name: "some-frontend-ip",
+			name: "node outbound load balancer",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType:       &udr,
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "10.2.0.4"},
 				},
-				Detail: "Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation.",
+				APIServerLB:    createValidAPIServerInternalLB(),
+				NodeOutboundLB: &LoadBalancerSpec{Name: "my-outbound-lb"},
 			},
+			expectedErr: field.Forbidden(field.NewPath("spec", "networkSpec", "nodeOutboundLB"), "cannot be set when outboundType is userDefinedRouting"),
 		},
 		{
-			name: "FrontendIps can update when frontendIpsCount changes",
-			lb: &LoadBalancerSpec{
-				FrontendIPs: []FrontendIP{{
-					Name: "some-frontend-ip-1",
-				}, {
-					Name: "some-frontend-ip-2",
-				}},
-				FrontendIPsCount: pointer.Int32(2),
-			},
-			old: &LoadBalancerSpec{
-				FrontendIPs: []FrontendIP{{
-					Name: "old-frontend-ip",
-				}},
-				LoadBalancerClassSpec: LoadBalancerClassSpec{},
+			name: "control plane outbound load balancer",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType:       &udr,
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "10.2.0.4"},
+				},
+				APIServerLB:            createValidAPIServerInternalLB(),
+				ControlPlaneOutboundLB: &LoadBalancerSpec{Name: "my-cp-outbound-lb"},
 			},
-			wantErr: false,
+			expectedErr: field.Forbidden(field.NewPath("spec", "networkSpec", "controlPlaneOutboundLB"), "cannot be set when outboundType is userDefinedRouting"),
 		},
 		{
-			name: "frontend ips count exceeds max value",
-			lb: &LoadBalancerSpec{
-				FrontendIPsCount: pointer.Int32(100),
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "nodeOutboundLB.frontendIPsCount",
-				BadValue: 100,
-				Detail:   "Max front end ips allowed is 16",
+			name: "NAT gateway on a subnet",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					OutboundType:       &udr,
+					UserDefinedRouting: &UserDefinedRouting{NextHopIPAddress: "10.2.0.4"},
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+				Subnets: Subnets{
+					{
+						SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"},
+						NatGateway:      NatGateway{NatGatewayClassSpec: NatGatewayClassSpec{Name: "node-natgw"}},
+					},
+				},
 			},
+			expectedErr: field.Forbidden(field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("natGateway"), "cannot be set when outboundType is userDefinedRouting"),
 		},
 	}
 
 	for _, test := range testcases {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
 			t.Parallel()
-			err := validateNodeOutboundLB(test.lb, test.old, test.apiServerLB, field.NewPath("nodeOutboundLB"))
-			if test.wantErr {
-				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			errs := validateUserDefinedRouting(test.network, field.NewPath("spec", "networkSpec"))
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
 			} else {
-				g.Expect(err).To(BeEmpty())
+				g.Expect(errs).To(BeEmpty())
 			}
 		})
 	}
 }
 
-func TestValidateControlPlaneNodeOutboundLB(t *testing.T) {
-	g := NewWithT(t)
+func TestValidatePrivateLinkService(t *testing.T) {
+	withPLS := func(lb LoadBalancerSpec, pls *PrivateLinkService) LoadBalancerSpec {
+		lb.PrivateLinkService = pls
+		return lb
+	}
+	subnets := Subnets{
+		{
+			SubnetClassSpec: SubnetClassSpec{
+				Role:       SubnetControlPlane,
+				Name:       "control-plane-subnet",
+				CIDRBlocks: []string{"10.0.0.0/16"},
+			},
+		},
+	}
+	fldPath := field.NewPath("spec", "networkSpec", "apiServerLB", "privateLinkService")
 
 	testcases := []struct {
 		name        string
-		lb          *LoadBalancerSpec
-		old         *LoadBalancerSpec
-		apiServerLB LoadBalancerSpec
-		wantErr     bool
-		expectedErr field.Error
+		network     NetworkSpec
+		old         NetworkSpec
+		expectedErr *field.Error
 	}{
 		{
-			name: "cp outbound lb cannot be set for public clusters",
-			lb:   &LoadBalancerSpec{Name: "foo"},
-			apiServerLB: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Public,
-				},
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueForbidden",
-				Field:    "controlPlaneOutboundLB",
-				BadValue: LoadBalancerSpec{Name: "foo"},
-				Detail:   "Control plane outbound load balancer cannot be set for public clusters.",
+			name: "no private link service",
+			network: NetworkSpec{
+				APIServerLB: createValidAPIServerInternalLB(),
 			},
 		},
 		{
-			name: "cp outbound lb can be set for private clusters",
-			lb:   &LoadBalancerSpec{Name: "foo"},
-			apiServerLB: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
-				},
+			name: "valid private link service",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{
+					Name:                      "my-pls",
+					NATSubnetName:             "control-plane-subnet",
+					NATIPConfigurations:       []PrivateLinkServiceNATIPConfiguration{{Name: "natip-0", PrivateIPAddress: "10.0.0.50"}, {Name: "natip-1"}},
+					VisibilitySubscriptions:   []string{"sub-1", "sub-2"},
+					AutoApprovalSubscriptions: []string{"sub-1"},
+				}),
+				Subnets: subnets,
 			},
-			wantErr: false,
 		},
 		{
-			name: "cp outbound lb can be nil for private clusters",
-			lb:   nil,
-			apiServerLB: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
-				},
+			name: "public API server load balancer",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerLB(), &PrivateLinkService{Name: "my-pls"}),
 			},
-			wantErr: false,
+			expectedErr: field.Forbidden(fldPath, "can only be set when the API server load balancer type is Internal"),
 		},
 		{
-			name: "frontend ips count exceeds max value",
-			lb: &LoadBalancerSpec{
-				FrontendIPsCount: pointer.Int32(100),
+			name: "name changed",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{Name: "my-pls"}),
+			},
+			old: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{Name: "old-pls"}),
+			},
+			expectedErr: field.Forbidden(fldPath.Child("name"), "private link service name should not be modified after AzureCluster creation."),
+		},
+		{
+			name: "unknown NAT subnet",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{Name: "my-pls", NATSubnetName: "foo"}),
+				Subnets:     subnets,
+			},
+			expectedErr: field.Invalid(fldPath.Child("natSubnetName"), "foo", "must be the name of a subnet of the cluster"),
+		},
+		{
+			name: "duplicate NAT IP configuration name",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{
+					Name:                "my-pls",
+					NATIPConfigurations: []PrivateLinkServiceNATIPConfiguration{{Name: "natip"}, {Name: "natip"}},
+				}),
+			},
+			expectedErr: field.Duplicate(fldPath.Child("natIPConfigurations").Index(1).Child("name"), "natip"),
+		},
+		{
+			name: "NAT IP address outside of the NAT subnet",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{
+					Name:                "my-pls",
+					NATSubnetName:       "control-plane-subnet",
+					NATIPConfigurations: []PrivateLinkServiceNATIPConfiguration{{Name: "natip", PrivateIPAddress: "10.1.0.4"}},
+				}),
+				Subnets: subnets,
+			},
+			expectedErr: field.Invalid(fldPath.Child("natIPConfigurations").Index(0).Child("privateIPAddress"), "10.1.0.4", "must be in the NAT subnet range ([10.0.0.0/16])"),
+		},
+		{
+			name: "auto-approval subscription not visible",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{
+					Name:                      "my-pls",
+					VisibilitySubscriptions:   []string{"sub-1"},
+					AutoApprovalSubscriptions: []string{"sub-2"},
+				}),
+			},
+			expectedErr: field.Invalid(fldPath.Child("autoApprovalSubscriptions").Index(0), "sub-2", "must also be listed in visibilitySubscriptions"),
+		},
+		{
+			name: "auto-approval subscription visible to everyone",
+			network: NetworkSpec{
+				APIServerLB: withPLS(createValidAPIServerInternalLB(), &PrivateLinkService{
+					Name:                      "my-pls",
+					VisibilitySubscriptions:   []string{"*"},
+					AutoApprovalSubscriptions: []string{"sub-2"},
+				}),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validatePrivateLinkService(test.network, test.old, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePublicIPPrefix(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "publicIPPrefix")
+	prefixID := "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress"
+
+	testcases := []struct {
+		name        string
+		prefix      *PublicIPPrefix
+		old         *PublicIPPrefix
+		expectedErr *field.Error
+	}{
+		{
+			name: "no public IP prefix",
+		},
+		{
+			name:   "public IP prefix created by CAPZ",
+			prefix: &PublicIPPrefix{Name: "my-prefix", PrefixLength: pointer.Int32(28)},
+		},
+		{
+			name:   "pre-existing public IP prefix",
+			prefix: &PublicIPPrefix{ID: prefixID},
+		},
+		{
+			name:   "public IP prefix unchanged on an existing cluster",
+			prefix: &PublicIPPrefix{ID: prefixID},
+			old:    &PublicIPPrefix{ID: prefixID},
+		},
+		{
+			name:        "public IP prefix in another subscription",
+			prefix:      &PublicIPPrefix{ID: "/subscriptions/456/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress"},
+			expectedErr: field.Invalid(fldPath.Child("id"), "/subscriptions/456/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress", "public IP prefix must be in the subscription of the cluster"),
+		},
+		{
+			name:        "public IP prefix modified",
+			prefix:      &PublicIPPrefix{Name: "my-prefix", PrefixLength: pointer.Int32(29)},
+			old:         &PublicIPPrefix{Name: "my-prefix", PrefixLength: pointer.Int32(28)},
+			expectedErr: field.Forbidden(fldPath, "public IP prefix should not be modified once set"),
+		},
+		{
+			name:        "both id and name",
+			prefix:      &PublicIPPrefix{ID: prefixID, Name: "my-prefix"},
+			expectedErr: field.Forbidden(fldPath.Child("name"), "cannot be set together with id"),
+		},
+		{
+			name:        "prefix length with id",
+			prefix:      &PublicIPPrefix{ID: prefixID, PrefixLength: pointer.Int32(28)},
+			expectedErr: field.Forbidden(fldPath.Child("prefixLength"), "cannot be set together with id"),
+		},
+		{
+			name:        "invalid id",
+			prefix:      &PublicIPPrefix{ID: "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPAddresses/egress"},
+			expectedErr: field.Invalid(fldPath.Child("id"), "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPAddresses/egress", fmt.Sprintf("public IP prefix ID doesn't match regex %s", publicIPPrefixIDPattern)),
+		},
+		{
+			name:        "neither id nor name",
+			prefix:      &PublicIPPrefix{},
+			expectedErr: field.Required(fldPath, "one of id or name must be set"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validatePublicIPPrefix(test.prefix, test.old, "123", fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAdditionalLBRules(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "apiServerLB", "additionalRules")
+	konnectivity := LoadBalancingRule{Name: "konnectivity", Protocol: LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8132}
+
+	testcases := []struct {
+		name        string
+		rules       []LoadBalancingRule
+		old         []LoadBalancingRule
+		expectedErr *field.Error
+	}{
+		{
+			name: "no additional rules",
+		},
+		{
+			name:  "rule added to an existing cluster",
+			rules: []LoadBalancingRule{konnectivity, {Name: "dns", Protocol: LoadBalancingRuleProtocolUDP, FrontendPort: 8132, BackendPort: 8132}},
+			old:   []LoadBalancingRule{konnectivity},
+		},
+		{
+			name:        "duplicate frontend port",
+			rules:       []LoadBalancingRule{konnectivity, {Name: "other", Protocol: LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8133}},
+			expectedErr: field.Duplicate(fldPath.Index(1).Child("frontendPort"), int32(8132)),
+		},
+		{
+			name:        "rule named after the API server rule",
+			rules:       []LoadBalancingRule{{Name: "lbrulehttps", Protocol: LoadBalancingRuleProtocolTCP, FrontendPort: 8443, BackendPort: 8443}},
+			expectedErr: field.Invalid(fldPath.Index(0).Child("name"), "lbrulehttps", "must be unique and different from the names of the API server rule and probe"),
+		},
+		{
+			name:        "rule whose probe would be named after the API server probe",
+			rules:       []LoadBalancingRule{{Name: "HTTPS", Protocol: LoadBalancingRuleProtocolTCP, FrontendPort: 8443, BackendPort: 8443}},
+			expectedErr: field.Invalid(fldPath.Index(0).Child("name"), "HTTPS", "must be unique and different from the names of the API server rule and probe"),
+		},
+		{
+			name:        "duplicate rule name",
+			rules:       []LoadBalancingRule{konnectivity, {Name: "Konnectivity", Protocol: LoadBalancingRuleProtocolUDP, FrontendPort: 8132, BackendPort: 8132}},
+			expectedErr: field.Invalid(fldPath.Index(1).Child("name"), "Konnectivity", "must be unique and different from the names of the API server rule and probe"),
+		},
+		{
+			name:        "rule modified",
+			rules:       []LoadBalancingRule{{Name: "konnectivity", Protocol: LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8133}},
+			old:         []LoadBalancingRule{konnectivity},
+			expectedErr: field.Forbidden(fldPath.Index(0), "load-balancing rules cannot be modified after they are added"),
+		},
+		{
+			name:        "rule removed",
+			old:         []LoadBalancingRule{konnectivity},
+			expectedErr: field.Forbidden(fldPath, "load-balancing rule konnectivity cannot be removed"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateAdditionalLBRules(test.rules, test.old, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateInternalLB(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "apiServerLB", "internalLB")
+	cidrs := []string{"10.0.0.0/16"}
+	internalLB := &InternalLoadBalancer{Name: "my-internal-lb", FrontendIPClass: FrontendIPClass{PrivateIPAddress: "10.0.0.100"}}
+	apiServerLB := func(lbType LBType, internalLB *InternalLoadBalancer) LoadBalancerSpec {
+		return LoadBalancerSpec{
+			Name:                  "my-lb",
+			InternalLB:            internalLB,
+			LoadBalancerClassSpec: LoadBalancerClassSpec{Type: lbType},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		lb          LoadBalancerSpec
+		old         LoadBalancerSpec
+		expectedErr *field.Error
+	}{
+		{
+			name: "no internal load balancer",
+			lb:   apiServerLB(Public, nil),
+		},
+		{
+			name: "internal load balancer with a public API server load balancer",
+			lb:   apiServerLB(Public, internalLB),
+		},
+		{
+			name:        "internal load balancer with an internal API server load balancer",
+			lb:          apiServerLB(Internal, internalLB),
+			expectedErr: field.Forbidden(fldPath, "can only be set when the API server load balancer type is Public"),
+		},
+		{
+			name:        "internal load balancer named after the API server load balancer",
+			lb:          apiServerLB(Public, &InternalLoadBalancer{Name: "my-lb"}),
+			expectedErr: field.Invalid(fldPath.Child("name"), "my-lb", "must be different from the API server load balancer name"),
+		},
+		{
+			name: "private IP outside of the control plane subnet",
+			lb:   apiServerLB(Public, &InternalLoadBalancer{Name: "my-internal-lb", FrontendIPClass: FrontendIPClass{PrivateIPAddress: "10.1.0.100"}}),
+			expectedErr: field.Invalid(fldPath.Child("privateIP"), "10.1.0.100",
+				fmt.Sprintf("Internal LB IP address needs to be in control plane subnet range (%s)", cidrs)),
+		},
+		{
+			name:        "internal load balancer modified",
+			lb:          apiServerLB(Public, &InternalLoadBalancer{Name: "my-internal-lb", FrontendIPClass: FrontendIPClass{PrivateIPAddress: "10.0.0.101"}}),
+			old:         apiServerLB(Public, internalLB),
+			expectedErr: field.Forbidden(fldPath, "internal load balancer should not be modified after it is added"),
+		},
+		{
+			name: "internal load balancer unchanged",
+			lb:   apiServerLB(Public, internalLB),
+			old:  apiServerLB(Public, internalLB),
+		},
+		{
+			name:        "internal load balancer added to an existing cluster",
+			lb:          apiServerLB(Public, internalLB),
+			old:         apiServerLB(Public, nil),
+			expectedErr: field.Forbidden(fldPath, "internal load balancer can only be added when the cluster is created"),
+		},
+		{
+			name:        "internal load balancer removed",
+			lb:          apiServerLB(Public, nil),
+			old:         apiServerLB(Public, internalLB),
+			expectedErr: field.Forbidden(fldPath, "internal load balancer cannot be removed after it is added"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateInternalLB(test.lb, test.old, cidrs, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateSubnetZones(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "subnets")
+	zonalSubnet := func(name, zone, natGateway string) SubnetSpec {
+		return SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{Name: name, Role: SubnetNode, Zone: zone},
+			NatGateway:      NatGateway{NatGatewayClassSpec: NatGatewayClassSpec{Name: natGateway}},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		subnets     Subnets
+		old         Subnets
+		expectedErr *field.Error
+	}{
+		{
+			name:    "subnets without zones",
+			subnets: Subnets{zonalSubnet("node-1", "", "natgw"), zonalSubnet("node-2", "", "natgw")},
+		},
+		{
+			name:    "node subnet per zone",
+			subnets: Subnets{zonalSubnet("node-1", "1", "natgw-1"), zonalSubnet("node-2", "2", "natgw-2"), zonalSubnet("node-3", "2", "natgw-2")},
+		},
+		{
+			name:    "zone set on a new subnet of an existing cluster",
+			subnets: Subnets{zonalSubnet("node-1", "", "natgw-1"), zonalSubnet("node-2", "2", "natgw-2")},
+			old:     Subnets{zonalSubnet("node-1", "", "natgw-1")},
+		},
+		{
+			name: "zone on a control plane subnet",
+			subnets: Subnets{{
+				SubnetClassSpec: SubnetClassSpec{Name: "cp", Role: SubnetControlPlane, Zone: "1"},
+			}},
+			expectedErr: field.Forbidden(fldPath.Index(0).Child("zone"), "only subnets with role node can be pinned to a zone"),
+		},
+		{
+			name:        "zone changed",
+			subnets:     Subnets{zonalSubnet("node-1", "2", "natgw-1")},
+			old:         Subnets{zonalSubnet("node-1", "1", "natgw-1")},
+			expectedErr: field.Forbidden(fldPath.Index(0).Child("zone"), "the zone of a subnet cannot be changed"),
+		},
+		{
+			name:        "NAT gateway shared across zones",
+			subnets:     Subnets{zonalSubnet("node-1", "1", "natgw"), zonalSubnet("node-2", "2", "natgw")},
+			expectedErr: field.Invalid(fldPath.Index(1).Child("natGateway", "name"), "natgw", "a NAT gateway cannot be shared by subnets in different zones"),
+		},
+		{
+			name:    "NAT gateway IP in the zone of the subnet",
+			subnets: Subnets{withNatGatewayIPZones(zonalSubnet("node-1", "1", "natgw"), &PublicIPZones{Placement: PublicIPZonal, Zone: "1"})},
+		},
+		{
+			name:    "zone-redundant NAT gateway IP of a subnet without a zone",
+			subnets: Subnets{withNatGatewayIPZones(zonalSubnet("node-1", "", "natgw"), &PublicIPZones{Placement: PublicIPZoneRedundant})},
+		},
+		{
+			name:    "NAT gateway IP in another zone than the subnet",
+			subnets: Subnets{withNatGatewayIPZones(zonalSubnet("node-1", "1", "natgw"), &PublicIPZones{Placement: PublicIPZonal, Zone: "2"})},
+			expectedErr: field.Invalid(fldPath.Index(0).Child("natGateway", "ip", "zones"), &PublicIPZones{Placement: PublicIPZonal, Zone: "2"},
+				"the public IP of a NAT gateway in zone 1 must be Zonal in the same zone"),
+		},
+		{
+			name:    "zone-redundant NAT gateway IP of a zonal subnet",
+			subnets: Subnets{withNatGatewayIPZones(zonalSubnet("node-1", "1", "natgw"), &PublicIPZones{Placement: PublicIPZoneRedundant})},
+			expectedErr: field.Invalid(fldPath.Index(0).Child("natGateway", "ip", "zones"), &PublicIPZones{Placement: PublicIPZoneRedundant},
+				"the public IP of a NAT gateway in zone 1 must be Zonal in the same zone"),
+		},
+		{
+			name:    "zonal NAT gateway IP of a subnet without a zone",
+			subnets: Subnets{withNatGatewayIPZones(zonalSubnet("node-1", "", "natgw"), &PublicIPZones{Placement: PublicIPZonal, Zone: "1"})},
+			expectedErr: field.Invalid(fldPath.Index(0).Child("natGateway", "ip", "zones"), &PublicIPZones{Placement: PublicIPZonal, Zone: "1"},
+				"the public IP of a NAT gateway without a zone cannot be Zonal, set the zone of the subnet instead"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateSubnetZones(test.subnets, test.old, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func withNatGatewayIPZones(subnet SubnetSpec, zones *PublicIPZones) SubnetSpec {
+	subnet.NatGateway.NatGatewayIP = PublicIPSpec{Name: subnet.NatGateway.Name + "-ip", Zones: zones}
+	return subnet
+}
+
+func TestValidateNetworkPublicIPZones(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec")
+	networkSpec := func(apiServerIPZones, outboundIPZones *PublicIPZones) NetworkSpec {
+		return NetworkSpec{
+			APIServerLB: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{Name: "api", PublicIP: &PublicIPSpec{Name: "api-ip", Zones: apiServerIPZones}}},
+			},
+			NodeOutboundLB: &LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{Name: "outbound-1", PublicIP: &PublicIPSpec{Name: "outbound-ip-1"}},
+					{Name: "outbound-2", PublicIP: &PublicIPSpec{Name: "outbound-ip-2", Zones: outboundIPZones}},
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name         string
+		networkSpec  NetworkSpec
+		old          NetworkSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name:        "default zones",
+			networkSpec: networkSpec(nil, nil),
+		},
+		{
+			name:        "explicit zones",
+			networkSpec: networkSpec(&PublicIPZones{Placement: PublicIPZoneRedundant}, &PublicIPZones{Placement: PublicIPZonal, Zone: "3"}),
+			old:         networkSpec(&PublicIPZones{Placement: PublicIPZoneRedundant}, &PublicIPZones{Placement: PublicIPZonal, Zone: "3"}),
+		},
+		{
+			name:        "zones of a new public IP of an existing cluster",
+			networkSpec: networkSpec(nil, &PublicIPZones{Placement: PublicIPNoZone}),
+			old: NetworkSpec{
+				APIServerLB: LoadBalancerSpec{
+					FrontendIPs: []FrontendIP{{Name: "api", PublicIP: &PublicIPSpec{Name: "api-ip"}}},
+				},
+			},
+		},
+		{
+			name:        "invalid zones",
+			networkSpec: networkSpec(&PublicIPZones{Placement: PublicIPZonal}, &PublicIPZones{Placement: PublicIPNoZone, Zone: "1"}),
+			expectedErrs: field.ErrorList{
+				field.Required(fldPath.Child("apiServerLB", "frontendIPs").Index(0).Child("publicIP", "zones", "zone"), "zone is required for a Zonal public IP"),
+				field.Forbidden(fldPath.Child("nodeOutboundLB", "frontendIPs").Index(1).Child("publicIP", "zones", "zone"), "zone can only be set for a Zonal public IP"),
+			},
+		},
+		{
+			name:        "zones changed",
+			networkSpec: networkSpec(&PublicIPZones{Placement: PublicIPNoZone}, &PublicIPZones{Placement: PublicIPZonal, Zone: "2"}),
+			old:         networkSpec(nil, &PublicIPZones{Placement: PublicIPZonal, Zone: "1"}),
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath.Child("apiServerLB", "frontendIPs").Index(0).Child("publicIP", "zones"), "the zones of a public IP cannot be changed"),
+				field.Forbidden(fldPath.Child("nodeOutboundLB", "frontendIPs").Index(1).Child("publicIP", "zones"), "the zones of a public IP cannot be changed"),
+			},
+		},
+		{
+			name: "invalid zones of a NAT gateway IP",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{{
+					SubnetClassSpec: SubnetClassSpec{Name: "node", Role: SubnetNode},
+					NatGateway: NatGateway{
+						NatGatewayClassSpec: NatGatewayClassSpec{Name: "natgw"},
+						NatGatewayIP:        PublicIPSpec{Name: "natgw-ip", Zones: &PublicIPZones{Placement: PublicIPZonal}},
+					},
+				}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Required(fldPath.Child("subnets").Index(0).Child("natGateway", "ip", "zones", "zone"), "zone is required for a Zonal public IP"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateNetworkPublicIPZones(test.networkSpec, test.old, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateRouteTables(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "subnets")
+	udr := OutboundTypeUserDefinedRouting
+	subnet := func(name, routeTable string, routes ...Route) SubnetSpec {
+		return SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{Name: name, Role: SubnetNode},
+			RouteTable:      RouteTable{Name: routeTable, Routes: routes},
+		}
+	}
+	onprem := Route{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopVirtualNetworkGateway}
+	appliance := Route{Name: "to-appliance", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopVirtualAppliance, NextHopIPAddress: "10.0.0.4"}
+
+	testcases := []struct {
+		name         string
+		networkSpec  NetworkSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no routes",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{subnet("node", "node-rt")},
+			},
+		},
+		{
+			name: "valid routes shared by subnets using the same route table",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					subnet("node-1", "node-rt", onprem, appliance),
+					subnet("node-2", "node-rt", onprem),
+				},
+			},
+		},
+		{
+			name: "invalid routes",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					subnet("node", "node-rt",
+						Route{Name: "-invalid", AddressPrefix: "10.0.0.0", NextHopType: RouteNextHopInternet},
+						Route{Name: "to-appliance", AddressPrefix: "10.1.0.0/16", NextHopType: RouteNextHopVirtualAppliance},
+						Route{Name: "to-appliance", AddressPrefix: "10.2.0.0/16", NextHopType: RouteNextHopVirtualAppliance, NextHopIPAddress: "not-an-ip"},
+						Route{Name: "to-vnet", AddressPrefix: "10.3.0.0/16", NextHopType: RouteNextHopVnetLocal, NextHopIPAddress: "10.0.0.4"},
+					),
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(0).Child("routeTable", "routes").Index(0).Child("name"), "-invalid",
+					fmt.Sprintf("name of route doesn't match regex %s", routeRegex)),
+				field.Invalid(fldPath.Index(0).Child("routeTable", "routes").Index(0).Child("addressPrefix"), "10.0.0.0", "must be a valid CIDR"),
+				field.Required(fldPath.Index(0).Child("routeTable", "routes").Index(1).Child("nextHopIPAddress"),
+					"nextHopIPAddress is required when nextHopType is VirtualAppliance"),
+				field.Duplicate(fldPath.Index(0).Child("routeTable", "routes").Index(2).Child("name"), "to-appliance"),
+				field.Invalid(fldPath.Index(0).Child("routeTable", "routes").Index(2).Child("nextHopIPAddress"), "not-an-ip", "must be a valid IP address"),
+				field.Forbidden(fldPath.Index(0).Child("routeTable", "routes").Index(3).Child("nextHopIPAddress"),
+					"nextHopIPAddress can only be set when nextHopType is VirtualAppliance"),
+			},
+		},
+		{
+			name: "conflicting routes of subnets using the same route table",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					subnet("node-1", "node-rt", onprem),
+					subnet("node-2", "node-rt", Route{Name: "to-onprem", AddressPrefix: "172.16.0.0/12", NextHopType: RouteNextHopVirtualNetworkGateway}),
+					subnet("node-3", "other-rt", Route{Name: "to-onprem", AddressPrefix: "172.16.0.0/12", NextHopType: RouteNextHopVirtualNetworkGateway}),
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(1).Child("routeTable", "routes").Index(0),
+					Route{Name: "to-onprem", AddressPrefix: "172.16.0.0/12", NextHopType: RouteNextHopVirtualNetworkGateway},
+					"conflicts with route to-onprem of another subnet using route table node-rt"),
+			},
+		},
+		{
+			name: "route name reserved for user-defined routing",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{OutboundType: &udr},
+				Subnets: Subnets{
+					subnet("node", "node-rt", Route{Name: UserDefinedRoutingRouteName, AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopInternet}),
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(0).Child("routeTable", "routes").Index(0).Child("name"), UserDefinedRoutingRouteName,
+					"name is reserved for the default route of user-defined routing"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateRouteTables(test.networkSpec, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateDualStackSubnets(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "subnets")
+	nodeSubnet := func(name string, cidrBlocks ...string) SubnetSpec {
+		return SubnetSpec{SubnetClassSpec: SubnetClassSpec{Name: name, Role: SubnetNode, CIDRBlocks: cidrBlocks}}
+	}
+	networkSpec := func(vnetCIDRBlocks []string, subnets ...SubnetSpec) NetworkSpec {
+		return NetworkSpec{
+			Vnet:    VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: vnetCIDRBlocks}},
+			Subnets: subnets,
+		}
+	}
+	dualStack := []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"}
+
+	testcases := []struct {
+		name         string
+		networkSpec  NetworkSpec
+		old          NetworkSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name:        "single-stack virtual network",
+			networkSpec: networkSpec([]string{"10.0.0.0/8"}, nodeSubnet("node", "10.1.0.0/16")),
+		},
+		{
+			name: "dual-stack virtual network with dual-stack node subnets",
+			networkSpec: networkSpec(dualStack,
+				nodeSubnet("node-1", "10.1.0.0/16", "2001:1234:5678:9a01::/64"),
+				SubnetSpec{SubnetClassSpec: SubnetClassSpec{Name: "node-2", Role: SubnetNode, PrefixLength: pointer.Int32(16), IPv6PrefixLength: pointer.Int32(64)}},
+				SubnetSpec{SubnetClassSpec: SubnetClassSpec{Name: "cp", Role: SubnetControlPlane, CIDRBlocks: []string{"10.0.0.0/16"}}},
+			),
+		},
+		{
+			name:        "single-stack node subnet added to a dual-stack virtual network",
+			networkSpec: networkSpec(dualStack, nodeSubnet("node-1", "10.1.0.0/16"), nodeSubnet("node-2", "10.2.0.0/16")),
+			old:         networkSpec(dualStack, nodeSubnet("node-1", "10.1.0.0/16")),
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Index(1).Child("cidrBlocks"), []string{"10.2.0.0/16"},
+					"a node subnet of a dual-stack virtual network must have an IPv6 CIDR block or an ipv6PrefixLength"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateDualStackSubnets(test.networkSpec, test.old, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateIPv6FrontendIPs(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "nodeOutboundLB", "ipv6FrontendIPs")
+	frontendIP := func(name, publicIPName string) FrontendIP {
+		return FrontendIP{Name: name, PublicIP: &PublicIPSpec{Name: publicIPName}}
+	}
+
+	testcases := []struct {
+		name         string
+		frontendIPs  []FrontendIP
+		old          *LoadBalancerSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name:        "new frontend IP",
+			frontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6")},
+		},
+		{
+			name:        "frontend IP added",
+			frontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6"), frontendIP("fe-ipv6-2", "pip-ipv6-2")},
+			old:         &LoadBalancerSpec{IPv6FrontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6")}},
+		},
+		{
+			name:        "frontend IP added to an existing load balancer",
+			frontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6")},
+			old:         &LoadBalancerSpec{},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath, "IPv6 frontend IPs can only be set when the node outbound load balancer is created"),
+			},
+		},
+		{
+			name:        "frontend IP without a public IP",
+			frontendIPs: []FrontendIP{{Name: "fe-ipv6"}},
+			expectedErrs: field.ErrorList{
+				field.Required(fldPath.Index(0).Child("publicIP"), "an IPv6 frontend IP requires a public IP"),
+			},
+		},
+		{
+			name:        "frontend IP modified",
+			frontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6-new")},
+			old:         &LoadBalancerSpec{IPv6FrontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6")}},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath.Index(0), "IPv6 frontend IPs cannot be modified after they are created"),
+			},
+		},
+		{
+			name:        "frontend IP removed",
+			frontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6")},
+			old:         &LoadBalancerSpec{IPv6FrontendIPs: []FrontendIP{frontendIP("fe-ipv6", "pip-ipv6"), frontendIP("fe-ipv6-2", "pip-ipv6-2")}},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath, "IPv6 frontend IPs cannot be removed after they are created"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateIPv6FrontendIPs(test.frontendIPs, test.old, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVnetPeerings(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "vnet", "peerings")
+	peering := func(subscriptionID, resourceGroup, name string) VnetPeeringSpec {
+		return VnetPeeringSpec{VnetPeeringClassSpec: VnetPeeringClassSpec{SubscriptionID: subscriptionID, ResourceGroup: resourceGroup, RemoteVnetName: name}}
+	}
+
+	testcases := []struct {
+		name        string
+		peerings    VnetPeerings
+		expectedErr *field.Error
+	}{
+		{
+			name:     "peerings with different virtual networks",
+			peerings: VnetPeerings{peering("", "rg", "vnet-1"), peering("", "rg", "vnet-2")},
+		},
+		{
+			name:     "peerings with virtual networks of the same name in different subscriptions",
+			peerings: VnetPeerings{peering("", "rg", "hub"), peering("connectivity", "rg", "hub")},
+		},
+		{
+			name:        "duplicate peering",
+			peerings:    VnetPeerings{peering("connectivity", "rg", "hub"), peering("connectivity", "rg", "hub")},
+			expectedErr: field.Duplicate(fldPath, "connectivity/rg/hub"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateVnetPeerings(test.peerings, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerDNS(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "apiServerDNS")
+	zoneID := "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com"
+	publicLB := LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}}
+
+	testcases := []struct {
+		name        string
+		dns         *APIServerDNS
+		lb          LoadBalancerSpec
+		expectedErr *field.Error
+	}{
+		{
+			name: "no API server DNS",
+			lb:   publicLB,
+		},
+		{
+			name: "valid API server DNS",
+			dns:  &APIServerDNS{ZoneID: zoneID, RecordName: "api.prod-eu1", CNAMERecordNames: []string{"kubernetes.prod-eu1"}},
+			lb:   publicLB,
+		},
+		{
+			name: "API server record at the apex of the zone",
+			dns:  &APIServerDNS{ZoneID: zoneID, RecordName: "@"},
+			lb:   publicLB,
+		},
+		{
+			name:        "private API server",
+			dns:         &APIServerDNS{ZoneID: zoneID, RecordName: "api"},
+			lb:          LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Internal}},
+			expectedErr: field.Forbidden(fldPath, "can only be set for a public API server load balancer"),
+		},
+		{
+			name:        "invalid zone ID",
+			dns:         &APIServerDNS{ZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.com", RecordName: "api"},
+			lb:          publicLB,
+			expectedErr: field.Invalid(fldPath.Child("zoneID"), "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.com", fmt.Sprintf("DNS zone ID doesn't match regex %s", dnsZoneIDPattern)),
+		},
+		{
+			name:        "invalid record name",
+			dns:         &APIServerDNS{ZoneID: zoneID, RecordName: "api..prod"},
+			lb:          publicLB,
+			expectedErr: field.Invalid(fldPath.Child("recordName"), "api..prod", fmt.Sprintf("record name doesn't match regex %s", dnsRecordNameRegex)),
+		},
+		{
+			name:        "CNAME at the apex of the zone",
+			dns:         &APIServerDNS{ZoneID: zoneID, RecordName: "api", CNAMERecordNames: []string{"@"}},
+			lb:          publicLB,
+			expectedErr: field.Invalid(fldPath.Child("cnameRecordNames").Index(0), "@", fmt.Sprintf("record name doesn't match regex %s", dnsRecordNameRegex)),
+		},
+		{
+			name:        "CNAME with the name of the API server record",
+			dns:         &APIServerDNS{ZoneID: zoneID, RecordName: "api", CNAMERecordNames: []string{"API"}},
+			lb:          publicLB,
+			expectedErr: field.Duplicate(fldPath.Child("cnameRecordNames").Index(0), "API"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateAPIServerDNS(test.dns, test.lb, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateSharedVnet(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "vnet")
+
+	testcases := []struct {
+		name        string
+		vnet        VnetSpec
+		expectedErr *field.Error
+	}{
+		{
+			name: "vnet not shared in the cluster resource group",
+			vnet: VnetSpec{ResourceGroup: "cluster-rg", Name: "vnet"},
+		},
+		{
+			name: "shared vnet in another resource group",
+			vnet: VnetSpec{ResourceGroup: "network-rg", Name: "vnet", VnetClassSpec: VnetClassSpec{Shared: true}},
+		},
+		{
+			name:        "shared vnet in the cluster resource group",
+			vnet:        VnetSpec{ResourceGroup: "Cluster-RG", Name: "vnet", VnetClassSpec: VnetClassSpec{Shared: true}},
+			expectedErr: field.Invalid(fldPath.Child("resourceGroup"), "Cluster-RG", "a shared vnet must be in another resource group than the cluster"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateSharedVnet(test.vnet, "cluster-rg", fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateApplicationSecurityGroups(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "applicationSecurityGroups")
+
+	testcases := []struct {
+		name        string
+		asgs        []ApplicationSecurityGroup
+		expectedErr *field.Error
+	}{
+		{
+			name: "valid application security groups",
+			asgs: []ApplicationSecurityGroup{{Name: "control-plane", Role: SubnetControlPlane}, {Name: "node", Role: SubnetNode}, {Name: "web"}},
+		},
+		{
+			name:        "invalid name",
+			asgs:        []ApplicationSecurityGroup{{Name: "-web"}},
+			expectedErr: field.Invalid(fldPath.Index(0).Child("name"), "-web", fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex)),
+		},
+		{
+			name:        "duplicate name",
+			asgs:        []ApplicationSecurityGroup{{Name: "web"}, {Name: "web", Role: SubnetNode}},
+			expectedErr: field.Duplicate(fldPath.Index(1).Child("name"), "web"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateApplicationSecurityGroups(test.asgs, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateSecurityRuleApplicationSecurityGroups(t *testing.T) {
+	asgs := []ApplicationSecurityGroup{{Name: "control-plane", Role: SubnetControlPlane}, {Name: "node", Role: SubnetNode}}
+	fldPath := field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("securityGroup", "securityRules").Index(0)
+
+	testcases := []struct {
+		name        string
+		rule        SecurityRule
+		expectedErr *field.Error
+	}{
+		{
+			name: "rule without application security groups",
+			rule: SecurityRule{Name: "allow_ssh", Source: pointer.String("*"), Destination: pointer.String("*")},
+		},
+		{
+			name: "rule referencing application security groups",
+			rule: SecurityRule{Name: "allow_apiserver", SourceApplicationSecurityGroups: []string{"node"}, DestinationApplicationSecurityGroups: []string{"control-plane"}},
+		},
+		{
+			name:        "source set together with source application security groups",
+			rule:        SecurityRule{Name: "allow_apiserver", Source: pointer.String("*"), SourceApplicationSecurityGroups: []string{"node"}},
+			expectedErr: field.Forbidden(fldPath.Child("sourceApplicationSecurityGroups"), "cannot be set together with source"),
+		},
+		{
+			name:        "destination set together with destination application security groups",
+			rule:        SecurityRule{Name: "allow_apiserver", Destination: pointer.String("*"), DestinationApplicationSecurityGroups: []string{"control-plane"}},
+			expectedErr: field.Forbidden(fldPath.Child("destinationApplicationSecurityGroups"), "cannot be set together with destination"),
+		},
+		{
+			name:        "unknown application security group",
+			rule:        SecurityRule{Name: "allow_apiserver", SourceApplicationSecurityGroups: []string{"node"}, DestinationApplicationSecurityGroups: []string{"web"}},
+			expectedErr: field.NotFound(fldPath.Child("destinationApplicationSecurityGroups").Index(0), "web"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateSecurityRuleApplicationSecurityGroups(test.rule, asgs, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNodeOutboundLB(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		lb          *LoadBalancerSpec
+		old         *LoadBalancerSpec
+		apiServerLB LoadBalancerSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "no lb for public clusters",
+			lb:   nil,
+			apiServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "nodeOutboundLB",
+				BadValue: nil,
+				Detail:   "Node outbound load balancer cannot be nil for public clusters.",
+			},
+		},
+		{
+			name: "no lb allowed for internal clusters",
+			lb:   nil,
+			apiServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid ID update",
+			lb: &LoadBalancerSpec{
+				ID: "some-id",
+			},
+			old: &LoadBalancerSpec{
+				ID: "old-id",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "nodeOutboundLB.id",
+				BadValue: "some-id",
+				Detail:   "Node outbound load balancer ID should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "invalid Name update",
+			lb: &LoadBalancerSpec{
+				Name: "some-name",
+			},
+			old: &LoadBalancerSpec{
+				Name: "old-name",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "nodeOutboundLB.name",
+				BadValue: "some-name",
+				Detail:   "Node outbound load balancer Name should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "invalid SKU update",
+			lb: &LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					SKU: "some-sku",
+				},
+			},
+			old: &LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					SKU: "old-sku",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "nodeOutboundLB.sku",
+				BadValue: "some-sku",
+				Detail:   "Node outbound load balancer SKU should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "invalid FrontendIps update",
+			lb: &LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{
+					Name: "some-frontend-ip",
+				}},
+			},
+			old: &LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{
+					Name: "old-frontend-ip",
+				}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:  "FieldValueForbidden",
+				Field: "nodeOutboundLB.frontendIPs[0]",
+				BadValue: FrontendIP{
+					Name: "some-frontend-ip",
+				},
+				Detail: "Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "FrontendIps can update when frontendIpsCount changes",
+			lb: &LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{
+					Name: "some-frontend-ip-1",
+				}, {
+					Name: "some-frontend-ip-2",
+				}},
+				FrontendIPsCount: pointer.Int32(2),
+			},
+			old: &LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{
+					Name: "old-frontend-ip",
+				}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "frontend ips count exceeds max value",
+			lb: &LoadBalancerSpec{
+				FrontendIPsCount: pointer.Int32(100),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "nodeOutboundLB.frontendIPsCount",
+				BadValue: 100,
+				Detail:   "Max front end ips allowed is 16",
+			},
+		},
+		{
+			name: "SNAT ports per node not a multiple of 8",
+			lb: &LoadBalancerSpec{
+				SNATPortsPerNode: pointer.Int32(1020),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "nodeOutboundLB.snatPortsPerNode",
+				BadValue: 1020,
+				Detail:   "must be a multiple of 8",
+			},
+		},
+		{
+			name: "frontend ips count of an automatically sized lb increased",
+			lb: &LoadBalancerSpec{
+				SNATPortsPerNode: pointer.Int32(1024),
+				FrontendIPsCount: pointer.Int32(3),
+			},
+			old: &LoadBalancerSpec{
+				SNATPortsPerNode: pointer.Int32(1024),
+				FrontendIPsCount: pointer.Int32(2),
+			},
+			wantErr: false,
+		},
+		{
+			name: "frontend ips count of an automatically sized lb decreased",
+			lb: &LoadBalancerSpec{
+				SNATPortsPerNode: pointer.Int32(1024),
+				FrontendIPsCount: pointer.Int32(1),
+			},
+			old: &LoadBalancerSpec{
+				SNATPortsPerNode: pointer.Int32(1024),
+				FrontendIPsCount: pointer.Int32(2),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "nodeOutboundLB.frontendIPsCount",
+				Detail: "frontend IPs count of an automatically sized node outbound load balancer cannot be decreased",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateNodeOutboundLB(test.lb, test.old, test.apiServerLB, field.NewPath("nodeOutboundLB"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneNodeOutboundLB(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		lb          *LoadBalancerSpec
+		old         *LoadBalancerSpec
+		apiServerLB LoadBalancerSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "cp outbound lb cannot be set for public clusters",
+			lb:   &LoadBalancerSpec{Name: "foo"},
+			apiServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "controlPlaneOutboundLB",
+				BadValue: LoadBalancerSpec{Name: "foo"},
+				Detail:   "Control plane outbound load balancer cannot be set for public clusters.",
+			},
+		},
+		{
+			name: "cp outbound lb can be set for private clusters",
+			lb:   &LoadBalancerSpec{Name: "foo"},
+			apiServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "cp outbound lb can be nil for private clusters",
+			lb:   nil,
+			apiServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "frontend ips count exceeds max value",
+			lb: &LoadBalancerSpec{
+				FrontendIPsCount: pointer.Int32(100),
 			},
 			apiServerLB: LoadBalancerSpec{
 				LoadBalancerClassSpec: LoadBalancerClassSpec{
@@ -1529,3 +2795,449 @@ func TestClusterWithExtendedLocationInvalid(t *testing.T) {
 		g.Expect(err).NotTo(BeNil())
 	})
 }
+
+func TestValidatePrivateDNSZoneID(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "privateDNSZoneID")
+	zoneID := "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private"
+
+	testcases := []struct {
+		name        string
+		zoneID      string
+		zoneName    string
+		expectedErr *field.Error
+	}{
+		{
+			name:   "no private DNS zone ID",
+			zoneID: "",
+		},
+		{
+			name:   "valid private DNS zone ID",
+			zoneID: zoneID,
+		},
+		{
+			name:        "public DNS zone ID",
+			zoneID:      "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+			expectedErr: field.Invalid(fldPath, "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com", fmt.Sprintf("private DNS zone ID doesn't match regex %s", privateDNSZoneIDPattern)),
+		},
+		{
+			name:        "private DNS zone ID along with a private DNS zone name",
+			zoneID:      zoneID,
+			zoneName:    "example.private",
+			expectedErr: field.Forbidden(fldPath, "cannot be set along with PrivateDNSZoneName"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validatePrivateDNSZoneID(test.zoneID, test.zoneName, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateDNSNodeRecords(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "privateDNSNodeRecords")
+
+	testcases := []struct {
+		name        string
+		nodeRecords bool
+		zoneID      string
+		lbType      LBType
+		expectedErr *field.Error
+	}{
+		{
+			name:        "node records disabled",
+			nodeRecords: false,
+			lbType:      Public,
+		},
+		{
+			name:        "node records for a private API server",
+			nodeRecords: true,
+			lbType:      Internal,
+		},
+		{
+			name:        "node records in an existing private DNS zone",
+			nodeRecords: true,
+			zoneID:      "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private",
+			lbType:      Public,
+		},
+		{
+			name:        "node records without a private DNS zone",
+			nodeRecords: true,
+			lbType:      Public,
+			expectedErr: field.Forbidden(fldPath, "PrivateDNSNodeRecords is available only if APIServerLB.Type is Internal or PrivateDNSZoneID is set"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validatePrivateDNSNodeRecords(test.nodeRecords, test.zoneID, test.lbType, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateEndpointDNSZone(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("privateEndpoints").Index(0).Child("privateDNSZone")
+	zoneID := "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+	connections := func(groupIDs ...string) []PrivateLinkServiceConnection {
+		return []PrivateLinkServiceConnection{
+			{
+				PrivateLinkServiceID: "/subscriptions/123/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account",
+				GroupIDs:             groupIDs,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name             string
+		privateEndpoint  PrivateEndpointSpec
+		expectedZoneName string
+		expectedErr      *field.Error
+	}{
+		{
+			name:            "no private DNS zone",
+			privateEndpoint: PrivateEndpointSpec{PrivateLinkServiceConnections: connections("blob")},
+		},
+		{
+			name: "private DNS zone inferred from the group ID",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("vault"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{},
+			},
+			expectedZoneName: "privatelink.vaultcore.azure.net",
+		},
+		{
+			name: "private DNS zone with a name",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("custom"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{Name: "privatelink.example.com"},
+			},
+			expectedZoneName: "privatelink.example.com",
+		},
+		{
+			name: "existing private DNS zone",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("blob"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{ID: zoneID},
+			},
+			expectedZoneName: "privatelink.blob.core.windows.net",
+		},
+		{
+			name: "private DNS zone that cannot be inferred from the group ID",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("custom"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{},
+			},
+			expectedErr: field.Required(fldPath, "name or id is required when the private DNS zone cannot be inferred from the group ID of the first private link service connection"),
+		},
+		{
+			name: "private DNS zone with both a name and an ID",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("blob"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{Name: "privatelink.blob.core.windows.net", ID: zoneID},
+			},
+			expectedZoneName: "privatelink.blob.core.windows.net",
+			expectedErr:      field.Forbidden(fldPath.Child("id"), "cannot be set along with name"),
+		},
+		{
+			name: "private DNS zone with an invalid ID",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("blob"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{ID: "privatelink.blob.core.windows.net"},
+			},
+			expectedZoneName: "privatelink.blob.core.windows.net",
+			expectedErr: field.Invalid(fldPath.Child("id"), "privatelink.blob.core.windows.net",
+				fmt.Sprintf("private DNS zone ID doesn't match regex %s", privateDNSZoneIDPattern)),
+		},
+		{
+			name: "private DNS zone with an invalid name",
+			privateEndpoint: PrivateEndpointSpec{
+				PrivateLinkServiceConnections: connections("blob"),
+				PrivateDNSZone:                &PrivateEndpointDNSZone{Name: "-invalid-"},
+			},
+			expectedZoneName: "-invalid-",
+			expectedErr: field.Invalid(fldPath.Child("name"), "-invalid-",
+				"private DNS zone name can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character"),
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(test.privateEndpoint.GetPrivateDNSZoneName()).To(Equal(test.expectedZoneName))
+			errs := validatePrivateEndpointDNSZone(test.privateEndpoint, fldPath)
+			if test.expectedErr != nil {
+				g.Expect(errs).To(ConsistOf(test.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateBastionSpec(t *testing.T) {
+	fldPath := field.NewPath("spec", "azureBastion", "bastionSpec")
+
+	testcases := []struct {
+		name         string
+		bastion      *AzureBastion
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no azure bastion",
+		},
+		{
+			name:    "basic azure bastion",
+			bastion: &AzureBastion{Sku: BasicBastionHostSku},
+		},
+		{
+			name: "standard azure bastion with all features",
+			bastion: &AzureBastion{
+				Sku:                 StandardBastionHostSku,
+				ScaleUnits:          pointer.Int32(10),
+				EnableTunneling:     true,
+				EnableIPConnect:     true,
+				EnableShareableLink: true,
+				EnableFileCopy:      true,
+			},
+		},
+		{
+			name: "basic azure bastion with standard features",
+			bastion: &AzureBastion{
+				Sku:                 BasicBastionHostSku,
+				ScaleUnits:          pointer.Int32(10),
+				EnableTunneling:     true,
+				EnableIPConnect:     true,
+				EnableShareableLink: true,
+				EnableFileCopy:      true,
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("sku"), BasicBastionHostSku, "sku must be Standard if tunneling is enabled"),
+				field.Invalid(fldPath.Child("sku"), BasicBastionHostSku, "sku must be Standard if scale units are set"),
+				field.Invalid(fldPath.Child("sku"), BasicBastionHostSku, "sku must be Standard if IP connect is enabled"),
+				field.Invalid(fldPath.Child("sku"), BasicBastionHostSku, "sku must be Standard if shareable links are enabled"),
+				field.Invalid(fldPath.Child("sku"), BasicBastionHostSku, "sku must be Standard if file copy is enabled"),
+			},
+		},
+		{
+			name: "azure bastion with a zonal public IP without a zone",
+			bastion: &AzureBastion{
+				Sku:      BasicBastionHostSku,
+				PublicIP: PublicIPSpec{Name: "bastion-ip", Zones: &PublicIPZones{Placement: PublicIPZonal}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Required(fldPath.Child("publicIP", "zones", "zone"), "zone is required for a Zonal public IP"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateBastionSpec(BastionSpec{AzureBastion: test.bastion}, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNSGFlowLogs(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "flowLogs")
+	storageAccountID := "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+	workspaceResourceID := "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/analytics"
+	workspaceID := "4b1a3ad4-2e4f-4b8e-9e0f-3a1f5c6d7e8f"
+
+	testcases := []struct {
+		name         string
+		flowLogs     *NSGFlowLogs
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no flow logs",
+		},
+		{
+			name:     "flow logs with a storage account",
+			flowLogs: &NSGFlowLogs{StorageAccountID: storageAccountID, RetentionDays: 30, FormatVersion: 2},
+		},
+		{
+			name: "flow logs with traffic analytics",
+			flowLogs: &NSGFlowLogs{
+				StorageAccountID: storageAccountID,
+				TrafficAnalytics: &TrafficAnalytics{
+					WorkspaceResourceID: workspaceResourceID,
+					WorkspaceID:         workspaceID,
+					IntervalMinutes:     10,
+				},
+			},
+		},
+		{
+			name:     "flow logs with an invalid storage account ID",
+			flowLogs: &NSGFlowLogs{StorageAccountID: "flowlogs"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("storageAccountID"), "flowlogs",
+					fmt.Sprintf("storage account ID doesn't match regex %s", storageAccountIDPattern)),
+			},
+		},
+		{
+			name: "flow logs with invalid traffic analytics",
+			flowLogs: &NSGFlowLogs{
+				StorageAccountID: storageAccountID,
+				TrafficAnalytics: &TrafficAnalytics{
+					WorkspaceResourceID: storageAccountID,
+					WorkspaceID:         "analytics",
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("trafficAnalytics", "workspaceResourceID"), storageAccountID,
+					fmt.Sprintf("Log Analytics workspace ID doesn't match regex %s", logAnalyticsWorkspaceIDPattern)),
+				field.Invalid(fldPath.Child("trafficAnalytics", "workspaceID"), "analytics", "workspace ID must be a GUID"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateNSGFlowLogs(test.flowLogs, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateSubnetStaticIPPool(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("staticIPPool")
+
+	testcases := []struct {
+		name         string
+		pool         *PrivateIPAddressRange
+		cidrs        []string
+		expectedErrs field.ErrorList
+	}{
+		{
+			name:  "no static IP pool",
+			cidrs: []string{"10.1.0.0/16"},
+		},
+		{
+			name:  "static IP pool within the subnet",
+			pool:  &PrivateIPAddressRange{Start: "10.1.0.10", End: "10.1.0.20"},
+			cidrs: []string{"10.1.0.0/16"},
+		},
+		{
+			name:  "IPv6 static IP pool within the subnet of a dual-stack subnet",
+			pool:  &PrivateIPAddressRange{Start: "2001:1234:5678:9abd::10", End: "2001:1234:5678:9abd::20"},
+			cidrs: []string{"10.1.0.0/16", "2001:1234:5678:9abd::/64"},
+		},
+		{
+			name:  "static IP pool spanning all the addresses of the subnet not reserved by Azure",
+			pool:  &PrivateIPAddressRange{Start: "10.1.0.4", End: "10.1.255.254"},
+			cidrs: []string{"10.1.0.0/16"},
+		},
+		{
+			name:  "static IP pool including the DNS addresses reserved by Azure",
+			pool:  &PrivateIPAddressRange{Start: "10.1.0.3", End: "10.1.0.20"},
+			cidrs: []string{"10.1.0.0/16"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, &PrivateIPAddressRange{Start: "10.1.0.3", End: "10.1.0.20"},
+					"must not include the first four and the last addresses of the subnet CIDR block 10.1.0.0/16, which are reserved by Azure"),
+			},
+		},
+		{
+			name:  "static IP pool including the last address of the subnet",
+			pool:  &PrivateIPAddressRange{Start: "10.1.255.200", End: "10.1.255.255"},
+			cidrs: []string{"10.1.0.0/16"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, &PrivateIPAddressRange{Start: "10.1.255.200", End: "10.1.255.255"},
+					"must not include the first four and the last addresses of the subnet CIDR block 10.1.0.0/16, which are reserved by Azure"),
+			},
+		},
+		{
+			name:  "IPv6 static IP pool including the gateway address reserved by Azure",
+			pool:  &PrivateIPAddressRange{Start: "2001:1234:5678:9abd::1", End: "2001:1234:5678:9abd::20"},
+			cidrs: []string{"10.1.0.0/16", "2001:1234:5678:9abd::/64"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, &PrivateIPAddressRange{Start: "2001:1234:5678:9abd::1", End: "2001:1234:5678:9abd::20"},
+					"must not include the first four and the last addresses of the subnet CIDR block 2001:1234:5678:9abd::/64, which are reserved by Azure"),
+			},
+		},
+		{
+			name: "static IP pool of a subnet with unknown CIDR blocks",
+			pool: &PrivateIPAddressRange{Start: "10.1.0.10", End: "10.1.0.10"},
+		},
+		{
+			name:  "static IP pool with invalid addresses",
+			pool:  &PrivateIPAddressRange{Start: "10.1.0", End: "first"},
+			cidrs: []string{"10.1.0.0/16"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("start"), "10.1.0", "must be a valid IP address"),
+				field.Invalid(fldPath.Child("end"), "first", "must be a valid IP address"),
+			},
+		},
+		{
+			name:  "static IP pool ending before it starts",
+			pool:  &PrivateIPAddressRange{Start: "10.1.0.20", End: "10.1.0.10"},
+			cidrs: []string{"10.1.0.0/16"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("end"), "10.1.0.10", "must be an address of the same family as start and not lower than it"),
+			},
+		},
+		{
+			name:  "static IP pool mixing address families",
+			pool:  &PrivateIPAddressRange{Start: "10.1.0.10", End: "2001:1234:5678:9abd::20"},
+			cidrs: []string{"10.1.0.0/16"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("end"), "2001:1234:5678:9abd::20", "must be an address of the same family as start and not lower than it"),
+			},
+		},
+		{
+			name:  "static IP pool outside of the subnet",
+			pool:  &PrivateIPAddressRange{Start: "10.1.255.250", End: "10.2.0.10"},
+			cidrs: []string{"10.1.0.0/16"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, &PrivateIPAddressRange{Start: "10.1.255.250", End: "10.2.0.10"},
+					"must be a range of addresses of one of the subnet CIDR blocks ([10.1.0.0/16])"),
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validateSubnetStaticIPPool(test.pool, test.cidrs, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -114,14 +114,38 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
-	// Allow enabling azure bastion but avoid disabling it.
-	if old.Spec.BastionSpec.AzureBastion != nil && !reflect.DeepEqual(old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion) {
-		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "BastionSpec", "AzureBastion"),
-				c.Spec.BastionSpec.AzureBastion, "azure bastion cannot be removed from a cluster"),
-		)
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "PrivateDNSZoneID"),
+		old.Spec.NetworkSpec.PrivateDNSZoneID,
+		c.Spec.NetworkSpec.PrivateDNSZoneID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "Vnet", "Shared"),
+		old.Spec.NetworkSpec.Vnet.Shared,
+		c.Spec.NetworkSpec.Vnet.Shared); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "OutboundType"),
+		old.Spec.NetworkSpec.OutboundType,
+		c.Spec.NetworkSpec.OutboundType); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	// Public IPs are only allocated from the prefix when they are created, so it cannot be added to an existing cluster.
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "PublicIPPrefix"),
+		old.Spec.NetworkSpec.PublicIPPrefix,
+		c.Spec.NetworkSpec.PublicIPPrefix); err != nil {
+		allErrs = append(allErrs, err)
 	}
 
+	// Allow enabling azure bastion and updating its SKU and features, but avoid disabling it.
+	allErrs = append(allErrs, c.validateAzureBastionUpdate(old)...)
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "NetworkSpec", "ControlPlaneOutboundLB"),
 		old.Spec.NetworkSpec.ControlPlaneOutboundLB,
@@ -129,6 +153,10 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, c.validateAPIServerDNSUpdate(old)...)
+
+	allErrs = append(allErrs, c.validateFlowLogsUpdate(old)...)
+
 	allErrs = append(allErrs, c.validateSubnetUpdate(old)...)
 
 	if len(allErrs) == 0 {
@@ -138,6 +166,77 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 	return apierrors.NewInvalid(GroupVersion.WithKind("AzureCluster").GroupKind(), c.Name, allErrs)
 }
 
+// validateAPIServerDNSUpdate validates that the API server record is not changed, as the control plane endpoint is immutable.
+func (c *AzureCluster) validateAPIServerDNSUpdate(old *AzureCluster) field.ErrorList {
+	var zoneID, recordName, oldZoneID, oldRecordName string
+	if dns := c.Spec.NetworkSpec.APIServerDNS; dns != nil {
+		zoneID, recordName = dns.ZoneID, dns.RecordName
+	}
+	if dns := old.Spec.NetworkSpec.APIServerDNS; dns != nil {
+		oldZoneID, oldRecordName = dns.ZoneID, dns.RecordName
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "NetworkSpec", "APIServerDNS")
+	if err := webhookutils.ValidateImmutable(fldPath.Child("ZoneID"), oldZoneID, zoneID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := webhookutils.ValidateImmutable(fldPath.Child("RecordName"), oldRecordName, recordName); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	return allErrs
+}
+
+// validateAzureBastionUpdate validates that the Azure Bastion Host is not removed nor moved to another subnet or
+// public IP, and that its SKU is not downgraded. Its SKU, scale units and features are otherwise updated in place.
+func (c *AzureCluster) validateAzureBastionUpdate(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+	oldBastion, bastion := old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion
+	if oldBastion == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "BastionSpec", "AzureBastion")
+	if bastion == nil {
+		return append(allErrs, field.Invalid(fldPath, bastion, "azure bastion cannot be removed from a cluster"))
+	}
+	if err := webhookutils.ValidateImmutable(fldPath.Child("Name"), oldBastion.Name, bastion.Name); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := webhookutils.ValidateImmutable(fldPath.Child("Subnet"), oldBastion.Subnet, bastion.Subnet); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := webhookutils.ValidateImmutable(fldPath.Child("PublicIP"), oldBastion.PublicIP, bastion.PublicIP); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if oldBastion.Sku == StandardBastionHostSku && bastion.Sku != StandardBastionHostSku {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("Sku"), "sku cannot be downgraded from Standard to Basic"))
+	}
+	return allErrs
+}
+
+// validateFlowLogsUpdate validates that the security group flow logs are not removed nor moved to another Network Watcher,
+// as the existing flow logs would be left behind. The rest of their configuration is updated in place.
+func (c *AzureCluster) validateFlowLogsUpdate(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+	oldFlowLogs, flowLogs := old.Spec.NetworkSpec.FlowLogs, c.Spec.NetworkSpec.FlowLogs
+	if oldFlowLogs == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("Spec", "NetworkSpec", "FlowLogs")
+	if flowLogs == nil {
+		return append(allErrs, field.Forbidden(fldPath, "flow logs cannot be removed from a cluster"))
+	}
+	if err := webhookutils.ValidateImmutable(fldPath.Child("NetworkWatcherResourceGroup"), oldFlowLogs.NetworkWatcherResourceGroup, flowLogs.NetworkWatcherResourceGroup); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := webhookutils.ValidateImmutable(fldPath.Child("NetworkWatcherName"), oldFlowLogs.NetworkWatcherName, flowLogs.NetworkWatcherName); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	return allErrs
+}
+
 // validateSubnetUpdate validates a ClusterSpec.NetworkSpec.Subnets for immutability.
 func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 	var allErrs field.ErrorList
@@ -156,7 +255,8 @@ func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 			// This technically allows the cidr block to be modified in the brief
 			// moments before the Vnet is created (because the tags haven't been
 			// set yet) but once the Vnet has been created it becomes immutable.
-			if old.Spec.NetworkSpec.Vnet.Tags.HasOwned(old.Name) && !reflect.DeepEqual(subnet.CIDRBlocks, oldSubnet.CIDRBlocks) {
+			// CIDR blocks that are allocated automatically can be set once.
+			if old.Spec.NetworkSpec.Vnet.Tags.HasOwned(old.Name) && !oldSubnet.NeedsCIDRAllocation() && !reflect.DeepEqual(subnet.CIDRBlocks, oldSubnet.CIDRBlocks) {
 				allErrs = append(allErrs,
 					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("CIDRBlocks"),
 						c.Spec.NetworkSpec.Subnets[i].CIDRBlocks, "field is immutable"),
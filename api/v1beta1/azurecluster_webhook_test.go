@@ -18,8 +18,11 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -318,6 +321,209 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:       "API server DNS cannot be added",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerDNS = &APIServerDNS{
+					ZoneID:     "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+					RecordName: "api",
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "API server DNS CNAMEs can be updated",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerDNS = &APIServerDNS{
+					ZoneID:     "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+					RecordName: "api",
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerDNS = &APIServerDNS{
+					ZoneID:           "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+					RecordName:       "api",
+					CNAMERecordNames: []string{"kubernetes"},
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name:       "vnet shared is immutable",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Shared = true
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "bastion", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion SKU and features can be updated",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "bastion", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{
+					Name:                "bastion",
+					Sku:                 StandardBastionHostSku,
+					ScaleUnits:          pointer.Int32(4),
+					EnableTunneling:     true,
+					EnableIPConnect:     true,
+					EnableShareableLink: true,
+					EnableFileCopy:      true,
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azure bastion SKU cannot be downgraded",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "bastion", Sku: StandardBastionHostSku}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "bastion", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion name is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "bastion", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "bastion-new", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "flow logs can be enabled",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.FlowLogs = &NSGFlowLogs{
+					StorageAccountID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "flow logs retention can be updated",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.FlowLogs = &NSGFlowLogs{
+					StorageAccountID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.FlowLogs = &NSGFlowLogs{
+					StorageAccountID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+					RetentionDays:    90,
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "flow logs cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.FlowLogs = &NSGFlowLogs{
+					StorageAccountID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				}
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "flow logs Network Watcher is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.FlowLogs = &NSGFlowLogs{
+					StorageAccountID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.FlowLogs = &NSGFlowLogs{
+					StorageAccountID:   "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+					NetworkWatcherName: "watcher",
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "public IP prefix cannot be added to an existing cluster",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PublicIPPrefix = &PublicIPPrefix{
+					ID: "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress",
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "private DNS zone ID is immutable",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PrivateDNSZoneID = "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private DNS node records can be enabled",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PrivateDNSZoneID = "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private"
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PrivateDNSZoneID = "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private"
+				cluster.Spec.NetworkSpec.PrivateDNSNodeRecords = true
+				return cluster
+			}(),
+			wantErr: false,
+		},
 		{
 			name: "natGateway name is immutable",
 			oldCluster: func() *AzureCluster {
@@ -342,6 +548,68 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "subnet CIDR blocks can be allocated once",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{DefaultVnetCIDR}
+				cluster.Spec.NetworkSpec.Subnets[1].PrefixLength = pointer.Int32(16)
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{DefaultVnetCIDR}
+				cluster.Spec.NetworkSpec.Subnets[1].PrefixLength = pointer.Int32(16)
+				cluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks = []string{"10.1.0.0/16"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "allocated subnet CIDR blocks are immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{DefaultVnetCIDR}
+				cluster.Spec.NetworkSpec.Subnets[1].PrefixLength = pointer.Int32(16)
+				cluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks = []string{"10.1.0.0/16"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = Tags{ClusterTagKey(cluster.Name): string(ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{DefaultVnetCIDR}
+				cluster.Spec.NetworkSpec.Subnets[1].PrefixLength = pointer.Int32(16)
+				cluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks = []string{"10.2.0.0/16"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "existing dual-stack azurecluster without IPv6 frontend IPs - valid spec",
+			oldCluster: createExistingDualStackCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createExistingDualStackCluster()
+				cluster.Default()
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name:       "existing dual-stack azurecluster with IPv6 frontend IPs added - invalid spec",
+			oldCluster: createExistingDualStackCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createExistingDualStackCluster()
+				cluster.Spec.NetworkSpec.NodeOutboundLB.IPv6FrontendIPs = []FrontendIP{{
+					Name:     "test-cluster-frontEnd-ipv6",
+					PublicIP: &PublicIPSpec{Name: "pip-test-cluster-node-outbound-ipv6"},
+				}}
+				return cluster
+			}(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -356,3 +624,13 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+// createExistingDualStackCluster returns a dual-stack cluster created before its node outbound LB had IPv6 frontend IPs.
+func createExistingDualStackCluster() *AzureCluster {
+	cluster := createValidCluster()
+	cluster.CreationTimestamp = metav1.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"}
+	cluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks = []string{"10.1.0.0/16", "2001:1234:5678:9abd::/64"}
+	cluster.Default()
+	return cluster
+}
@@ -31,6 +31,9 @@ func (c *AzureClusterTemplate) setNetworkTemplateSpecDefaults() {
 	c.setSubnetsTemplateDefaults()
 
 	apiServerLB := &c.Spec.Template.Spec.NetworkSpec.APIServerLB
+	if apiServerLB.Type == "" && c.Spec.Template.Spec.NetworkSpec.IsUserDefinedRouting() {
+		apiServerLB.Type = Internal
+	}
 	apiServerLB.setAPIServerLBDefaults()
 	c.setNodeOutboundLBDefaults()
 	c.setControlPlaneOutboundLBDefaults()
@@ -88,7 +91,7 @@ func (c *AzureClusterTemplate) setSubnetsTemplateDefaults() {
 
 func (c *AzureClusterTemplate) setNodeOutboundLBDefaults() {
 	if c.Spec.Template.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.Template.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.Template.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.Template.Spec.NetworkSpec.IsUserDefinedRouting() {
 			return
 		}
 
@@ -45,6 +45,10 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 		field.NewPath("spec").Child("template").Child("spec").
 			Child("networkSpec").Child("vnet").Child("cidrBlocks"))...)
 
+	allErrs = append(allErrs, validateVnetSettings(
+		c.Spec.Template.Spec.NetworkSpec.Vnet.VnetClassSpec,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("vnet"))...)
+
 	allErrs = append(allErrs, validateSubnetTemplates(
 		c.Spec.Template.Spec.NetworkSpec.Subnets,
 		c.Spec.Template.Spec.NetworkSpec.Vnet,
@@ -79,6 +83,15 @@ func (c *AzureClusterTemplate) validateNetworkSpec() field.ErrorList {
 		allErrs = append(allErrs, c.validateNodeOutboundLB()...)
 	}
 
+	fldPath := field.NewPath("spec").Child("template").Child("spec").Child("networkSpec")
+	allErrs = append(allErrs, validateApplicationSecurityGroups(networkSpec.ApplicationSecurityGroups, fldPath.Child("applicationSecurityGroups"))...)
+	for i, subnet := range networkSpec.Subnets {
+		for j, rule := range subnet.SecurityGroup.SecurityRules {
+			allErrs = append(allErrs, validateSecurityRuleApplicationSecurityGroups(rule, networkSpec.ApplicationSecurityGroups,
+				fldPath.Child("subnets").Index(i).Child("securityGroup", "securityRules").Index(j))...)
+		}
+	}
+
 	return allErrs
 }
 
@@ -112,6 +125,9 @@ func validateSubnetTemplates(subnets SubnetTemplatesSpec, vnet VnetTemplateSpec,
 			}
 		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fld.Index(i).Child("cidrBlocks"))...)
+		if err := validateSubnetZone(subnet.SubnetClassSpec, fld.Index(i).Child("zone")); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 	for k, v := range requiredSubnetRoles {
 		if !v {
@@ -156,13 +172,26 @@ func (c *AzureClusterTemplate) validateControlPlaneOutboundLB() field.ErrorList
 func (c *AzureClusterTemplate) validatePrivateDNSZoneName() field.ErrorList {
 	var allErrs field.ErrorList
 
-	fldPath := field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("privateDNSZoneName")
+	fldPath := field.NewPath("spec").Child("template").Child("spec").Child("networkSpec")
 	networkSpec := c.Spec.Template.Spec.NetworkSpec
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(
 		networkSpec.PrivateDNSZoneName,
 		networkSpec.APIServerLB.Type,
-		fldPath,
+		fldPath.Child("privateDNSZoneName"),
+	)...)
+
+	allErrs = append(allErrs, validatePrivateDNSZoneID(
+		networkSpec.PrivateDNSZoneID,
+		networkSpec.PrivateDNSZoneName,
+		fldPath.Child("privateDNSZoneID"),
+	)...)
+
+	allErrs = append(allErrs, validatePrivateDNSNodeRecords(
+		networkSpec.PrivateDNSNodeRecords,
+		networkSpec.PrivateDNSZoneID,
+		networkSpec.APIServerLB.Type,
+		fldPath.Child("privateDNSNodeRecords"),
 	)...)
 
 	return allErrs
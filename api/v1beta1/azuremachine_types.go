@@ -142,6 +142,31 @@ type AzureMachineSpec struct {
 	// The primary interface will be the first networkInterface specified (index 0) in the list.
 	// +optional
 	NetworkInterfaces []NetworkInterface `json:"networkInterfaces,omitempty"`
+
+	// ApplicationSecurityGroups is a list of names of the cluster's application security groups the VM's
+	// network interfaces are associated with, in addition to the ones matching the machine's role.
+	// +optional
+	ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
+
+	// BootstrapDataStorage configures the delivery of the bootstrap data through an Azure Blob Storage container
+	// instead of passing it directly as the VM's customData, which is limited to 64 KB.
+	// When set, customData only carries a small stub which fetches the bootstrap data from the blob,
+	// and the blob is deleted once the Machine's Node has joined the cluster.
+	// +optional
+	BootstrapDataStorage *BootstrapDataStorage `json:"bootstrapDataStorage,omitempty"`
+
+	// BootstrapWatchdog enables detection of Machines which fail to bootstrap.
+	// When set, if the Machine does not get a NodeRef within the configured timeout after the VM is running,
+	// the boot diagnostics serial log of the VM is retrieved and inspected for known cloud-init, Ignition
+	// and kubeadm error signatures, and the BootstrapFailed condition is set on the AzureMachine.
+	// Requires boot diagnostics to be enabled in Diagnostics.
+	// +optional
+	BootstrapWatchdog *BootstrapWatchdog `json:"bootstrapWatchdog,omitempty"`
+
+	// ResourceHealth configures how the Azure Resource Health availability status of the VM, reported in the
+	// AzureResourceAvailable condition, is acted upon. Requires the MachineResourceHealth feature flag.
+	// +optional
+	ResourceHealth *MachineResourceHealth `json:"resourceHealth,omitempty"`
 }
 
 // SpotVMOptions defines the options relevant to running the Machine on Spot VMs.
@@ -233,6 +258,25 @@ type AzureMachineStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// Extensions reports the state of the VM extensions of the machine, as seen in their instance view.
+	// +optional
+	Extensions []ExtensionStatus `json:"extensions,omitempty"`
+
+	// BootstrapDataBlob is the state of the blob staging the bootstrap data, when BootstrapDataStorage is set.
+	// +optional
+	BootstrapDataBlob BootstrapDataBlobState `json:"bootstrapDataBlob,omitempty"`
+}
+
+// ExtensionStatus reports the state of a VM extension, as seen in its instance view.
+type ExtensionStatus struct {
+	// Name is the name of the extension.
+	Name string `json:"name"`
+
+	// Conditions maps the statuses and substatuses of the extension instance view, such as its provisioning state
+	// or the result of the command it ran, to conditions.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // AdditionalCapabilities enables or disables a capability on the virtual machine.
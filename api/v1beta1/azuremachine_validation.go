@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"net/netip"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
@@ -66,6 +67,18 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateBootstrapDataStorage(spec.Identity, spec.BootstrapDataStorage, field.NewPath("bootstrapDataStorage")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateBootstrapWatchdog(spec.Diagnostics, spec.BootstrapWatchdog, field.NewPath("bootstrapWatchdog")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateMachineResourceHealth(spec.ResourceHealth, field.NewPath("resourceHealth")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	return allErrs
 }
 
@@ -85,7 +98,48 @@ func ValidateNetwork(subnetName string, acceleratedNetworking *bool, networkInte
 		}
 	}
 
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+	for i, nic := range networkInterfaces {
+		allErrs = append(allErrs, validatePrivateIPAddress(nic.PrivateIPAddress, fldPath.Index(i).Child("privateIPAddress"))...)
+	}
+
+	return allErrs
+}
+
+// validatePrivateIPAddress validates the static private IP address of a network interface.
+func validatePrivateIPAddress(privateIP *PrivateIPAddressSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if privateIP == nil {
+		return allErrs
+	}
+
+	sources := 0
+	if privateIP.Address != "" {
+		sources++
+		if _, err := netip.ParseAddr(privateIP.Address); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), privateIP.Address, "must be a valid IP address"))
+		}
+	}
+	if privateIP.FromSubnetPool {
+		sources++
+	}
+	if ref := privateIP.PoolRef; ref != nil {
+		sources++
+		if ref.APIGroup == nil || *ref.APIGroup == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("poolRef", "apiGroup"), "the API group of the IPAM pool is required"))
+		}
+		if ref.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("poolRef", "kind"), "the kind of the IPAM pool is required"))
+		}
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("poolRef", "name"), "the name of the IPAM pool is required"))
+		}
+	}
+	if sources != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, privateIP, "exactly one of address, fromSubnetPool or poolRef must be set"))
+	}
+
+	return allErrs
 }
 
 // ValidateSSHKey validates an SSHKey.
@@ -395,6 +449,75 @@ func ValidateDiagnostics(diagnostics *Diagnostics, fieldPath *field.Path) field.
 	return allErrs
 }
 
+// ValidateBootstrapDataStorage validates the configuration of the bootstrap data storage.
+func ValidateBootstrapDataStorage(identityType VMIdentity, storage *BootstrapDataStorage, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if storage == nil {
+		return allErrs
+	}
+
+	if storage.StorageAccountName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("storageAccountName"), "the storage account name cannot be empty"))
+	}
+
+	switch storage.AccessMethod {
+	case BootstrapDataAccessMethodManagedIdentity:
+		if identityType == "" || identityType == VMIdentityNone {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("accessMethod"), storage.AccessMethod,
+				fmt.Sprintf("accessMethod '%s' requires the machine to have a system-assigned or user-assigned identity", BootstrapDataAccessMethodManagedIdentity)))
+		}
+		if storage.SASExpiry != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sasExpiry"),
+				fmt.Sprintf("sasExpiry can only be set when accessMethod is '%s'", BootstrapDataAccessMethodSAS)))
+		}
+	case "", BootstrapDataAccessMethodSAS:
+		if storage.SASExpiry != nil && storage.SASExpiry.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sasExpiry"), storage.SASExpiry.Duration.String(), "sasExpiry must be a positive duration"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("accessMethod"), storage.AccessMethod,
+			[]string{string(BootstrapDataAccessMethodSAS), string(BootstrapDataAccessMethodManagedIdentity)}))
+	}
+
+	return allErrs
+}
+
+// ValidateBootstrapWatchdog validates the configuration of the bootstrap watchdog.
+func ValidateBootstrapWatchdog(diagnostics *Diagnostics, watchdog *BootstrapWatchdog, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if watchdog == nil {
+		return allErrs
+	}
+
+	if diagnostics != nil && diagnostics.Boot != nil && diagnostics.Boot.StorageAccountType == DisabledDiagnosticsStorage {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			fmt.Sprintf("bootstrapWatchdog cannot be set when boot diagnostics storageAccountType is '%s'", DisabledDiagnosticsStorage)))
+	}
+
+	if watchdog.Timeout != nil && watchdog.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), watchdog.Timeout.Duration.String(), "timeout must be a positive duration"))
+	}
+
+	return allErrs
+}
+
+// ValidateMachineResourceHealth validates the configuration of the Azure Resource Health reporting of a VM.
+func ValidateMachineResourceHealth(resourceHealth *MachineResourceHealth, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if resourceHealth == nil {
+		return allErrs
+	}
+
+	if resourceHealth.RemediateAfter != nil && resourceHealth.RemediateAfter.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("remediateAfter"), resourceHealth.RemediateAfter.Duration.String(), "remediateAfter must be a positive duration"))
+	}
+
+	return allErrs
+}
+
 // ValidateConfidentialCompute validates the configuration options when the machine is a Confidential VM.
 // https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/create-or-update?tabs=HTTP#vmdisksecurityprofile
 // https://learn.microsoft.com/en-us/rest/api/compute/virtual-machines/create-or-update?tabs=HTTP#securityencryptiontypes
@@ -22,11 +22,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 )
@@ -1051,3 +1054,271 @@ func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachine_ValidateBootstrapDataStorage(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		identity VMIdentity
+		storage  *BootstrapDataStorage
+		wantErr  bool
+	}{
+		{
+			name:     "not configured",
+			identity: VMIdentityNone,
+			storage:  nil,
+			wantErr:  false,
+		},
+		{
+			name:     "valid SAS config",
+			identity: VMIdentityNone,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+				AccessMethod:       BootstrapDataAccessMethodSAS,
+				SASExpiry:          &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "valid config with defaulted access method",
+			identity: VMIdentityNone,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "valid managed identity config",
+			identity: VMIdentitySystemAssigned,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+				AccessMethod:       BootstrapDataAccessMethodManagedIdentity,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid config with empty storage account name",
+			identity: VMIdentityNone,
+			storage:  &BootstrapDataStorage{},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid managed identity config without a VM identity",
+			identity: VMIdentityNone,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+				AccessMethod:       BootstrapDataAccessMethodManagedIdentity,
+			},
+			wantErr: true,
+		},
+		{
+			name:     "invalid managed identity config with SAS expiry",
+			identity: VMIdentityUserAssigned,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+				AccessMethod:       BootstrapDataAccessMethodManagedIdentity,
+				SASExpiry:          &metav1.Duration{Duration: time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "invalid SAS config with negative expiry",
+			identity: VMIdentityNone,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+				AccessMethod:       BootstrapDataAccessMethodSAS,
+				SASExpiry:          &metav1.Duration{Duration: -time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "invalid access method",
+			identity: VMIdentityNone,
+			storage: &BootstrapDataStorage{
+				StorageAccountName: "bootstrapdata",
+				AccessMethod:       "Anonymous",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateBootstrapDataStorage(test.identity, test.storage, field.NewPath("bootstrapDataStorage"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateBootstrapWatchdog(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		diagnostics *Diagnostics
+		watchdog    *BootstrapWatchdog
+		wantErr     bool
+	}{
+		{
+			name:        "not configured",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: DisabledDiagnosticsStorage}},
+			watchdog:    nil,
+			wantErr:     false,
+		},
+		{
+			name:        "valid config with managed boot diagnostics",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
+			watchdog:    &BootstrapWatchdog{Timeout: &metav1.Duration{Duration: 15 * time.Minute}},
+			wantErr:     false,
+		},
+		{
+			name:        "valid config with defaulted timeout",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: UserManagedDiagnosticsStorage}},
+			watchdog:    &BootstrapWatchdog{},
+			wantErr:     false,
+		},
+		{
+			name:        "invalid config with disabled boot diagnostics",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: DisabledDiagnosticsStorage}},
+			watchdog:    &BootstrapWatchdog{},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid config with negative timeout",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
+			watchdog:    &BootstrapWatchdog{Timeout: &metav1.Duration{Duration: -time.Minute}},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateBootstrapWatchdog(test.diagnostics, test.watchdog, field.NewPath("bootstrapWatchdog"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateMachineResourceHealth(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name           string
+		resourceHealth *MachineResourceHealth
+		wantErr        bool
+	}{
+		{
+			name:           "not configured",
+			resourceHealth: nil,
+			wantErr:        false,
+		},
+		{
+			name:           "remediation disabled",
+			resourceHealth: &MachineResourceHealth{},
+			wantErr:        false,
+		},
+		{
+			name:           "valid remediation delay",
+			resourceHealth: &MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 30 * time.Minute}},
+			wantErr:        false,
+		},
+		{
+			name:           "invalid zero remediation delay",
+			resourceHealth: &MachineResourceHealth{RemediateAfter: &metav1.Duration{}},
+			wantErr:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateMachineResourceHealth(test.resourceHealth, field.NewPath("resourceHealth"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidatePrivateIPAddress(t *testing.T) {
+	fldPath := field.NewPath("networkInterfaces").Index(0).Child("privateIPAddress")
+
+	tests := []struct {
+		name         string
+		privateIP    *PrivateIPAddressSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no static private IP address",
+		},
+		{
+			name:      "explicit address",
+			privateIP: &PrivateIPAddressSpec{Address: "10.0.0.10"},
+		},
+		{
+			name:      "address from the subnet pool",
+			privateIP: &PrivateIPAddressSpec{FromSubnetPool: true},
+		},
+		{
+			name: "address from an IPAM pool",
+			privateIP: &PrivateIPAddressSpec{PoolRef: &corev1.TypedLocalObjectReference{
+				APIGroup: pointer.String("ipam.cluster.x-k8s.io"),
+				Kind:     "InClusterIPPool",
+				Name:     "control-plane-pool",
+			}},
+		},
+		{
+			name:      "invalid address",
+			privateIP: &PrivateIPAddressSpec{Address: "10.0.0"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("address"), "10.0.0", "must be a valid IP address"),
+			},
+		},
+		{
+			name:      "incomplete IPAM pool reference",
+			privateIP: &PrivateIPAddressSpec{PoolRef: &corev1.TypedLocalObjectReference{Name: "control-plane-pool"}},
+			expectedErrs: field.ErrorList{
+				field.Required(fldPath.Child("poolRef", "apiGroup"), "the API group of the IPAM pool is required"),
+				field.Required(fldPath.Child("poolRef", "kind"), "the kind of the IPAM pool is required"),
+			},
+		},
+		{
+			name:      "no address source",
+			privateIP: &PrivateIPAddressSpec{},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, &PrivateIPAddressSpec{}, "exactly one of address, fromSubnetPool or poolRef must be set"),
+			},
+		},
+		{
+			name:      "several address sources",
+			privateIP: &PrivateIPAddressSpec{Address: "10.0.0.10", FromSubnetPool: true},
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, &PrivateIPAddressSpec{Address: "10.0.0.10", FromSubnetPool: true}, "exactly one of address, fromSubnetPool or poolRef must be set"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			errs := validatePrivateIPAddress(test.privateIP, fldPath)
+			if len(test.expectedErrs) > 0 {
+				g.Expect(errs).To(ConsistOf(test.expectedErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
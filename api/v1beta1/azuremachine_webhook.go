@@ -20,10 +20,12 @@ import (
 	"context"
 	"reflect"
 
+	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -65,6 +67,10 @@ func (mw *azureMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 		allErrs = append(allErrs, errs...)
 	}
 
+	if err := mw.validateAllocatePublicIP(ctx, m, field.NewPath("allocatePublicIP")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -72,6 +78,44 @@ func (mw *azureMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 	return apierrors.NewInvalid(GroupVersion.WithKind("AzureMachine").GroupKind(), m.Name, allErrs)
 }
 
+// validateAllocatePublicIP rejects a public IP on a machine of a cluster with the userDefinedRouting outbound type,
+// as the replies to the traffic reaching the public IP would be routed through the virtual appliance and dropped.
+// The machine is not validated if its AzureCluster is not found, as the webhook cannot tell its outbound type.
+func (mw *azureMachineWebhook) validateAllocatePublicIP(ctx context.Context, m *AzureMachine, fldPath *field.Path) *field.Error {
+	clusterName, ok := m.Labels[clusterv1.ClusterNameLabel]
+	if !m.Spec.AllocatePublicIP || !ok || mw.Client == nil {
+		return nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := mw.Client.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.InternalError(fldPath, errors.Wrapf(err, "failed to get owner cluster %s/%s", m.Namespace, clusterName))
+	}
+	if cluster.Spec.InfrastructureRef == nil || cluster.Spec.InfrastructureRef.Kind != "AzureCluster" {
+		return nil
+	}
+
+	azureCluster := &AzureCluster{}
+	key := client.ObjectKey{Namespace: cluster.Spec.InfrastructureRef.Namespace, Name: cluster.Spec.InfrastructureRef.Name}
+	if key.Namespace == "" {
+		key.Namespace = m.Namespace
+	}
+	if err := mw.Client.Get(ctx, key, azureCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.InternalError(fldPath, errors.Wrapf(err, "failed to get AzureCluster %s/%s", key.Namespace, key.Name))
+	}
+
+	if azureCluster.Spec.NetworkSpec.IsUserDefinedRouting() {
+		return field.Forbidden(fldPath, "a public IP cannot be allocated to a machine of a cluster with the userDefinedRouting outbound type")
+	}
+	return nil
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
 	var allErrs field.ErrorList
@@ -178,6 +222,21 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ApplicationSecurityGroups"),
+		old.Spec.ApplicationSecurityGroups,
+		m.Spec.ApplicationSecurityGroups); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	// The controller would lose track of the blob holding the bootstrap data if its storage changed.
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "BootstrapDataStorage"),
+		old.Spec.BootstrapDataStorage,
+		m.Spec.BootstrapDataStorage); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if old.Spec.Diagnostics != nil {
 		if err := webhookutils.ValidateImmutable(
 			field.NewPath("Spec", "Diagnostics"),
@@ -205,6 +264,10 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		}
 	}
 
+	allErrs = append(allErrs, ValidateBootstrapWatchdog(m.Spec.Diagnostics, m.Spec.BootstrapWatchdog, field.NewPath("spec", "bootstrapWatchdog"))...)
+
+	allErrs = append(allErrs, ValidateMachineResourceHealth(m.Spec.ResourceHealth, field.NewPath("spec", "resourceHealth"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	. "github.com/onsi/gomega"
@@ -693,6 +694,88 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.ApplicationSecurityGroups is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: []string{"web"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: []string{"web", "db"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "invalidTest: azuremachine.spec.BootstrapWatchdog.Timeout must be positive",
+			oldMachine: &AzureMachine{},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapWatchdog: &BootstrapWatchdog{Timeout: &metav1.Duration{Duration: 0}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "validTest: azuremachine.spec.BootstrapWatchdog.Timeout can be updated",
+			oldMachine: &AzureMachine{},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapWatchdog: &BootstrapWatchdog{Timeout: &metav1.Duration{Duration: 30 * time.Minute}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "invalidTest: azuremachine.spec.ResourceHealth.RemediateAfter must be positive",
+			oldMachine: &AzureMachine{},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ResourceHealth: &MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: -time.Minute}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "validTest: azuremachine.spec.ResourceHealth.RemediateAfter can be updated",
+			oldMachine: &AzureMachine{},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ResourceHealth: &MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 10 * time.Minute}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.BootstrapDataStorage is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{StorageAccountName: "bootstrapdata", ContainerName: "cluster"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{StorageAccountName: "otherbootstrapdata", ContainerName: "cluster"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.BootstrapDataStorage is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{StorageAccountName: "bootstrapdata", ContainerName: "cluster"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{StorageAccountName: "bootstrapdata", ContainerName: "cluster"},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.Diagnostics is immutable",
 			oldMachine: &AzureMachine{
@@ -808,12 +891,14 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 type mockDefaultClient struct {
 	client.Client
 	SubscriptionID string
+	OutboundType   *OutboundType
 }
 
 func (m mockDefaultClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	switch obj := obj.(type) {
 	case *AzureCluster:
 		obj.Spec.SubscriptionID = m.SubscriptionID
+		obj.Spec.NetworkSpec.OutboundType = m.OutboundType
 	case *clusterv1.Cluster:
 		obj.Spec.InfrastructureRef = &corev1.ObjectReference{
 			Kind: "AzureCluster",
@@ -825,6 +910,57 @@ func (m mockDefaultClient) Get(ctx context.Context, key client.ObjectKey, obj cl
 	return nil
 }
 
+func TestAzureMachine_ValidateCreateAllocatePublicIP(t *testing.T) {
+	userDefinedRouting := OutboundTypeUserDefinedRouting
+
+	tests := []struct {
+		name             string
+		allocatePublicIP bool
+		outboundType     *OutboundType
+		wantErr          bool
+	}{
+		{
+			name:             "public IP allocated to a machine of a cluster with the default outbound type",
+			allocatePublicIP: true,
+			outboundType:     nil,
+			wantErr:          false,
+		},
+		{
+			name:             "no public IP allocated to a machine of a cluster with the userDefinedRouting outbound type",
+			allocatePublicIP: false,
+			outboundType:     &userDefinedRouting,
+			wantErr:          false,
+		},
+		{
+			name:             "public IP allocated to a machine of a cluster with the userDefinedRouting outbound type",
+			allocatePublicIP: true,
+			outboundType:     &userDefinedRouting,
+			wantErr:          true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := createMachineWithSSHPublicKey(validSSHPublicKey)
+			machine.Labels = map[string]string{
+				clusterv1.ClusterNameLabel: "test-cluster",
+			}
+			machine.Spec.AllocatePublicIP = tc.allocatePublicIP
+			mw := &azureMachineWebhook{
+				Client: mockDefaultClient{OutboundType: tc.outboundType},
+			}
+			err := mw.ValidateCreate(context.Background(), machine)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("allocatePublicIP"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_Default(t *testing.T) {
 	g := NewWithT(t)
 
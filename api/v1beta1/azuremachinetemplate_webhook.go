@@ -35,6 +35,7 @@ const (
 	AzureMachineTemplateImmutableMsg                      = "AzureMachineTemplate spec.template.spec field is immutable. Please create new resource instead. ref doc: https://cluster-api.sigs.k8s.io/tasks/updating-machine-templates.html"
 	AzureMachineTemplateRoleAssignmentNameMsg             = "AzureMachineTemplate spec.template.spec.roleAssignmentName field can't be set"
 	AzureMachineTemplateSystemAssignedIdentityRoleNameMsg = "AzureMachineTemplate spec.template.spec.systemAssignedIdentityRole.name field can't be set"
+	AzureMachineTemplatePrivateIPAddressMsg               = "AzureMachineTemplate spec.template.spec.networkInterfaces.privateIPAddress.address field can't be set, use fromSubnetPool or poolRef instead"
 )
 
 // SetupWebhookWithManager sets up and registers the webhook with the manager.
@@ -83,6 +84,10 @@ func (r *AzureMachineTemplate) ValidateCreate(ctx context.Context, obj runtime.O
 		if networkInterface.PrivateIPConfigs < 1 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces", "privateIPConfigs"), r.Spec.Template.Spec.NetworkInterfaces[i].PrivateIPConfigs, "networkInterface privateIPConfigs must be set to a minimum value of 1"))
 		}
+		// Every machine created from the template would request the same address.
+		if networkInterface.PrivateIPAddress != nil && networkInterface.PrivateIPAddress.Address != "" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces").Index(i).Child("privateIPAddress", "address"), AzureMachineTemplatePrivateIPAddressMsg))
+		}
 	}
 
 	if len(allErrs) == 0 {
@@ -231,6 +231,32 @@ func TestAzureMachineTemplate_ValidateCreate(t *testing.T) {
 			),
 			wantErr: false,
 		},
+		{
+			name: "azuremachinetemplate with network interfaces and explicit static private IP address",
+			machineTemplate: createAzureMachineTemplateFromMachine(
+				createMachineWithNetworkConfig(
+					"",
+					nil,
+					[]NetworkInterface{
+						{SubnetName: "subnet1", PrivateIPConfigs: 1, PrivateIPAddress: &PrivateIPAddressSpec{Address: "10.0.0.10"}},
+					},
+				),
+			),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinetemplate with network interfaces and static private IP address from the subnet pool",
+			machineTemplate: createAzureMachineTemplateFromMachine(
+				createMachineWithNetworkConfig(
+					"",
+					nil,
+					[]NetworkInterface{
+						{SubnetName: "subnet1", PrivateIPConfigs: 1, PrivateIPAddress: &PrivateIPAddressSpec{FromSubnetPool: true}},
+					},
+				),
+			),
+			wantErr: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -24,6 +24,14 @@ const (
 	NetworkInfrastructureReadyCondition clusterv1.ConditionType = "NetworkInfrastructureReady"
 	// NamespaceNotAllowedByIdentity used to indicate cluster in a namespace not allowed by identity.
 	NamespaceNotAllowedByIdentity = "NamespaceNotAllowedByIdentity"
+	// SubnetCIDRsAllocatedCondition reports whether CIDR blocks were allocated to the subnets that only specify a prefix length.
+	SubnetCIDRsAllocatedCondition clusterv1.ConditionType = "SubnetCIDRsAllocated"
+	// SubnetCIDRsExhaustedReason is used when the virtual network's address space has no room left for a subnet's CIDR block.
+	SubnetCIDRsExhaustedReason = "SubnetCIDRsExhausted"
+	// VNetSettingsConfiguredCondition reports whether a custom virtual network has the DDoS protection plan and DNS servers of the spec.
+	VNetSettingsConfiguredCondition clusterv1.ConditionType = "VNetSettingsConfigured"
+	// VNetSettingsMissingReason is used when a custom virtual network is missing the DDoS protection plan or DNS servers of the spec.
+	VNetSettingsMissingReason = "VNetSettingsMissing"
 )
 
 // AzureMachine Conditions and Reasons.
@@ -52,6 +60,16 @@ const (
 	BootstrapInProgressReason = "BootstrapInProgress"
 	// BootstrapFailedReason is used to indicate the bootstrap process ran into an error.
 	BootstrapFailedReason = "BootstrapFailed"
+	// BootstrapFailedCondition is set to true when the bootstrap watchdog detected that the machine failed to bootstrap.
+	BootstrapFailedCondition clusterv1.ConditionType = "BootstrapFailed"
+	// BootstrapTimeoutReason is used when the machine did not join the cluster in time and no known error signature was found.
+	BootstrapTimeoutReason = "BootstrapTimeout"
+	// CloudInitFailedReason is used when a cloud-init error signature was found in the boot diagnostics serial log.
+	CloudInitFailedReason = "CloudInitFailed"
+	// IgnitionFailedReason is used when an Ignition error signature was found in the boot diagnostics serial log.
+	IgnitionFailedReason = "IgnitionFailed"
+	// KubeadmFailedReason is used when a kubeadm error signature was found in the boot diagnostics serial log.
+	KubeadmFailedReason = "KubeadmFailed"
 )
 
 // AzureMachinePool Conditions and Reasons.
@@ -80,13 +98,25 @@ const (
 	ScaleSetModelOutOfDateReason = "ScaleSetModelOutOfDate"
 )
 
+// AzureMachineRunCommand Conditions and Reasons.
+const (
+	// RunCommandCompletedCondition reports whether the script finished running on all the targeted machines.
+	RunCommandCompletedCondition clusterv1.ConditionType = "RunCommandCompleted"
+	// RunCommandRunningReason is used while the script has not finished running on all the targeted machines.
+	RunCommandRunningReason = "RunCommandRunning"
+	// RunCommandFailedReason is used when the script failed or timed out on at least one of the targeted machines.
+	RunCommandFailedReason = "RunCommandFailed"
+	// NoMachinesFoundReason is used when no provisioned machine matches the targeted machines.
+	NoMachinesFoundReason = "NoMachinesFound"
+)
+
 // AzureManagedCluster Conditions and Reasons.
 const (
 	// ManagedClusterRunningCondition means the AKS cluster exists and is in a running state.
 	ManagedClusterRunningCondition clusterv1.ConditionType = "ManagedClusterRunning"
 	// AgentPoolsReadyCondition means the AKS agent pools exist and are ready to be used.
 	AgentPoolsReadyCondition clusterv1.ConditionType = "AgentPoolsReady"
-	// AzureResourceAvailableCondition means the AKS cluster is healthy according to Azure's Resource Health API.
+	// AzureResourceAvailableCondition means the AKS cluster or the VM is healthy according to Azure's Resource Health API.
 	AzureResourceAvailableCondition clusterv1.ConditionType = "AzureResourceAvailable"
 )
 
@@ -100,6 +130,8 @@ const (
 	VnetPeeringReadyCondition clusterv1.ConditionType = "VnetPeeringReady"
 	// SecurityGroupsReadyCondition means the security groups exist and are ready to be used.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
+	// NSGFlowLogsReadyCondition means the Network Watcher flow logs of the security groups exist and are ready to be used.
+	NSGFlowLogsReadyCondition clusterv1.ConditionType = "NSGFlowLogsReady"
 	// RouteTablesReadyCondition means the route tables exist and are ready to be used.
 	RouteTablesReadyCondition clusterv1.ConditionType = "RouteTablesReady"
 	// PublicIPsReadyCondition means the public IPs exist and are ready to be used.
@@ -130,6 +162,19 @@ const (
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
 	// PrivateEndpointsReadyCondition means the private endpoints exist and are ready to be used.
 	PrivateEndpointsReadyCondition clusterv1.ConditionType = "PrivateEndpointsReady"
+	// PrivateEndpointDNSZonesReadyCondition means the private DNS zones of the private endpoints exist and are linked to the virtual network.
+	PrivateEndpointDNSZonesReadyCondition clusterv1.ConditionType = "PrivateEndpointDNSZonesReady"
+	// PrivateLinkServiceReadyCondition means the private link service exists and is ready to be used.
+	PrivateLinkServiceReadyCondition clusterv1.ConditionType = "PrivateLinkServiceReady"
+	// PublicIPPrefixReadyCondition means the public IP prefix exists and is ready to be used.
+	PublicIPPrefixReadyCondition clusterv1.ConditionType = "PublicIPPrefixReady"
+	// APIServerDNSReadyCondition means the API server records exist in the public DNS zone and are ready to be used.
+	APIServerDNSReadyCondition clusterv1.ConditionType = "APIServerDNSReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
+	// NodeOutboundSNATPortsReadyCondition means the node outbound load balancer provides the targeted number of
+	// SNAT ports to each node.
+	NodeOutboundSNATPortsReadyCondition clusterv1.ConditionType = "NodeOutboundSNATPortsReady"
 
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
@@ -143,6 +188,10 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// SNATPortsTargetNotMetReason means fewer SNAT ports than targeted could be allocated to each node.
+	SNATPortsTargetNotMetReason = "SNATPortsTargetNotMet"
+	// AvailabilityStatusUnknownReason means the availability status of the resource could not be retrieved.
+	AvailabilityStatusUnknownReason = "AvailabilityStatusUnknown"
 )
 
 const (
@@ -131,6 +131,9 @@ const (
 	// CommonRole describes the value for the common role.
 	CommonRole = "common"
 
+	// SharedVnetRole describes the value for the role of a virtual network shared by several clusters.
+	SharedVnetRole = "sharedVnet"
+
 	// VMTagsLastAppliedAnnotation is the key for the machine object annotation
 	// which tracks the AdditionalTags in the Machine Provider Config.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
@@ -17,8 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"path"
+
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/net"
 )
 
@@ -109,9 +113,151 @@ type NetworkSpec struct {
 	// +optional
 	ControlPlaneOutboundLB *LoadBalancerSpec `json:"controlPlaneOutboundLB,omitempty"`
 
+	// PublicIPPrefix is the public IP prefix the public IPs created by CAPZ for the API server load balancer,
+	// the outbound load balancers, the NAT gateways and the machines are allocated from.
+	// It can only be set when the cluster is created and is immutable afterwards.
+	// +optional
+	PublicIPPrefix *PublicIPPrefix `json:"publicIPPrefix,omitempty"`
+
+	// APIServerDNS configures records of the API server in an existing public Azure DNS zone.
+	// When set, the FQDN of the API server record is used as the host of the control plane endpoint.
+	// +optional
+	APIServerDNS *APIServerDNS `json:"apiServerDNS,omitempty"`
+
+	// FlowLogs configures Network Watcher flow logs for the network security groups managed by CAPZ.
+	// +optional
+	FlowLogs *NSGFlowLogs `json:"flowLogs,omitempty"`
+
 	NetworkClassSpec `json:",inline"`
 }
 
+// NSGFlowLogs defines the Network Watcher flow logs of the network security groups of a cluster.
+// The flow logs are created in the Network Watcher of the cluster's region and deleted with the network security groups.
+type NSGFlowLogs struct {
+	// StorageAccountID is the Azure resource ID of the storage account the flow logs are written to.
+	// It must be in the same region as the cluster.
+	StorageAccountID string `json:"storageAccountID"`
+
+	// RetentionDays is the number of days flow log records are retained in the storage account.
+	// Defaults to 0, which retains them forever.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=365
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+
+	// FormatVersion is the version of the flow log format. Defaults to 2, which includes flow state and throughput information.
+	// +kubebuilder:validation:Enum=1;2
+	// +kubebuilder:default=2
+	// +optional
+	FormatVersion int32 `json:"formatVersion,omitempty"`
+
+	// TrafficAnalytics configures traffic analytics of the flow logs in a Log Analytics workspace.
+	// +optional
+	TrafficAnalytics *TrafficAnalytics `json:"trafficAnalytics,omitempty"`
+
+	// NetworkWatcherResourceGroup is the resource group of the Network Watcher of the cluster's region.
+	// Defaults to "NetworkWatcherRG", the resource group Azure creates Network Watchers in.
+	// +optional
+	NetworkWatcherResourceGroup string `json:"networkWatcherResourceGroup,omitempty"`
+
+	// NetworkWatcherName is the name of the Network Watcher of the cluster's region.
+	// Defaults to "NetworkWatcher_<location>", the name Azure gives to Network Watchers.
+	// +optional
+	NetworkWatcherName string `json:"networkWatcherName,omitempty"`
+}
+
+// TrafficAnalytics defines the Log Analytics workspace traffic analytics of flow logs are sent to.
+type TrafficAnalytics struct {
+	// WorkspaceResourceID is the Azure resource ID of the Log Analytics workspace.
+	WorkspaceResourceID string `json:"workspaceResourceID"`
+
+	// WorkspaceID is the workspace ID (GUID) of the Log Analytics workspace.
+	WorkspaceID string `json:"workspaceID"`
+
+	// WorkspaceRegion is the region of the Log Analytics workspace. Defaults to the region of the cluster.
+	// +optional
+	WorkspaceRegion string `json:"workspaceRegion,omitempty"`
+
+	// IntervalMinutes is how often traffic analytics processes the flow logs, in minutes. Defaults to 60.
+	// +kubebuilder:validation:Enum=10;60
+	// +kubebuilder:default=60
+	// +optional
+	IntervalMinutes int32 `json:"intervalMinutes,omitempty"`
+}
+
+// APIServerDNS defines the records of the API server in an existing public Azure DNS zone.
+// CAPZ only manages the record sets it created, and deletes them with the cluster.
+type APIServerDNS struct {
+	// ZoneID is the Azure resource ID of the existing public DNS zone. It can be in another subscription and resource group.
+	ZoneID string `json:"zoneID"`
+
+	// RecordName is the name of the API server record set relative to the zone, e.g. "api.prod-eu1" in the zone "example.com".
+	// The record set is an alias of the API server public IP, so it follows the IP address.
+	RecordName string `json:"recordName"`
+
+	// CNAMERecordNames are the names of record sets relative to the zone that are CNAMEs of the API server record.
+	// +optional
+	CNAMERecordNames []string `json:"cnameRecordNames,omitempty"`
+
+	// TTL is the time to live of the record sets in seconds. Defaults to 300.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+}
+
+// PublicIPPrefix defines the public IP prefix the cluster's public IPs are allocated from.
+// Exactly one of ID or Name must be set.
+type PublicIPPrefix struct {
+	// ID is the Azure resource ID of an existing public IP prefix in the cluster's subscription and location.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the public IP prefix created by CAPZ in the cluster's resource group.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// PrefixLength is the length of the public IP prefix created by CAPZ. Defaults to 28, i.e. 16 public IPs.
+	// +kubebuilder:validation:Minimum=21
+	// +kubebuilder:validation:Maximum=31
+	// +optional
+	PrefixLength *int32 `json:"prefixLength,omitempty"`
+}
+
+// PublicIPPrefixStatus describes the observed state of a public IP prefix.
+type PublicIPPrefixStatus struct {
+	// ID is the Azure resource ID of the public IP prefix.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// IPPrefix is the allocated public IP prefix, in CIDR notation.
+	// +optional
+	IPPrefix string `json:"ipPrefix,omitempty"`
+}
+
+// SNATPortsStatus describes the SNAT port allocation of an automatically sized outbound load balancer.
+type SNATPortsStatus struct {
+	// MaxNodeCount is the maximum number of nodes the load balancer is sized for.
+	MaxNodeCount int32 `json:"maxNodeCount"`
+
+	// AllocatedOutboundPorts is the number of SNAT ports allocated to each node.
+	AllocatedOutboundPorts int32 `json:"allocatedOutboundPorts"`
+}
+
+// OutboundType enumerates the ways egress traffic can leave the cluster's subnets.
+type OutboundType string
+
+const (
+	// OutboundTypeUserDefinedRouting routes egress traffic to a user-provided next hop, e.g. an Azure Firewall or a network virtual appliance.
+	OutboundTypeUserDefinedRouting OutboundType = "userDefinedRouting"
+)
+
+// UserDefinedRouting configures the default route programmed on the control plane and node subnets' route tables.
+type UserDefinedRouting struct {
+	// NextHopIPAddress is the private IP address of the Azure Firewall or network virtual appliance
+	// that egress traffic from the control plane and node subnets is routed to.
+	NextHopIPAddress string `json:"nextHopIPAddress"`
+}
+
 // VnetSpec configures an Azure virtual network.
 type VnetSpec struct {
 	// ResourceGroup is the name of the resource group of the existing virtual network
@@ -148,6 +294,17 @@ type VnetPeeringClassSpec struct {
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
 
+	// SubscriptionID is the ID of the subscription of the remote virtual network.
+	// Defaults to the subscription of the AzureCluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to the AzureClusterIdentity used to manage the reverse peering in the remote virtual network.
+	// When the identity belongs to another tenant than the AzureCluster's identity, both peerings are created with tokens
+	// from both tenants. Defaults to the identity of the AzureCluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
 	// ForwardPeeringProperties specifies VnetPeeringProperties for peering from the cluster's virtual network to the
 	// remote virtual network.
 	// +optional
@@ -187,6 +344,20 @@ type VnetPeeringProperties struct {
 // VnetPeerings is a slice of VnetPeering.
 type VnetPeerings []VnetPeeringSpec
 
+// VnetPeeringStatus is the observed state of a virtual network peering.
+type VnetPeeringStatus struct {
+	// Name is the name of the virtual network peering.
+	Name string `json:"name"`
+
+	// PeeringState is the state of the peering, one of Initiated, Connected or Disconnected.
+	// +optional
+	PeeringState string `json:"peeringState,omitempty"`
+
+	// PeeringSyncLevel tells whether the peering is in sync with the address spaces of the local and remote virtual networks.
+	// +optional
+	PeeringSyncLevel string `json:"peeringSyncLevel,omitempty"`
+}
+
 // IsManaged returns true if the vnet is managed.
 func (v *VnetSpec) IsManaged(clusterName string) bool {
 	return v.ID == "" || v.Tags.HasOwned(clusterName)
@@ -225,6 +396,41 @@ type RouteTable struct {
 	// +optional
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+	// Routes are the routes CAPZ manages in the route table.
+	// Routes added to the route table by other components, such as the cloud-controller-manager, are left untouched.
+	// +optional
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// RouteNextHopType is the type of Azure hop a route sends its packets to.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopVirtualNetworkGateway sends packets to the virtual network gateway.
+	RouteNextHopVirtualNetworkGateway RouteNextHopType = "VirtualNetworkGateway"
+	// RouteNextHopVnetLocal sends packets within the virtual network.
+	RouteNextHopVnetLocal RouteNextHopType = "VnetLocal"
+	// RouteNextHopInternet sends packets to the Internet.
+	RouteNextHopInternet RouteNextHopType = "Internet"
+	// RouteNextHopVirtualAppliance sends packets to a virtual appliance, such as a firewall.
+	RouteNextHopVirtualAppliance RouteNextHopType = "VirtualAppliance"
+	// RouteNextHopNone drops packets.
+	RouteNextHopNone RouteNextHopType = "None"
+)
+
+// Route defines a route of an Azure route table.
+type Route struct {
+	// Name is the name of the route, unique within the route table.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// AddressPrefix is the destination CIDR the route applies to.
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of Azure hop the packets are sent to.
+	// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+	NextHopType RouteNextHopType `json:"nextHopType"`
+	// NextHopIPAddress is the IP address packets are forwarded to. Only allowed, and required, when NextHopType is VirtualAppliance.
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
 }
 
 // NatGateway defines an Azure NAT gateway.
@@ -297,6 +503,14 @@ type SecurityRule struct {
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// SourceApplicationSecurityGroups is a list of names of the cluster's application security groups network traffic originates from.
+	// Cannot be used together with Source.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups is a list of names of the cluster's application security groups network traffic is sent to.
+	// Cannot be used together with Destination.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
 }
 
 // SecurityRules is a slice of Azure security rules for security groups.
@@ -317,13 +531,169 @@ type LoadBalancerSpec struct {
 	// FrontendIPsCount specifies the number of frontend IP addresses for the load balancer.
 	// +optional
 	FrontendIPsCount *int32 `json:"frontendIPsCount,omitempty"`
+	// IPv6FrontendIPs are the IPv6 frontend IPs used for the egress of the IPv6 addresses of dual-stack nodes.
+	// Defaults to a single frontend IP when a cluster with a dual-stack node subnet is created, and cannot be set on an existing
+	// load balancer afterwards. Frontend IPs can then be added but not modified or removed.
+	// Only supported for the node outbound load balancer.
+	// +optional
+	IPv6FrontendIPs []FrontendIP `json:"ipv6FrontendIPs,omitempty"`
+	// SNATPortsPerNode enables the automatic sizing of the node outbound load balancer. CAPZ computes the number of
+	// frontend IPs and the SNAT ports allocated to each node from the maximum node count of the cluster's MachineDeployments
+	// and MachinePools, so that each node gets this number of SNAT ports. FrontendIPsCount is then managed by CAPZ and only grows.
+	// Must be a multiple of 8. Only supported for the node outbound load balancer.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=64000
+	// +optional
+	SNATPortsPerNode *int32 `json:"snatPortsPerNode,omitempty"`
 	// BackendPool describes the backend pool of the load balancer.
 	// +optional
 	BackendPool BackendPool `json:"backendPool,omitempty"`
+	// PrivateLinkService configures an Azure Private Link Service in front of the load balancer frontend IP, so
+	// consumers in other virtual networks or tenants can reach it through private endpoints.
+	// Only supported for the internal API server load balancer.
+	// +optional
+	PrivateLinkService *PrivateLinkService `json:"privateLinkService,omitempty"`
+	// HealthProbe configures the HTTPS health probe of the API server.
+	// Only supported for the API server load balancer.
+	// +optional
+	HealthProbe *LoadBalancerHealthProbe `json:"healthProbe,omitempty"`
+	// AdditionalRules are load-balancing rules added next to the API server rule, e.g. to expose konnectivity.
+	// Rules cannot be modified or removed once added.
+	// Only supported for the API server load balancer.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AdditionalRules []LoadBalancingRule `json:"additionalRules,omitempty"`
+	// InternalLB adds an internal load balancer exposing the API server on a private IP of the control plane subnet,
+	// so clients in the virtual network can reach the API server privately while external clients use the public endpoint.
+	// Only supported for a public API server load balancer, and can only be set when the cluster is created.
+	// +optional
+	InternalLB *InternalLoadBalancer `json:"internalLB,omitempty"`
 
 	LoadBalancerClassSpec `json:",inline"`
 }
 
+// LoadBalancerHealthProbe defines the HTTPS health probe of the API server load balancer.
+type LoadBalancerHealthProbe struct {
+	// RequestPath is the path of the HTTPS request sent to the API server. Defaults to /readyz.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+	// IntervalInSeconds is the interval between two probes. Defaults to 15.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalInSeconds *int32 `json:"intervalInSeconds,omitempty"`
+	// NumberOfProbes is the number of consecutive failed probes after which an endpoint is taken out of rotation.
+	// Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfProbes *int32 `json:"numberOfProbes,omitempty"`
+}
+
+// LoadBalancingRuleProtocol defines the transport protocol of a load-balancing rule.
+type LoadBalancingRuleProtocol string
+
+const (
+	// LoadBalancingRuleProtocolTCP is the TCP load-balancing rule protocol.
+	LoadBalancingRuleProtocolTCP = LoadBalancingRuleProtocol("Tcp")
+	// LoadBalancingRuleProtocolUDP is the UDP load-balancing rule protocol.
+	LoadBalancingRuleProtocolUDP = LoadBalancingRuleProtocol("Udp")
+)
+
+// LoadBalancingRule defines a load-balancing rule from a frontend port to the control plane machines.
+type LoadBalancingRule struct {
+	// Name of the load-balancing rule. Must be unique, and cannot be LBRuleHTTPS or HTTPS, which are used by the API server rule and probe.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Protocol of the rule. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Udp
+	// +optional
+	Protocol LoadBalancingRuleProtocol `json:"protocol,omitempty"`
+	// FrontendPort is the port of the load balancer frontend.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65534
+	FrontendPort int32 `json:"frontendPort"`
+	// BackendPort is the port of the control plane machines. Defaults to the frontend port.
+	// TCP rules are probed with a TCP health probe on the backend port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	BackendPort int32 `json:"backendPort,omitempty"`
+}
+
+// InternalLoadBalancer defines an internal load balancer exposing the API server next to a public API server load balancer.
+type InternalLoadBalancer struct {
+	// Name of the internal load balancer. Defaults to <cluster name>-internal-lb.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	FrontendIPClass `json:",inline"`
+}
+
+// PrivateLinkService defines an Azure Private Link Service fronting a load balancer.
+type PrivateLinkService struct {
+	// Name of the private link service. Defaults to <load balancer name>-pls.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// NATSubnetName is the name of the subnet the NAT IP addresses are allocated from.
+	// Defaults to the control plane subnet.
+	// +optional
+	NATSubnetName string `json:"natSubnetName,omitempty"`
+	// NATIPConfigurations are the IP configurations used to source NAT the traffic of the consumers.
+	// Defaults to a single dynamically allocated IP address.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	NATIPConfigurations []PrivateLinkServiceNATIPConfiguration `json:"natIPConfigurations,omitempty"`
+	// VisibilitySubscriptions is the list of subscription IDs that can see the private link service and
+	// request a private endpoint connection to it. Use "*" to make it visible to every subscription.
+	// +optional
+	VisibilitySubscriptions []string `json:"visibilitySubscriptions,omitempty"`
+	// AutoApprovalSubscriptions is the list of subscription IDs whose private endpoint connections are approved
+	// automatically. Connections from other subscriptions have to be approved manually.
+	// +optional
+	AutoApprovalSubscriptions []string `json:"autoApprovalSubscriptions,omitempty"`
+	// EnableProxyProtocol enables the TCP PROXY protocol v2 on the private link service.
+	// +optional
+	EnableProxyProtocol *bool `json:"enableProxyProtocol,omitempty"`
+}
+
+// PrivateLinkServiceNATIPConfiguration defines a NAT IP configuration of a private link service.
+type PrivateLinkServiceNATIPConfiguration struct {
+	// Name of the IP configuration.
+	Name string `json:"name"`
+	// PrivateIPAddress is the static private IP address of the IP configuration.
+	// The IP address is allocated dynamically when empty.
+	// +optional
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
+}
+
+// PrivateLinkServiceStatus describes the observed state of a private link service.
+type PrivateLinkServiceStatus struct {
+	// ID is the Azure resource ID of the private link service.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Alias is the globally unique name consumers use to create a private endpoint to the private link service.
+	// +optional
+	Alias string `json:"alias,omitempty"`
+	// PrivateEndpointConnections are the private endpoint connections of the consumers.
+	// +optional
+	PrivateEndpointConnections []PrivateEndpointConnection `json:"privateEndpointConnections,omitempty"`
+}
+
+// PrivateEndpointConnection describes a consumer private endpoint connected to a private link service.
+type PrivateEndpointConnection struct {
+	// Name of the private endpoint connection.
+	Name string `json:"name"`
+	// PrivateEndpointID is the Azure resource ID of the consumer private endpoint.
+	// +optional
+	PrivateEndpointID string `json:"privateEndpointID,omitempty"`
+	// Status of the connection, e.g. Pending, Approved, Rejected or Disconnected.
+	// +optional
+	Status string `json:"status,omitempty"`
+	// Description of the connection status.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
 // SKU defines an Azure load balancer SKU.
 type SKU string
 
@@ -359,6 +729,49 @@ type PublicIPSpec struct {
 	DNSName string `json:"dnsName,omitempty"`
 	// +optional
 	IPTags []IPTag `json:"ipTags,omitempty"`
+	// Zones configures the availability zones of the public IP.
+	// Defaults to zone-redundant across the failure domains of the cluster, or to the zone of the subnet
+	// for the public IP of the NAT gateway of a subnet pinned to a zone.
+	// +optional
+	Zones *PublicIPZones `json:"zones,omitempty"`
+}
+
+// PublicIPZonePlacement defines how a public IP is placed in availability zones.
+type PublicIPZonePlacement string
+
+const (
+	// PublicIPZoneRedundant places a public IP in all the failure domains of the cluster.
+	PublicIPZoneRedundant PublicIPZonePlacement = "ZoneRedundant"
+	// PublicIPZonal places a public IP in a single availability zone.
+	PublicIPZonal PublicIPZonePlacement = "Zonal"
+	// PublicIPNoZone creates a public IP without any availability zone.
+	PublicIPNoZone PublicIPZonePlacement = "NoZone"
+)
+
+// PublicIPZones defines the availability zones of a public IP.
+type PublicIPZones struct {
+	// Placement is ZoneRedundant to place the public IP in all the failure domains of the cluster,
+	// Zonal to place it in Zone only, or NoZone to create it without any availability zone.
+	// +kubebuilder:validation:Enum=ZoneRedundant;Zonal;NoZone
+	Placement PublicIPZonePlacement `json:"placement"`
+	// Zone is the availability zone of a Zonal public IP.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// GetZones returns the availability zones of the public IP given the zones it defaults to.
+func (ip PublicIPSpec) GetZones(defaultZones []string) []string {
+	if ip.Zones == nil {
+		return defaultZones
+	}
+	switch ip.Zones.Placement {
+	case PublicIPZonal:
+		return []string{ip.Zones.Zone}
+	case PublicIPNoZone:
+		return []string{}
+	default:
+		return defaultZones
+	}
 }
 
 // IPTag contains the IpTag associated with the object.
@@ -762,6 +1175,61 @@ type PrivateEndpointSpec struct {
 	// Defaults to false.
 	// +optional
 	ManualApproval bool `json:"manualApproval,omitempty"`
+	// PrivateDNSZone configures the private DNS zone in which the private endpoint registers its IP addresses,
+	// through a private DNS zone group. The zone is linked to the cluster virtual network.
+	// +optional
+	PrivateDNSZone *PrivateEndpointDNSZone `json:"privateDNSZone,omitempty"`
+}
+
+// PrivateEndpointDNSZone defines the private DNS zone of a private endpoint.
+// If neither Name nor ID is set, the zone name is inferred from the group ID of the first private link service connection.
+type PrivateEndpointDNSZone struct {
+	// Name specifies the name of the private DNS zone, for example privatelink.blob.core.windows.net.
+	// The zone is created in the cluster resource group, unless it already exists there, in which case it is reused as is.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// ID specifies the resource ID of an existing private DNS zone. CAPZ links the zone to the cluster virtual network
+	// but never modifies nor deletes it. Cannot be set along with Name.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// privateEndpointDNSZoneNames maps the group IDs of the most common private link resources to the name of their
+// private DNS zone in the Azure public cloud.
+var privateEndpointDNSZoneNames = map[string]string{
+	"blob":                "privatelink.blob.core.windows.net",
+	"blob_secondary":      "privatelink.blob.core.windows.net",
+	"file":                "privatelink.file.core.windows.net",
+	"queue":               "privatelink.queue.core.windows.net",
+	"table":               "privatelink.table.core.windows.net",
+	"web":                 "privatelink.web.core.windows.net",
+	"dfs":                 "privatelink.dfs.core.windows.net",
+	"vault":               "privatelink.vaultcore.azure.net",
+	"registry":            "privatelink.azurecr.io",
+	"sqlServer":           "privatelink.database.windows.net",
+	"Sql":                 "privatelink.documents.azure.com",
+	"namespace":           "privatelink.servicebus.windows.net",
+	"configurationStores": "privatelink.azconfig.io",
+	"redisCache":          "privatelink.redis.cache.windows.net",
+	"postgresqlServer":    "privatelink.postgres.database.azure.com",
+	"mysqlServer":         "privatelink.mysql.database.azure.com",
+}
+
+// GetPrivateDNSZoneName returns the name of the private DNS zone of the private endpoint, or "" if the private endpoint
+// has no private DNS zone or if its name cannot be inferred from the group ID of its first private link service connection.
+func (pe PrivateEndpointSpec) GetPrivateDNSZoneName() string {
+	switch {
+	case pe.PrivateDNSZone == nil:
+		return ""
+	case pe.PrivateDNSZone.Name != "":
+		return pe.PrivateDNSZone.Name
+	case pe.PrivateDNSZone.ID != "":
+		return path.Base(pe.PrivateDNSZone.ID)
+	case len(pe.PrivateLinkServiceConnections) == 0 || len(pe.PrivateLinkServiceConnections[0].GroupIDs) == 0:
+		return ""
+	default:
+		return privateEndpointDNSZoneNames[pe.PrivateLinkServiceConnections[0].GroupIDs[0]]
+	}
 }
 
 // NetworkInterface defines a network interface.
@@ -780,6 +1248,38 @@ type NetworkInterface struct {
 	// +kubebuilder:validation:nullable
 	// +optional
 	AcceleratedNetworking *bool `json:"acceleratedNetworking,omitempty"`
+
+	// PrivateIPAddress configures a static private IP address for the primary IP configuration of the network interface.
+	// If omitted, the private IP address is dynamically assigned by Azure.
+	// +optional
+	PrivateIPAddress *PrivateIPAddressSpec `json:"privateIPAddress,omitempty"`
+}
+
+// PrivateIPAddressSpec defines how the static private IP address of a network interface is obtained.
+// Exactly one of Address, FromSubnetPool or PoolRef must be set.
+type PrivateIPAddressSpec struct {
+	// Address is an explicit static private IP address in the subnet of the network interface.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// FromSubnetPool allocates the address from the static IP pool of the subnet of the network interface,
+	// defined on the AzureCluster. The address is released when the machine is deleted.
+	// +optional
+	FromSubnetPool bool `json:"fromSubnetPool,omitempty"`
+
+	// PoolRef is a reference to a Cluster API IPAM pool in the namespace of the machine. The address is claimed
+	// through an IPAddressClaim and released when the machine is deleted.
+	// +optional
+	PoolRef *corev1.TypedLocalObjectReference `json:"poolRef,omitempty"`
+}
+
+// PrivateIPAddressRange defines an inclusive range of private IP addresses.
+type PrivateIPAddressRange struct {
+	// Start is the first IP address of the range.
+	Start string `json:"start"`
+
+	// End is the last IP address of the range.
+	End string `json:"end"`
 }
 
 // GetControlPlaneSubnet returns the cluster control plane subnet.
@@ -817,6 +1317,9 @@ func (s SubnetSpec) IsNatGatewayEnabled() bool {
 
 // IsIPv6Enabled returns whether or not IPv6 is enabled on the subnet.
 func (s SubnetSpec) IsIPv6Enabled() bool {
+	if s.IPv6PrefixLength != nil {
+		return true
+	}
 	for _, cidr := range s.CIDRBlocks {
 		if net.IsIPv6CIDRString(cidr) {
 			return true
@@ -984,6 +1487,27 @@ type AzureBastion struct {
 	// +kubebuilder:default=false
 	// +optional
 	EnableTunneling bool `json:"enableTunneling,omitempty"`
+	// ScaleUnits configures the number of instances of the Azure Bastion Host, which determines how many concurrent
+	// sessions it supports. Can only be set with the Standard SKU. Defaults to 2.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	ScaleUnits *int32 `json:"scaleUnits,omitempty"`
+	// EnableIPConnect enables connecting to virtual machines through their private IP address.
+	// Can only be enabled with the Standard SKU. Defaults to false.
+	// +kubebuilder:default=false
+	// +optional
+	EnableIPConnect bool `json:"enableIPConnect,omitempty"`
+	// EnableShareableLink enables accessing virtual machines through shareable links, without using the Azure portal.
+	// Can only be enabled with the Standard SKU. Defaults to false.
+	// +kubebuilder:default=false
+	// +optional
+	EnableShareableLink bool `json:"enableShareableLink,omitempty"`
+	// EnableFileCopy enables uploading and downloading files through the native client.
+	// Can only be enabled with the Standard SKU. Defaults to false.
+	// +kubebuilder:default=false
+	// +optional
+	EnableFileCopy bool `json:"enableFileCopy,omitempty"`
 }
 
 // BackendPool describes the backend pool of the load balancer.
@@ -1009,6 +1533,24 @@ type Diagnostics struct {
 	Boot *BootDiagnostics `json:"boot,omitempty"`
 }
 
+// BootstrapWatchdog configures the detection of bootstrap failures of a virtual machine.
+type BootstrapWatchdog struct {
+	// Timeout is the amount of time to wait for the Machine to get a NodeRef once the VM is running
+	// before the boot diagnostics serial log is inspected. Defaults to 20m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// MachineResourceHealth configures how the Azure Resource Health availability status of a virtual machine is acted upon.
+type MachineResourceHealth struct {
+	// RemediateAfter is the amount of time a VM can be unavailable because of a platform-initiated event before the
+	// AzureMachine is marked as failed, so that it gets remediated by a MachineHealthCheck.
+	// Unavailability initiated by the user or by the guest OS never marks the AzureMachine as failed.
+	// If not set, platform-initiated unavailability is only reported in the AzureResourceAvailable condition.
+	// +optional
+	RemediateAfter *metav1.Duration `json:"remediateAfter,omitempty"`
+}
+
 // BootDiagnostics configures the boot diagnostics settings for the virtual machine.
 // This allows you to configure capturing serial output from the virtual machine on boot.
 // This is useful for debugging software based launch issues.
@@ -1059,6 +1601,55 @@ type UserManagedBootDiagnostics struct {
 	StorageAccountURI string `json:"storageAccountURI"`
 }
 
+// BootstrapDataAccessMethod defines how a VM accesses bootstrap data staged in Azure Blob Storage.
+type BootstrapDataAccessMethod string
+
+const (
+	// BootstrapDataAccessMethodSAS grants the VM access to the bootstrap data blob through a short-lived,
+	// read-only shared access signature embedded in the customData stub.
+	BootstrapDataAccessMethodSAS BootstrapDataAccessMethod = "SAS"
+	// BootstrapDataAccessMethodManagedIdentity makes the VM fetch the bootstrap data blob using its managed identity.
+	// The identity must be granted the Storage Blob Data Reader role on the storage account or container.
+	// This method is only supported with Ignition bootstrap data.
+	BootstrapDataAccessMethodManagedIdentity BootstrapDataAccessMethod = "ManagedIdentity"
+)
+
+// BootstrapDataBlobState describes the lifecycle of the blob staging the bootstrap data of a machine.
+// +kubebuilder:validation:Enum=Uploaded;Deleted
+type BootstrapDataBlobState string
+
+const (
+	// BootstrapDataBlobUploaded means the bootstrap data blob was uploaded and is waiting for the machine to bootstrap.
+	BootstrapDataBlobUploaded BootstrapDataBlobState = "Uploaded"
+	// BootstrapDataBlobDeleted means the bootstrap data blob was deleted after the machine's Node joined the cluster.
+	BootstrapDataBlobDeleted BootstrapDataBlobState = "Deleted"
+)
+
+// BootstrapDataStorage defines an Azure Blob Storage container used to stage bootstrap data.
+type BootstrapDataStorage struct {
+	// StorageAccountName is the name of an existing storage account used to stage the bootstrap data.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=24
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+$`
+	StorageAccountName string `json:"storageAccountName"`
+
+	// ContainerName is the name of the blob container holding the bootstrap data.
+	// The container is created if it does not exist. If not specified, the cluster name is used.
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// AccessMethod defines how the VM accesses the bootstrap data blob.
+	// +kubebuilder:validation:Enum=SAS;ManagedIdentity
+	// +kubebuilder:default=SAS
+	// +optional
+	AccessMethod BootstrapDataAccessMethod `json:"accessMethod,omitempty"`
+
+	// SASExpiry is the validity period of the shared access signature, when AccessMethod is SAS.
+	// Defaults to 1 hour.
+	// +optional
+	SASExpiry *metav1.Duration `json:"sasExpiry,omitempty"`
+}
+
 // OrchestrationModeType represents the orchestration mode for a Virtual Machine Scale Set backing an AzureMachinePool.
 // +kubebuilder:validation:Enum=Flexible;Uniform
 type OrchestrationModeType string
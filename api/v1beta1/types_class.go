@@ -73,6 +73,54 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// PrivateDNSZoneID is the resource ID of an existing Azure Private DNS zone to use instead of creating one,
+	// e.g. a zone owned by a central team in another subscription or resource group.
+	// CAPZ never modifies nor deletes the zone, and only manages the virtual network links and records it creates in it.
+	// Cannot be combined with PrivateDNSZoneName.
+	// +optional
+	PrivateDNSZoneID string `json:"privateDNSZoneID,omitempty"`
+
+	// PrivateDNSNodeRecords enables an A record in the private DNS zone of the cluster for each AzureMachine,
+	// named after the machine and pointing at its private IP. The records are maintained as machines come and go.
+	// Requires an internal API server load balancer or PrivateDNSZoneID.
+	// +optional
+	PrivateDNSNodeRecords bool `json:"privateDNSNodeRecords,omitempty"`
+
+	// OutboundType specifies how egress traffic leaves the cluster's control plane and node subnets.
+	// When unset, CAPZ provides outbound connectivity through outbound load balancers and NAT gateways.
+	// When set to userDefinedRouting, CAPZ creates no outbound load balancer, NAT gateway or outbound public IP,
+	// and instead routes all egress traffic to the next hop configured in UserDefinedRouting.
+	// +kubebuilder:validation:Enum=userDefinedRouting
+	// +optional
+	OutboundType *OutboundType `json:"outboundType,omitempty"`
+
+	// UserDefinedRouting configures the egress route used when OutboundType is userDefinedRouting.
+	// +optional
+	UserDefinedRouting *UserDefinedRouting `json:"userDefinedRouting,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups created for the cluster.
+	// Security rules can reference them by name as source or destination, and machines are associated with them
+	// either through their role or by listing them in their spec.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ApplicationSecurityGroups []ApplicationSecurityGroup `json:"applicationSecurityGroups,omitempty"`
+}
+
+// ApplicationSecurityGroup defines an Azure application security group for the cluster.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string `json:"name"`
+	// Role associates the network interfaces of every machine with the given role with the application security group.
+	// +kubebuilder:validation:Enum=control-plane;node
+	// +optional
+	Role SubnetRole `json:"role,omitempty"`
+}
+
+// IsUserDefinedRouting returns true if egress traffic is routed through a user-provided next hop.
+func (n NetworkClassSpec) IsUserDefinedRouting() bool {
+	return n.OutboundType != nil && *n.OutboundType == OutboundTypeUserDefinedRouting
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
@@ -84,6 +132,22 @@ type VnetClassSpec struct {
 	// Tags is a collection of tags describing the resource.
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+
+	// DDoSProtectionPlanID is the Azure resource ID of an existing DDoS protection plan to associate with the virtual network.
+	// +optional
+	DDoSProtectionPlanID string `json:"ddosProtectionPlanID,omitempty"`
+
+	// DNSServers is the list of IP addresses of the custom DNS servers of the virtual network.
+	// The Azure-provided DNS is used when empty.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// Shared marks the virtual network created by CAPZ as shared by several AzureClusters.
+	// Every AzureCluster setting Shared with the same virtual network name and resource group uses the same virtual network
+	// and manages its own subnets, security groups and route tables in it. The virtual network is deleted with the last of them.
+	// A shared virtual network must be in another resource group than the ones of the AzureClusters.
+	// +optional
+	Shared bool `json:"shared,omitempty"`
 }
 
 // SubnetClassSpec defines the SubnetSpec properties that may be shared across several Azure clusters.
@@ -99,6 +163,33 @@ type SubnetClassSpec struct {
 	// +optional
 	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
 
+	// PrefixLength is the length of the IPv4 address prefix to allocate to the subnet from the virtual network's address space.
+	// When set and CIDRBlocks is empty, a non-overlapping CIDR block is allocated automatically and persisted in CIDRBlocks.
+	// Only subnets of a managed virtual network get their CIDR blocks allocated.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=29
+	// +optional
+	PrefixLength *int32 `json:"prefixLength,omitempty"`
+
+	// IPv6PrefixLength is the length of the IPv6 address prefix to allocate to the subnet from the virtual network's address space.
+	// Azure only supports /64 IPv6 subnets.
+	// +kubebuilder:validation:Minimum=64
+	// +kubebuilder:validation:Maximum=64
+	// +optional
+	IPv6PrefixLength *int32 `json:"ipv6PrefixLength,omitempty"`
+
+	// StaticIPPool is a range of private IP addresses of the subnet that static private IP addresses of machines are allocated
+	// from, for network interfaces requesting one from the pool of their subnet. The range must be reserved for CAPZ,
+	// and cannot include the first four addresses and the last address of the subnet, which are reserved by Azure.
+	// +optional
+	StaticIPPool *PrivateIPAddressRange `json:"staticIPPool,omitempty"`
+
+	// Zone is the availability zone of a node subnet. When set, the subnet's NAT gateway and its public IP are
+	// created in that zone, and machines placed in the matching failure domain without an explicit subnet name
+	// are attached to this subnet. Spreading node subnets across zones keeps egress working through a zone outage.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
 	// ServiceEndpoints is a slice of Virtual Network service endpoints to enable for the subnets.
 	// +optional
 	ServiceEndpoints ServiceEndpoints `json:"serviceEndpoints,omitempty"`
@@ -149,11 +240,21 @@ func (vc *VnetClassSpec) setDefaults() {
 
 // setDefaults sets default values for SubnetClassSpec.
 func (sc *SubnetClassSpec) setDefaults(cidr string) {
-	if len(sc.CIDRBlocks) == 0 {
+	if len(sc.CIDRBlocks) == 0 && !sc.HasCIDRAllocation() {
 		sc.CIDRBlocks = []string{cidr}
 	}
 }
 
+// HasCIDRAllocation returns true if the subnet's CIDR blocks are allocated automatically from the virtual network's address space.
+func (sc SubnetClassSpec) HasCIDRAllocation() bool {
+	return sc.PrefixLength != nil || sc.IPv6PrefixLength != nil
+}
+
+// NeedsCIDRAllocation returns true if the subnet's CIDR blocks are allocated automatically but have not been allocated yet.
+func (sc SubnetClassSpec) NeedsCIDRAllocation() bool {
+	return sc.HasCIDRAllocation() && len(sc.CIDRBlocks) == 0
+}
+
 // setDefaults sets default values for SecurityGroupClass.
 func (sgc *SecurityGroupClass) setDefaults() {
 	for i := range sgc.SecurityRules {
@@ -111,6 +111,9 @@ func (s SubnetTemplateSpec) IsNatGatewayEnabled() bool {
 
 // IsIPv6Enabled returns whether or not IPv6 is enabled on the subnet.
 func (s SubnetTemplateSpec) IsIPv6Enabled() bool {
+	if s.IPv6PrefixLength != nil {
+		return true
+	}
 	for _, cidr := range s.CIDRBlocks {
 		if net.IsIPv6CIDRString(cidr) {
 			return true
@@ -99,6 +99,31 @@ func (in *APIServerAccessProfile) DeepCopy() *APIServerAccessProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerDNS) DeepCopyInto(out *APIServerDNS) {
+	*out = *in
+	if in.CNAMERecordNames != nil {
+		in, out := &in.CNAMERecordNames, &out.CNAMERecordNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDNS.
+func (in *APIServerDNS) DeepCopy() *APIServerDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalCapabilities) DeepCopyInto(out *AdditionalCapabilities) {
 	*out = *in
@@ -181,6 +206,21 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSecurityGroup) DeepCopyInto(out *ApplicationSecurityGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSecurityGroup.
+func (in *ApplicationSecurityGroup) DeepCopy() *ApplicationSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalerProfile) DeepCopyInto(out *AutoScalerProfile) {
 	*out = *in
@@ -286,6 +326,11 @@ func (in *AzureBastion) DeepCopyInto(out *AzureBastion) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
 	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	if in.ScaleUnits != nil {
+		in, out := &in.ScaleUnits, &out.ScaleUnits
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBastion.
@@ -553,6 +598,26 @@ func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateLinkService != nil {
+		in, out := &in.PrivateLinkService, &out.PrivateLinkService
+		*out = new(PrivateLinkServiceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicIPPrefix != nil {
+		in, out := &in.PublicIPPrefix, &out.PublicIPPrefix
+		*out = new(PublicIPPrefixStatus)
+		**out = **in
+	}
+	if in.NodeOutboundSNAT != nil {
+		in, out := &in.NodeOutboundSNAT, &out.NodeOutboundSNAT
+		*out = new(SNATPortsStatus)
+		**out = **in
+	}
+	if in.VnetPeerings != nil {
+		in, out := &in.VnetPeerings, &out.VnetPeerings
+		*out = make([]VnetPeeringStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterStatus.
@@ -849,6 +914,26 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootstrapDataStorage != nil {
+		in, out := &in.BootstrapDataStorage, &out.BootstrapDataStorage
+		*out = new(BootstrapDataStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootstrapWatchdog != nil {
+		in, out := &in.BootstrapWatchdog, &out.BootstrapWatchdog
+		*out = new(BootstrapWatchdog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceHealth != nil {
+		in, out := &in.ResourceHealth, &out.ResourceHealth
+		*out = new(MachineResourceHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineSpec.
@@ -896,6 +981,13 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineStatus.
@@ -1617,6 +1709,46 @@ func (in *BootDiagnostics) DeepCopy() *BootDiagnostics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapDataStorage) DeepCopyInto(out *BootstrapDataStorage) {
+	*out = *in
+	if in.SASExpiry != nil {
+		in, out := &in.SASExpiry, &out.SASExpiry
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapDataStorage.
+func (in *BootstrapDataStorage) DeepCopy() *BootstrapDataStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapDataStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapWatchdog) DeepCopyInto(out *BootstrapWatchdog) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapWatchdog.
+func (in *BootstrapWatchdog) DeepCopy() *BootstrapWatchdog {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapWatchdog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -1762,6 +1894,28 @@ func (in *ExtendedLocationSpec) DeepCopy() *ExtendedLocationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionStatus) DeepCopyInto(out *ExtensionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionStatus.
+func (in *ExtensionStatus) DeepCopy() *ExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
 	*out = *in
@@ -1897,6 +2051,22 @@ func (in *ImagePlan) DeepCopy() *ImagePlan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalLoadBalancer) DeepCopyInto(out *InternalLoadBalancer) {
+	*out = *in
+	out.FrontendIPClass = in.FrontendIPClass
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalLoadBalancer.
+func (in *InternalLoadBalancer) DeepCopy() *InternalLoadBalancer {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalLoadBalancer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
 	*out = *in
@@ -2022,6 +2192,31 @@ func (in *LoadBalancerClassSpec) DeepCopy() *LoadBalancerClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthProbe) DeepCopyInto(out *LoadBalancerHealthProbe) {
+	*out = *in
+	if in.IntervalInSeconds != nil {
+		in, out := &in.IntervalInSeconds, &out.IntervalInSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumberOfProbes != nil {
+		in, out := &in.NumberOfProbes, &out.NumberOfProbes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthProbe.
+func (in *LoadBalancerHealthProbe) DeepCopy() *LoadBalancerHealthProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
 	*out = *in
@@ -2077,7 +2272,39 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.IPv6FrontendIPs != nil {
+		in, out := &in.IPv6FrontendIPs, &out.IPv6FrontendIPs
+		*out = make([]FrontendIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SNATPortsPerNode != nil {
+		in, out := &in.SNATPortsPerNode, &out.SNATPortsPerNode
+		*out = new(int32)
+		**out = **in
+	}
 	out.BackendPool = in.BackendPool
+	if in.PrivateLinkService != nil {
+		in, out := &in.PrivateLinkService, &out.PrivateLinkService
+		*out = new(PrivateLinkService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthProbe != nil {
+		in, out := &in.HealthProbe, &out.HealthProbe
+		*out = new(LoadBalancerHealthProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalRules != nil {
+		in, out := &in.AdditionalRules, &out.AdditionalRules
+		*out = make([]LoadBalancingRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.InternalLB != nil {
+		in, out := &in.InternalLB, &out.InternalLB
+		*out = new(InternalLoadBalancer)
+		**out = **in
+	}
 	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
 }
 
@@ -2091,6 +2318,41 @@ func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancingRule) DeepCopyInto(out *LoadBalancingRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancingRule.
+func (in *LoadBalancingRule) DeepCopy() *LoadBalancingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineResourceHealth) DeepCopyInto(out *MachineResourceHealth) {
+	*out = *in
+	if in.RemediateAfter != nil {
+		in, out := &in.RemediateAfter, &out.RemediateAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineResourceHealth.
+func (in *MachineResourceHealth) DeepCopy() *MachineResourceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineResourceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet) {
 	*out = *in
@@ -2186,6 +2448,26 @@ func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSGFlowLogs) DeepCopyInto(out *NSGFlowLogs) {
+	*out = *in
+	if in.TrafficAnalytics != nil {
+		in, out := &in.TrafficAnalytics, &out.TrafficAnalytics
+		*out = new(TrafficAnalytics)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSGFlowLogs.
+func (in *NSGFlowLogs) DeepCopy() *NSGFlowLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(NSGFlowLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NatGateway) DeepCopyInto(out *NatGateway) {
 	*out = *in
@@ -2221,6 +2503,21 @@ func (in *NatGatewayClassSpec) DeepCopy() *NatGatewayClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
 	*out = *in
+	if in.OutboundType != nil {
+		in, out := &in.OutboundType, &out.OutboundType
+		*out = new(OutboundType)
+		**out = **in
+	}
+	if in.UserDefinedRouting != nil {
+		in, out := &in.UserDefinedRouting, &out.UserDefinedRouting
+		*out = new(UserDefinedRouting)
+		**out = **in
+	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]ApplicationSecurityGroup, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
@@ -2241,6 +2538,11 @@ func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PrivateIPAddress != nil {
+		in, out := &in.PrivateIPAddress, &out.PrivateIPAddress
+		*out = new(PrivateIPAddressSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
@@ -2275,7 +2577,22 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(LoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.NetworkClassSpec = in.NetworkClassSpec
+	if in.PublicIPPrefix != nil {
+		in, out := &in.PublicIPPrefix, &out.PublicIPPrefix
+		*out = new(PublicIPPrefix)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerDNS != nil {
+		in, out := &in.APIServerDNS, &out.APIServerDNS
+		*out = new(APIServerDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(NSGFlowLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -2291,7 +2608,7 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkTemplateSpec) DeepCopyInto(out *NetworkTemplateSpec) {
 	*out = *in
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 	in.Vnet.DeepCopyInto(&out.Vnet)
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -2353,6 +2670,36 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpointConnection) DeepCopyInto(out *PrivateEndpointConnection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointConnection.
+func (in *PrivateEndpointConnection) DeepCopy() *PrivateEndpointConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpointConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpointDNSZone) DeepCopyInto(out *PrivateEndpointDNSZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointDNSZone.
+func (in *PrivateEndpointDNSZone) DeepCopy() *PrivateEndpointDNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpointDNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 	*out = *in
@@ -2373,6 +2720,11 @@ func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateDNSZone != nil {
+		in, out := &in.PrivateDNSZone, &out.PrivateDNSZone
+		*out = new(PrivateEndpointDNSZone)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
@@ -2406,6 +2758,76 @@ func (in PrivateEndpoints) DeepCopy() PrivateEndpoints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateIPAddressRange) DeepCopyInto(out *PrivateIPAddressRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateIPAddressRange.
+func (in *PrivateIPAddressRange) DeepCopy() *PrivateIPAddressRange {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateIPAddressRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateIPAddressSpec) DeepCopyInto(out *PrivateIPAddressSpec) {
+	*out = *in
+	if in.PoolRef != nil {
+		in, out := &in.PoolRef, &out.PoolRef
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateIPAddressSpec.
+func (in *PrivateIPAddressSpec) DeepCopy() *PrivateIPAddressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateIPAddressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkService) DeepCopyInto(out *PrivateLinkService) {
+	*out = *in
+	if in.NATIPConfigurations != nil {
+		in, out := &in.NATIPConfigurations, &out.NATIPConfigurations
+		*out = make([]PrivateLinkServiceNATIPConfiguration, len(*in))
+		copy(*out, *in)
+	}
+	if in.VisibilitySubscriptions != nil {
+		in, out := &in.VisibilitySubscriptions, &out.VisibilitySubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovalSubscriptions != nil {
+		in, out := &in.AutoApprovalSubscriptions, &out.AutoApprovalSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableProxyProtocol != nil {
+		in, out := &in.EnableProxyProtocol, &out.EnableProxyProtocol
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkService.
+func (in *PrivateLinkService) DeepCopy() *PrivateLinkService {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateLinkServiceConnection) DeepCopyInto(out *PrivateLinkServiceConnection) {
 	*out = *in
@@ -2426,6 +2848,76 @@ func (in *PrivateLinkServiceConnection) DeepCopy() *PrivateLinkServiceConnection
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkServiceNATIPConfiguration) DeepCopyInto(out *PrivateLinkServiceNATIPConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkServiceNATIPConfiguration.
+func (in *PrivateLinkServiceNATIPConfiguration) DeepCopy() *PrivateLinkServiceNATIPConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkServiceNATIPConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkServiceStatus) DeepCopyInto(out *PrivateLinkServiceStatus) {
+	*out = *in
+	if in.PrivateEndpointConnections != nil {
+		in, out := &in.PrivateEndpointConnections, &out.PrivateEndpointConnections
+		*out = make([]PrivateEndpointConnection, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkServiceStatus.
+func (in *PrivateLinkServiceStatus) DeepCopy() *PrivateLinkServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPPrefix) DeepCopyInto(out *PublicIPPrefix) {
+	*out = *in
+	if in.PrefixLength != nil {
+		in, out := &in.PrefixLength, &out.PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPPrefix.
+func (in *PublicIPPrefix) DeepCopy() *PublicIPPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPPrefixStatus) DeepCopyInto(out *PublicIPPrefixStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPPrefixStatus.
+func (in *PublicIPPrefixStatus) DeepCopy() *PublicIPPrefixStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPPrefixStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
@@ -2434,6 +2926,11 @@ func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 		*out = make([]IPTag, len(*in))
 		copy(*out, *in)
 	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = new(PublicIPZones)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPSpec.
@@ -2446,6 +2943,21 @@ func (in *PublicIPSpec) DeepCopy() *PublicIPSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPZones) DeepCopyInto(out *PublicIPZones) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPZones.
+func (in *PublicIPZones) DeepCopy() *PublicIPZones {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPZones)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitConfig) DeepCopyInto(out *RateLimitConfig) {
 	*out = *in
@@ -2487,9 +2999,29 @@ func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTable.
@@ -2502,6 +3034,21 @@ func (in *RouteTable) DeepCopy() *RouteTable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATPortsStatus) DeepCopyInto(out *SNATPortsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATPortsStatus.
+func (in *SNATPortsStatus) DeepCopy() *SNATPortsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATPortsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -2595,6 +3142,16 @@ func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
@@ -2702,6 +3259,21 @@ func (in *SubnetClassSpec) DeepCopyInto(out *SubnetClassSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrefixLength != nil {
+		in, out := &in.PrefixLength, &out.PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPv6PrefixLength != nil {
+		in, out := &in.IPv6PrefixLength, &out.IPv6PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StaticIPPool != nil {
+		in, out := &in.StaticIPPool, &out.StaticIPPool
+		*out = new(PrivateIPAddressRange)
+		**out = **in
+	}
 	if in.ServiceEndpoints != nil {
 		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
 		*out = make(ServiceEndpoints, len(*in))
@@ -2732,7 +3304,7 @@ func (in *SubnetClassSpec) DeepCopy() *SubnetClassSpec {
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
-	out.RouteTable = in.RouteTable
+	in.RouteTable.DeepCopyInto(&out.RouteTable)
 	in.NatGateway.DeepCopyInto(&out.NatGateway)
 	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
 }
@@ -3032,6 +3604,21 @@ func (in Taints) DeepCopy() Taints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficAnalytics) DeepCopyInto(out *TrafficAnalytics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficAnalytics.
+func (in *TrafficAnalytics) DeepCopy() *TrafficAnalytics {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficAnalytics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UefiSettings) DeepCopyInto(out *UefiSettings) {
 	*out = *in
@@ -3072,6 +3659,21 @@ func (in *UserAssignedIdentity) DeepCopy() *UserAssignedIdentity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDefinedRouting) DeepCopyInto(out *UserDefinedRouting) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDefinedRouting.
+func (in *UserDefinedRouting) DeepCopy() *UserDefinedRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDefinedRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserManagedBootDiagnostics) DeepCopyInto(out *UserManagedBootDiagnostics) {
 	*out = *in
@@ -3151,6 +3753,11 @@ func (in *VnetClassSpec) DeepCopyInto(out *VnetClassSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetClassSpec.
@@ -3166,6 +3773,11 @@ func (in *VnetClassSpec) DeepCopy() *VnetClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringClassSpec) DeepCopyInto(out *VnetPeeringClassSpec) {
 	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 	in.ForwardPeeringProperties.DeepCopyInto(&out.ForwardPeeringProperties)
 	in.ReversePeeringProperties.DeepCopyInto(&out.ReversePeeringProperties)
 }
@@ -3231,6 +3843,21 @@ func (in *VnetPeeringSpec) DeepCopy() *VnetPeeringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VnetPeeringStatus) DeepCopyInto(out *VnetPeeringStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringStatus.
+func (in *VnetPeeringStatus) DeepCopy() *VnetPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VnetPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in VnetPeerings) DeepCopyInto(out *VnetPeerings) {
 	{
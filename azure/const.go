@@ -35,9 +35,27 @@ const (
 	// for annotation formatting rules.
 	ManagedClusterTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-managedcluster"
 
+	// RouteTableRoutesLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the names of the routes CAPZ applied to each route table, so that the routes removed
+	// from the spec can be deleted without touching the routes added by other components.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	RouteTableRoutesLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-routes"
+
+	// VNetSettingsLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the DDoS protection plan and the DNS servers CAPZ applied to the vnet, so that
+	// the settings removed from the spec can be cleared without touching the settings configured by other components.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	VNetSettingsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-vnet-settings"
+
 	// CustomDataHashAnnotation is the key for the machine object annotation
 	// which tracks the hash of the custom data.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	CustomDataHashAnnotation = "sigs.k8s.io/cluster-api-provider-azure-vmss-custom-data-hash"
+
+	// AutoscalerMaxSizeAnnotation is the key of the MachineDeployment and MachinePool annotation
+	// which holds the maximum size of the node group managed by the cluster-autoscaler.
+	AutoscalerMaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
 )
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// componentStatusPrefix is the prefix of the codes of the extension substatuses reporting the output of a command,
+	// e.g. "ComponentStatus/StdOut/succeeded".
+	componentStatusPrefix = "ComponentStatus"
+	// maxExtensionMessageLength is the maximum length of the condition messages built from an extension instance view.
+	maxExtensionMessageLength = 256
+)
+
+// SDKToExtensionStatus converts the instance view of a VM extension to an ExtensionStatus.
+// Each status and substatus of the instance view is mapped to a condition, whose type is derived from the status code,
+// e.g. "ProvisioningState/failed" is mapped to a false "ProvisioningState" condition.
+func SDKToExtensionStatus(name string, instanceView compute.VirtualMachineExtensionInstanceView) infrav1.ExtensionStatus {
+	status := infrav1.ExtensionStatus{Name: name}
+
+	var statuses []compute.InstanceViewStatus
+	if instanceView.Statuses != nil {
+		statuses = append(statuses, *instanceView.Statuses...)
+	}
+	if instanceView.Substatuses != nil {
+		statuses = append(statuses, *instanceView.Substatuses...)
+	}
+
+	for _, s := range statuses {
+		if s.Code == nil {
+			continue
+		}
+		conditionType, state := parseExtensionStatusCode(*s.Code)
+
+		switch {
+		case s.Level == compute.StatusLevelTypesError || strings.EqualFold(state, "failed"):
+			status.Conditions = append(status.Conditions, *conditions.FalseCondition(conditionType, infrav1.FailedReason, clusterv1.ConditionSeverityError, truncateExtensionMessage(extensionStatusMessage(s))))
+		case strings.EqualFold(state, "succeeded"):
+			status.Conditions = append(status.Conditions, *conditions.TrueCondition(conditionType))
+		case s.Level == compute.StatusLevelTypesWarning:
+			status.Conditions = append(status.Conditions, *conditions.FalseCondition(conditionType, toCamelCase(state), clusterv1.ConditionSeverityWarning, truncateExtensionMessage(extensionStatusMessage(s))))
+		default:
+			status.Conditions = append(status.Conditions, *conditions.FalseCondition(conditionType, toCamelCase(state), clusterv1.ConditionSeverityInfo, truncateExtensionMessage(extensionStatusMessage(s))))
+		}
+	}
+
+	return status
+}
+
+// SDKToVMSSExtensionStatus converts the summary of a VMSS extension across the scale set instances to an ExtensionStatus.
+// The status codes are grouped by condition type, and a condition is true only if all instances report success.
+func SDKToVMSSExtensionStatus(summary compute.VirtualMachineScaleSetVMExtensionsSummary) infrav1.ExtensionStatus {
+	status := infrav1.ExtensionStatus{}
+	if summary.Name != nil {
+		status.Name = *summary.Name
+	}
+	if summary.StatusesSummary == nil {
+		return status
+	}
+
+	type stateCounts struct {
+		failed  bool
+		pending []string
+	}
+	counts := map[clusterv1.ConditionType]*stateCounts{}
+	var conditionTypes []clusterv1.ConditionType
+	for _, codeCount := range *summary.StatusesSummary {
+		if codeCount.Code == nil || codeCount.Count == nil {
+			continue
+		}
+		conditionType, state := parseExtensionStatusCode(*codeCount.Code)
+		c, ok := counts[conditionType]
+		if !ok {
+			c = &stateCounts{}
+			counts[conditionType] = c
+			conditionTypes = append(conditionTypes, conditionType)
+		}
+		if strings.EqualFold(state, "succeeded") {
+			continue
+		}
+		if strings.EqualFold(state, "failed") {
+			c.failed = true
+		}
+		c.pending = append(c.pending, fmt.Sprintf("%d instance(s) %s", *codeCount.Count, state))
+	}
+
+	sort.Slice(conditionTypes, func(i, j int) bool { return conditionTypes[i] < conditionTypes[j] })
+	for _, conditionType := range conditionTypes {
+		c := counts[conditionType]
+		switch {
+		case len(c.pending) == 0:
+			status.Conditions = append(status.Conditions, *conditions.TrueCondition(conditionType))
+		case c.failed:
+			status.Conditions = append(status.Conditions, *conditions.FalseCondition(conditionType, infrav1.FailedReason, clusterv1.ConditionSeverityError, strings.Join(c.pending, ", ")))
+		default:
+			status.Conditions = append(status.Conditions, *conditions.FalseCondition(conditionType, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, strings.Join(c.pending, ", ")))
+		}
+	}
+
+	return status
+}
+
+// parseExtensionStatusCode splits an extension status code into a condition type and a state, e.g.
+// "ProvisioningState/failed/1" into "ProvisioningState" and "failed", or "ComponentStatus/StdErr/succeeded" into
+// "StdErr" and "succeeded". Any trailing segment, such as an exit code, is ignored.
+func parseExtensionStatusCode(code string) (clusterv1.ConditionType, string) {
+	segments := strings.Split(code, "/")
+	if len(segments) > 2 && segments[0] == componentStatusPrefix {
+		segments = segments[1:]
+	}
+	if len(segments) == 1 {
+		return clusterv1.ConditionType(toCamelCase(segments[0])), segments[0]
+	}
+	return clusterv1.ConditionType(segments[0]), segments[1]
+}
+
+// extensionStatusMessage returns the most detailed message of an instance view status.
+func extensionStatusMessage(s compute.InstanceViewStatus) string {
+	if s.Message != nil && *s.Message != "" {
+		return *s.Message
+	}
+	if s.DisplayStatus != nil {
+		return *s.DisplayStatus
+	}
+	return ""
+}
+
+// truncateExtensionMessage keeps the end of long messages, which is usually where errors are reported.
+func truncateExtensionMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if len(message) <= maxExtensionMessageLength {
+		return message
+	}
+	return "..." + message[len(message)-maxExtensionMessageLength:]
+}
+
+// toCamelCase converts an Azure state such as "succeeded" or "in progress" to a CamelCase reason as specified by CAPI.
+func toCamelCase(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(s) {
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestSDKToExtensionStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceView compute.VirtualMachineExtensionInstanceView
+		expected     infrav1.ExtensionStatus
+	}{
+		{
+			name:         "empty instance view",
+			instanceView: compute.VirtualMachineExtensionInstanceView{},
+			expected:     infrav1.ExtensionStatus{Name: "my-extension"},
+		},
+		{
+			name: "succeeded extension",
+			instanceView: compute.VirtualMachineExtensionInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{
+						Code:          pointer.String("ProvisioningState/succeeded"),
+						Level:         compute.StatusLevelTypesInfo,
+						DisplayStatus: pointer.String("Provisioning succeeded"),
+					},
+				},
+				Substatuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    pointer.String("ComponentStatus/StdOut/succeeded"),
+						Level:   compute.StatusLevelTypesInfo,
+						Message: pointer.String("done"),
+					},
+				},
+			},
+			expected: infrav1.ExtensionStatus{
+				Name: "my-extension",
+				Conditions: clusterv1.Conditions{
+					{Type: "ProvisioningState", Status: corev1.ConditionTrue},
+					{Type: "StdOut", Status: corev1.ConditionTrue},
+				},
+			},
+		},
+		{
+			name: "failed extension",
+			instanceView: compute.VirtualMachineExtensionInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{
+						Code:          pointer.String("ProvisioningState/failed/1"),
+						Level:         compute.StatusLevelTypesError,
+						DisplayStatus: pointer.String("Provisioning failed"),
+						Message:       pointer.String("Enable failed: exit status 1"),
+					},
+				},
+			},
+			expected: infrav1.ExtensionStatus{
+				Name: "my-extension",
+				Conditions: clusterv1.Conditions{
+					{
+						Type:     "ProvisioningState",
+						Status:   corev1.ConditionFalse,
+						Severity: clusterv1.ConditionSeverityError,
+						Reason:   infrav1.FailedReason,
+						Message:  "Enable failed: exit status 1",
+					},
+				},
+			},
+		},
+		{
+			name: "transitioning extension",
+			instanceView: compute.VirtualMachineExtensionInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{
+						Code:          pointer.String("ProvisioningState/transitioning"),
+						Level:         compute.StatusLevelTypesInfo,
+						DisplayStatus: pointer.String("Transitioning"),
+					},
+				},
+			},
+			expected: infrav1.ExtensionStatus{
+				Name: "my-extension",
+				Conditions: clusterv1.Conditions{
+					{
+						Type:     "ProvisioningState",
+						Status:   corev1.ConditionFalse,
+						Severity: clusterv1.ConditionSeverityInfo,
+						Reason:   "Transitioning",
+						Message:  "Transitioning",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			g.Expect(SDKToExtensionStatus("my-extension", test.instanceView)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestSDKToVMSSExtensionStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  compute.VirtualMachineScaleSetVMExtensionsSummary
+		expected infrav1.ExtensionStatus
+	}{
+		{
+			name: "succeeded on all instances",
+			summary: compute.VirtualMachineScaleSetVMExtensionsSummary{
+				Name: pointer.String("my-extension"),
+				StatusesSummary: &[]compute.VirtualMachineStatusCodeCount{
+					{Code: pointer.String("ProvisioningState/succeeded"), Count: pointer.Int32(3)},
+				},
+			},
+			expected: infrav1.ExtensionStatus{
+				Name: "my-extension",
+				Conditions: clusterv1.Conditions{
+					{Type: "ProvisioningState", Status: corev1.ConditionTrue},
+				},
+			},
+		},
+		{
+			name: "failed on some instances",
+			summary: compute.VirtualMachineScaleSetVMExtensionsSummary{
+				Name: pointer.String("my-extension"),
+				StatusesSummary: &[]compute.VirtualMachineStatusCodeCount{
+					{Code: pointer.String("ProvisioningState/succeeded"), Count: pointer.Int32(2)},
+					{Code: pointer.String("ProvisioningState/failed/1"), Count: pointer.Int32(1)},
+				},
+			},
+			expected: infrav1.ExtensionStatus{
+				Name: "my-extension",
+				Conditions: clusterv1.Conditions{
+					{
+						Type:     "ProvisioningState",
+						Status:   corev1.ConditionFalse,
+						Severity: clusterv1.ConditionSeverityError,
+						Reason:   infrav1.FailedReason,
+						Message:  "1 instance(s) failed",
+					},
+				},
+			},
+		},
+		{
+			name: "still provisioning on some instances",
+			summary: compute.VirtualMachineScaleSetVMExtensionsSummary{
+				Name: pointer.String("my-extension"),
+				StatusesSummary: &[]compute.VirtualMachineStatusCodeCount{
+					{Code: pointer.String("ProvisioningState/creating"), Count: pointer.Int32(2)},
+				},
+			},
+			expected: infrav1.ExtensionStatus{
+				Name: "my-extension",
+				Conditions: clusterv1.Conditions{
+					{
+						Type:     "ProvisioningState",
+						Status:   corev1.ConditionFalse,
+						Severity: clusterv1.ConditionSeverityInfo,
+						Reason:   infrav1.UpdatingReason,
+						Message:  "2 instance(s) creating",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			g.Expect(SDKToVMSSExtensionStatus(test.summary)).To(Equal(test.expected))
+		})
+	}
+}
@@ -25,6 +25,20 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// platformInitiatedReasons are the condition reasons derived from the reason types of the health impacting events
+// originated by the Azure platform, as opposed to the ones initiated by the user or the guest OS.
+var platformInitiatedReasons = map[string]bool{
+	"Unplanned":         true,
+	"Planned":           true,
+	"PlatformInitiated": true,
+}
+
+// IsPlatformInitiatedReason returns true if the reason of a condition returned by SDKAvailabilityStatusToCondition
+// describes a health impacting event initiated by the Azure platform.
+func IsPlatformInitiatedReason(reason string) bool {
+	return platformInitiatedReasons[reason]
+}
+
 // SDKAvailabilityStatusToCondition converts an Azure Resource Health availability status to a status condition.
 func SDKAvailabilityStatusToCondition(availStatus resourcehealth.AvailabilityStatus) *clusterv1.Condition {
 	if availStatus.Properties == nil {
@@ -52,17 +66,19 @@ func SDKAvailabilityStatusToCondition(availStatus resourcehealth.AvailabilitySta
 		}
 	}
 
+	var message string
+	if availStatus.Properties.Summary != nil {
+		message = *availStatus.Properties.Summary
+	}
+
 	var severity clusterv1.ConditionSeverity
 	switch availStatus.Properties.AvailabilityState {
 	case resourcehealth.AvailabilityStateValuesUnavailable:
 		severity = clusterv1.ConditionSeverityError
-	case resourcehealth.AvailabilityStateValuesDegraded, resourcehealth.AvailabilityStateValuesUnknown:
+	case resourcehealth.AvailabilityStateValuesDegraded:
 		severity = clusterv1.ConditionSeverityWarning
-	}
-
-	var message string
-	if availStatus.Properties.Summary != nil {
-		message = *availStatus.Properties.Summary
+	case resourcehealth.AvailabilityStateValuesUnknown:
+		return conditions.UnknownCondition(infrav1.AzureResourceAvailableCondition, reason.String(), message)
 	}
 
 	return conditions.FalseCondition(infrav1.AzureResourceAvailableCondition, reason.String(), severity, message)
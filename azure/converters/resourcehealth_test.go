@@ -82,6 +82,19 @@ func TestAzureAvailabilityStatusToCondition(t *testing.T) {
 				Message:  "The Summary",
 			},
 		},
+		{
+			name: "unknown",
+			avail: resourcehealth.AvailabilityStatus{
+				Properties: &resourcehealth.AvailabilityStatusProperties{
+					AvailabilityState: resourcehealth.AvailabilityStateValuesUnknown,
+					Summary:           pointer.String("The Summary"),
+				},
+			},
+			expected: &clusterv1.Condition{
+				Status:  corev1.ConditionUnknown,
+				Message: "The Summary",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -77,6 +77,19 @@ const (
 	PrivateAPIServerHostname = "apiserver"
 )
 
+const (
+	// DefaultNetworkWatcherResourceGroup is the resource group Azure creates the Network Watcher of each region in.
+	DefaultNetworkWatcherResourceGroup = "NetworkWatcherRG"
+)
+
+const (
+	// SNATPortsPerFrontendIP is the number of SNAT ports that each frontend IP of an outbound rule provides.
+	SNATPortsPerFrontendIP = 64000
+	// MinSNATPortsPerNode is the smallest number of SNAT ports an outbound rule can allocate to each backend instance.
+	// Azure treats 0 allocated outbound ports as the default allocation based on the backend pool size.
+	MinSNATPortsPerNode = 8
+)
+
 const (
 	// ControlPlaneNodeGroup will be used to create availability set for control plane machines.
 	ControlPlaneNodeGroup = "control-plane"
@@ -125,6 +138,11 @@ func GenerateOutboundBackendAddressPoolName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "outboundBackendPool")
 }
 
+// GenerateOutboundIPv6BackendAddressPoolName generates a load balancer outbound backend address pool name for IPv6 addresses.
+func GenerateOutboundIPv6BackendAddressPoolName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "outboundBackendPool-ipv6")
+}
+
 // GenerateFrontendIPConfigName generates a load balancer frontend IP config name.
 func GenerateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
@@ -165,6 +183,17 @@ func GenerateVNetLinkName(vnetName string) string {
 	return fmt.Sprintf("%s-link", vnetName)
 }
 
+// GenerateNetworkWatcherName generates the name Azure gives to the Network Watcher of a region.
+func GenerateNetworkWatcherName(location string) string {
+	return fmt.Sprintf("NetworkWatcher_%s", location)
+}
+
+// GenerateNSGFlowLogName generates the name of the flow log of a network security group based on the resource group and name
+// of the security group, as the flow logs of all the security groups of a region share the same Network Watcher.
+func GenerateNSGFlowLogName(resourceGroup, nsgName string) string {
+	return fmt.Sprintf("%s-%s-flowlog", resourceGroup, nsgName)
+}
+
 // GenerateNICName generates the name of a network interface based on the name of a VM.
 func GenerateNICName(machineName string, multiNIC bool, index int) string {
 	if multiNIC {
@@ -241,6 +270,16 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// PublicIPPrefixID returns the azure resource ID for a given public IP prefix.
+func PublicIPPrefixID(subscriptionID, resourceGroup, prefixName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPPrefixes/%s", subscriptionID, resourceGroup, prefixName)
+}
+
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, asgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, asgName)
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
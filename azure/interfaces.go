@@ -19,6 +19,7 @@ package azure
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-service-operator/v2/pkg/genruntime"
 	"github.com/Azure/go-autorest/autorest"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -47,6 +48,7 @@ type Authorizer interface {
 	TenantID() string
 	BaseURI() string
 	Authorizer() autorest.Authorizer
+	Token() azcore.TokenCredential
 	HashKey() string
 }
 
@@ -66,8 +68,12 @@ type NetworkDescriber interface {
 	APIServerLBPoolName(string) string
 	IsAPIServerPrivate() bool
 	GetPrivateDNSZoneName() string
+	PrivateDNSNodeRecordsZoneID() string
 	OutboundLBName(string) string
 	OutboundPoolName(string) string
+	OutboundIPv6PoolName(string) string
+	ApplicationSecurityGroups() []infrav1.ApplicationSecurityGroup
+	PublicIPPrefixID() string
 }
 
 // ClusterDescriber is an interface which can get common Azure Cluster information.
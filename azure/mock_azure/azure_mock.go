@@ -24,6 +24,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	genruntime "github.com/Azure/azure-service-operator/v2/pkg/genruntime"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
@@ -297,6 +298,20 @@ func (mr *MockAuthorizerMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockAuthorizer)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockAuthorizer) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockAuthorizerMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockAuthorizer)(nil).Token))
+}
+
 // MockNetworkDescriber is a mock of NetworkDescriber interface.
 type MockNetworkDescriber struct {
 	ctrl     *gomock.Controller
@@ -362,6 +377,20 @@ func (mr *MockNetworkDescriberMockRecorder) APIServerLBPoolName(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIServerLBPoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).APIServerLBPoolName), arg0)
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNetworkDescriber) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNetworkDescriberMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNetworkDescriber)(nil).ApplicationSecurityGroups))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockNetworkDescriber) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -460,6 +489,20 @@ func (mr *MockNetworkDescriberMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockNetworkDescriber)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockNetworkDescriber) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockNetworkDescriberMockRecorder) OutboundIPv6PoolName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockNetworkDescriber) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -488,6 +531,34 @@ func (mr *MockNetworkDescriberMockRecorder) OutboundPoolName(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).OutboundPoolName), arg0)
 }
 
+// PrivateDNSNodeRecordsZoneID mocks base method.
+func (m *MockNetworkDescriber) PrivateDNSNodeRecordsZoneID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSNodeRecordsZoneID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PrivateDNSNodeRecordsZoneID indicates an expected call of PrivateDNSNodeRecordsZoneID.
+func (mr *MockNetworkDescriberMockRecorder) PrivateDNSNodeRecordsZoneID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSNodeRecordsZoneID", reflect.TypeOf((*MockNetworkDescriber)(nil).PrivateDNSNodeRecordsZoneID))
+}
+
+// PublicIPPrefixID mocks base method.
+func (m *MockNetworkDescriber) PublicIPPrefixID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicIPPrefixID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PublicIPPrefixID indicates an expected call of PublicIPPrefixID.
+func (mr *MockNetworkDescriberMockRecorder) PublicIPPrefixID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPPrefixID", reflect.TypeOf((*MockNetworkDescriber)(nil).PublicIPPrefixID))
+}
+
 // SetSubnet mocks base method.
 func (m *MockNetworkDescriber) SetSubnet(arg0 v1beta1.SubnetSpec) {
 	m.ctrl.T.Helper()
@@ -817,6 +888,20 @@ func (mr *MockClusterDescriberMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockClusterDescriber)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockClusterDescriber) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockClusterDescriberMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockClusterDescriber)(nil).Token))
+}
+
 // MockAsyncStatusUpdater is a mock of AsyncStatusUpdater interface.
 type MockAsyncStatusUpdater struct {
 	ctrl     *gomock.Controller
@@ -993,6 +1078,20 @@ func (mr *MockClusterScoperMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockClusterScoper)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockClusterScoper) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockClusterScoperMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockClusterScoper)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockClusterScoper) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -1287,6 +1386,20 @@ func (mr *MockClusterScoperMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockClusterScoper)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockClusterScoper) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockClusterScoperMockRecorder) OutboundIPv6PoolName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockClusterScoper)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockClusterScoper) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -1315,6 +1428,34 @@ func (mr *MockClusterScoperMockRecorder) OutboundPoolName(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockClusterScoper)(nil).OutboundPoolName), arg0)
 }
 
+// PrivateDNSNodeRecordsZoneID mocks base method.
+func (m *MockClusterScoper) PrivateDNSNodeRecordsZoneID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSNodeRecordsZoneID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PrivateDNSNodeRecordsZoneID indicates an expected call of PrivateDNSNodeRecordsZoneID.
+func (mr *MockClusterScoperMockRecorder) PrivateDNSNodeRecordsZoneID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSNodeRecordsZoneID", reflect.TypeOf((*MockClusterScoper)(nil).PrivateDNSNodeRecordsZoneID))
+}
+
+// PublicIPPrefixID mocks base method.
+func (m *MockClusterScoper) PublicIPPrefixID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicIPPrefixID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PublicIPPrefixID indicates an expected call of PublicIPPrefixID.
+func (mr *MockClusterScoperMockRecorder) PublicIPPrefixID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPPrefixID", reflect.TypeOf((*MockClusterScoper)(nil).PublicIPPrefixID))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockClusterScoper) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -1397,6 +1538,20 @@ func (mr *MockClusterScoperMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockClusterScoper)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockClusterScoper) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockClusterScoperMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockClusterScoper)(nil).Token))
+}
+
 // Vnet mocks base method.
 func (m *MockClusterScoper) Vnet() *v1beta1.VnetSpec {
 	m.ctrl.T.Helper()
@@ -1700,6 +1855,20 @@ func (mr *MockManagedClusterScoperMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockManagedClusterScoper)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockManagedClusterScoper) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockManagedClusterScoperMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockManagedClusterScoper)(nil).Token))
+}
+
 // MockResourceSpecGetter is a mock of ResourceSpecGetter interface.
 type MockResourceSpecGetter struct {
 	ctrl     *gomock.Controller
@@ -24,6 +24,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -35,6 +36,7 @@ type AzureClients struct {
 	auth.EnvironmentSettings
 
 	Authorizer                 autorest.Authorizer
+	TokenCredential            azcore.TokenCredential
 	ResourceManagerEndpoint    string
 	ResourceManagerVMDNSSuffix string
 }
@@ -65,6 +67,11 @@ func (c *AzureClients) SubscriptionID() string {
 	return c.Values[auth.SubscriptionID]
 }
 
+// Token returns the Azure token credential used by clients of Azure data plane APIs.
+func (c *AzureClients) Token() azcore.TokenCredential {
+	return c.TokenCredential
+}
+
 // HashKey returns a base64 url encoded sha256 hash for the Auth scope (Azure TenantID + CloudEnv + SubscriptionID +
 // ClientID).
 func (c *AzureClients) HashKey() string {
@@ -94,12 +101,15 @@ func (c *AzureClients) setCredentials(subscriptionID, environmentName string) er
 	c.Values[auth.SubscriptionID] = strings.TrimSuffix(subscriptionID, "\n")
 	c.Values[auth.TenantID] = strings.TrimSuffix(c.Values[auth.TenantID], "\n")
 
-	if c.Authorizer == nil {
-		c.Authorizer, err = azureutil.GetAuthorizer(settings)
+	if c.TokenCredential == nil {
+		c.TokenCredential, err = azureutil.GetTokenCredential(settings)
 		if err != nil {
 			return err
 		}
 	}
+	if c.Authorizer == nil {
+		c.Authorizer = azureutil.NewTokenCredentialAuthorizer(c.TokenCredential, settings.Environment.TokenAudience)
+	}
 	return nil
 }
 
@@ -133,8 +143,12 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 	}
 	c.Values[auth.ClientSecret] = strings.TrimSuffix(clientSecret, "\n")
 
-	c.Authorizer, err = credentialsProvider.GetAuthorizer(ctx, c.ResourceManagerEndpoint, c.Environment.ActiveDirectoryEndpoint, c.Environment.TokenAudience)
-	return err
+	c.TokenCredential, err = credentialsProvider.GetTokenCredential(ctx, c.ResourceManagerEndpoint, c.Environment.ActiveDirectoryEndpoint, c.Environment.TokenAudience)
+	if err != nil {
+		return err
+	}
+	c.Authorizer = azureutil.NewTokenCredentialAuthorizer(c.TokenCredential, c.Environment.TokenAudience)
+	return nil
 }
 
 func (c *AzureClients) getSettingsFromEnvironment(environmentName string) (s auth.EnvironmentSettings, err error) {
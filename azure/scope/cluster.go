@@ -25,13 +25,17 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/net"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/asogroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
@@ -39,15 +43,22 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicdns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cidr"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	capifeature "sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -124,6 +135,9 @@ type ClusterScope struct {
 // ClusterCache stores ClusterCache data locally so we don't have to hit the API multiple times within the same reconcile loop.
 type ClusterCache struct {
 	isVnetManaged *bool
+	// vnetSubnetCIDRs are the CIDR blocks of the subnets of the vnet that are not part of the cluster spec,
+	// e.g. the subnets of other clusters sharing the vnet.
+	vnetSubnetCIDRs []string
 }
 
 // BaseURI returns the Azure ResourceManagerEndpoint.
@@ -159,8 +173,9 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 					IsIPv6:           false, // Set to default value
 					Location:         s.Location(),
 					ExtendedLocation: s.ExtendedLocation(),
-					FailureDomains:   s.FailureDomains(),
+					FailureDomains:   ip.PublicIP.GetZones(s.FailureDomains()),
 					AdditionalTags:   s.AdditionalTags(),
+					PublicIPPrefixID: s.PublicIPPrefixID(),
 				})
 			}
 		}
@@ -174,9 +189,10 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 				ClusterName:      s.ClusterName(),
 				Location:         s.Location(),
 				ExtendedLocation: s.ExtendedLocation(),
-				FailureDomains:   s.FailureDomains(),
+				FailureDomains:   s.APIServerPublicIP().GetZones(s.FailureDomains()),
 				AdditionalTags:   s.AdditionalTags(),
 				IPTags:           s.APIServerPublicIP().IPTags,
+				PublicIPPrefixID: s.PublicIPPrefixID(),
 			},
 		}
 	}
@@ -193,7 +209,24 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 				IsIPv6:           false, // Set to default value
 				Location:         s.Location(),
 				ExtendedLocation: s.ExtendedLocation(),
-				FailureDomains:   s.FailureDomains(),
+				FailureDomains:   ip.PublicIP.GetZones(s.FailureDomains()),
+				AdditionalTags:   s.AdditionalTags(),
+				PublicIPPrefixID: s.PublicIPPrefixID(),
+			})
+		}
+		for _, ip := range s.NodeOutboundLB().IPv6FrontendIPs {
+			if ip.PublicIP == nil {
+				continue
+			}
+			publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+				Name:             ip.PublicIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				ClusterName:      s.ClusterName(),
+				DNSName:          "", // Set to default value
+				IsIPv6:           true,
+				Location:         s.Location(),
+				ExtendedLocation: s.ExtendedLocation(),
+				FailureDomains:   ip.PublicIP.GetZones(s.FailureDomains()),
 				AdditionalTags:   s.AdditionalTags(),
 			})
 		}
@@ -203,16 +236,23 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	var nodeNatGatewayIPSpecs []azure.ResourceSpecGetter
 	for _, subnet := range s.NodeSubnets() {
 		if subnet.IsNatGatewayEnabled() {
+			// The public IP of a zonal NAT gateway must be in the same zone as the NAT gateway.
+			failureDomains := s.FailureDomains()
+			if subnet.Zone != "" {
+				failureDomains = []string{subnet.Zone}
+			}
+			failureDomains = subnet.NatGateway.NatGatewayIP.GetZones(failureDomains)
 			nodeNatGatewayIPSpecs = append(nodeNatGatewayIPSpecs, &publicips.PublicIPSpec{
-				Name:           subnet.NatGateway.NatGatewayIP.Name,
-				ResourceGroup:  s.ResourceGroup(),
-				DNSName:        subnet.NatGateway.NatGatewayIP.DNSName,
-				IsIPv6:         false, // Public IP is IPv4 by default
-				ClusterName:    s.ClusterName(),
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
-				IPTags:         subnet.NatGateway.NatGatewayIP.IPTags,
+				Name:             subnet.NatGateway.NatGatewayIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          subnet.NatGateway.NatGatewayIP.DNSName,
+				IsIPv6:           false, // Public IP is IPv4 by default
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   failureDomains,
+				AdditionalTags:   s.AdditionalTags(),
+				IPTags:           subnet.NatGateway.NatGatewayIP.IPTags,
+				PublicIPPrefixID: s.PublicIPPrefixID(),
 			})
 		}
 		publicIPSpecs = append(publicIPSpecs, nodeNatGatewayIPSpecs...)
@@ -227,7 +267,7 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 			IsIPv6:         false, // Public IP is IPv4 by default
 			ClusterName:    s.ClusterName(),
 			Location:       s.Location(),
-			FailureDomains: s.FailureDomains(),
+			FailureDomains: azureBastion.PublicIP.GetZones(s.FailureDomains()),
 			AdditionalTags: s.AdditionalTags(),
 			IPTags:         azureBastion.PublicIP.IPTags,
 		}
@@ -258,31 +298,69 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			Role:                 infrav1.APIServerRole,
 			BackendPoolName:      s.APIServerLB().BackendPool.Name,
 			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
+			HealthProbe:          s.APIServerLB().HealthProbe,
+			AdditionalRules:      s.APIServerLB().AdditionalRules,
 			AdditionalTags:       s.AdditionalTags(),
 		},
 	}
 
-	// Node outbound LB
-	if s.NodeOutboundLB() != nil {
+	// Internal LB exposing the API server privately next to a public API server LB
+	if internalLB := s.APIServerLB().InternalLB; internalLB != nil && s.APIServerLB().Type == infrav1.Public {
 		specs = append(specs, &loadbalancers.LBSpec{
-			Name:                 s.NodeOutboundLB().Name,
-			ResourceGroup:        s.ResourceGroup(),
-			SubscriptionID:       s.SubscriptionID(),
-			ClusterName:          s.ClusterName(),
-			Location:             s.Location(),
-			ExtendedLocation:     s.ExtendedLocation(),
-			VNetName:             s.Vnet().Name,
-			VNetResourceGroup:    s.Vnet().ResourceGroup,
-			FrontendIPConfigs:    s.NodeOutboundLB().FrontendIPs,
-			Type:                 s.NodeOutboundLB().Type,
-			SKU:                  s.NodeOutboundLB().SKU,
-			BackendPoolName:      s.NodeOutboundLB().BackendPool.Name,
-			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
-			Role:                 infrav1.NodeOutboundRole,
+			Name:              internalLB.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			ClusterName:       s.ClusterName(),
+			Location:          s.Location(),
+			ExtendedLocation:  s.ExtendedLocation(),
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			SubnetName:        s.ControlPlaneSubnet().Name,
+			FrontendIPConfigs: []infrav1.FrontendIP{
+				{
+					Name:            azure.GenerateFrontendIPConfigName(internalLB.Name),
+					FrontendIPClass: internalLB.FrontendIPClass,
+				},
+			},
+			APIServerPort:        s.APIServerPort(),
+			Type:                 infrav1.Internal,
+			SKU:                  s.APIServerLB().SKU,
+			Role:                 infrav1.APIServerRole,
+			BackendPoolName:      s.APIServerLBPoolName(internalLB.Name),
+			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
+			HealthProbe:          s.APIServerLB().HealthProbe,
+			AdditionalRules:      s.APIServerLB().AdditionalRules,
 			AdditionalTags:       s.AdditionalTags(),
 		})
 	}
 
+	// Node outbound LB
+	if s.NodeOutboundLB() != nil {
+		spec := &loadbalancers.LBSpec{
+			Name:                  s.NodeOutboundLB().Name,
+			ResourceGroup:         s.ResourceGroup(),
+			SubscriptionID:        s.SubscriptionID(),
+			ClusterName:           s.ClusterName(),
+			Location:              s.Location(),
+			ExtendedLocation:      s.ExtendedLocation(),
+			VNetName:              s.Vnet().Name,
+			VNetResourceGroup:     s.Vnet().ResourceGroup,
+			FrontendIPConfigs:     s.NodeOutboundLB().FrontendIPs,
+			Type:                  s.NodeOutboundLB().Type,
+			SKU:                   s.NodeOutboundLB().SKU,
+			BackendPoolName:       s.NodeOutboundLB().BackendPool.Name,
+			IdleTimeoutInMinutes:  s.NodeOutboundLB().IdleTimeoutInMinutes,
+			Role:                  infrav1.NodeOutboundRole,
+			IPv6FrontendIPConfigs: s.NodeOutboundLB().IPv6FrontendIPs,
+			IPv6BackendPoolName:   s.OutboundIPv6PoolName(s.NodeOutboundLB().Name),
+			AdditionalTags:        s.AdditionalTags(),
+		}
+		if s.AzureCluster.Status.NodeOutboundSNAT != nil {
+			spec.AllocatedOutboundPorts = pointer.Int32(s.AzureCluster.Status.NodeOutboundSNAT.AllocatedOutboundPorts)
+		}
+		specs = append(specs, spec)
+	}
+
 	// Control Plane Outbound LB
 	if s.ControlPlaneOutboundLB() != nil {
 		specs = append(specs, &loadbalancers.LBSpec{
@@ -310,21 +388,82 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 // RouteTableSpecs returns the subnet route tables.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
+	// A route table can be shared by several subnets, in which case it has the routes of all of them.
+	tableSpecs := make(map[string]*routetables.RouteTableSpec)
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
-		if subnet.RouteTable.Name != "" {
-			specs = append(specs, &routetables.RouteTableSpec{
+		if subnet.RouteTable.Name == "" {
+			continue
+		}
+		spec, ok := tableSpecs[subnet.RouteTable.Name]
+		if !ok {
+			spec = &routetables.RouteTableSpec{
 				Name:           subnet.RouteTable.Name,
 				Location:       s.Location(),
 				ResourceGroup:  s.ResourceGroup(),
 				ClusterName:    s.ClusterName(),
 				AdditionalTags: s.AdditionalTags(),
-			})
+			}
+			tableSpecs[spec.Name] = spec
+			specs = append(specs, spec)
+		}
+		for _, route := range s.subnetRoutes(subnet) {
+			if !hasRoute(spec.Routes, route.Name) {
+				spec.Routes = append(spec.Routes, route)
+			}
+		}
+	}
+
+	lastApplied, err := s.AnnotationJSON(azure.RouteTableRoutesLastAppliedAnnotation)
+	if err != nil {
+		// The annotation is rewritten once the route tables are reconciled.
+		lastApplied = map[string]interface{}{}
+	}
+	for _, spec := range tableSpecs {
+		names, _ := lastApplied[spec.Name].([]interface{})
+		for _, name := range names {
+			if name, ok := name.(string); ok && !hasRoute(spec.Routes, name) {
+				spec.StaleRouteNames = append(spec.StaleRouteNames, name)
+			}
 		}
 	}
 
 	return specs
 }
 
+// hasRoute returns true if the routes contain a route with the given name.
+func hasRoute(routes []routetables.RouteSpec, name string) bool {
+	for _, route := range routes {
+		if route.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// subnetRoutes returns the routes CAPZ manages in the route table of the given subnet.
+func (s *ClusterScope) subnetRoutes(subnet infrav1.SubnetSpec) []routetables.RouteSpec {
+	var routes []routetables.RouteSpec
+	networkSpec := s.AzureCluster.Spec.NetworkSpec
+	if networkSpec.IsUserDefinedRouting() && networkSpec.UserDefinedRouting != nil &&
+		(subnet.Role == infrav1.SubnetControlPlane || subnet.Role == infrav1.SubnetNode) {
+		routes = append(routes, routetables.RouteSpec{
+			Name:             infrav1.UserDefinedRoutingRouteName,
+			AddressPrefix:    "0.0.0.0/0",
+			NextHopType:      "VirtualAppliance",
+			NextHopIPAddress: networkSpec.UserDefinedRouting.NextHopIPAddress,
+		})
+	}
+	for _, route := range subnet.RouteTable.Routes {
+		routes = append(routes, routetables.RouteSpec{
+			Name:             route.Name,
+			AddressPrefix:    route.AddressPrefix,
+			NextHopType:      string(route.NextHopType),
+			NextHopIPAddress: route.NextHopIPAddress,
+		})
+	}
+	return routes
+}
+
 // NatGatewaySpecs returns the node NAT gateway.
 func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 	natGatewaySet := make(map[string]struct{})
@@ -344,6 +483,7 @@ func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 					NatGatewayIP: infrav1.PublicIPSpec{
 						Name: subnet.NatGateway.NatGatewayIP.Name,
 					},
+					Zone:           subnet.Zone,
 					AdditionalTags: s.AdditionalTags(),
 				})
 			}
@@ -362,6 +502,7 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 			SecurityRules:  subnet.SecurityGroup.SecurityRules,
 			ResourceGroup:  s.ResourceGroup(),
 			Location:       s.Location(),
+			SubscriptionID: s.SubscriptionID(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
 		}
@@ -370,6 +511,72 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 	return nsgspecs
 }
 
+// NSGFlowLogSpecs returns the specs of the Network Watcher flow logs of the security groups.
+func (s *ClusterScope) NSGFlowLogSpecs() []azure.ResourceSpecGetter {
+	flowLogs := s.AzureCluster.Spec.NetworkSpec.FlowLogs
+	if flowLogs == nil {
+		return nil
+	}
+
+	watcherResourceGroup := flowLogs.NetworkWatcherResourceGroup
+	if watcherResourceGroup == "" {
+		watcherResourceGroup = azure.DefaultNetworkWatcherResourceGroup
+	}
+	watcherName := flowLogs.NetworkWatcherName
+	if watcherName == "" {
+		watcherName = azure.GenerateNetworkWatcherName(s.Location())
+	}
+	formatVersion := flowLogs.FormatVersion
+	if formatVersion == 0 {
+		formatVersion = 2
+	}
+	var trafficAnalytics *infrav1.TrafficAnalytics
+	if flowLogs.TrafficAnalytics != nil {
+		trafficAnalytics = flowLogs.TrafficAnalytics.DeepCopy()
+		if trafficAnalytics.IntervalMinutes == 0 {
+			trafficAnalytics.IntervalMinutes = 60
+		}
+	}
+
+	flowLogSpecs := make([]azure.ResourceSpecGetter, 0, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		if subnet.SecurityGroup.Name == "" {
+			continue
+		}
+		flowLogSpecs = append(flowLogSpecs, &securitygroups.FlowLogSpec{
+			Name:               azure.GenerateNSGFlowLogName(s.ResourceGroup(), subnet.SecurityGroup.Name),
+			ResourceGroup:      watcherResourceGroup,
+			NetworkWatcherName: watcherName,
+			Location:           s.Location(),
+			ClusterName:        s.ClusterName(),
+			SecurityGroupID:    azure.SecurityGroupID(s.SubscriptionID(), s.ResourceGroup(), subnet.SecurityGroup.Name),
+			StorageAccountID:   flowLogs.StorageAccountID,
+			RetentionDays:      flowLogs.RetentionDays,
+			FormatVersion:      formatVersion,
+			TrafficAnalytics:   trafficAnalytics,
+			AdditionalTags:     s.AdditionalTags(),
+		})
+	}
+
+	return flowLogSpecs
+}
+
+// ASGSpecs returns the application security group specs.
+func (s *ClusterScope) ASGSpecs() []azure.ResourceSpecGetter {
+	asgSpecs := make([]azure.ResourceSpecGetter, len(s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups))
+	for i, asg := range s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups {
+		asgSpecs[i] = &applicationsecuritygroups.ASGSpec{
+			Name:           asg.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		}
+	}
+
+	return asgSpecs
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
@@ -393,6 +600,8 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 			Role:              subnet.Role,
 			NatGatewayName:    subnet.NatGateway.Name,
 			ServiceEndpoints:  subnet.ServiceEndpoints,
+
+			DisablePrivateLinkServiceNetworkPolicies: s.isPrivateLinkServiceNATSubnet(subnet.Name),
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
 	}
@@ -443,13 +652,16 @@ func (s *ClusterScope) ASOGroupSpec() azure.ASOResourceSpecGetter {
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
 	for i, peering := range s.Vnet().Peerings {
+		remoteSubscriptionID := s.peeringSubscriptionID(peering)
 		forwardPeering := &vnetpeerings.VnetPeeringSpec{
 			PeeringName:               azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
 			SourceVnetName:            s.Vnet().Name,
 			SourceResourceGroup:       s.Vnet().ResourceGroup,
 			RemoteVnetName:            peering.RemoteVnetName,
 			RemoteResourceGroup:       peering.ResourceGroup,
-			SubscriptionID:            s.SubscriptionID(),
+			SubscriptionID:            remoteSubscriptionID,
+			SourceSubscriptionID:      s.SubscriptionID(),
+			RemoteIdentityRef:         peering.IdentityRef,
 			AllowForwardedTraffic:     peering.ForwardPeeringProperties.AllowForwardedTraffic,
 			AllowGatewayTransit:       peering.ForwardPeeringProperties.AllowGatewayTransit,
 			AllowVirtualNetworkAccess: peering.ForwardPeeringProperties.AllowVirtualNetworkAccess,
@@ -462,6 +674,9 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 			RemoteVnetName:            s.Vnet().Name,
 			RemoteResourceGroup:       s.Vnet().ResourceGroup,
 			SubscriptionID:            s.SubscriptionID(),
+			SourceSubscriptionID:      remoteSubscriptionID,
+			RemoteIdentityRef:         peering.IdentityRef,
+			Reverse:                   true,
 			AllowForwardedTraffic:     peering.ReversePeeringProperties.AllowForwardedTraffic,
 			AllowGatewayTransit:       peering.ReversePeeringProperties.AllowGatewayTransit,
 			AllowVirtualNetworkAccess: peering.ReversePeeringProperties.AllowVirtualNetworkAccess,
@@ -474,9 +689,42 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	return peeringSpecs
 }
 
+// peeringSubscriptionID returns the subscription ID of the remote virtual network of a peering.
+func (s *ClusterScope) peeringSubscriptionID(peering infrav1.VnetPeeringSpec) string {
+	if peering.SubscriptionID != "" {
+		return peering.SubscriptionID
+	}
+	return s.SubscriptionID()
+}
+
+// RemoteVnetAuthorizer returns an authorizer for the AzureClusterIdentity that manages a remote virtual network,
+// along with the tenant ID of the identity.
+func (s *ClusterScope) RemoteVnetAuthorizer(ctx context.Context, identityRef *corev1.ObjectReference) (autorest.Authorizer, string, error) {
+	credentialsProvider, err := NewAzureCredentialsProvider(ctx, s.Client, identityRef, s.AzureCluster.Namespace)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to init credentials provider")
+	}
+	authorizer, err := credentialsProvider.GetAuthorizer(ctx, s.ResourceManagerEndpoint, s.Environment.ActiveDirectoryEndpoint, s.Environment.TokenAudience, s.AzureCluster.ObjectMeta)
+	if err != nil {
+		return nil, "", err
+	}
+	return authorizer, credentialsProvider.GetTenantID(), nil
+}
+
+// SetVnetPeeringStatus sets the observed state of a virtual network peering.
+func (s *ClusterScope) SetVnetPeeringStatus(status infrav1.VnetPeeringStatus) {
+	for i := range s.AzureCluster.Status.VnetPeerings {
+		if s.AzureCluster.Status.VnetPeerings[i].Name == status.Name {
+			s.AzureCluster.Status.VnetPeerings[i] = status
+			return
+		}
+	}
+	s.AzureCluster.Status.VnetPeerings = append(s.AzureCluster.Status.VnetPeerings, status)
+}
+
 // VNetSpec returns the virtual network spec.
 func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
-	return &virtualnetworks.VNetSpec{
+	spec := &virtualnetworks.VNetSpec{
 		ResourceGroup:    s.Vnet().ResourceGroup,
 		Name:             s.Vnet().Name,
 		CIDRs:            s.Vnet().CIDRBlocks,
@@ -484,57 +732,141 @@ func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 		Location:         s.Location(),
 		ClusterName:      s.ClusterName(),
 		AdditionalTags:   s.AdditionalTags(),
+
+		DDoSProtectionPlanID: s.Vnet().DDoSProtectionPlanID,
+		DNSServers:           s.Vnet().DNSServers,
+		Shared:               s.Vnet().Shared,
+	}
+
+	// An invalid annotation is rewritten once the vnet is reconciled.
+	if lastApplied, err := s.AnnotationJSON(azure.VNetSettingsLastAppliedAnnotation); err == nil {
+		spec.SetSettingsToClear(lastApplied)
 	}
+	return spec
 }
 
 // PrivateDNSSpec returns the private dns zone spec.
+// The zone is created for a private API server, unless an existing zone is referenced by its resource ID,
+// in which case only the vnet links and the API server record are created in it.
 func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter) {
-	if s.IsAPIServerPrivate() {
-		zone := privatedns.ZoneSpec{
-			Name:           s.GetPrivateDNSZoneName(),
-			ResourceGroup:  s.ResourceGroup(),
-			ClusterName:    s.ClusterName(),
-			AdditionalTags: s.AdditionalTags(),
-		}
+	existingZoneID := s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneID
+	if !s.IsAPIServerPrivate() && existingZoneID == "" {
+		return nil, nil, nil
+	}
 
-		links := make([]azure.ResourceSpecGetter, 1+len(s.Vnet().Peerings))
-		links[0] = privatedns.LinkSpec{
-			Name:              azure.GenerateVNetLinkName(s.Vnet().Name),
-			ZoneName:          s.GetPrivateDNSZoneName(),
-			SubscriptionID:    s.SubscriptionID(),
-			VNetResourceGroup: s.Vnet().ResourceGroup,
-			VNetName:          s.Vnet().Name,
-			ResourceGroup:     s.ResourceGroup(),
-			ClusterName:       s.ClusterName(),
-			AdditionalTags:    s.AdditionalTags(),
+	zone := privatedns.ZoneSpec{
+		Name:           s.GetPrivateDNSZoneName(),
+		ResourceGroup:  s.ResourceGroup(),
+		ClusterName:    s.ClusterName(),
+		AdditionalTags: s.AdditionalTags(),
+	}
+	// The records of an existing zone are tracked individually, as they are not deleted along with the zone.
+	var recordClusterName string
+	if existingZoneID != "" {
+		if zoneID, err := azure.ParseResourceID(existingZoneID); err == nil {
+			zone.ResourceGroup = zoneID.ResourceGroupName
+			zone.SubscriptionID = zoneID.SubscriptionID
 		}
-		for i, peering := range s.Vnet().Peerings {
-			links[i+1] = privatedns.LinkSpec{
-				Name:              azure.GenerateVNetLinkName(peering.RemoteVnetName),
-				ZoneName:          s.GetPrivateDNSZoneName(),
-				SubscriptionID:    s.SubscriptionID(),
-				VNetResourceGroup: peering.ResourceGroup,
-				VNetName:          peering.RemoteVnetName,
-				ResourceGroup:     s.ResourceGroup(),
-				ClusterName:       s.ClusterName(),
-				AdditionalTags:    s.AdditionalTags(),
-			}
+		zone.Existing = true
+		recordClusterName = s.ClusterName()
+	}
+
+	links := make([]azure.ResourceSpecGetter, 1+len(s.Vnet().Peerings))
+	links[0] = privatedns.LinkSpec{
+		Name:               azure.GenerateVNetLinkName(s.Vnet().Name),
+		ZoneName:           zone.Name,
+		SubscriptionID:     s.SubscriptionID(),
+		VNetResourceGroup:  s.Vnet().ResourceGroup,
+		VNetName:           s.Vnet().Name,
+		ResourceGroup:      zone.ResourceGroup,
+		ClusterName:        s.ClusterName(),
+		AdditionalTags:     s.AdditionalTags(),
+		ZoneSubscriptionID: zone.SubscriptionID,
+	}
+	for i, peering := range s.Vnet().Peerings {
+		links[i+1] = privatedns.LinkSpec{
+			Name:               azure.GenerateVNetLinkName(peering.RemoteVnetName),
+			ZoneName:           zone.Name,
+			SubscriptionID:     s.peeringSubscriptionID(peering),
+			VNetResourceGroup:  peering.ResourceGroup,
+			VNetName:           peering.RemoteVnetName,
+			ResourceGroup:      zone.ResourceGroup,
+			ClusterName:        s.ClusterName(),
+			AdditionalTags:     s.AdditionalTags(),
+			ZoneSubscriptionID: zone.SubscriptionID,
 		}
+	}
 
-		records := make([]azure.ResourceSpecGetter, 1)
-		records[0] = privatedns.RecordSpec{
+	var records []azure.ResourceSpecGetter
+	if s.IsAPIServerPrivate() {
+		records = append(records, privatedns.RecordSpec{
 			Record: infrav1.AddressRecord{
 				Hostname: azure.PrivateAPIServerHostname,
 				IP:       s.APIServerPrivateIP(),
 			},
-			ZoneName:      s.GetPrivateDNSZoneName(),
-			ResourceGroup: s.ResourceGroup(),
-		}
+			ZoneName:       zone.Name,
+			ResourceGroup:  zone.ResourceGroup,
+			SubscriptionID: zone.SubscriptionID,
+			ClusterName:    recordClusterName,
+		})
+	}
+
+	return zone, links, records
+}
+
+// PrivateDNSNodeRecordsZoneID returns the resource ID of the private DNS zone the records of the machines are maintained in,
+// or an empty string if the cluster has no node records.
+func (s *ClusterScope) PrivateDNSNodeRecordsZoneID() string {
+	if !s.AzureCluster.Spec.NetworkSpec.PrivateDNSNodeRecords {
+		return ""
+	}
+	if existingZoneID := s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneID; existingZoneID != "" {
+		return existingZoneID
+	}
+	return "/" + azure.PrivateDNSZoneID(s.SubscriptionID(), s.ResourceGroup(), s.GetPrivateDNSZoneName())
+}
+
+// APIServerDNSRecordSpecs returns the specs of the API server record sets in the public DNS zone, if any.
+// The API server record set is an alias of the API server public IP, and the CNAME record sets point at it.
+func (s *ClusterScope) APIServerDNSRecordSpecs() []azure.ResourceSpecGetter {
+	apiServerDNS := s.AzureCluster.Spec.NetworkSpec.APIServerDNS
+	if apiServerDNS == nil || s.IsAPIServerPrivate() {
+		return nil
+	}
+	zoneID, err := azure.ParseResourceID(apiServerDNS.ZoneID)
+	if err != nil {
+		return nil
+	}
+
+	record := publicdns.RecordSpec{
+		Name:           apiServerDNS.RecordName,
+		Type:           dns.A,
+		ZoneName:       zoneID.Name,
+		ResourceGroup:  zoneID.ResourceGroupName,
+		SubscriptionID: zoneID.SubscriptionID,
+		TTL:            pointer.Int64Deref(apiServerDNS.TTL, infrav1.DefaultAPIServerDNSTTL),
+		ClusterName:    s.ClusterName(),
+	}
 
-		return zone, links, records
+	apiServerRecord := record
+	apiServerRecord.TargetResourceID = azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.APIServerPublicIP().Name)
+	specs := []azure.ResourceSpecGetter{&apiServerRecord}
+	for _, name := range apiServerDNS.CNAMERecordNames {
+		cnameRecord := record
+		cnameRecord.Name = name
+		cnameRecord.Type = dns.CNAME
+		cnameRecord.CNAME = apiServerDNSName(apiServerDNS.RecordName, zoneID.Name)
+		specs = append(specs, &cnameRecord)
 	}
+	return specs
+}
 
-	return nil, nil, nil
+// apiServerDNSName returns the FQDN of a record set of a public DNS zone.
+func apiServerDNSName(recordName, zoneName string) string {
+	if recordName == "@" {
+		return zoneName
+	}
+	return recordName + "." + zoneName
 }
 
 // IsAzureBastionEnabled returns true if the azure bastion is enabled.
@@ -554,14 +886,18 @@ func (s *ClusterScope) AzureBastionSpec() azure.ResourceSpecGetter {
 		publicIPID := azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.AzureBastion().PublicIP.Name)
 
 		return &bastionhosts.AzureBastionSpec{
-			Name:            s.AzureBastion().Name,
-			ResourceGroup:   s.ResourceGroup(),
-			Location:        s.Location(),
-			ClusterName:     s.ClusterName(),
-			SubnetID:        subnetID,
-			PublicIPID:      publicIPID,
-			Sku:             s.AzureBastion().Sku,
-			EnableTunneling: s.AzureBastion().EnableTunneling,
+			Name:                s.AzureBastion().Name,
+			ResourceGroup:       s.ResourceGroup(),
+			Location:            s.Location(),
+			ClusterName:         s.ClusterName(),
+			SubnetID:            subnetID,
+			PublicIPID:          publicIPID,
+			Sku:                 s.AzureBastion().Sku,
+			EnableTunneling:     s.AzureBastion().EnableTunneling,
+			ScaleUnits:          s.AzureBastion().ScaleUnits,
+			EnableIPConnect:     s.AzureBastion().EnableIPConnect,
+			EnableShareableLink: s.AzureBastion().EnableShareableLink,
+			EnableFileCopy:      s.AzureBastion().EnableFileCopy,
 		}
 	}
 
@@ -593,6 +929,11 @@ func (s *ClusterScope) IsIPv6Enabled() bool {
 	return false
 }
 
+// ApplicationSecurityGroups returns the cluster application security groups.
+func (s *ClusterScope) ApplicationSecurityGroups() []infrav1.ApplicationSecurityGroup {
+	return s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups
+}
+
 // Subnets returns the cluster subnets.
 func (s *ClusterScope) Subnets() infrav1.Subnets {
 	return s.AzureCluster.Spec.NetworkSpec.Subnets
@@ -648,12 +989,67 @@ func (s *ClusterScope) SetNatGatewayIDInSubnets(name string, id string) {
 }
 
 // UpdateSubnetCIDRs updates the subnet CIDRs for the subnet with the same name.
+// The CIDRs of subnets that are not part of the cluster spec are kept aside, so they are not allocated to the cluster's subnets.
 func (s *ClusterScope) UpdateSubnetCIDRs(name string, cidrBlocks []string) {
 	subnetSpecInfra := s.Subnet(name)
+	if subnetSpecInfra.Name == "" {
+		s.cache.vnetSubnetCIDRs = append(s.cache.vnetSubnetCIDRs, cidrBlocks...)
+		return
+	}
 	subnetSpecInfra.CIDRBlocks = cidrBlocks
 	s.SetSubnet(subnetSpecInfra)
 }
 
+// AllocateSubnetCIDRs allocates CIDR blocks from the virtual network's address space to the subnets that only specify a prefix length.
+// The allocated CIDR blocks are persisted in the subnets' spec, so they stay stable and are never reassigned while the subnet exists.
+// Subnets of a virtual network that is not managed get their CIDR blocks from the existing subnets instead.
+// The CIDR blocks of the other subnets of the virtual network, e.g. the ones of other clusters sharing it, are never allocated.
+func (s *ClusterScope) AllocateSubnetCIDRs() error {
+	var pending bool
+	used := append([]string{}, s.cache.vnetSubnetCIDRs...)
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		used = append(used, subnet.CIDRBlocks...)
+		pending = pending || subnet.NeedsCIDRAllocation()
+	}
+	if azureBastion := s.AzureCluster.Spec.BastionSpec.AzureBastion; azureBastion != nil {
+		used = append(used, azureBastion.Subnet.CIDRBlocks...)
+	}
+	if !pending || !s.IsVnetManaged() {
+		return nil
+	}
+
+	for i, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		if !subnet.NeedsCIDRAllocation() {
+			continue
+		}
+		var cidrBlocks []string
+		for _, family := range []struct {
+			prefixLength *int32
+			ipv6         bool
+		}{
+			{prefixLength: subnet.PrefixLength},
+			{prefixLength: subnet.IPv6PrefixLength, ipv6: true},
+		} {
+			if family.prefixLength == nil {
+				continue
+			}
+			cidrBlock, err := cidr.Allocate(s.Vnet().CIDRBlocks, used, int(*family.prefixLength), family.ipv6)
+			if err != nil {
+				if errors.Is(err, cidr.ErrExhausted) {
+					conditions.MarkFalse(s.AzureCluster, infrav1.SubnetCIDRsAllocatedCondition, infrav1.SubnetCIDRsExhaustedReason, clusterv1.ConditionSeverityError,
+						"no room left in virtual network %s for subnet %s", s.Vnet().Name, subnet.Name)
+				}
+				return errors.Wrapf(err, "failed to allocate a CIDR block to subnet %s", subnet.Name)
+			}
+			cidrBlocks = append(cidrBlocks, cidrBlock)
+			used = append(used, cidrBlock)
+		}
+		s.AzureCluster.Spec.NetworkSpec.Subnets[i].CIDRBlocks = cidrBlocks
+	}
+	conditions.MarkTrue(s.AzureCluster, infrav1.SubnetCIDRsAllocatedCondition)
+	return nil
+}
+
 // UpdateSubnetID updates the subnet ID for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetID(name string, id string) {
 	subnetSpecInfra := s.Subnet(name)
@@ -707,6 +1103,9 @@ func (s *ClusterScope) GetPrivateDNSZoneName() string {
 	if len(s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneName) > 0 {
 		return s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneName
 	}
+	if zoneID, err := azure.ParseResourceID(s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneID); err == nil {
+		return zoneID.Name
+	}
 	return azure.GeneratePrivateDNSZoneName(s.ClusterName())
 }
 
@@ -740,6 +1139,15 @@ func (s *ClusterScope) OutboundPoolName(loadBalancerName string) string {
 	return azure.GenerateOutboundBackendAddressPoolName(loadBalancerName)
 }
 
+// OutboundIPv6PoolName returns the IPv6 outbound LB backend pool name, or an empty string if the LB has no IPv6 frontend IP.
+func (s *ClusterScope) OutboundIPv6PoolName(loadBalancerName string) string {
+	lb := s.NodeOutboundLB()
+	if loadBalancerName == "" || lb == nil || lb.Name != loadBalancerName || len(lb.IPv6FrontendIPs) == 0 {
+		return ""
+	}
+	return azure.GenerateOutboundIPv6BackendAddressPoolName(loadBalancerName)
+}
+
 // ResourceGroup returns the cluster resource group.
 func (s *ClusterScope) ResourceGroup() string {
 	return s.AzureCluster.Spec.ResourceGroup
@@ -842,11 +1250,20 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.BastionHostReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
+			infrav1.SubnetCIDRsAllocatedCondition,
+			infrav1.VNetSettingsConfiguredCondition,
 			infrav1.SecurityGroupsReadyCondition,
+			infrav1.NSGFlowLogsReadyCondition,
 			infrav1.PrivateDNSZoneReadyCondition,
 			infrav1.PrivateDNSLinkReadyCondition,
 			infrav1.PrivateDNSRecordReadyCondition,
 			infrav1.PrivateEndpointsReadyCondition,
+			infrav1.PrivateEndpointDNSZonesReadyCondition,
+			infrav1.PrivateLinkServiceReadyCondition,
+			infrav1.ApplicationSecurityGroupsReadyCondition,
+			infrav1.PublicIPPrefixReadyCondition,
+			infrav1.NodeOutboundSNATPortsReadyCondition,
+			infrav1.APIServerDNSReadyCondition,
 		}})
 }
 
@@ -873,10 +1290,16 @@ func (s *ClusterScope) APIServerPort() int32 {
 }
 
 // APIServerHost returns the hostname used to reach the API server.
+// The FQDN of the API server record set of the public DNS zone is preferred to the DNS name of the public IP when set.
 func (s *ClusterScope) APIServerHost() string {
 	if s.IsAPIServerPrivate() {
 		return azure.GeneratePrivateFQDN(s.GetPrivateDNSZoneName())
 	}
+	if apiServerDNS := s.AzureCluster.Spec.NetworkSpec.APIServerDNS; apiServerDNS != nil {
+		if zoneID, err := azure.ParseResourceID(apiServerDNS.ZoneID); err == nil {
+			return apiServerDNSName(apiServerDNS.RecordName, zoneID.Name)
+		}
+	}
 	return s.APIServerPublicIP().DNSName
 }
 
@@ -935,6 +1358,125 @@ func (s *ClusterScope) SetControlPlaneSecurityRules() {
 	}
 }
 
+// SetNodeOutboundSNATPorts sizes the node outbound load balancer for the maximum number of nodes the cluster can scale to.
+// Frontend IPs are added to the load balancer until every node can be allocated the target number of SNAT ports, and
+// the number of ports actually allocated to each node is recorded in the AzureCluster status.
+// Note that this is not done in a webhook as it requires listing the MachineDeployments and MachinePools of the cluster.
+func (s *ClusterScope) SetNodeOutboundSNATPorts(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.ClusterScope.SetNodeOutboundSNATPorts")
+	defer done()
+
+	lb := s.NodeOutboundLB()
+	if lb == nil || lb.SNATPortsPerNode == nil {
+		s.AzureCluster.Status.NodeOutboundSNAT = nil
+		conditions.Delete(s.AzureCluster, infrav1.NodeOutboundSNATPortsReadyCondition)
+		return nil
+	}
+
+	maxNodeCount, err := s.maxNodeCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	frontendIPsCount, ports := nodeOutboundSNATSizing(maxNodeCount, *lb.SNATPortsPerNode, pointer.Int32Deref(lb.FrontendIPsCount, 1))
+	if frontendIPsCount > pointer.Int32Deref(lb.FrontendIPsCount, 1) {
+		lb.FrontendIPsCount = pointer.Int32(frontendIPsCount)
+		s.AzureCluster.SetNodeOutboundLBDefaults()
+	}
+	s.AzureCluster.Status.NodeOutboundSNAT = &infrav1.SNATPortsStatus{
+		MaxNodeCount:           maxNodeCount,
+		AllocatedOutboundPorts: ports,
+	}
+
+	if ports < *lb.SNATPortsPerNode {
+		msg := fmt.Sprintf("only %d of the %d requested SNAT ports can be allocated to each of up to %d nodes with %d frontend IPs",
+			ports, *lb.SNATPortsPerNode, maxNodeCount, frontendIPsCount)
+		if maxNodes := int64(frontendIPsCount) * azure.SNATPortsPerFrontendIP / azure.MinSNATPortsPerNode; int64(maxNodeCount) > maxNodes {
+			msg = fmt.Sprintf("the %d frontend IPs can only allocate the minimum of %d SNAT ports to %d of up to %d nodes",
+				frontendIPsCount, azure.MinSNATPortsPerNode, maxNodes, maxNodeCount)
+		}
+		log.Info("node outbound SNAT ports target cannot be met", "reason", msg)
+		conditions.MarkFalse(s.AzureCluster, infrav1.NodeOutboundSNATPortsReadyCondition, infrav1.SNATPortsTargetNotMetReason, clusterv1.ConditionSeverityWarning, msg)
+		return nil
+	}
+	conditions.MarkTrue(s.AzureCluster, infrav1.NodeOutboundSNATPortsReadyCondition)
+	return nil
+}
+
+// maxNodeCount returns the maximum number of nodes of the cluster's MachineDeployments and MachinePools,
+// taking into account the cluster-autoscaler maximum size and the surge of rolling updates.
+func (s *ClusterScope) maxNodeCount(ctx context.Context) (int32, error) {
+	var count int32
+	listOptions := []client.ListOption{
+		client.InNamespace(s.Namespace()),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: s.ClusterName()},
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := s.Client.List(ctx, machineDeployments, listOptions...); err != nil {
+		return 0, errors.Wrap(err, "failed to list MachineDeployments")
+	}
+	for _, md := range machineDeployments.Items {
+		replicas := maxReplicas(md.Spec.Replicas, md.Annotations)
+		surge := 1
+		if md.Spec.Strategy != nil && md.Spec.Strategy.RollingUpdate != nil && md.Spec.Strategy.RollingUpdate.MaxSurge != nil {
+			var err error
+			surge, err = intstr.GetScaledValueFromIntOrPercent(md.Spec.Strategy.RollingUpdate.MaxSurge, int(replicas), true)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to get the max surge of MachineDeployment %s", md.Name)
+			}
+		}
+		count += replicas + int32(surge)
+	}
+
+	if feature.Gates.Enabled(capifeature.MachinePool) {
+		machinePools := &expv1.MachinePoolList{}
+		if err := s.Client.List(ctx, machinePools, listOptions...); err != nil {
+			return 0, errors.Wrap(err, "failed to list MachinePools")
+		}
+		for _, mp := range machinePools.Items {
+			count += maxReplicas(mp.Spec.Replicas, mp.Annotations)
+		}
+	}
+
+	return count, nil
+}
+
+// maxReplicas returns the larger of the replicas and the cluster-autoscaler maximum size annotation.
+func maxReplicas(replicas *int32, annotations map[string]string) int32 {
+	count := pointer.Int32Deref(replicas, 1)
+	if maxSize, err := strconv.ParseInt(annotations[azure.AutoscalerMaxSizeAnnotation], 10, 32); err == nil && int32(maxSize) > count {
+		count = int32(maxSize)
+	}
+	return count
+}
+
+// nodeOutboundSNATSizing returns the number of frontend IPs needed to allocate the given number of SNAT ports to each of
+// maxNodeCount nodes, and the number of ports that can actually be allocated to each node. The number of frontend IPs never
+// decreases and is capped by the maximum number of outbound IPs of a load balancer. At least MinSNATPortsPerNode ports are
+// allocated to each node, even if the frontend IPs cannot provide them to all of them.
+func nodeOutboundSNATSizing(maxNodeCount, portsPerNode, frontendIPsCount int32) (int32, int32) {
+	if maxNodeCount < 1 {
+		maxNodeCount = 1
+	}
+	needed := (int64(maxNodeCount)*int64(portsPerNode) + azure.SNATPortsPerFrontendIP - 1) / azure.SNATPortsPerFrontendIP
+	if needed > int64(frontendIPsCount) {
+		frontendIPsCount = int32(needed)
+	}
+	if frontendIPsCount > infrav1.MaxLoadBalancerOutboundIPs {
+		frontendIPsCount = infrav1.MaxLoadBalancerOutboundIPs
+	}
+	// Ports are allocated to each backend instance in multiples of 8.
+	ports := int32(int64(frontendIPsCount) * azure.SNATPortsPerFrontendIP / int64(maxNodeCount) / 8 * 8)
+	if ports > portsPerNode {
+		ports = portsPerNode
+	}
+	if ports < azure.MinSNATPortsPerNode {
+		ports = azure.MinSNATPortsPerNode
+	}
+	return frontendIPsCount, ports
+}
+
 // SetDNSName sets the API Server public IP DNS name.
 // Note: this logic exists only for purposes of ensuring backwards compatibility for old clusters created without an APIServerLB, and should be removed in the future.
 func (s *ClusterScope) SetDNSName() {
@@ -996,6 +1538,16 @@ func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 	}
 }
 
+// SetConditionTrue sets the specified AzureCluster condition to true.
+func (s *ClusterScope) SetConditionTrue(conditionType clusterv1.ConditionType) {
+	conditions.MarkTrue(s.AzureCluster, conditionType)
+}
+
+// SetConditionFalse sets the specified AzureCluster condition to false.
+func (s *ClusterScope) SetConditionFalse(conditionType clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(s.AzureCluster, conditionType, reason, severity, message)
+}
+
 // UpdatePutStatus updates a condition on the AzureCluster status after a PUT operation.
 func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -1068,23 +1620,140 @@ func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
 
 // PrivateEndpointSpecs returns the private endpoint specs.
 func (s *ClusterScope) PrivateEndpointSpecs() []azure.ResourceSpecGetter {
-	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
-	if s.IsAzureBastionEnabled() {
-		numberOfSubnets++
+	subnets := s.privateEndpointSubnets()
+	privateEndpointSpecs := make([]azure.ResourceSpecGetter, 0, len(subnets))
+
+	for _, subnet := range subnets {
+		privateEndpointSpecs = append(privateEndpointSpecs, s.getPrivateEndpoints(subnet)...)
+	}
+
+	return privateEndpointSpecs
+}
+
+// PrivateEndpointDNSZoneSpecs returns the specs of the private DNS zones of the private endpoints and of their links to the vnet.
+func (s *ClusterScope) PrivateEndpointDNSZoneSpecs() (zonesSpec, linksSpec []azure.ResourceSpecGetter) {
+	var privateEndpoints []infrav1.PrivateEndpointSpec
+	for _, subnet := range s.privateEndpointSubnets() {
+		privateEndpoints = append(privateEndpoints, subnet.PrivateEndpoints...)
 	}
+	return privateEndpointDNSZoneSpecs(privateEndpoints, s.Vnet(), s.SubscriptionID(), s.ResourceGroup(), s.ClusterName(), s.AdditionalTags())
+}
 
-	privateEndpointSpecs := make([]azure.ResourceSpecGetter, 0, numberOfSubnets)
+// HasExistingPrivateEndpointDNSZones returns true if a private endpoint uses an existing private DNS zone, which is
+// not part of the cluster resource group.
+func (s *ClusterScope) HasExistingPrivateEndpointDNSZones() bool {
+	for _, subnet := range s.privateEndpointSubnets() {
+		for _, privateEndpoint := range subnet.PrivateEndpoints {
+			if privateEndpoint.PrivateDNSZone != nil && privateEndpoint.PrivateDNSZone.ID != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	subnets := s.AzureCluster.Spec.NetworkSpec.Subnets
+// privateEndpointSubnets returns the subnets that can hold private endpoints.
+func (s *ClusterScope) privateEndpointSubnets() infrav1.Subnets {
+	subnets := make(infrav1.Subnets, 0, len(s.AzureCluster.Spec.NetworkSpec.Subnets)+1)
+	subnets = append(subnets, s.AzureCluster.Spec.NetworkSpec.Subnets...)
 	if s.IsAzureBastionEnabled() {
 		subnets = append(subnets, s.AzureCluster.Spec.BastionSpec.AzureBastion.Subnet)
 	}
+	return subnets
+}
 
-	for _, subnet := range subnets {
-		privateEndpointSpecs = append(privateEndpointSpecs, s.getPrivateEndpoints(subnet)...)
+// PrivateLinkServiceSpec returns the private link service fronting the API server load balancer, if any.
+func (s *ClusterScope) PrivateLinkServiceSpec() azure.ResourceSpecGetter {
+	lb := s.APIServerLB()
+	if lb.PrivateLinkService == nil || lb.Type != infrav1.Internal || len(lb.FrontendIPs) == 0 {
+		return nil
+	}
+	pls := lb.PrivateLinkService
+
+	return &privatelinkservices.PrivateLinkServiceSpec{
+		Name:                      pls.Name,
+		ResourceGroup:             s.ResourceGroup(),
+		SubscriptionID:            s.SubscriptionID(),
+		Location:                  s.Location(),
+		LoadBalancerName:          lb.Name,
+		FrontendIPConfigName:      lb.FrontendIPs[0].Name,
+		VNetResourceGroup:         s.Vnet().ResourceGroup,
+		VNetName:                  s.Vnet().Name,
+		NATSubnetName:             pls.NATSubnetName,
+		NATIPConfigurations:       pls.NATIPConfigurations,
+		VisibilitySubscriptions:   pls.VisibilitySubscriptions,
+		AutoApprovalSubscriptions: pls.AutoApprovalSubscriptions,
+		EnableProxyProtocol:       pls.EnableProxyProtocol,
+		ClusterName:               s.ClusterName(),
+		AdditionalTags:            s.AdditionalTags(),
 	}
+}
 
-	return privateEndpointSpecs
+// SetPrivateLinkServiceStatus sets the observed state of the private link service in the AzureCluster status.
+func (s *ClusterScope) SetPrivateLinkServiceStatus(status *infrav1.PrivateLinkServiceStatus) {
+	s.AzureCluster.Status.PrivateLinkService = status
+}
+
+// PublicIPPrefixSpec returns the public IP prefix spec, or nil if the cluster's public IPs are not allocated from a prefix.
+func (s *ClusterScope) PublicIPPrefixSpec() azure.ResourceSpecGetter {
+	prefix := s.AzureCluster.Spec.NetworkSpec.PublicIPPrefix
+	if prefix == nil {
+		return nil
+	}
+
+	if prefix.ID != "" {
+		resourceID, err := azure.ParseResourceID(prefix.ID)
+		if err != nil {
+			return nil
+		}
+		return &publicipprefixes.PublicIPPrefixSpec{
+			Name:          resourceID.Name,
+			ResourceGroup: resourceID.ResourceGroupName,
+			ClusterName:   s.ClusterName(),
+			Location:      s.Location(),
+		}
+	}
+
+	return &publicipprefixes.PublicIPPrefixSpec{
+		Name:           prefix.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		ClusterName:    s.ClusterName(),
+		Location:       s.Location(),
+		PrefixLength:   pointer.Int32Deref(prefix.PrefixLength, infrav1.DefaultPublicIPPrefixLength),
+		FailureDomains: s.FailureDomains(),
+		AdditionalTags: s.AdditionalTags(),
+		Managed:        true,
+	}
+}
+
+// IsPublicIPPrefixManaged returns true if the public IP prefix is created by CAPZ.
+func (s *ClusterScope) IsPublicIPPrefixManaged() bool {
+	prefix := s.AzureCluster.Spec.NetworkSpec.PublicIPPrefix
+	return prefix != nil && prefix.ID == ""
+}
+
+// PublicIPPrefixID returns the ID of the public IP prefix the cluster's public IPs are allocated from, if any.
+func (s *ClusterScope) PublicIPPrefixID() string {
+	prefix := s.AzureCluster.Spec.NetworkSpec.PublicIPPrefix
+	switch {
+	case prefix == nil:
+		return ""
+	case prefix.ID != "":
+		return prefix.ID
+	default:
+		return azure.PublicIPPrefixID(s.SubscriptionID(), s.ResourceGroup(), prefix.Name)
+	}
+}
+
+// SetPublicIPPrefixStatus sets the observed state of the public IP prefix in the AzureCluster status.
+func (s *ClusterScope) SetPublicIPPrefixStatus(status *infrav1.PublicIPPrefixStatus) {
+	s.AzureCluster.Status.PublicIPPrefix = status
+}
+
+// isPrivateLinkServiceNATSubnet returns true if the private link service NAT IP addresses are allocated from the subnet.
+func (s *ClusterScope) isPrivateLinkServiceNATSubnet(subnetName string) bool {
+	pls := s.APIServerLB().PrivateLinkService
+	return pls != nil && pls.NATSubnetName == subnetName
 }
 
 func (s *ClusterScope) getPrivateEndpoints(subnet infrav1.SubnetSpec) []azure.ResourceSpecGetter {
@@ -1104,6 +1773,10 @@ func (s *ClusterScope) getPrivateEndpoints(subnet infrav1.SubnetSpec) []azure.Re
 			AdditionalTags:             s.AdditionalTags(),
 		}
 
+		if zone := privateEndpointDNSZoneSpec(privateEndpoint, s.SubscriptionID(), s.ResourceGroup(), s.ClusterName(), s.AdditionalTags()); zone != nil {
+			privateEndpointSpec.PrivateDNSZoneIDs = []string{privateDNSZoneResourceID(*zone)}
+		}
+
 		for _, privateLinkServiceConnection := range privateEndpoint.PrivateLinkServiceConnections {
 			pl := privateendpoints.PrivateLinkServiceConnection{
 				PrivateLinkServiceID: privateLinkServiceConnection.PrivateLinkServiceID,
@@ -1119,3 +1792,69 @@ func (s *ClusterScope) getPrivateEndpoints(subnet infrav1.SubnetSpec) []azure.Re
 
 	return privateEndpointSpecs
 }
+
+// privateEndpointDNSZoneSpec returns the spec of the private DNS zone of a private endpoint, or nil if the private endpoint has none.
+// Unless an existing zone is referenced by its ID, the zone lives in the resource group of the cluster.
+func privateEndpointDNSZoneSpec(privateEndpoint infrav1.PrivateEndpointSpec, subscriptionID, resourceGroup, clusterName string, additionalTags infrav1.Tags) *privatedns.ZoneSpec {
+	zoneName := privateEndpoint.GetPrivateDNSZoneName()
+	if zoneName == "" {
+		return nil
+	}
+
+	if zoneID := privateEndpoint.PrivateDNSZone.ID; zoneID != "" {
+		resourceID, err := azure.ParseResourceID(zoneID)
+		if err != nil {
+			return nil
+		}
+		return &privatedns.ZoneSpec{
+			Name:           resourceID.Name,
+			ResourceGroup:  resourceID.ResourceGroupName,
+			SubscriptionID: resourceID.SubscriptionID,
+			ClusterName:    clusterName,
+			Existing:       true,
+		}
+	}
+
+	return &privatedns.ZoneSpec{
+		Name:           zoneName,
+		ResourceGroup:  resourceGroup,
+		SubscriptionID: subscriptionID,
+		ClusterName:    clusterName,
+		AdditionalTags: additionalTags,
+	}
+}
+
+// privateEndpointDNSZoneSpecs returns the specs of the private DNS zones of the private endpoints, each zone being listed once,
+// and of the links of the zones to the vnet.
+func privateEndpointDNSZoneSpecs(privateEndpoints []infrav1.PrivateEndpointSpec, vnet *infrav1.VnetSpec, subscriptionID, resourceGroup, clusterName string, additionalTags infrav1.Tags) (zonesSpec, linksSpec []azure.ResourceSpecGetter) {
+	zoneNames := make(map[string]struct{})
+	for _, privateEndpoint := range privateEndpoints {
+		zone := privateEndpointDNSZoneSpec(privateEndpoint, subscriptionID, resourceGroup, clusterName, additionalTags)
+		if zone == nil {
+			continue
+		}
+		if _, ok := zoneNames[zone.Name]; ok {
+			continue
+		}
+		zoneNames[zone.Name] = struct{}{}
+
+		zonesSpec = append(zonesSpec, *zone)
+		linksSpec = append(linksSpec, privatedns.LinkSpec{
+			Name:               azure.GenerateVNetLinkName(vnet.Name),
+			ZoneName:           zone.Name,
+			SubscriptionID:     subscriptionID,
+			VNetResourceGroup:  vnet.ResourceGroup,
+			VNetName:           vnet.Name,
+			ResourceGroup:      zone.ResourceGroup,
+			ClusterName:        clusterName,
+			AdditionalTags:     additionalTags,
+			ZoneSubscriptionID: zone.SubscriptionID,
+		})
+	}
+	return zonesSpec, linksSpec
+}
+
+// privateDNSZoneResourceID returns the resource ID of a private DNS zone.
+func privateDNSZoneResourceID(zone privatedns.ZoneSpec) string {
+	return "/" + azure.PrivateDNSZoneID(zone.SubscriptionID, zone.ResourceGroup, zone.Name)
+}
@@ -23,24 +23,35 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicdns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -142,6 +153,35 @@ func TestAPIServerHost(t *testing.T) {
 			},
 			want: "apiserver.example.private",
 		},
+		{
+			name: "public apiserver lb (public dns zone record)",
+			azureCluster: infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: fakeSubscriptionID,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										DNSName: "my-cluster-apiserver.capz.io",
+									},
+								},
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+							},
+						},
+						APIServerDNS: &infrav1.APIServerDNS{
+							ZoneID:     "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com",
+							RecordName: "api.my-cluster",
+						},
+					},
+				},
+			},
+			want: "api.my-cluster.example.com",
+		},
 	}
 
 	for _, tc := range tests {
@@ -511,6 +551,112 @@ func TestPublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Azure cluster with explicit zones for the control plane outbound IPs",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "cluster.x-k8s.io/v1beta1",
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+				Status: infrav1.AzureClusterStatus{
+					FailureDomains: map[string]clusterv1.FailureDomainSpec{
+						"failure-domain-id-1": {},
+						"failure-domain-id-2": {},
+						"failure-domain-id-3": {},
+					},
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+						AdditionalTags: infrav1.Tags{
+							"Name": "my-publicip-ipv6",
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+						},
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						ControlPlaneOutboundLB: &infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "zone-redundant",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:  "pip-zone-redundant",
+										Zones: &infrav1.PublicIPZones{Placement: infrav1.PublicIPZoneRedundant},
+									},
+								},
+								{
+									Name: "zonal",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:  "pip-zonal",
+										Zones: &infrav1.PublicIPZones{Placement: infrav1.PublicIPZonal, Zone: "failure-domain-id-2"},
+									},
+								},
+								{
+									Name: "no-zone",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:  "pip-no-zone",
+										Zones: &infrav1.PublicIPZones{Placement: infrav1.PublicIPNoZone},
+									},
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Internal,
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "pip-zone-redundant",
+					ResourceGroup:  "my-rg",
+					DNSName:        "",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+					AdditionalTags: infrav1.Tags{
+						"Name": "my-publicip-ipv6",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-zonal",
+					ResourceGroup:  "my-rg",
+					DNSName:        "",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{"failure-domain-id-2"},
+					AdditionalTags: infrav1.Tags{
+						"Name": "my-publicip-ipv6",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-no-zone",
+					ResourceGroup:  "my-rg",
+					DNSName:        "",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{
+						"Name": "my-publicip-ipv6",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					},
+				},
+			},
+		},
 		{
 			name: "Azure cluster with public type apiserver LB",
 			azureCluster: &infrav1.AzureCluster{
@@ -850,6 +996,159 @@ func TestRouteTableSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns default egress routes for control plane and node subnets with user-defined routing",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								OutboundType: (*infrav1.OutboundType)(pointer.String(string(infrav1.OutboundTypeUserDefinedRouting))),
+								UserDefinedRouting: &infrav1.UserDefinedRouting{
+									NextHopIPAddress: "10.2.0.4",
+								},
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetControlPlane,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "my-cluster-controlplane-routetable",
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "my-cluster-node-routetable",
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "my-cluster-controlplane-routetable",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+					Routes: []routetables.RouteSpec{
+						{
+							Name:             "default-egress",
+							AddressPrefix:    "0.0.0.0/0",
+							NextHopType:      "VirtualAppliance",
+							NextHopIPAddress: "10.2.0.4",
+						},
+					},
+				},
+				&routetables.RouteTableSpec{
+					Name:           "my-cluster-node-routetable",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+					Routes: []routetables.RouteSpec{
+						{
+							Name:             "default-egress",
+							AddressPrefix:    "0.0.0.0/0",
+							NextHopType:      "VirtualAppliance",
+							NextHopIPAddress: "10.2.0.4",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "returns custom routes of the subnets sharing a route table and the routes removed from the spec",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							azure.RouteTableRoutesLastAppliedAnnotation: `{"my-cluster-node-routetable":["to-onprem","to-appliance"]}`,
+						},
+					},
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+										Name: "node-1",
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "my-cluster-node-routetable",
+										Routes: []infrav1.Route{
+											{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: infrav1.RouteNextHopVirtualNetworkGateway},
+										},
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+										Name: "node-2",
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "my-cluster-node-routetable",
+										Routes: []infrav1.Route{
+											{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: infrav1.RouteNextHopVirtualNetworkGateway},
+											{Name: "pods", AddressPrefix: "10.244.0.0/16", NextHopType: infrav1.RouteNextHopVirtualAppliance, NextHopIPAddress: "10.1.0.4"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "my-cluster-node-routetable",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+					Routes: []routetables.RouteSpec{
+						{
+							Name:          "to-onprem",
+							AddressPrefix: "192.168.0.0/16",
+							NextHopType:   "VirtualNetworkGateway",
+						},
+						{
+							Name:             "pods",
+							AddressPrefix:    "10.244.0.0/16",
+							NextHopType:      "VirtualAppliance",
+							NextHopIPAddress: "10.1.0.4",
+						},
+					},
+					StaleRouteNames: []string{"to-appliance"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -863,6 +1162,42 @@ func TestRouteTableSpecs(t *testing.T) {
 	}
 }
 
+func TestVNetSpecSettingsToClear(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					azure.VNetSettingsLastAppliedAnnotation: `{"ddosProtectionPlanID":"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ddosProtectionPlans/my-plan","dnsServers":["10.0.0.4"]}`,
+				},
+			},
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						ResourceGroup: "my-rg",
+						Name:          "my-vnet",
+						VnetClassSpec: infrav1.VnetClassSpec{
+							DNSServers: []string{"10.0.0.5"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	spec, ok := clusterScope.VNetSpec().(*virtualnetworks.VNetSpec)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(spec.ClearDDoSProtectionPlan).To(BeTrue())
+	g.Expect(spec.ClearDNSServers).To(BeFalse())
+}
+
 func TestNatGatewaySpecs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -944,7 +1279,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "returns specified node NAT gateway if present and ignores duplicate",
+			name: "returns zonal NAT gateways of zonal node subnets",
 			clusterScope: ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
@@ -969,27 +1304,111 @@ func TestNatGatewaySpecs(t *testing.T) {
 								{
 									SubnetClassSpec: infrav1.SubnetClassSpec{
 										Role: infrav1.SubnetNode,
-									},
-									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-1",
-										Name: "fake-route-table-1",
+										Zone: "1",
 									},
 									NatGateway: infrav1.NatGateway{
 										NatGatewayIP: infrav1.PublicIPSpec{
-											Name: "44.78.67.90",
+											Name: "pip-nat-gateway-1",
 										},
 										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
-											Name: "fake-nat-gateway-1",
+											Name: "nat-gateway-1",
 										},
 									},
 								},
-								// Duplicate Entry
 								{
 									SubnetClassSpec: infrav1.SubnetClassSpec{
 										Role: infrav1.SubnetNode,
+										Zone: "2",
 									},
-									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-1",
+									NatGateway: infrav1.NatGateway{
+										NatGatewayIP: infrav1.PublicIPSpec{
+											Name: "pip-nat-gateway-2",
+										},
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "nat-gateway-2",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&natgateways.NatGatewaySpec{
+					Name:           "nat-gateway-1",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					SubscriptionID: "123",
+					ClusterName:    "my-cluster",
+					NatGatewayIP: infrav1.PublicIPSpec{
+						Name: "pip-nat-gateway-1",
+					},
+					Zone:           "1",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&natgateways.NatGatewaySpec{
+					Name:           "nat-gateway-2",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					SubscriptionID: "123",
+					ClusterName:    "my-cluster",
+					NatGatewayIP: infrav1.PublicIPSpec{
+						Name: "pip-nat-gateway-2",
+					},
+					Zone:           "2",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns specified node NAT gateway if present and ignores duplicate",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										ID:   "fake-route-table-id-1",
+										Name: "fake-route-table-1",
+									},
+									NatGateway: infrav1.NatGateway{
+										NatGatewayIP: infrav1.PublicIPSpec{
+											Name: "44.78.67.90",
+										},
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "fake-nat-gateway-1",
+										},
+									},
+								},
+								// Duplicate Entry
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										ID:   "fake-route-table-id-1",
 										Name: "fake-route-table-1",
 									},
 									NatGateway: infrav1.NatGateway{
@@ -1192,6 +1611,131 @@ func TestNSGSpecs(t *testing.T) {
 	}
 }
 
+func TestNSGFlowLogSpecs(t *testing.T) {
+	storageAccountID := "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs"
+	subnets := infrav1.Subnets{
+		{SecurityGroup: infrav1.SecurityGroup{Name: "control-plane-nsg"}},
+		{SecurityGroup: infrav1.SecurityGroup{Name: "node-nsg"}},
+		{},
+	}
+
+	tests := []struct {
+		name     string
+		flowLogs *infrav1.NSGFlowLogs
+		want     []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if flow logs are not configured",
+		},
+		{
+			name:     "returns a flow log in the default Network Watcher for each security group",
+			flowLogs: &infrav1.NSGFlowLogs{StorageAccountID: storageAccountID, RetentionDays: 30},
+			want: []azure.ResourceSpecGetter{
+				&securitygroups.FlowLogSpec{
+					Name:               "my-rg-control-plane-nsg-flowlog",
+					ResourceGroup:      "NetworkWatcherRG",
+					NetworkWatcherName: "NetworkWatcher_westeurope",
+					Location:           "westeurope",
+					ClusterName:        "my-cluster",
+					SecurityGroupID:    "/subscriptions//resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/control-plane-nsg",
+					StorageAccountID:   storageAccountID,
+					RetentionDays:      30,
+					FormatVersion:      2,
+					AdditionalTags:     make(infrav1.Tags),
+				},
+				&securitygroups.FlowLogSpec{
+					Name:               "my-rg-node-nsg-flowlog",
+					ResourceGroup:      "NetworkWatcherRG",
+					NetworkWatcherName: "NetworkWatcher_westeurope",
+					Location:           "westeurope",
+					ClusterName:        "my-cluster",
+					SecurityGroupID:    "/subscriptions//resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nsg",
+					StorageAccountID:   storageAccountID,
+					RetentionDays:      30,
+					FormatVersion:      2,
+					AdditionalTags:     make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns flow logs with traffic analytics in a custom Network Watcher",
+			flowLogs: &infrav1.NSGFlowLogs{
+				StorageAccountID:            storageAccountID,
+				FormatVersion:               1,
+				NetworkWatcherResourceGroup: "watchers-rg",
+				NetworkWatcherName:          "watcher",
+				TrafficAnalytics: &infrav1.TrafficAnalytics{
+					WorkspaceResourceID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/analytics",
+					WorkspaceID:         "4b1a3ad4-2e4f-4b8e-9e0f-3a1f5c6d7e8f",
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&securitygroups.FlowLogSpec{
+					Name:               "my-rg-control-plane-nsg-flowlog",
+					ResourceGroup:      "watchers-rg",
+					NetworkWatcherName: "watcher",
+					Location:           "westeurope",
+					ClusterName:        "my-cluster",
+					SecurityGroupID:    "/subscriptions//resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/control-plane-nsg",
+					StorageAccountID:   storageAccountID,
+					FormatVersion:      1,
+					TrafficAnalytics: &infrav1.TrafficAnalytics{
+						WorkspaceResourceID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/analytics",
+						WorkspaceID:         "4b1a3ad4-2e4f-4b8e-9e0f-3a1f5c6d7e8f",
+						IntervalMinutes:     60,
+					},
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&securitygroups.FlowLogSpec{
+					Name:               "my-rg-node-nsg-flowlog",
+					ResourceGroup:      "watchers-rg",
+					NetworkWatcherName: "watcher",
+					Location:           "westeurope",
+					ClusterName:        "my-cluster",
+					SecurityGroupID:    "/subscriptions//resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nsg",
+					StorageAccountID:   storageAccountID,
+					FormatVersion:      1,
+					TrafficAnalytics: &infrav1.TrafficAnalytics{
+						WorkspaceResourceID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/analytics",
+						WorkspaceID:         "4b1a3ad4-2e4f-4b8e-9e0f-3a1f5c6d7e8f",
+						IntervalMinutes:     60,
+					},
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			clusterScope := ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "westeurope",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets:  subnets,
+							FlowLogs: tt.flowLogs,
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			}
+			g.Expect(clusterScope.NSGFlowLogSpecs()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestSubnetSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1664,6 +2208,317 @@ func TestAzureBastionSpec(t *testing.T) {
 	}
 }
 
+func TestPrivateLinkServiceSpec(t *testing.T) {
+	newClusterScope := func(lb infrav1.LoadBalancerSpec) ClusterScope {
+		return ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						Location: "westus",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-vnet-rg",
+						},
+						APIServerLB: lb,
+					},
+				},
+			},
+			cache: &ClusterCache{},
+		}
+	}
+	internalLB := func(pls *infrav1.PrivateLinkService) infrav1.LoadBalancerSpec {
+		return infrav1.LoadBalancerSpec{
+			Name: "my-lb",
+			FrontendIPs: []infrav1.FrontendIP{
+				{
+					Name: "my-lb-frontEnd",
+				},
+			},
+			PrivateLinkService: pls,
+			LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+				Type: infrav1.Internal,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nil if no private link service is specified",
+			clusterScope: newClusterScope(internalLB(nil)),
+			want:         nil,
+		},
+		{
+			name: "returns private link service spec if specified",
+			clusterScope: newClusterScope(internalLB(&infrav1.PrivateLinkService{
+				Name:                      "my-lb-pls",
+				NATSubnetName:             "my-subnet",
+				NATIPConfigurations:       []infrav1.PrivateLinkServiceNATIPConfiguration{{Name: "my-lb-pls-natip-0"}},
+				VisibilitySubscriptions:   []string{"456"},
+				AutoApprovalSubscriptions: []string{"456"},
+			})),
+			want: &privatelinkservices.PrivateLinkServiceSpec{
+				Name:                      "my-lb-pls",
+				ResourceGroup:             "my-rg",
+				SubscriptionID:            "123",
+				Location:                  "westus",
+				LoadBalancerName:          "my-lb",
+				FrontendIPConfigName:      "my-lb-frontEnd",
+				VNetResourceGroup:         "my-vnet-rg",
+				VNetName:                  "my-vnet",
+				NATSubnetName:             "my-subnet",
+				NATIPConfigurations:       []infrav1.PrivateLinkServiceNATIPConfiguration{{Name: "my-lb-pls-natip-0"}},
+				VisibilitySubscriptions:   []string{"456"},
+				AutoApprovalSubscriptions: []string{"456"},
+				ClusterName:               "my-cluster",
+				AdditionalTags:            infrav1.Tags{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.PrivateLinkServiceSpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrivateLinkServiceSpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestPublicIPPrefixSpec(t *testing.T) {
+	newClusterScope := func(prefix *infrav1.PublicIPPrefix) ClusterScope {
+		return ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						Location: "westus",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						PublicIPPrefix: prefix,
+					},
+				},
+			},
+			cache: &ClusterCache{},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         azure.ResourceSpecGetter
+		wantID       string
+		wantManaged  bool
+	}{
+		{
+			name:         "returns nil if no public IP prefix is specified",
+			clusterScope: newClusterScope(nil),
+			want:         nil,
+			wantID:       "",
+		},
+		{
+			name:         "returns a managed public IP prefix spec",
+			clusterScope: newClusterScope(&infrav1.PublicIPPrefix{Name: "my-cluster-pip-prefix", PrefixLength: pointer.Int32(29)}),
+			want: &publicipprefixes.PublicIPPrefixSpec{
+				Name:           "my-cluster-pip-prefix",
+				ResourceGroup:  "my-rg",
+				ClusterName:    "my-cluster",
+				Location:       "westus",
+				PrefixLength:   29,
+				FailureDomains: []string{},
+				AdditionalTags: infrav1.Tags{},
+				Managed:        true,
+			},
+			wantID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-cluster-pip-prefix",
+			wantManaged: true,
+		},
+		{
+			name:         "returns a pre-existing public IP prefix spec",
+			clusterScope: newClusterScope(&infrav1.PublicIPPrefix{ID: "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress"}),
+			want: &publicipprefixes.PublicIPPrefixSpec{
+				Name:          "egress",
+				ResourceGroup: "network-rg",
+				ClusterName:   "my-cluster",
+				Location:      "westus",
+			},
+			wantID: "/subscriptions/123/resourceGroups/network-rg/providers/Microsoft.Network/publicIPPrefixes/egress",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			if got := tt.clusterScope.PublicIPPrefixSpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PublicIPPrefixSpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+			g.Expect(tt.clusterScope.PublicIPPrefixID()).To(Equal(tt.wantID))
+			g.Expect(tt.clusterScope.IsPublicIPPrefixManaged()).To(Equal(tt.wantManaged))
+		})
+	}
+}
+
+func TestAPIServerDNSRecordSpecs(t *testing.T) {
+	newClusterScope := func(lbType infrav1.LBType, apiServerDNS *infrav1.APIServerDNS) ClusterScope {
+		return ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-my-cluster-apiserver",
+									},
+								},
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: lbType,
+							},
+						},
+						APIServerDNS: apiServerDNS,
+					},
+				},
+			},
+			cache: &ClusterCache{},
+		}
+	}
+	zoneID := "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com"
+
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nil if no API server DNS is specified",
+			clusterScope: newClusterScope(infrav1.Public, nil),
+			want:         nil,
+		},
+		{
+			name:         "returns nil for a private API server",
+			clusterScope: newClusterScope(infrav1.Internal, &infrav1.APIServerDNS{ZoneID: zoneID, RecordName: "api"}),
+			want:         nil,
+		},
+		{
+			name: "returns an alias record and CNAME records in the zone",
+			clusterScope: newClusterScope(infrav1.Public, &infrav1.APIServerDNS{
+				ZoneID:           zoneID,
+				RecordName:       "api",
+				CNAMERecordNames: []string{"kube"},
+				TTL:              pointer.Int64(60),
+			}),
+			want: []azure.ResourceSpecGetter{
+				&publicdns.RecordSpec{
+					Name:             "api",
+					Type:             dns.A,
+					ZoneName:         "example.com",
+					ResourceGroup:    "dns-rg",
+					SubscriptionID:   "456",
+					TTL:              60,
+					ClusterName:      "my-cluster",
+					TargetResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-cluster-apiserver",
+				},
+				&publicdns.RecordSpec{
+					Name:           "kube",
+					Type:           dns.CNAME,
+					ZoneName:       "example.com",
+					ResourceGroup:  "dns-rg",
+					SubscriptionID: "456",
+					TTL:            60,
+					ClusterName:    "my-cluster",
+					CNAME:          "api.example.com",
+				},
+			},
+		},
+		{
+			name: "CNAME records of the zone apex point at the zone name",
+			clusterScope: newClusterScope(infrav1.Public, &infrav1.APIServerDNS{
+				ZoneID:           zoneID,
+				RecordName:       "@",
+				CNAMERecordNames: []string{"kube"},
+			}),
+			want: []azure.ResourceSpecGetter{
+				&publicdns.RecordSpec{
+					Name:             "@",
+					Type:             dns.A,
+					ZoneName:         "example.com",
+					ResourceGroup:    "dns-rg",
+					SubscriptionID:   "456",
+					TTL:              infrav1.DefaultAPIServerDNSTTL,
+					ClusterName:      "my-cluster",
+					TargetResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-cluster-apiserver",
+				},
+				&publicdns.RecordSpec{
+					Name:           "kube",
+					Type:           dns.CNAME,
+					ZoneName:       "example.com",
+					ResourceGroup:  "dns-rg",
+					SubscriptionID: "456",
+					TTL:            infrav1.DefaultAPIServerDNSTTL,
+					ClusterName:    "my-cluster",
+					CNAME:          "example.com",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(tt.clusterScope.APIServerDNSRecordSpecs()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestSubnet(t *testing.T) {
 	tests := []struct {
 		clusterName             string
@@ -1877,6 +2732,15 @@ func TestGetPrivateDNSZoneName(t *testing.T) {
 			clusterName:              "my-cluster-2",
 			expectPrivateDNSZoneName: "my-cluster-2.capz.io",
 		},
+		{
+			clusterName: "my-cluster-3",
+			azureClusterNetworkSpec: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID: "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.private",
+				},
+			},
+			expectPrivateDNSZoneName: "corp.private",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.clusterName, func(t *testing.T) {
@@ -1927,6 +2791,317 @@ func TestGetPrivateDNSZoneName(t *testing.T) {
 	}
 }
 
+func TestPrivateDNSSpec(t *testing.T) {
+	newClusterScope := func(lbType infrav1.LBType, zoneID string, nodeRecords bool) ClusterScope {
+		return ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						NetworkClassSpec: infrav1.NetworkClassSpec{
+							PrivateDNSZoneID:      zoneID,
+							PrivateDNSNodeRecords: nodeRecords,
+						},
+						Vnet: infrav1.VnetSpec{
+							ResourceGroup: "my-rg",
+							Name:          "my-vnet",
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									FrontendIPClass: infrav1.FrontendIPClass{
+										PrivateIPAddress: "10.0.0.100",
+									},
+								},
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: lbType,
+							},
+						},
+					},
+				},
+			},
+			cache: &ClusterCache{},
+		}
+	}
+	existingZoneID := "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.private"
+
+	tests := []struct {
+		name              string
+		clusterScope      ClusterScope
+		wantZone          azure.ResourceSpecGetter
+		wantLinks         []azure.ResourceSpecGetter
+		wantRecords       []azure.ResourceSpecGetter
+		wantNodeRecordsID string
+	}{
+		{
+			name:         "no private DNS zone for a public API server",
+			clusterScope: newClusterScope(infrav1.Public, "", false),
+		},
+		{
+			name:         "private DNS zone created for a private API server",
+			clusterScope: newClusterScope(infrav1.Internal, "", true),
+			wantZone: privatedns.ZoneSpec{
+				Name:           "my-cluster.capz.io",
+				ResourceGroup:  "my-rg",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{},
+			},
+			wantLinks: []azure.ResourceSpecGetter{
+				privatedns.LinkSpec{
+					Name:              "my-vnet-link",
+					ZoneName:          "my-cluster.capz.io",
+					SubscriptionID:    "123",
+					VNetResourceGroup: "my-rg",
+					VNetName:          "my-vnet",
+					ResourceGroup:     "my-rg",
+					ClusterName:       "my-cluster",
+					AdditionalTags:    infrav1.Tags{},
+				},
+			},
+			wantRecords: []azure.ResourceSpecGetter{
+				privatedns.RecordSpec{
+					Record:        infrav1.AddressRecord{Hostname: "apiserver", IP: "10.0.0.100"},
+					ZoneName:      "my-cluster.capz.io",
+					ResourceGroup: "my-rg",
+				},
+			},
+			wantNodeRecordsID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/my-cluster.capz.io",
+		},
+		{
+			name:         "existing private DNS zone in another subscription for a private API server",
+			clusterScope: newClusterScope(infrav1.Internal, existingZoneID, false),
+			wantZone: privatedns.ZoneSpec{
+				Name:           "corp.private",
+				ResourceGroup:  "dns-rg",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{},
+				SubscriptionID: "456",
+				Existing:       true,
+			},
+			wantLinks: []azure.ResourceSpecGetter{
+				privatedns.LinkSpec{
+					Name:               "my-vnet-link",
+					ZoneName:           "corp.private",
+					SubscriptionID:     "123",
+					VNetResourceGroup:  "my-rg",
+					VNetName:           "my-vnet",
+					ResourceGroup:      "dns-rg",
+					ClusterName:        "my-cluster",
+					AdditionalTags:     infrav1.Tags{},
+					ZoneSubscriptionID: "456",
+				},
+			},
+			wantRecords: []azure.ResourceSpecGetter{
+				privatedns.RecordSpec{
+					Record:         infrav1.AddressRecord{Hostname: "apiserver", IP: "10.0.0.100"},
+					ZoneName:       "corp.private",
+					ResourceGroup:  "dns-rg",
+					SubscriptionID: "456",
+					ClusterName:    "my-cluster",
+				},
+			},
+		},
+		{
+			name:         "existing private DNS zone for the node records of a public API server",
+			clusterScope: newClusterScope(infrav1.Public, existingZoneID, true),
+			wantZone: privatedns.ZoneSpec{
+				Name:           "corp.private",
+				ResourceGroup:  "dns-rg",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{},
+				SubscriptionID: "456",
+				Existing:       true,
+			},
+			wantLinks: []azure.ResourceSpecGetter{
+				privatedns.LinkSpec{
+					Name:               "my-vnet-link",
+					ZoneName:           "corp.private",
+					SubscriptionID:     "123",
+					VNetResourceGroup:  "my-rg",
+					VNetName:           "my-vnet",
+					ResourceGroup:      "dns-rg",
+					ClusterName:        "my-cluster",
+					AdditionalTags:     infrav1.Tags{},
+					ZoneSubscriptionID: "456",
+				},
+			},
+			wantNodeRecordsID: existingZoneID,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			zone, links, records := tt.clusterScope.PrivateDNSSpec()
+			if tt.wantZone == nil {
+				g.Expect(zone).To(BeNil())
+			} else {
+				g.Expect(zone).To(Equal(tt.wantZone))
+			}
+			g.Expect(links).To(Equal(tt.wantLinks))
+			g.Expect(records).To(Equal(tt.wantRecords))
+			g.Expect(tt.clusterScope.PrivateDNSNodeRecordsZoneID()).To(Equal(tt.wantNodeRecordsID))
+		})
+	}
+}
+
+func TestPrivateEndpointDNSZoneSpecs(t *testing.T) {
+	newClusterScope := func(privateEndpoints ...infrav1.PrivateEndpointSpec) ClusterScope {
+		return ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							ResourceGroup: "vnet-rg",
+							Name:          "my-vnet",
+						},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:             infrav1.SubnetNode,
+									Name:             "node",
+									PrivateEndpoints: privateEndpoints,
+								},
+								ID: "node-subnet-id",
+							},
+						},
+					},
+				},
+			},
+			cache: &ClusterCache{},
+		}
+	}
+	privateEndpoint := func(name, groupID string, zone *infrav1.PrivateEndpointDNSZone) infrav1.PrivateEndpointSpec {
+		return infrav1.PrivateEndpointSpec{
+			Name: name,
+			PrivateLinkServiceConnections: []infrav1.PrivateLinkServiceConnection{
+				{
+					PrivateLinkServiceID: "/subscriptions/123/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account",
+					GroupIDs:             []string{groupID},
+				},
+			},
+			PrivateDNSZone: zone,
+		}
+	}
+	blobZoneID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+	vaultZoneID := "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"
+
+	tests := []struct {
+		name             string
+		clusterScope     ClusterScope
+		wantZones        []azure.ResourceSpecGetter
+		wantLinks        []azure.ResourceSpecGetter
+		wantZoneIDs      map[string][]string
+		wantExistingZone bool
+	}{
+		{
+			name:         "no private DNS zones for private endpoints without DNS configuration",
+			clusterScope: newClusterScope(privateEndpoint("pe-blob", "blob", nil)),
+			wantZoneIDs:  map[string][]string{"pe-blob": nil},
+		},
+		{
+			name: "private DNS zones are created once in the cluster resource group or referenced by ID",
+			clusterScope: newClusterScope(
+				privateEndpoint("pe-blob", "blob", &infrav1.PrivateEndpointDNSZone{}),
+				privateEndpoint("pe-blob2", "blob", &infrav1.PrivateEndpointDNSZone{Name: "privatelink.blob.core.windows.net"}),
+				privateEndpoint("pe-vault", "vault", &infrav1.PrivateEndpointDNSZone{ID: vaultZoneID}),
+			),
+			wantZones: []azure.ResourceSpecGetter{
+				privatedns.ZoneSpec{
+					Name:           "privatelink.blob.core.windows.net",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "123",
+					ClusterName:    "my-cluster",
+					AdditionalTags: infrav1.Tags{},
+				},
+				privatedns.ZoneSpec{
+					Name:           "privatelink.vaultcore.azure.net",
+					ResourceGroup:  "dns-rg",
+					SubscriptionID: "456",
+					ClusterName:    "my-cluster",
+					Existing:       true,
+				},
+			},
+			wantLinks: []azure.ResourceSpecGetter{
+				privatedns.LinkSpec{
+					Name:               "my-vnet-link",
+					ZoneName:           "privatelink.blob.core.windows.net",
+					SubscriptionID:     "123",
+					VNetResourceGroup:  "vnet-rg",
+					VNetName:           "my-vnet",
+					ResourceGroup:      "my-rg",
+					ClusterName:        "my-cluster",
+					AdditionalTags:     infrav1.Tags{},
+					ZoneSubscriptionID: "123",
+				},
+				privatedns.LinkSpec{
+					Name:               "my-vnet-link",
+					ZoneName:           "privatelink.vaultcore.azure.net",
+					SubscriptionID:     "123",
+					VNetResourceGroup:  "vnet-rg",
+					VNetName:           "my-vnet",
+					ResourceGroup:      "dns-rg",
+					ClusterName:        "my-cluster",
+					AdditionalTags:     infrav1.Tags{},
+					ZoneSubscriptionID: "456",
+				},
+			},
+			wantZoneIDs: map[string][]string{
+				"pe-blob":  {blobZoneID},
+				"pe-blob2": {blobZoneID},
+				"pe-vault": {vaultZoneID},
+			},
+			wantExistingZone: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			zones, links := tt.clusterScope.PrivateEndpointDNSZoneSpecs()
+			g.Expect(zones).To(Equal(tt.wantZones))
+			g.Expect(links).To(Equal(tt.wantLinks))
+			g.Expect(tt.clusterScope.HasExistingPrivateEndpointDNSZones()).To(Equal(tt.wantExistingZone))
+
+			zoneIDs := make(map[string][]string)
+			for _, spec := range tt.clusterScope.PrivateEndpointSpecs() {
+				privateEndpointSpec := spec.(*privateendpoints.PrivateEndpointSpec)
+				zoneIDs[privateEndpointSpec.Name] = privateEndpointSpec.PrivateDNSZoneIDs
+			}
+			g.Expect(zoneIDs).To(Equal(tt.wantZoneIDs))
+		})
+	}
+}
+
 func TestAPIServerLBPoolName(t *testing.T) {
 	tests := []struct {
 		lbName           string
@@ -2318,27 +3493,83 @@ func TestOutboundPoolName(t *testing.T) {
 						},
 					},
 				},
-				Spec: infrav1.AzureClusterSpec{
-					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
-						SubscriptionID: "123",
-					},
-				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+					},
+				},
+			}
+
+			initObjects := []runtime.Object{cluster, azureCluster}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
+
+			clusterScope, err := NewClusterScope(context.TODO(), ClusterScopeParams{
+				AzureClients: AzureClients{
+					Authorizer: autorest.NullAuthorizer{},
+				},
+				Cluster:      cluster,
+				AzureCluster: azureCluster,
+				Client:       fakeClient,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			got := clusterScope.OutboundPoolName(tc.loadBalancerName)
+			g.Expect(tc.expectOutboundPoolName).Should(Equal(got))
+		})
+	}
+}
+
+func TestOutboundIPv6PoolName(t *testing.T) {
+	tests := []struct {
+		name             string
+		nodeOutboundLB   *infrav1.LoadBalancerSpec
+		loadBalancerName string
+		want             string
+	}{
+		{
+			name:             "no node outbound LB",
+			loadBalancerName: "my-cluster",
+			want:             "",
+		},
+		{
+			name:             "node outbound LB without IPv6 frontend IPs",
+			nodeOutboundLB:   &infrav1.LoadBalancerSpec{Name: "my-cluster"},
+			loadBalancerName: "my-cluster",
+			want:             "",
+		},
+		{
+			name: "another LB",
+			nodeOutboundLB: &infrav1.LoadBalancerSpec{
+				Name:            "my-cluster",
+				IPv6FrontendIPs: []infrav1.FrontendIP{{Name: "my-cluster-frontEnd-ipv6"}},
+			},
+			loadBalancerName: "my-cluster-public-lb",
+			want:             "",
+		},
+		{
+			name: "node outbound LB with IPv6 frontend IPs",
+			nodeOutboundLB: &infrav1.LoadBalancerSpec{
+				Name:            "my-cluster",
+				IPv6FrontendIPs: []infrav1.FrontendIP{{Name: "my-cluster-frontEnd-ipv6"}},
+			},
+			loadBalancerName: "my-cluster",
+			want:             "my-cluster-outboundBackendPool-ipv6",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			clusterScope := ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							NodeOutboundLB: tc.nodeOutboundLB,
+						},
+					},
+				},
 			}
-
-			initObjects := []runtime.Object{cluster, azureCluster}
-			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
-
-			clusterScope, err := NewClusterScope(context.TODO(), ClusterScopeParams{
-				AzureClients: AzureClients{
-					Authorizer: autorest.NullAuthorizer{},
-				},
-				Cluster:      cluster,
-				AzureCluster: azureCluster,
-				Client:       fakeClient,
-			})
-			g.Expect(err).NotTo(HaveOccurred())
-			got := clusterScope.OutboundPoolName(tc.loadBalancerName)
-			g.Expect(tc.expectOutboundPoolName).Should(Equal(got))
+			g.Expect(clusterScope.OutboundIPv6PoolName(tc.loadBalancerName)).To(Equal(tc.want))
 		})
 	}
 }
@@ -2921,6 +4152,127 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Public API Server LB with an internal LB, additional rules and a custom health probe",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus2",
+					},
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "api-server-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "api-server-lb-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+								SKU:  infrav1.SKUStandard,
+							},
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "api-server-lb-frontend-ip",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "api-server-lb-frontend-ip",
+									},
+								},
+							},
+							HealthProbe: &infrav1.LoadBalancerHealthProbe{
+								NumberOfProbes: pointer.Int32(2),
+							},
+							AdditionalRules: []infrav1.LoadBalancingRule{
+								{Name: "konnectivity", Protocol: infrav1.LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8132},
+							},
+							InternalLB: &infrav1.InternalLoadBalancer{
+								Name: "api-server-internal-lb",
+								FrontendIPClass: infrav1.FrontendIPClass{
+									PrivateIPAddress: "10.0.0.100",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&loadbalancers.LBSpec{
+					Name:              "api-server-lb",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					ClusterName:       "my-cluster",
+					Location:          "westus2",
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "my-rg",
+					SubnetName:        "cp-subnet",
+					FrontendIPConfigs: []infrav1.FrontendIP{
+						{
+							Name: "api-server-lb-frontend-ip",
+							PublicIP: &infrav1.PublicIPSpec{
+								Name: "api-server-lb-frontend-ip",
+							},
+						},
+					},
+					APIServerPort:   6443,
+					Type:            infrav1.Public,
+					SKU:             infrav1.SKUStandard,
+					Role:            infrav1.APIServerRole,
+					BackendPoolName: "api-server-lb-backend-pool",
+					HealthProbe: &infrav1.LoadBalancerHealthProbe{
+						NumberOfProbes: pointer.Int32(2),
+					},
+					AdditionalRules: []infrav1.LoadBalancingRule{
+						{Name: "konnectivity", Protocol: infrav1.LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8132},
+					},
+					AdditionalTags: infrav1.Tags{},
+				},
+				&loadbalancers.LBSpec{
+					Name:              "api-server-internal-lb",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					ClusterName:       "my-cluster",
+					Location:          "westus2",
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "my-rg",
+					SubnetName:        "cp-subnet",
+					FrontendIPConfigs: []infrav1.FrontendIP{
+						{
+							Name: "api-server-internal-lb-frontEnd",
+							FrontendIPClass: infrav1.FrontendIPClass{
+								PrivateIPAddress: "10.0.0.100",
+							},
+						},
+					},
+					APIServerPort:   6443,
+					Type:            infrav1.Internal,
+					SKU:             infrav1.SKUStandard,
+					Role:            infrav1.APIServerRole,
+					BackendPoolName: "api-server-internal-lb-backendPool",
+					HealthProbe: &infrav1.LoadBalancerHealthProbe{
+						NumberOfProbes: pointer.Int32(2),
+					},
+					AdditionalRules: []infrav1.LoadBalancingRule{
+						{Name: "konnectivity", Protocol: infrav1.LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8132},
+					},
+					AdditionalTags: infrav1.Tags{},
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -3148,20 +4500,64 @@ func TestVNetPeerings(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet1-To-vnet2",
-					SourceResourceGroup: "rg1",
-					SourceVnetName:      "vnet1",
-					RemoteResourceGroup: "rg2",
-					RemoteVnetName:      "vnet2",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet1-To-vnet2",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "rg2",
+					RemoteVnetName:       "vnet2",
+					SubscriptionID:       fakeSubscriptionID,
+					SourceSubscriptionID: fakeSubscriptionID,
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "vnet2-To-vnet1",
+					SourceResourceGroup:  "rg2",
+					SourceVnetName:       "vnet2",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       fakeSubscriptionID,
+					SourceSubscriptionID: fakeSubscriptionID,
+					Reverse:              true,
+				},
+			},
+		},
+		{
+			name:           "VNet peering in another subscription and tenant is specified",
+			subscriptionID: fakeSubscriptionID,
+			azureClusterVNetSpec: infrav1.VnetSpec{
+				ResourceGroup: "rg1",
+				Name:          "vnet1",
+				Peerings: infrav1.VnetPeerings{
+					{
+						VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+							ResourceGroup:  "hub-rg",
+							RemoteVnetName: "hub",
+							SubscriptionID: "connectivity",
+							IdentityRef:    &corev1.ObjectReference{Name: "connectivity-identity"},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "vnet1-To-hub",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "hub-rg",
+					RemoteVnetName:       "hub",
+					SubscriptionID:       "connectivity",
+					SourceSubscriptionID: fakeSubscriptionID,
+					RemoteIdentityRef:    &corev1.ObjectReference{Name: "connectivity-identity"},
 				},
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet2-To-vnet1",
-					SourceResourceGroup: "rg2",
-					SourceVnetName:      "vnet2",
-					RemoteResourceGroup: "rg1",
-					RemoteVnetName:      "vnet1",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "hub-To-vnet1",
+					SourceResourceGroup:  "hub-rg",
+					SourceVnetName:       "hub",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       fakeSubscriptionID,
+					SourceSubscriptionID: "connectivity",
+					RemoteIdentityRef:    &corev1.ObjectReference{Name: "connectivity-identity"},
+					Reverse:              true,
 				},
 			},
 		},
@@ -3198,6 +4594,7 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg2",
 					RemoteVnetName:        "vnet2",
 					SubscriptionID:        fakeSubscriptionID,
+					SourceSubscriptionID:  fakeSubscriptionID,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(false),
 					UseRemoteGateways:     pointer.Bool(true),
@@ -3209,6 +4606,8 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg1",
 					RemoteVnetName:        "vnet1",
 					SubscriptionID:        fakeSubscriptionID,
+					SourceSubscriptionID:  fakeSubscriptionID,
+					Reverse:               true,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(true),
 					UseRemoteGateways:     pointer.Bool(false),
@@ -3254,6 +4653,7 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg2",
 					RemoteVnetName:        "vnet2",
 					SubscriptionID:        fakeSubscriptionID,
+					SourceSubscriptionID:  fakeSubscriptionID,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(false),
 					UseRemoteGateways:     pointer.Bool(true),
@@ -3265,25 +4665,30 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg1",
 					RemoteVnetName:        "vnet1",
 					SubscriptionID:        fakeSubscriptionID,
+					SourceSubscriptionID:  fakeSubscriptionID,
+					Reverse:               true,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(true),
 					UseRemoteGateways:     pointer.Bool(false),
 				},
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet1-To-vnet3",
-					SourceResourceGroup: "rg1",
-					SourceVnetName:      "vnet1",
-					RemoteResourceGroup: "rg3",
-					RemoteVnetName:      "vnet3",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet1-To-vnet3",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "rg3",
+					RemoteVnetName:       "vnet3",
+					SubscriptionID:       fakeSubscriptionID,
+					SourceSubscriptionID: fakeSubscriptionID,
 				},
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet3-To-vnet1",
-					SourceResourceGroup: "rg3",
-					SourceVnetName:      "vnet3",
-					RemoteResourceGroup: "rg1",
-					RemoteVnetName:      "vnet1",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet3-To-vnet1",
+					SourceResourceGroup:  "rg3",
+					SourceVnetName:       "vnet3",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       fakeSubscriptionID,
+					SourceSubscriptionID: fakeSubscriptionID,
+					Reverse:              true,
 				},
 			},
 		},
@@ -3344,3 +4749,333 @@ func TestVNetPeerings(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocateSubnetCIDRs(t *testing.T) {
+	tests := []struct {
+		name            string
+		vnet            infrav1.VnetSpec
+		subnets         infrav1.Subnets
+		azureBastion    *infrav1.AzureBastion
+		vnetSubnetCIDRs []string
+		wantCIDRBlocks  [][]string
+		wantErr         string
+		wantCondition   bool
+		wantConditionOK bool
+	}{
+		{
+			name: "no subnet to allocate",
+			vnet: infrav1.VnetSpec{VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}}},
+			subnets: infrav1.Subnets{
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/16"}}},
+			},
+			wantCIDRBlocks: [][]string{{"10.0.0.0/16"}},
+		},
+		{
+			name: "allocates CIDR blocks that do not overlap with existing subnets",
+			vnet: infrav1.VnetSpec{VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"}}},
+			subnets: infrav1.Subnets{
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/16", "2001:1234:5678:9a00::/64"}}},
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-1", PrefixLength: pointer.Int32(16)}},
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-2", PrefixLength: pointer.Int32(24), IPv6PrefixLength: pointer.Int32(64)}},
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-3", PrefixLength: pointer.Int32(16), CIDRBlocks: []string{"10.1.0.0/16"}}},
+			},
+			azureBastion: &infrav1.AzureBastion{
+				Subnet: infrav1.SubnetSpec{SubnetClassSpec: infrav1.SubnetClassSpec{CIDRBlocks: []string{"10.2.0.0/27"}}},
+			},
+			wantCIDRBlocks: [][]string{
+				{"10.0.0.0/16", "2001:1234:5678:9a00::/64"},
+				{"10.3.0.0/16"},
+				{"10.2.1.0/24", "2001:1234:5678:9a01::/64"},
+				{"10.1.0.0/16"},
+			},
+			wantCondition:   true,
+			wantConditionOK: true,
+		},
+		{
+			name: "skips CIDR blocks of subnets of other clusters sharing the virtual network",
+			vnet: infrav1.VnetSpec{VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}, Shared: true}},
+			subnets: infrav1.Subnets{
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp", PrefixLength: pointer.Int32(16)}},
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node", PrefixLength: pointer.Int32(16)}},
+			},
+			vnetSubnetCIDRs: []string{"10.0.0.0/16", "10.2.0.0/16"},
+			wantCIDRBlocks: [][]string{
+				{"10.1.0.0/16"},
+				{"10.3.0.0/16"},
+			},
+			wantCondition:   true,
+			wantConditionOK: true,
+		},
+		{
+			name: "address space exhausted",
+			vnet: infrav1.VnetSpec{VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/16"}}},
+			subnets: infrav1.Subnets{
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp", CIDRBlocks: []string{"10.0.0.0/17"}}},
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node", PrefixLength: pointer.Int32(16)}},
+			},
+			wantCIDRBlocks:  [][]string{{"10.0.0.0/17"}, nil},
+			wantErr:         "failed to allocate a CIDR block to subnet node",
+			wantCondition:   true,
+			wantConditionOK: false,
+		},
+		{
+			name: "virtual network not managed",
+			vnet: infrav1.VnetSpec{
+				ID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}},
+			},
+			subnets: infrav1.Subnets{
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node", PrefixLength: pointer.Int32(16)}},
+			},
+			wantCIDRBlocks: [][]string{nil},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet:    tc.vnet,
+							Subnets: tc.subnets,
+						},
+						BastionSpec: infrav1.BastionSpec{AzureBastion: tc.azureBastion},
+					},
+				},
+				cache: &ClusterCache{vnetSubnetCIDRs: tc.vnetSubnetCIDRs},
+			}
+
+			err := clusterScope.AllocateSubnetCIDRs()
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			for i, subnet := range clusterScope.AzureCluster.Spec.NetworkSpec.Subnets {
+				g.Expect(subnet.CIDRBlocks).To(Equal(tc.wantCIDRBlocks[i]))
+			}
+			g.Expect(conditions.Has(clusterScope.AzureCluster, infrav1.SubnetCIDRsAllocatedCondition)).To(Equal(tc.wantCondition))
+			if tc.wantCondition {
+				g.Expect(conditions.IsTrue(clusterScope.AzureCluster, infrav1.SubnetCIDRsAllocatedCondition)).To(Equal(tc.wantConditionOK))
+			}
+		})
+	}
+}
+
+func TestNodeOutboundSNATSizing(t *testing.T) {
+	tests := []struct {
+		name                     string
+		maxNodeCount             int32
+		portsPerNode             int32
+		frontendIPsCount         int32
+		expectedFrontendIPsCount int32
+		expectedPorts            int32
+	}{
+		{
+			name:                     "single frontend IP is enough",
+			maxNodeCount:             10,
+			portsPerNode:             1024,
+			frontendIPsCount:         1,
+			expectedFrontendIPsCount: 1,
+			expectedPorts:            1024,
+		},
+		{
+			name:                     "frontend IPs are added for more nodes",
+			maxNodeCount:             134,
+			portsPerNode:             1024,
+			frontendIPsCount:         1,
+			expectedFrontendIPsCount: 3,
+			expectedPorts:            1024,
+		},
+		{
+			name:                     "frontend IPs are never removed",
+			maxNodeCount:             100,
+			portsPerNode:             64,
+			frontendIPsCount:         4,
+			expectedFrontendIPsCount: 4,
+			expectedPorts:            64,
+		},
+		{
+			name:                     "ports are reduced when the maximum number of frontend IPs is reached",
+			maxNodeCount:             2000,
+			portsPerNode:             1024,
+			frontendIPsCount:         1,
+			expectedFrontendIPsCount: 16,
+			expectedPorts:            512,
+		},
+		{
+			name:                     "the minimum number of ports is allocated when the frontend IPs cannot serve all nodes",
+			maxNodeCount:             200000,
+			portsPerNode:             1024,
+			frontendIPsCount:         1,
+			expectedFrontendIPsCount: 16,
+			expectedPorts:            8,
+		},
+		{
+			name:                     "cluster without nodes",
+			maxNodeCount:             0,
+			portsPerNode:             1024,
+			frontendIPsCount:         1,
+			expectedFrontendIPsCount: 1,
+			expectedPorts:            1024,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			frontendIPsCount, ports := nodeOutboundSNATSizing(tc.maxNodeCount, tc.portsPerNode, tc.frontendIPsCount)
+			g.Expect(frontendIPsCount).To(Equal(tc.expectedFrontendIPsCount))
+			g.Expect(ports).To(Equal(tc.expectedPorts))
+		})
+	}
+}
+
+func TestSetNodeOutboundSNATPorts(t *testing.T) {
+	tests := []struct {
+		name                     string
+		snatPortsPerNode         *int32
+		objects                  []client.Object
+		expectedStatus           *infrav1.SNATPortsStatus
+		expectedFrontendIPsCount int32
+		expectedConditionStatus  corev1.ConditionStatus
+		expectedConditionMessage string
+	}{
+		{
+			name:                     "SNAT ports are not sized automatically",
+			expectedFrontendIPsCount: 1,
+		},
+		{
+			name:             "frontend IPs are added for the max size of the node groups",
+			snatPortsPerNode: pointer.Int32(1024),
+			objects: []client.Object{
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "md-0",
+						Namespace:   "default",
+						Labels:      map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+						Annotations: map[string]string{azure.AutoscalerMaxSizeAnnotation: "120"},
+					},
+					Spec: clusterv1.MachineDeploymentSpec{ClusterName: "my-cluster", Replicas: pointer.Int32(3)},
+				},
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "md-1",
+						Namespace: "default",
+						Labels:    map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+					},
+					Spec: clusterv1.MachineDeploymentSpec{
+						ClusterName: "my-cluster",
+						Replicas:    pointer.Int32(10),
+						Strategy: &clusterv1.MachineDeploymentStrategy{
+							RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{MaxSurge: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"}},
+						},
+					},
+				},
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-md",
+						Namespace: "default",
+						Labels:    map[string]string{clusterv1.ClusterNameLabel: "other-cluster"},
+					},
+					Spec: clusterv1.MachineDeploymentSpec{ClusterName: "other-cluster", Replicas: pointer.Int32(1000)},
+				},
+			},
+			expectedStatus:           &infrav1.SNATPortsStatus{MaxNodeCount: 134, AllocatedOutboundPorts: 1024},
+			expectedFrontendIPsCount: 3,
+			expectedConditionStatus:  corev1.ConditionTrue,
+		},
+		{
+			name:             "SNAT ports target cannot be met",
+			snatPortsPerNode: pointer.Int32(1024),
+			objects: []client.Object{
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "md-0",
+						Namespace: "default",
+						Labels:    map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+					},
+					Spec: clusterv1.MachineDeploymentSpec{
+						ClusterName: "my-cluster",
+						Replicas:    pointer.Int32(1999),
+					},
+				},
+			},
+			expectedStatus:           &infrav1.SNATPortsStatus{MaxNodeCount: 2000, AllocatedOutboundPorts: 512},
+			expectedFrontendIPsCount: 16,
+			expectedConditionStatus:  corev1.ConditionFalse,
+		},
+		{
+			name:             "frontend IPs cannot allocate the minimum number of SNAT ports to all nodes",
+			snatPortsPerNode: pointer.Int32(1024),
+			objects: []client.Object{
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "md-0",
+						Namespace: "default",
+						Labels:    map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+					},
+					Spec: clusterv1.MachineDeploymentSpec{
+						ClusterName: "my-cluster",
+						Replicas:    pointer.Int32(199999),
+					},
+				},
+			},
+			expectedStatus:           &infrav1.SNATPortsStatus{MaxNodeCount: 200000, AllocatedOutboundPorts: 8},
+			expectedFrontendIPsCount: 16,
+			expectedConditionStatus:  corev1.ConditionFalse,
+			expectedConditionMessage: "the 16 frontend IPs can only allocate the minimum of 8 SNAT ports to 128000 of up to 200000 nodes",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = clusterv1.AddToScheme(scheme)
+			_ = infrav1.AddToScheme(scheme)
+
+			azureCluster := &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							Name:             "my-cluster",
+							FrontendIPsCount: pointer.Int32(1),
+							SNATPortsPerNode: tc.snatPortsPerNode,
+						},
+					},
+				},
+			}
+			azureCluster.SetNodeOutboundLBDefaults()
+
+			clusterScope := &ClusterScope{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build(),
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+				},
+				AzureCluster: azureCluster,
+			}
+
+			g.Expect(clusterScope.SetNodeOutboundSNATPorts(context.TODO())).To(Succeed())
+			g.Expect(azureCluster.Status.NodeOutboundSNAT).To(Equal(tc.expectedStatus))
+			lb := azureCluster.Spec.NetworkSpec.NodeOutboundLB
+			g.Expect(lb.FrontendIPsCount).To(Equal(pointer.Int32(tc.expectedFrontendIPsCount)))
+			g.Expect(lb.FrontendIPs).To(HaveLen(int(tc.expectedFrontendIPsCount)))
+			g.Expect(lb.FrontendIPs[0].PublicIP.Name).To(Equal("pip-my-cluster-node-outbound"))
+			if tc.expectedStatus == nil {
+				g.Expect(conditions.Has(azureCluster, infrav1.NodeOutboundSNATPortsReadyCondition)).To(BeFalse())
+			} else {
+				g.Expect(conditions.Get(azureCluster, infrav1.NodeOutboundSNATPortsReadyCondition).Status).To(Equal(tc.expectedConditionStatus))
+			}
+			if tc.expectedConditionMessage != "" {
+				g.Expect(conditions.GetMessage(azureCluster, infrav1.NodeOutboundSNATPortsReadyCondition)).To(Equal(tc.expectedConditionMessage))
+			}
+		})
+	}
+}
@@ -20,19 +20,28 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"net/netip"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/net"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapdata"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapwatchdog"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
@@ -95,14 +104,30 @@ type MachineScope struct {
 	Machine      *clusterv1.Machine
 	AzureMachine *infrav1.AzureMachine
 	cache        *MachineCache
+
+	// bootstrapFailureLog is the tail of the boot diagnostics serial log captured in this reconcile loop
+	// when the machine was detected as failed to bootstrap.
+	bootstrapFailureLog string
+	// extensionFailures are the outputs of the VM extensions found failed in this reconcile loop.
+	extensionFailures []ExtensionFailure
+	// privateIPAddresses are the static private IP addresses drawn from pools in this reconcile loop, by network interface name.
+	privateIPAddresses map[string]string
+}
+
+// ExtensionFailure describes a failed VM extension and the output it reported.
+type ExtensionFailure struct {
+	Name   string
+	Output string
 }
 
 // MachineCache stores common machine information so we don't have to hit the API multiple times within the same reconcile loop.
 type MachineCache struct {
-	BootstrapData      string
-	VMImage            *infrav1.Image
-	VMSKU              resourceskus.SKU
-	availabilitySetSKU resourceskus.SKU
+	BootstrapData       string
+	RawBootstrapData    []byte
+	BootstrapDataFormat string
+	VMImage             *infrav1.Image
+	VMSKU               resourceskus.SKU
+	availabilitySetSKU  resourceskus.SKU
 }
 
 // InitMachineCache sets cached information about the machine to be used in the scope.
@@ -119,6 +144,13 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 			return err
 		}
 
+		if m.AzureMachine.Spec.BootstrapDataStorage != nil {
+			m.cache.RawBootstrapData, m.cache.BootstrapDataFormat, err = m.GetRawBootstrapData(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
 		m.cache.VMImage, err = m.GetVMImage(ctx)
 		if err != nil {
 			return err
@@ -188,9 +220,14 @@ func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
 }
 
 // PublicIPSpecs returns the public IP specs.
+// The public IP of a zonal machine is created in the zone of the machine, so it doesn't depend on the other zones.
 func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
 	if m.AzureMachine.Spec.AllocatePublicIP {
+		failureDomains := m.FailureDomains()
+		if zone := m.AvailabilityZone(); zone != "" {
+			failureDomains = []string{zone}
+		}
 		specs = append(specs, &publicips.PublicIPSpec{
 			Name:             azure.GenerateNodePublicIPName(m.Name()),
 			ResourceGroup:    m.ResourceGroup(),
@@ -199,8 +236,9 @@ func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 			IsIPv6:           false, // Set to default value
 			Location:         m.Location(),
 			ExtendedLocation: m.ExtendedLocation(),
-			FailureDomains:   m.FailureDomains(),
+			FailureDomains:   failureDomains,
 			AdditionalTags:   m.ClusterScoper.AdditionalTags(),
+			PublicIPPrefixID: m.PublicIPPrefixID(),
 		})
 	}
 	return specs
@@ -246,27 +284,36 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 // BuildNICSpec takes a NetworkInterface from the AzureMachineSpec and returns a NICSpec for use by the networkinterfaces service.
 func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infrav1.NetworkInterface, primaryNetworkInterface bool) *networkinterfaces.NICSpec {
 	spec := &networkinterfaces.NICSpec{
-		Name:                  nicName,
-		ResourceGroup:         m.ResourceGroup(),
-		Location:              m.Location(),
-		ExtendedLocation:      m.ExtendedLocation(),
-		SubscriptionID:        m.SubscriptionID(),
-		MachineName:           m.Name(),
-		VNetName:              m.Vnet().Name,
-		VNetResourceGroup:     m.Vnet().ResourceGroup,
-		AcceleratedNetworking: infrav1NetworkInterface.AcceleratedNetworking,
-		IPv6Enabled:           m.IsIPv6Enabled(),
-		EnableIPForwarding:    m.AzureMachine.Spec.EnableIPForwarding,
-		SubnetName:            infrav1NetworkInterface.SubnetName,
-		AdditionalTags:        m.AdditionalTags(),
-		ClusterName:           m.ClusterName(),
-		IPConfigs:             []networkinterfaces.IPConfig{},
+		Name:                      nicName,
+		ResourceGroup:             m.ResourceGroup(),
+		Location:                  m.Location(),
+		ExtendedLocation:          m.ExtendedLocation(),
+		SubscriptionID:            m.SubscriptionID(),
+		MachineName:               m.Name(),
+		VNetName:                  m.Vnet().Name,
+		VNetResourceGroup:         m.Vnet().ResourceGroup,
+		AcceleratedNetworking:     infrav1NetworkInterface.AcceleratedNetworking,
+		IPv6Enabled:               m.IsIPv6Enabled(),
+		EnableIPForwarding:        m.AzureMachine.Spec.EnableIPForwarding,
+		SubnetName:                infrav1NetworkInterface.SubnetName,
+		AdditionalTags:            m.AdditionalTags(),
+		ClusterName:               m.ClusterName(),
+		IPConfigs:                 []networkinterfaces.IPConfig{},
+		ApplicationSecurityGroups: applicationSecurityGroupNames(m.ApplicationSecurityGroups(), m.Role(), m.AzureMachine.Spec.ApplicationSecurityGroups),
 	}
 
 	if m.cache != nil {
 		spec.SKU = &m.cache.VMSKU
 	}
 
+	if privateIP := infrav1NetworkInterface.PrivateIPAddress; privateIP != nil {
+		if privateIP.Address != "" {
+			spec.StaticIPAddress = privateIP.Address
+		} else {
+			spec.StaticIPAddress = m.privateIPAddresses[nicName]
+		}
+	}
+
 	for i := 0; i < infrav1NetworkInterface.PrivateIPConfigs; i++ {
 		spec.IPConfigs = append(spec.IPConfigs, networkinterfaces.IPConfig{})
 	}
@@ -283,27 +330,142 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 			} else {
 				spec.PublicLBNATRuleName = m.Name()
 				spec.PublicLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+				if internalLB := m.APIServerLB().InternalLB; internalLB != nil {
+					spec.InternalLBName = internalLB.Name
+					spec.InternalLBAddressPoolName = m.APIServerLBPoolName(internalLB.Name)
+				}
 			}
 		}
 
 		if m.Role() == infrav1.Node && m.AzureMachine.Spec.AllocatePublicIP {
 			spec.PublicIPName = azure.GenerateNodePublicIPName(m.Name())
 		}
-		// If the NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
-		if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !m.AzureMachine.Spec.AllocatePublicIP {
-			spec.PublicLBName = m.OutboundLBName(m.Role())
-			spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
-		}
-		// If the NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
-		if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !m.AzureMachine.Spec.AllocatePublicIP {
-			spec.PublicLBName = m.OutboundLBName(m.Role())
-			spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
+		if m.Role() == infrav1.Node && !m.AzureMachine.Spec.AllocatePublicIP {
+			// If the NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
+			if !m.Subnet().IsNatGatewayEnabled() {
+				spec.PublicLBName = m.OutboundLBName(m.Role())
+				spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
+			}
+			// NAT gateways only carry IPv4 traffic, so the IPv6 egress always goes through the LB.
+			if m.IsIPv6Enabled() {
+				if ipv6Pool := m.OutboundIPv6PoolName(m.OutboundLBName(m.Role())); ipv6Pool != "" {
+					spec.PublicLBName = m.OutboundLBName(m.Role())
+					spec.PublicLBIPv6AddressPoolName = ipv6Pool
+				}
+			}
 		}
 	}
 
 	return spec
 }
 
+// PrivateIPSpecs returns the specs of the static private IP addresses of the network interfaces drawn from pools.
+func (m *MachineScope) PrivateIPSpecs() []*privateips.PrivateIPSpec {
+	isMultiNIC := len(m.AzureMachine.Spec.NetworkInterfaces) > 1
+
+	var specs []*privateips.PrivateIPSpec
+	for i, nic := range m.AzureMachine.Spec.NetworkInterfaces {
+		privateIP := nic.PrivateIPAddress
+		if privateIP == nil || privateIP.Address != "" {
+			continue
+		}
+		nicName := azure.GenerateNICName(m.Name(), isMultiNIC, i)
+		spec := &privateips.PrivateIPSpec{
+			NICName:     nicName,
+			ClaimName:   nicName,
+			Namespace:   m.AzureMachine.Namespace,
+			ClusterName: m.ClusterName(),
+			Owner: metav1.OwnerReference{
+				APIVersion:         infrav1.GroupVersion.String(),
+				Kind:               "AzureMachine",
+				Name:               m.AzureMachine.Name,
+				BlockOwnerDeletion: pointer.Bool(true),
+				UID:                m.AzureMachine.UID,
+			},
+			PoolRef:    privateIP.PoolRef,
+			SubnetName: nic.SubnetName,
+		}
+		if privateIP.FromSubnetPool {
+			for _, subnet := range m.Subnets() {
+				if subnet.Name == nic.SubnetName && subnet.StaticIPPool != nil {
+					spec.SubnetPool = subnet.StaticIPPool
+					spec.SubnetPrefix = staticIPPoolPrefix(subnet)
+				}
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// staticIPPoolPrefix returns the prefix length of the subnet CIDR block containing its static IP pool,
+// or that of a single address if it is unknown.
+func staticIPPoolPrefix(subnet infrav1.SubnetSpec) int {
+	start, err := netip.ParseAddr(subnet.StaticIPPool.Start)
+	if err != nil {
+		return 0
+	}
+	for _, cidr := range subnet.CIDRBlocks {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(start) {
+			return prefix.Bits()
+		}
+	}
+	return start.BitLen()
+}
+
+// SetPrivateIPAddress sets the static private IP address drawn from a pool for a network interface.
+func (m *MachineScope) SetPrivateIPAddress(nicName, address string) {
+	if m.privateIPAddresses == nil {
+		m.privateIPAddresses = map[string]string{}
+	}
+	m.privateIPAddresses[nicName] = address
+}
+
+// GetClient returns the controller-runtime client.
+func (m *MachineScope) GetClient() client.Client {
+	return m.client
+}
+
+// zonalSubnetName returns the name of the only subnet pinned to the given availability zone, or an empty string
+// if there is none or more than one.
+func zonalSubnetName(subnets []infrav1.SubnetSpec, zone string) string {
+	if zone == "" {
+		return ""
+	}
+	var subnetName string
+	for _, subnet := range subnets {
+		if subnet.Zone != zone {
+			continue
+		}
+		if subnetName != "" {
+			return ""
+		}
+		subnetName = subnet.Name
+	}
+	return subnetName
+}
+
+// applicationSecurityGroupNames returns the names of the cluster application security groups a machine with the given role
+// is associated with: the ones of its role, followed by the ones listed in its spec.
+func applicationSecurityGroupNames(asgs []infrav1.ApplicationSecurityGroup, role string, names []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, asg := range asgs {
+		if asg.Role != "" && string(asg.Role) == role && !seen[asg.Name] {
+			seen[asg.Name] = true
+			result = append(result, asg.Name)
+		}
+	}
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
 // NICIDs returns the NIC resource IDs.
 func (m *MachineScope) NICIDs() []string {
 	nicspecs := m.NICSpecs()
@@ -392,6 +554,62 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 	return extensionSpecs
 }
 
+// ExtensionStatus returns the last known status of the VM extension with the given name.
+func (m *MachineScope) ExtensionStatus(name string) (infrav1.ExtensionStatus, bool) {
+	for _, status := range m.AzureMachine.Status.Extensions {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return infrav1.ExtensionStatus{}, false
+}
+
+// SetExtensionStatus sets the status of a VM extension, replacing any previous status of the same extension.
+func (m *MachineScope) SetExtensionStatus(status infrav1.ExtensionStatus) {
+	for i := range m.AzureMachine.Status.Extensions {
+		if m.AzureMachine.Status.Extensions[i].Name == status.Name {
+			m.AzureMachine.Status.Extensions[i] = status
+			return
+		}
+	}
+	m.AzureMachine.Status.Extensions = append(m.AzureMachine.Status.Extensions, status)
+}
+
+// RecordExtensionFailure keeps the output of a failed VM extension so it can be reported.
+func (m *MachineScope) RecordExtensionFailure(name, output string) {
+	m.extensionFailures = append(m.extensionFailures, ExtensionFailure{Name: name, Output: output})
+}
+
+// ExtensionFailures returns the VM extensions found failed during this reconcile loop.
+func (m *MachineScope) ExtensionFailures() []ExtensionFailure {
+	return m.extensionFailures
+}
+
+// PrivateDNSNodeRecordSpec returns the spec of the A record of the machine in the private DNS zone of the cluster,
+// or nil if the cluster has no node records or the machine has no private IPv4 address yet.
+func (m *MachineScope) PrivateDNSNodeRecordSpec() azure.ResourceSpecGetter {
+	zoneID, err := azure.ParseResourceID(m.PrivateDNSNodeRecordsZoneID())
+	if err != nil {
+		return nil
+	}
+
+	for _, address := range m.AzureMachine.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP && net.IsIPv4String(address.Address) {
+			return privatedns.RecordSpec{
+				Record: infrav1.AddressRecord{
+					Hostname: m.Name(),
+					IP:       address.Address,
+				},
+				ZoneName:       zoneID.Name,
+				ResourceGroup:  zoneID.ResourceGroupName,
+				SubscriptionID: zoneID.SubscriptionID,
+				ClusterName:    m.ClusterName(),
+			}
+		}
+	}
+	return nil
+}
+
 // Subnet returns the machine's subnet.
 func (m *MachineScope) Subnet() infrav1.SubnetSpec {
 	for _, subnet := range m.Subnets() {
@@ -639,6 +857,9 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 			infrav1.VMRunningCondition,
 			infrav1.AvailabilitySetReadyCondition,
 			infrav1.NetworkInterfaceReadyCondition,
+			infrav1.BootstrapFailedCondition,
+			infrav1.AzureResourceAvailableCondition,
+			infrav1.PrivateDNSRecordReadyCondition,
 		}})
 }
 
@@ -666,20 +887,202 @@ func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetBootstrapData")
 	defer done()
 
+	value, _, err := m.GetRawBootstrapData(ctx)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(value), nil
+}
+
+// GetRawBootstrapData returns the undecoded bootstrap data and its format from the secret in the Machine's bootstrap.dataSecretName.
+func (m *MachineScope) GetRawBootstrapData(ctx context.Context) ([]byte, string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetRawBootstrapData")
+	defer done()
+
 	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
-		return "", errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
+		return nil, "", errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
 	}
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.Machine.Spec.Bootstrap.DataSecretName}
 	if err := m.client.Get(ctx, key, secret); err != nil {
-		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for AzureMachine %s/%s", m.Namespace(), m.Name())
+		return nil, "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for AzureMachine %s/%s", m.Namespace(), m.Name())
 	}
 
 	value, ok := secret.Data["value"]
 	if !ok {
-		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
+		return nil, "", errors.New("error retrieving bootstrap data: secret value key is missing")
 	}
-	return base64.StdEncoding.EncodeToString(value), nil
+	return value, string(secret.Data["format"]), nil
+}
+
+// BootstrapDataBlobSpec returns the spec of the blob used to deliver the bootstrap data, or nil if the bootstrap data
+// is passed directly as customData.
+func (m *MachineScope) BootstrapDataBlobSpec() *azure.BootstrapDataBlobSpec {
+	storage := m.AzureMachine.Spec.BootstrapDataStorage
+	if storage == nil {
+		return nil
+	}
+
+	spec := &azure.BootstrapDataBlobSpec{
+		StorageAccountName: storage.StorageAccountName,
+		ContainerName:      storage.ContainerName,
+		BlobName:           m.Name(),
+		AccessMethod:       storage.AccessMethod,
+		SASExpiry:          bootstrapdata.DefaultSASExpiry,
+	}
+	if spec.ContainerName == "" {
+		spec.ContainerName = m.ClusterName()
+	}
+	if spec.AccessMethod == "" {
+		spec.AccessMethod = infrav1.BootstrapDataAccessMethodSAS
+	}
+	if storage.SASExpiry != nil {
+		spec.SASExpiry = storage.SASExpiry.Duration
+	}
+	if m.cache != nil {
+		spec.Data = m.cache.RawBootstrapData
+		spec.Format = m.cache.BootstrapDataFormat
+	}
+	return spec
+}
+
+// SetBootstrapDataStub replaces the customData of the VM with a stub fetching the bootstrap data from Azure Blob Storage.
+func (m *MachineScope) SetBootstrapDataStub(stub string) {
+	if m.cache != nil {
+		m.cache.BootstrapData = base64.StdEncoding.EncodeToString([]byte(stub))
+	}
+}
+
+// BootstrapDataBlobState returns the state of the blob staging the bootstrap data.
+func (m *MachineScope) BootstrapDataBlobState() infrav1.BootstrapDataBlobState {
+	return m.AzureMachine.Status.BootstrapDataBlob
+}
+
+// SetBootstrapDataBlobState sets the state of the blob staging the bootstrap data.
+func (m *MachineScope) SetBootstrapDataBlobState(state infrav1.BootstrapDataBlobState) {
+	m.AzureMachine.Status.BootstrapDataBlob = state
+}
+
+// HasNodeRef returns true if the Machine's Node has joined the cluster.
+func (m *MachineScope) HasNodeRef() bool {
+	return m.Machine.Status.NodeRef != nil
+}
+
+// BootstrapWatchdogSpec returns the spec of the bootstrap watchdog, or nil if the watchdog is not enabled.
+func (m *MachineScope) BootstrapWatchdogSpec() *azure.BootstrapWatchdogSpec {
+	watchdog := m.AzureMachine.Spec.BootstrapWatchdog
+	if watchdog == nil {
+		return nil
+	}
+
+	spec := &azure.BootstrapWatchdogSpec{
+		ResourceGroup: m.ResourceGroup(),
+		VMName:        m.Name(),
+		Timeout:       bootstrapwatchdog.DefaultTimeout,
+	}
+	if watchdog.Timeout != nil {
+		spec.Timeout = watchdog.Timeout.Duration
+	}
+	if conditions.IsTrue(m.AzureMachine, infrav1.VMRunningCondition) {
+		spec.RunningSince = conditions.GetLastTransitionTime(m.AzureMachine, infrav1.VMRunningCondition).Time
+	}
+	return spec
+}
+
+// BootstrapWatchdogRequeueAfter returns the amount of time after which the bootstrap watchdog needs to check the
+// machine again, or zero if no check is pending.
+func (m *MachineScope) BootstrapWatchdogRequeueAfter() time.Duration {
+	spec := m.BootstrapWatchdogSpec()
+	if spec == nil || spec.RunningSince.IsZero() || m.HasNodeRef() || m.IsBootstrapFailed() {
+		return 0
+	}
+	remaining := time.Until(spec.RunningSince.Add(spec.Timeout))
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// IsBootstrapFailed returns true if the machine has been detected as failed to bootstrap.
+func (m *MachineScope) IsBootstrapFailed() bool {
+	return conditions.IsTrue(m.AzureMachine, infrav1.BootstrapFailedCondition)
+}
+
+// SetBootstrapFailed marks the machine as failed to bootstrap and keeps the tail of the boot diagnostics serial log
+// so it can be reported.
+func (m *MachineScope) SetBootstrapFailed(reason, message, logTail string) {
+	conditions.Set(m.AzureMachine, &clusterv1.Condition{
+		Type:    infrav1.BootstrapFailedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	m.bootstrapFailureLog = logTail
+}
+
+// ClearBootstrapFailed removes the BootstrapFailed condition from the machine.
+func (m *MachineScope) ClearBootstrapFailed() {
+	conditions.Delete(m.AzureMachine, infrav1.BootstrapFailedCondition)
+}
+
+// BootstrapFailureLog returns the tail of the boot diagnostics serial log captured when the machine was detected as
+// failed to bootstrap during this reconcile loop.
+func (m *MachineScope) BootstrapFailureLog() string {
+	return m.bootstrapFailureLog
+}
+
+// AvailabilityStatusResource refers to the AzureMachine.
+func (m *MachineScope) AvailabilityStatusResource() conditions.Setter {
+	return m.AzureMachine
+}
+
+// AvailabilityStatusResourceURI returns the ID of the VM, or an empty string if it has not been created yet.
+func (m *MachineScope) AvailabilityStatusResourceURI() string {
+	return strings.TrimPrefix(m.ProviderID(), azure.ProviderIDPrefix)
+}
+
+// AvailabilityStatusFilter ignores the unknown availability status reported while a new VM is being set up.
+func (m *MachineScope) AvailabilityStatusFilter(cond *clusterv1.Condition) *clusterv1.Condition {
+	return filterInitialAvailabilityStatus(m.AzureMachine.CreationTimestamp, cond)
+}
+
+// machineResourceHealthInitialGracePeriod is how long the unknown availability status of a new VM is ignored.
+const machineResourceHealthInitialGracePeriod = 15 * time.Minute
+
+// filterInitialAvailabilityStatus reports a new VM as available while Azure Resource Health has not determined its
+// availability yet. A degraded or unavailable VM is always reported.
+func filterInitialAvailabilityStatus(created metav1.Time, cond *clusterv1.Condition) *clusterv1.Condition {
+	if time.Since(created.Time) < machineResourceHealthInitialGracePeriod && cond.Status == corev1.ConditionUnknown {
+		return conditions.TrueCondition(infrav1.AzureResourceAvailableCondition)
+	}
+	return cond
+}
+
+// ResourceHealthRemediation returns true if the VM has been unavailable because of a platform-initiated event for
+// longer than the configured remediation delay. Otherwise, it returns how long to wait before checking again, or 0 if
+// the VM is not affected by such an event.
+func (m *MachineScope) ResourceHealthRemediation() (bool, time.Duration) {
+	return resourceHealthRemediation(m.AzureMachine.Spec.ResourceHealth, m.AzureMachine)
+}
+
+// resourceHealthRemediation returns whether a VM must be remediated according to its AzureResourceAvailable condition,
+// or how long to wait before checking again. See MachineScope.ResourceHealthRemediation.
+func resourceHealthRemediation(rh *infrav1.MachineResourceHealth, from conditions.Getter) (bool, time.Duration) {
+	if rh == nil || rh.RemediateAfter == nil {
+		return false, 0
+	}
+
+	cond := conditions.Get(from, infrav1.AzureResourceAvailableCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse || cond.Severity != clusterv1.ConditionSeverityError ||
+		!converters.IsPlatformInitiatedReason(cond.Reason) {
+		return false, 0
+	}
+
+	remaining := time.Until(cond.LastTransitionTime.Add(rh.RemediateAfter.Duration))
+	if remaining <= 0 {
+		return true, 0
+	}
+	return false, remaining
 }
 
 // GetVMImage returns the image from the machine configuration, or a default one.
@@ -706,6 +1109,7 @@ func (m *MachineScope) GetVMImage(ctx context.Context) (*infrav1.Image, error) {
 }
 
 // SetSubnetName defaults the AzureMachine subnet name to the name of one the subnets with the machine role when there is only one of them.
+// When there are several of them, the subnet pinned to the machine's availability zone is used if there is exactly one.
 // Note: this logic exists only for purposes of ensuring backwards compatibility for old clusters created without the `subnetName` field being
 // set, and should be removed in the future when this field is no longer optional.
 func (m *MachineScope) SetSubnetName() error {
@@ -713,13 +1117,18 @@ func (m *MachineScope) SetSubnetName() error {
 		subnetName := ""
 		subnets := m.Subnets()
 		var subnetCount int
+		var roleSubnets []infrav1.SubnetSpec
 		for _, subnet := range subnets {
 			if string(subnet.Role) == m.Role() {
 				subnetCount++
 				subnetName = subnet.Name
+				roleSubnets = append(roleSubnets, subnet)
 			}
 		}
-		if subnetCount == 0 || subnetCount > 1 || subnetName == "" {
+		if subnetCount > 1 {
+			subnetName = zonalSubnetName(roleSubnets, m.AvailabilityZone())
+		}
+		if subnetCount == 0 || subnetName == "" {
 			return errors.New("a subnet name must be specified when no subnets are specified or more than 1 subnet of the same role exist")
 		}
 
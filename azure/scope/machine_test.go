@@ -20,12 +20,14 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -34,6 +36,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
@@ -41,6 +45,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestMachineScope_Name(t *testing.T) {
@@ -263,6 +268,7 @@ func TestMachineScope_PublicIPSpecs(t *testing.T) {
 		{
 			name: "appends to PublicIPSpec for node if AllocatePublicIP is true",
 			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -326,6 +332,77 @@ func TestMachineScope_PublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "creates the public IP of a zonal machine in its zone",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: pointer.String("failure-domain-id-2"),
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						AllocatePublicIP: true,
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-cluster",
+							// Note: m.ClusterName() takes the value from the Cluster object, not the AzureCluster object
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-cluster",
+						},
+						Status: infrav1.AzureClusterStatus{
+							FailureDomains: map[string]clusterv1.FailureDomainSpec{
+								"failure-domain-id-1": {},
+								"failure-domain-id-2": {},
+								"failure-domain-id-3": {},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								SubscriptionID: "123",
+								Location:       "centralIndia",
+								AdditionalTags: infrav1.Tags{
+									"Name": "my-publicip-ipv6",
+									"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+								},
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								APIServerLB: infrav1.LoadBalancerSpec{
+									LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+										Type: infrav1.Internal,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "pip-machine-name",
+					ResourceGroup:  "my-rg",
+					DNSName:        "",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{"failure-domain-id-2"},
+					AdditionalTags: infrav1.Tags{
+						"Name": "my-publicip-ipv6",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -961,6 +1038,184 @@ func TestMachineScope_Subnet(t *testing.T) {
 	}
 }
 
+func TestMachineScope_PrivateDNSNodeRecordSpec(t *testing.T) {
+	newMachineScope := func(nodeRecords bool, zoneID string, addresses []corev1.NodeAddress) MachineScope {
+		return MachineScope{
+			AzureMachine: &infrav1.AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-machine",
+				},
+				Status: infrav1.AzureMachineStatus{
+					Addresses: addresses,
+				},
+			},
+			ClusterScoper: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								PrivateDNSZoneID:      zoneID,
+								PrivateDNSNodeRecords: nodeRecords,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	addresses := []corev1.NodeAddress{
+		{Type: corev1.NodeInternalDNS, Address: "my-machine"},
+		{Type: corev1.NodeInternalIP, Address: "2001:1234:5678:9abd::5"},
+		{Type: corev1.NodeInternalIP, Address: "10.1.0.4"},
+	}
+
+	tests := []struct {
+		name         string
+		machineScope MachineScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nil if the cluster has no node records",
+			machineScope: newMachineScope(false, "", addresses),
+			want:         nil,
+		},
+		{
+			name:         "returns nil if the machine has no private IPv4 address yet",
+			machineScope: newMachineScope(true, "", nil),
+			want:         nil,
+		},
+		{
+			name:         "returns a record in the private DNS zone of the cluster",
+			machineScope: newMachineScope(true, "", addresses),
+			want: privatedns.RecordSpec{
+				Record:         infrav1.AddressRecord{Hostname: "my-machine", IP: "10.1.0.4"},
+				ZoneName:       "my-cluster.capz.io",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				ClusterName:    "my-cluster",
+			},
+		},
+		{
+			name:         "returns a record in an existing private DNS zone",
+			machineScope: newMachineScope(true, "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.private", addresses),
+			want: privatedns.RecordSpec{
+				Record:         infrav1.AddressRecord{Hostname: "my-machine", IP: "10.1.0.4"},
+				ZoneName:       "corp.private",
+				ResourceGroup:  "dns-rg",
+				SubscriptionID: "456",
+				ClusterName:    "my-cluster",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			got := tt.machineScope.PrivateDNSNodeRecordSpec()
+			if tt.want == nil {
+				g.Expect(got).To(BeNil())
+			} else {
+				g.Expect(got).To(Equal(tt.want))
+			}
+		})
+	}
+}
+
+func TestMachineScope_SetSubnetName(t *testing.T) {
+	nodeSubnet := func(name, zone string) infrav1.SubnetSpec {
+		return infrav1.SubnetSpec{SubnetClassSpec: infrav1.SubnetClassSpec{Name: name, Role: infrav1.SubnetNode, Zone: zone}}
+	}
+	cpSubnet := infrav1.SubnetSpec{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane}}
+
+	tests := []struct {
+		name          string
+		subnetName    string
+		failureDomain *string
+		subnets       []infrav1.SubnetSpec
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:       "keeps the subnet name of the spec",
+			subnetName: "my-subnet",
+			subnets:    []infrav1.SubnetSpec{cpSubnet, nodeSubnet("node-1", ""), nodeSubnet("node-2", "")},
+			want:       "my-subnet",
+		},
+		{
+			name:    "uses the only subnet of the machine role",
+			subnets: []infrav1.SubnetSpec{cpSubnet, nodeSubnet("node-1", "")},
+			want:    "node-1",
+		},
+		{
+			name:          "uses the subnet pinned to the machine failure domain",
+			failureDomain: pointer.String("2"),
+			subnets:       []infrav1.SubnetSpec{cpSubnet, nodeSubnet("node-1", "1"), nodeSubnet("node-2", "2"), nodeSubnet("node-3", "3")},
+			want:          "node-2",
+		},
+		{
+			name:          "fails when no subnet is pinned to the machine failure domain",
+			failureDomain: pointer.String("3"),
+			subnets:       []infrav1.SubnetSpec{cpSubnet, nodeSubnet("node-1", "1"), nodeSubnet("node-2", "2")},
+			wantErr:       true,
+		},
+		{
+			name:          "fails when several subnets are pinned to the machine failure domain",
+			failureDomain: pointer.String("1"),
+			subnets:       []infrav1.SubnetSpec{cpSubnet, nodeSubnet("node-1", "1"), nodeSubnet("node-2", "1")},
+			wantErr:       true,
+		},
+		{
+			name:    "fails when there are several subnets of the machine role and no failure domain",
+			subnets: []infrav1.SubnetSpec{cpSubnet, nodeSubnet("node-1", "1"), nodeSubnet("node-2", "2")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{FailureDomain: tt.failureDomain},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: tt.subnetName}},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							NetworkSpec: infrav1.NetworkSpec{Subnets: tt.subnets},
+						},
+					},
+				},
+			}
+			err := machineScope.SetSubnetName()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(machineScope.AzureMachine.Spec.NetworkInterfaces[0].SubnetName).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestMachineScope_AvailabilityZone(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1371,6 +1626,155 @@ func TestMachineScope_VMState(t *testing.T) {
 	}
 }
 
+func TestMachineScope_BootstrapDataBlobSpec(t *testing.T) {
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		machineScope MachineScope
+		want         *azure.BootstrapDataBlobSpec
+	}{
+		{
+			name: "returns nil if bootstrap data storage is not configured",
+			machineScope: MachineScope{
+				ClusterScoper: clusterScope,
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns defaulted spec with cached bootstrap data",
+			machineScope: MachineScope{
+				ClusterScoper: clusterScope,
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						BootstrapDataStorage: &infrav1.BootstrapDataStorage{
+							StorageAccountName: "bootstrapdata",
+						},
+					},
+				},
+				cache: &MachineCache{
+					RawBootstrapData:    []byte("#cloud-config\n"),
+					BootstrapDataFormat: "cloud-config",
+				},
+			},
+			want: &azure.BootstrapDataBlobSpec{
+				StorageAccountName: "bootstrapdata",
+				ContainerName:      "my-cluster",
+				BlobName:           "machine-name",
+				AccessMethod:       infrav1.BootstrapDataAccessMethodSAS,
+				SASExpiry:          time.Hour,
+				Format:             "cloud-config",
+				Data:               []byte("#cloud-config\n"),
+			},
+		},
+		{
+			name: "returns spec with user provided values",
+			machineScope: MachineScope{
+				ClusterScoper: clusterScope,
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						BootstrapDataStorage: &infrav1.BootstrapDataStorage{
+							StorageAccountName: "bootstrapdata",
+							ContainerName:      "bootstrap",
+							AccessMethod:       infrav1.BootstrapDataAccessMethodSAS,
+							SASExpiry:          &metav1.Duration{Duration: 10 * time.Minute},
+						},
+					},
+				},
+			},
+			want: &azure.BootstrapDataBlobSpec{
+				StorageAccountName: "bootstrapdata",
+				ContainerName:      "bootstrap",
+				BlobName:           "machine-name",
+				AccessMethod:       infrav1.BootstrapDataAccessMethodSAS,
+				SASExpiry:          10 * time.Minute,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := tt.machineScope.BootstrapDataBlobSpec()
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachineScope_BootstrapWatchdog(t *testing.T) {
+	g := NewWithT(t)
+
+	runningSince := metav1.NewTime(time.Now().Add(-5 * time.Minute).Truncate(time.Second))
+	machineScope := MachineScope{
+		ClusterScoper: &ClusterScope{
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
+		Machine: &clusterv1.Machine{},
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "machine-name",
+			},
+			Spec: infrav1.AzureMachineSpec{
+				BootstrapWatchdog: &infrav1.BootstrapWatchdog{},
+			},
+		},
+	}
+
+	g.Expect(machineScope.BootstrapWatchdogSpec()).To(Equal(&azure.BootstrapWatchdogSpec{
+		ResourceGroup: "my-rg",
+		VMName:        "machine-name",
+		Timeout:       20 * time.Minute,
+	}))
+	g.Expect(machineScope.BootstrapWatchdogRequeueAfter()).To(BeZero())
+
+	machineScope.AzureMachine.Status.Conditions = clusterv1.Conditions{
+		{
+			Type:               infrav1.VMRunningCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: runningSince,
+		},
+	}
+	g.Expect(machineScope.BootstrapWatchdogSpec().RunningSince).To(Equal(runningSince.Time))
+	g.Expect(machineScope.BootstrapWatchdogRequeueAfter()).To(BeNumerically("~", 15*time.Minute, time.Minute))
+
+	machineScope.SetBootstrapFailed(infrav1.KubeadmFailedReason, "kubeadm failed", "log tail")
+	g.Expect(machineScope.IsBootstrapFailed()).To(BeTrue())
+	g.Expect(machineScope.BootstrapFailureLog()).To(Equal("log tail"))
+	g.Expect(machineScope.BootstrapWatchdogRequeueAfter()).To(BeZero())
+
+	machineScope.ClearBootstrapFailed()
+	g.Expect(machineScope.IsBootstrapFailed()).To(BeFalse())
+
+	machineScope.AzureMachine.Spec.BootstrapWatchdog = nil
+	g.Expect(machineScope.BootstrapWatchdogSpec()).To(BeNil())
+}
+
 func TestMachineScope_GetVMImage(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1990,6 +2394,125 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Dual-stack Node Machine with NAT gateway",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+									VnetClassSpec: infrav1.VnetClassSpec{
+										CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+									},
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role:       infrav1.SubnetNode,
+											Name:       "subnet1",
+											CIDRBlocks: []string{"10.1.0.0/16", "2001:1234:5678:9a01::/64"},
+										},
+										NatGateway: infrav1.NatGateway{
+											NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+												Name: "natgw",
+											},
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+									IPv6FrontendIPs: []infrav1.FrontendIP{
+										{
+											Name: "outbound-lb-frontEnd-ipv6",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: pointer.String("azure:///subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                        "machine-name-nic",
+					ResourceGroup:               "my-rg",
+					Location:                    "westus",
+					SubscriptionID:              "123",
+					MachineName:                 "machine-name",
+					SubnetName:                  "subnet1",
+					IPConfigs:                   []networkinterfaces.IPConfig{{}},
+					VNetName:                    "vnet1",
+					VNetResourceGroup:           "rg1",
+					PublicLBName:                "outbound-lb",
+					PublicLBAddressPoolName:     "",
+					PublicLBIPv6AddressPoolName: "outbound-lb-outboundBackendPool-ipv6",
+					PublicLBNATRuleName:         "",
+					InternalLBName:              "",
+					InternalLBAddressPoolName:   "",
+					PublicIPName:                "",
+					AcceleratedNetworking:       nil,
+					DNSServers:                  nil,
+					IPv6Enabled:                 true,
+					EnableIPForwarding:          false,
+					SKU:                         nil,
+					ClusterName:                 "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
 		{
 			name: "Node Machine with public IP address",
 			machineScope: MachineScope{
@@ -2984,3 +3507,218 @@ func TestDiskSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineScope_ResourceHealthRemediation(t *testing.T) {
+	unavailableSince := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+	tests := []struct {
+		name              string
+		resourceHealth    *infrav1.MachineResourceHealth
+		condition         *clusterv1.Condition
+		expectRemediate   bool
+		expectRequeueNear time.Duration
+	}{
+		{
+			name:      "remediation not configured",
+			condition: &clusterv1.Condition{Type: infrav1.AzureResourceAvailableCondition, Status: corev1.ConditionFalse, Severity: clusterv1.ConditionSeverityError, Reason: "Unplanned", LastTransitionTime: unavailableSince},
+		},
+		{
+			name:           "available VM",
+			resourceHealth: &infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 5 * time.Minute}},
+			condition:      &clusterv1.Condition{Type: infrav1.AzureResourceAvailableCondition, Status: corev1.ConditionTrue, LastTransitionTime: unavailableSince},
+		},
+		{
+			name:           "user initiated unavailability",
+			resourceHealth: &infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 5 * time.Minute}},
+			condition:      &clusterv1.Condition{Type: infrav1.AzureResourceAvailableCondition, Status: corev1.ConditionFalse, Severity: clusterv1.ConditionSeverityError, Reason: "UserInitiated", LastTransitionTime: unavailableSince},
+		},
+		{
+			name:           "degraded VM",
+			resourceHealth: &infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 5 * time.Minute}},
+			condition:      &clusterv1.Condition{Type: infrav1.AzureResourceAvailableCondition, Status: corev1.ConditionFalse, Severity: clusterv1.ConditionSeverityWarning, Reason: "Unplanned", LastTransitionTime: unavailableSince},
+		},
+		{
+			name:              "platform initiated unavailability within the remediation delay",
+			resourceHealth:    &infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 30 * time.Minute}},
+			condition:         &clusterv1.Condition{Type: infrav1.AzureResourceAvailableCondition, Status: corev1.ConditionFalse, Severity: clusterv1.ConditionSeverityError, Reason: "Unplanned", LastTransitionTime: unavailableSince},
+			expectRequeueNear: 20 * time.Minute,
+		},
+		{
+			name:            "platform initiated unavailability past the remediation delay",
+			resourceHealth:  &infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 5 * time.Minute}},
+			condition:       &clusterv1.Condition{Type: infrav1.AzureResourceAvailableCondition, Status: corev1.ConditionFalse, Severity: clusterv1.ConditionSeverityError, Reason: "PlatformInitiated", LastTransitionTime: unavailableSince},
+			expectRemediate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					Spec:   infrav1.AzureMachineSpec{ResourceHealth: tc.resourceHealth},
+					Status: infrav1.AzureMachineStatus{Conditions: clusterv1.Conditions{*tc.condition}},
+				},
+			}
+			remediate, requeueAfter := machineScope.ResourceHealthRemediation()
+			g.Expect(remediate).To(Equal(tc.expectRemediate))
+			g.Expect(requeueAfter).To(BeNumerically("~", tc.expectRequeueNear, time.Minute))
+		})
+	}
+}
+
+func TestMachineScope_AvailabilityStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	machineScope := MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+		},
+	}
+	g.Expect(machineScope.AvailabilityStatusResourceURI()).To(BeEmpty())
+
+	machineScope.AzureMachine.Spec.ProviderID = pointer.String("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+	g.Expect(machineScope.AvailabilityStatusResourceURI()).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"))
+
+	unknown := conditions.UnknownCondition(infrav1.AzureResourceAvailableCondition, "", "")
+	g.Expect(conditions.IsTrue(&infrav1.AzureMachine{Status: infrav1.AzureMachineStatus{Conditions: clusterv1.Conditions{*machineScope.AvailabilityStatusFilter(unknown)}}}, infrav1.AzureResourceAvailableCondition)).To(BeTrue())
+
+	degraded := conditions.FalseCondition(infrav1.AzureResourceAvailableCondition, "Unplanned", clusterv1.ConditionSeverityWarning, "")
+	g.Expect(machineScope.AvailabilityStatusFilter(degraded)).To(Equal(degraded))
+
+	machineScope.AzureMachine.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	g.Expect(machineScope.AvailabilityStatusFilter(unknown)).To(Equal(unknown))
+}
+
+func TestApplicationSecurityGroupNames(t *testing.T) {
+	asgs := []infrav1.ApplicationSecurityGroup{
+		{Name: "control-plane", Role: infrav1.SubnetControlPlane},
+		{Name: "node", Role: infrav1.SubnetNode},
+		{Name: "web"},
+	}
+
+	tests := []struct {
+		name  string
+		asgs  []infrav1.ApplicationSecurityGroup
+		role  string
+		names []string
+		want  []string
+	}{
+		{
+			name: "no application security groups",
+			role: infrav1.Node,
+		},
+		{
+			name: "control plane machine joins the control plane application security group",
+			asgs: asgs,
+			role: infrav1.ControlPlane,
+			want: []string{"control-plane"},
+		},
+		{
+			name:  "node machine joins the node application security group and the ones of its spec",
+			asgs:  asgs,
+			role:  infrav1.Node,
+			names: []string{"web", "node"},
+			want:  []string{"node", "web"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(applicationSecurityGroupNames(tt.asgs, tt.role, tt.names)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachineScope_PrivateIPSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	poolRef := &corev1.TypedLocalObjectReference{
+		APIGroup: pointer.String("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "control-plane-pool",
+	}
+	staticIPPool := &infrav1.PrivateIPAddressRange{Start: "10.1.0.10", End: "10.1.0.20"}
+	machineScope := MachineScope{
+		ClusterScoper: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: "default",
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:         infrav1.SubnetControlPlane,
+									Name:         "cp-subnet",
+									CIDRBlocks:   []string{"10.0.0.0/16", "10.1.0.0/24"},
+									StaticIPPool: staticIPPool,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "machine",
+				Namespace: "default",
+				UID:       "uid",
+			},
+			Spec: infrav1.AzureMachineSpec{
+				NetworkInterfaces: []infrav1.NetworkInterface{
+					{SubnetName: "cp-subnet", PrivateIPAddress: &infrav1.PrivateIPAddressSpec{Address: "10.0.0.10"}},
+					{SubnetName: "cp-subnet", PrivateIPAddress: &infrav1.PrivateIPAddressSpec{FromSubnetPool: true}},
+					{SubnetName: "cp-subnet", PrivateIPAddress: &infrav1.PrivateIPAddressSpec{PoolRef: poolRef}},
+					{SubnetName: "cp-subnet"},
+				},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "machine",
+				Labels: map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+			},
+		},
+	}
+	owner := metav1.OwnerReference{
+		APIVersion:         infrav1.GroupVersion.String(),
+		Kind:               "AzureMachine",
+		Name:               "machine",
+		BlockOwnerDeletion: pointer.Bool(true),
+		UID:                "uid",
+	}
+
+	g.Expect(machineScope.PrivateIPSpecs()).To(Equal([]*privateips.PrivateIPSpec{
+		{
+			NICName:      "machine-nic-1",
+			ClaimName:    "machine-nic-1",
+			Namespace:    "default",
+			ClusterName:  "cluster",
+			Owner:        owner,
+			SubnetName:   "cp-subnet",
+			SubnetPool:   staticIPPool,
+			SubnetPrefix: 24,
+		},
+		{
+			NICName:     "machine-nic-2",
+			ClaimName:   "machine-nic-2",
+			Namespace:   "default",
+			ClusterName: "cluster",
+			Owner:       owner,
+			PoolRef:     poolRef,
+			SubnetName:  "cp-subnet",
+		},
+	}))
+
+	machineScope.SetPrivateIPAddress("machine-nic-1", "10.1.0.10")
+	g.Expect(machineScope.BuildNICSpec("machine-nic-0", machineScope.AzureMachine.Spec.NetworkInterfaces[0], false).StaticIPAddress).To(Equal("10.0.0.10"))
+	g.Expect(machineScope.BuildNICSpec("machine-nic-1", machineScope.AzureMachine.Spec.NetworkInterfaces[1], false).StaticIPAddress).To(Equal("10.1.0.10"))
+	g.Expect(machineScope.BuildNICSpec("machine-nic-2", machineScope.AzureMachine.Spec.NetworkInterfaces[2], false).StaticIPAddress).To(BeEmpty())
+	g.Expect(machineScope.BuildNICSpec("machine-nic-3", machineScope.AzureMachine.Spec.NetworkInterfaces[3], false).StaticIPAddress).To(BeEmpty())
+}
@@ -129,6 +129,7 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
 		PublicLBAddressPoolName:      azure.GenerateOutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
+		PublicLBIPv6AddressPoolName:  m.OutboundIPv6PoolName(m.OutboundLBName(infrav1.Node)),
 		AcceleratedNetworking:        m.AzureMachinePool.Spec.Template.NetworkInterfaces[0].AcceleratedNetworking,
 		Identity:                     m.AzureMachinePool.Spec.Identity,
 		UserAssignedIdentities:       m.AzureMachinePool.Spec.UserAssignedIdentities,
@@ -138,11 +139,26 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		FailureDomains:               m.MachinePool.Spec.FailureDomains,
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
 		NetworkInterfaces:            m.AzureMachinePool.Spec.Template.NetworkInterfaces,
-		IPv6Enabled:                  m.IsIPv6Enabled(),
+		IPv6SubnetNames:              m.ipv6SubnetNames(),
 		OrchestrationMode:            m.AzureMachinePool.Spec.OrchestrationMode,
+		ApplicationSecurityGroups:    applicationSecurityGroupNames(m.ApplicationSecurityGroups(), infrav1.Node, m.AzureMachinePool.Spec.Template.ApplicationSecurityGroups),
 	}
 }
 
+// ipv6SubnetNames returns the names of the dual-stack subnets of the network interfaces of the machine pool.
+func (m *MachinePoolScope) ipv6SubnetNames() []string {
+	if !m.IsIPv6Enabled() {
+		return nil
+	}
+	var names []string
+	for _, nic := range m.AzureMachinePool.Spec.Template.NetworkInterfaces {
+		if m.Subnet(nic.SubnetName).IsIPv6Enabled() {
+			names = append(names, nic.SubnetName)
+		}
+	}
+	return names
+}
+
 // Name returns the Azure Machine Pool Name.
 func (m *MachinePoolScope) Name() string {
 	// Windows Machine pools names cannot be longer than 9 chars
@@ -203,6 +219,16 @@ func (m *MachinePoolScope) SetVMSSState(vmssState *azure.VMSS) {
 	m.vmssState = vmssState
 }
 
+// ExtensionStatuses returns the status of the VMSS extensions, summarized across the scale set instances.
+func (m *MachinePoolScope) ExtensionStatuses() []infrav1.ExtensionStatus {
+	return m.AzureMachinePool.Status.Extensions
+}
+
+// SetExtensionStatuses sets the status of the VMSS extensions, summarized across the scale set instances.
+func (m *MachinePoolScope) SetExtensionStatuses(statuses []infrav1.ExtensionStatus) {
+	m.AzureMachinePool.Status.Extensions = statuses
+}
+
 // NeedsRequeue return true if any machines are not on the latest model or the VMSS is not in a terminal provisioning
 // state.
 func (m *MachinePoolScope) NeedsRequeue() bool {
@@ -719,6 +745,7 @@ func (m *MachinePoolScope) getDeploymentStrategy() machinepool.TypedDeleteSelect
 }
 
 // SetSubnetName defaults the AzureMachinePool subnet name to the name of the subnet with role 'node' when there is only one of them.
+// When there are several of them and the machine pool spans a single failure domain, the subnet pinned to that zone is used if there is exactly one.
 // Note: this logic exists only for purposes of ensuring backwards compatibility for old clusters created without the `subnetName` field being
 // set, and should be removed in the future when this field is no longer optional.
 func (m *MachinePoolScope) SetSubnetName() error {
@@ -727,7 +754,13 @@ func (m *MachinePoolScope) SetSubnetName() error {
 		for _, subnet := range m.NodeSubnets() {
 			subnetName = subnet.Name
 		}
-		if len(m.NodeSubnets()) == 0 || len(m.NodeSubnets()) > 1 || subnetName == "" {
+		if len(m.NodeSubnets()) > 1 {
+			subnetName = ""
+			if len(m.MachinePool.Spec.FailureDomains) == 1 {
+				subnetName = zonalSubnetName(m.NodeSubnets(), m.MachinePool.Spec.FailureDomains[0])
+			}
+		}
+		if len(m.NodeSubnets()) == 0 || subnetName == "" {
 			return errors.New("a subnet name must be specified when no subnets are specified or more than 1 subnet of role 'node' exist")
 		}
 
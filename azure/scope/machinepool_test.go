@@ -256,6 +256,73 @@ func TestMachinePoolScope_NetworkInterfaces(t *testing.T) {
 	}
 }
 
+func TestMachinePoolScope_ipv6SubnetNames(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{
+			SubnetClassSpec: infrav1.SubnetClassSpec{
+				Name:       "dual-stack-subnet",
+				Role:       infrav1.SubnetNode,
+				CIDRBlocks: []string{"10.1.0.0/16", "2001:1234:5678:9abd::/64"},
+			},
+		},
+		{
+			SubnetClassSpec: infrav1.SubnetClassSpec{
+				Name:       "single-stack-subnet",
+				Role:       infrav1.SubnetNode,
+				CIDRBlocks: []string{"10.2.0.0/16"},
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		vnetCIDRBlocks []string
+		want           []string
+	}{
+		{
+			name:           "single-stack cluster",
+			vnetCIDRBlocks: []string{"10.0.0.0/8"},
+			want:           nil,
+		},
+		{
+			name:           "dual-stack cluster",
+			vnetCIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+			want:           []string{"dual-stack-subnet"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			s := &MachinePoolScope{
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							NetworkInterfaces: []infrav1.NetworkInterface{
+								{SubnetName: "dual-stack-subnet"},
+								{SubnetName: "single-stack-subnet"},
+							},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: tt.vnetCIDRBlocks},
+								},
+								Subnets: subnets,
+							},
+						},
+					},
+				},
+			}
+			g.Expect(s.ipv6SubnetNames()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestMachinePoolScope_MaxSurge(t *testing.T) {
 	cases := []struct {
 		Name   string
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -221,6 +222,31 @@ func (s *MachinePoolMachineScope) UpdatePatchStatus(condition clusterv1.Conditio
 	}
 }
 
+// AvailabilityStatusResource refers to the AzureMachinePoolMachine.
+func (s *MachinePoolMachineScope) AvailabilityStatusResource() conditions.Setter {
+	return s.AzureMachinePoolMachine
+}
+
+// AvailabilityStatusResourceURI returns the ID of the scale set VM.
+func (s *MachinePoolMachineScope) AvailabilityStatusResourceURI() string {
+	return strings.TrimPrefix(s.ProviderID(), azure.ProviderIDPrefix)
+}
+
+// AvailabilityStatusFilter ignores the unknown availability status reported while a new scale set VM is being set up.
+func (s *MachinePoolMachineScope) AvailabilityStatusFilter(cond *clusterv1.Condition) *clusterv1.Condition {
+	return filterInitialAvailabilityStatus(s.AzureMachinePoolMachine.CreationTimestamp, cond)
+}
+
+// ResourceHealthRemediation returns true if the scale set VM has been unavailable because of a platform-initiated event
+// for longer than the remediation delay of the AzureMachinePool. Otherwise, it returns how long to wait before checking
+// again, or 0 if the VM is not affected by such an event.
+func (s *MachinePoolMachineScope) ResourceHealthRemediation() (bool, time.Duration) {
+	if s.AzureMachinePool == nil {
+		return false, 0
+	}
+	return resourceHealthRemediation(s.AzureMachinePool.Spec.ResourceHealth, s.AzureMachinePoolMachine)
+}
+
 // SetVMSSVM update the scope with the current state of the VMSS VM.
 func (s *MachinePoolMachineScope) SetVMSSVM(instance *azure.VMSSVM) {
 	s.instance = instance
@@ -266,6 +292,7 @@ func (s *MachinePoolMachineScope) PatchObject(ctx context.Context) error {
 			clusterv1.ReadyCondition,
 			clusterv1.MachineNodeHealthyCondition,
 			clusterv1.DrainingSucceededCondition,
+			infrav1.AzureResourceAvailableCondition,
 		}})
 }
 
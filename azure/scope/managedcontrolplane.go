@@ -219,6 +219,7 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
+			infrav1.PrivateEndpointDNSZonesReadyCondition,
 			infrav1.ManagedClusterRunningCondition,
 			infrav1.AgentPoolsReadyCondition,
 			infrav1.AzureResourceAvailableCondition,
@@ -339,6 +340,12 @@ func (s *ManagedControlPlaneScope) UpdateSubnetCIDRs(_ string, _ []string) {
 	// no-op
 }
 
+// AllocateSubnetCIDRs allocates CIDR blocks to the subnets that only specify a prefix length.
+// This is not used when using a managed control plane.
+func (s *ManagedControlPlaneScope) AllocateSubnetCIDRs() error {
+	return nil
+}
+
 // UpdateSubnetID updates the subnet ID for the subnet with the same name.
 // This is not used when using a managed control plane.
 func (s *ManagedControlPlaneScope) UpdateSubnetID(_ string, _ string) {
@@ -383,6 +390,18 @@ func (s *ManagedControlPlaneScope) IsIPv6Enabled() bool {
 	return false
 }
 
+// ApplicationSecurityGroups returns the cluster application security groups.
+// Currently always nil as managed control planes do not currently implement application security groups.
+func (s *ManagedControlPlaneScope) ApplicationSecurityGroups() []infrav1.ApplicationSecurityGroup {
+	return nil
+}
+
+// PublicIPPrefixID returns the ID of the public IP prefix the cluster's public IPs are allocated from.
+// Currently always empty as managed control planes do not currently implement public IP prefixes.
+func (s *ManagedControlPlaneScope) PublicIPPrefixID() string {
+	return ""
+}
+
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ManagedControlPlaneScope) IsVnetManaged() bool {
 	if s.cache.isVnetManaged != nil {
@@ -433,12 +452,24 @@ func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
 }
 
+// OutboundIPv6PoolName returns the IPv6 outbound LB backend pool name.
+// Currently always empty as managed control planes do not currently implement ipv6.
+func (s *ManagedControlPlaneScope) OutboundIPv6PoolName(_ string) string {
+	return ""
+}
+
 // GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
 // Currently always empty as managed control planes do not currently implement private clusters.
 func (s *ManagedControlPlaneScope) GetPrivateDNSZoneName() string {
 	return ""
 }
 
+// PrivateDNSNodeRecordsZoneID returns the resource ID of the private DNS zone the records of the machines are maintained in.
+// Currently always empty as managed control planes do not currently implement private DNS node records.
+func (s *ManagedControlPlaneScope) PrivateDNSNodeRecordsZoneID() string {
+	return ""
+}
+
 // CloudProviderConfigOverrides returns the cloud provider config overrides for the cluster.
 func (s *ManagedControlPlaneScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides {
 	return nil
@@ -651,6 +682,16 @@ func (s *ManagedControlPlaneScope) UpdateDeleteStatus(condition clusterv1.Condit
 	}
 }
 
+// SetConditionTrue sets the specified AzureManagedControlPlane condition to true.
+func (s *ManagedControlPlaneScope) SetConditionTrue(conditionType clusterv1.ConditionType) {
+	conditions.MarkTrue(s.ControlPlane, conditionType)
+}
+
+// SetConditionFalse sets the specified AzureManagedControlPlane condition to false.
+func (s *ManagedControlPlaneScope) SetConditionFalse(conditionType clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(s.ControlPlane, conditionType, reason, severity, message)
+}
+
 // UpdatePutStatus updates a condition on the AzureManagedControlPlane status after a PUT operation.
 func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -740,12 +781,17 @@ func (s *ManagedControlPlaneScope) AvailabilityStatusResourceURI() string {
 // occurs on startup for every AKS cluster.
 func (s *ManagedControlPlaneScope) AvailabilityStatusFilter(cond *clusterv1.Condition) *clusterv1.Condition {
 	if time.Since(s.ControlPlane.CreationTimestamp.Time) < resourceHealthWarningInitialGracePeriod &&
-		cond.Severity == clusterv1.ConditionSeverityWarning {
+		(cond.Severity == clusterv1.ConditionSeverityWarning || cond.Status == corev1.ConditionUnknown) {
 		return conditions.TrueCondition(infrav1.AzureResourceAvailableCondition)
 	}
 	return cond
 }
 
+// PrivateEndpointDNSZoneSpecs returns the specs of the private DNS zones of the private endpoints and of their links to the vnet.
+func (s *ManagedControlPlaneScope) PrivateEndpointDNSZoneSpecs() (zonesSpec, linksSpec []azure.ResourceSpecGetter) {
+	return privateEndpointDNSZoneSpecs(s.ControlPlane.Spec.VirtualNetwork.Subnet.PrivateEndpoints, s.Vnet(), s.SubscriptionID(), s.ResourceGroup(), s.ClusterName(), s.AdditionalTags())
+}
+
 // PrivateEndpointSpecs returns the private endpoint specs.
 func (s *ManagedControlPlaneScope) PrivateEndpointSpecs() []azure.ResourceSpecGetter {
 	privateEndpointSpecs := make([]azure.ResourceSpecGetter, len(s.ControlPlane.Spec.VirtualNetwork.Subnet.PrivateEndpoints))
@@ -769,6 +815,10 @@ func (s *ManagedControlPlaneScope) PrivateEndpointSpecs() []azure.ResourceSpecGe
 			AdditionalTags:            s.AdditionalTags(),
 		}
 
+		if zone := privateEndpointDNSZoneSpec(privateEndpoint, s.SubscriptionID(), s.ResourceGroup(), s.ClusterName(), s.AdditionalTags()); zone != nil {
+			privateEndpointSpec.PrivateDNSZoneIDs = []string{privateDNSZoneResourceID(*zone)}
+		}
+
 		for _, privateLinkServiceConnection := range privateEndpoint.PrivateLinkServiceConnections {
 			pl := privateendpoints.PrivateLinkServiceConnection{
 				PrivateLinkServiceID: privateLinkServiceConnection.PrivateLinkServiceID,
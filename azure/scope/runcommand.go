@@ -0,0 +1,381 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/strings/slices"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/runcommands"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RunCommandScopeParams defines the input parameters used to create a new RunCommandScope.
+type RunCommandScopeParams struct {
+	Client                 client.Client
+	ClusterScope           azure.ClusterScoper
+	AzureMachineRunCommand *infrav1exp.AzureMachineRunCommand
+}
+
+// RunCommandScope defines a scope defined around an AzureMachineRunCommand.
+type RunCommandScope struct {
+	azure.ClusterScoper
+	AzureMachineRunCommand *infrav1exp.AzureMachineRunCommand
+	client                 client.Client
+	patchHelper            *patch.Helper
+}
+
+// NewRunCommandScope creates a new RunCommandScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewRunCommandScope(params RunCommandScopeParams) (*RunCommandScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a RunCommandScope")
+	}
+
+	if params.ClusterScope == nil {
+		return nil, errors.New("cluster scope is required when creating a RunCommandScope")
+	}
+
+	if params.AzureMachineRunCommand == nil {
+		return nil, errors.New("azure machine run command is required when creating a RunCommandScope")
+	}
+
+	helper, err := patch.NewHelper(params.AzureMachineRunCommand, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &RunCommandScope{
+		ClusterScoper:          params.ClusterScope,
+		AzureMachineRunCommand: params.AzureMachineRunCommand,
+		client:                 params.Client,
+		patchHelper:            helper,
+	}, nil
+}
+
+// Name returns the name of the AzureMachineRunCommand.
+func (s *RunCommandScope) Name() string {
+	return s.AzureMachineRunCommand.Name
+}
+
+// ResolveTargets lists the machines of the cluster targeted by the AzureMachineRunCommand and records them in its
+// status. The targets are only resolved once, so machines created afterwards are not targeted. Machines that are not
+// provisioned yet, i.e. that do not have a provider ID, are ignored. If no machine matches, the AzureMachineRunCommand is
+// completed without running the script anywhere.
+func (s *RunCommandScope) ResolveTargets(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.RunCommandScope.ResolveTargets")
+	defer done()
+
+	if s.AzureMachineRunCommand.Status.TargetsResolved {
+		return nil
+	}
+
+	spec := s.AzureMachineRunCommand.Spec
+	selector := labels.Everything()
+	if spec.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse selector")
+		}
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(s.AzureMachineRunCommand.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: spec.ClusterName},
+	}
+
+	var candidates []infrav1exp.MachineRunCommandStatus
+	switch spec.TargetKind {
+	case infrav1exp.RunCommandTargetAzureMachinePoolMachine:
+		list := &infrav1exp.AzureMachinePoolMachineList{}
+		if err := s.client.List(ctx, list, opts...); err != nil {
+			return errors.Wrap(err, "failed to list AzureMachinePoolMachines")
+		}
+		for _, m := range list.Items {
+			if isTargeted(spec, m.Name, m.Labels, selector) && m.Spec.ProviderID != "" {
+				candidates = append(candidates, infrav1exp.MachineRunCommandStatus{Name: m.Name, ProviderID: m.Spec.ProviderID})
+			}
+		}
+	default:
+		list := &infrav1.AzureMachineList{}
+		if err := s.client.List(ctx, list, opts...); err != nil {
+			return errors.Wrap(err, "failed to list AzureMachines")
+		}
+		for _, m := range list.Items {
+			if isTargeted(spec, m.Name, m.Labels, selector) && m.Spec.ProviderID != nil && *m.Spec.ProviderID != "" {
+				candidates = append(candidates, infrav1exp.MachineRunCommandStatus{Name: m.Name, ProviderID: *m.Spec.ProviderID})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	for i := range candidates {
+		candidates[i].State = infrav1exp.RunCommandStatePending
+		if err := setRunCommandTarget(&runcommands.RunCommandSpec{}, candidates[i].ProviderID); err != nil {
+			candidates[i].State = infrav1exp.RunCommandStateFailed
+			candidates[i].Error = err.Error()
+		}
+	}
+	s.AzureMachineRunCommand.Status.Machines = candidates
+	s.AzureMachineRunCommand.Status.TargetsResolved = true
+	return nil
+}
+
+// isTargeted returns true if the machine is selected by the machine names or the label selector of the spec.
+func isTargeted(spec infrav1exp.AzureMachineRunCommandSpec, name string, machineLabels map[string]string, selector labels.Selector) bool {
+	if len(spec.MachineNames) > 0 {
+		return slices.Contains(spec.MachineNames, name)
+	}
+	return selector.Matches(labels.Set(machineLabels))
+}
+
+// RunCommandSpecs returns the run command specs of the machines the script is currently running on, plus the next
+// pending machines allowed by the maximum concurrency.
+func (s *RunCommandScope) RunCommandSpecs() []azure.ResourceSpecGetter {
+	maxConcurrency := int(s.AzureMachineRunCommand.Spec.MaxConcurrency)
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	var running, pending []infrav1exp.MachineRunCommandStatus
+	for _, m := range s.AzureMachineRunCommand.Status.Machines {
+		switch m.State {
+		case infrav1exp.RunCommandStateRunning:
+			running = append(running, m)
+		case infrav1exp.RunCommandStatePending:
+			pending = append(pending, m)
+		}
+	}
+	if free := maxConcurrency - len(running); free > 0 {
+		if free > len(pending) {
+			free = len(pending)
+		}
+		running = append(running, pending[:free]...)
+	}
+
+	return s.specsFor(running)
+}
+
+// StartedRunCommandSpecs returns the run command specs of all the machines the script was started on.
+func (s *RunCommandScope) StartedRunCommandSpecs() []azure.ResourceSpecGetter {
+	var started []infrav1exp.MachineRunCommandStatus
+	for _, m := range s.AzureMachineRunCommand.Status.Machines {
+		if m.State != infrav1exp.RunCommandStatePending {
+			started = append(started, m)
+		}
+	}
+	return s.specsFor(started)
+}
+
+func (s *RunCommandScope) specsFor(machines []infrav1exp.MachineRunCommandStatus) []azure.ResourceSpecGetter {
+	timeout := infrav1exp.DefaultRunCommandTimeout
+	if s.AzureMachineRunCommand.Spec.Timeout != nil {
+		timeout = s.AzureMachineRunCommand.Spec.Timeout.Duration
+	}
+
+	specs := make([]azure.ResourceSpecGetter, 0, len(machines))
+	for _, m := range machines {
+		spec := &runcommands.RunCommandSpec{
+			Name:        s.runCommandName(m.Name),
+			MachineName: m.Name,
+			ProviderID:  m.ProviderID,
+			Location:    s.Location(),
+			Script:      s.AzureMachineRunCommand.Spec.Script,
+			Timeout:     int32(timeout.Seconds()),
+		}
+		if err := setRunCommandTarget(spec, m.ProviderID); err != nil {
+			// Machines with an invalid provider ID are marked as failed when the targets are resolved.
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// runCommandName returns the name of the Azure run command created on a machine.
+func (s *RunCommandScope) runCommandName(machineName string) string {
+	return fmt.Sprintf("%s-%s", s.AzureMachineRunCommand.Name, machineName)
+}
+
+// setRunCommandTarget sets the resource group and the VM or scale set VM of the spec from the provider ID of a machine.
+func setRunCommandTarget(spec *runcommands.RunCommandSpec, providerID string) error {
+	resourceID, err := azure.ParseResourceID(providerID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse provider ID %q", providerID)
+	}
+	spec.ResourceGroup = resourceID.ResourceGroupName
+	if resourceID.Parent != nil && strings.EqualFold(resourceID.Parent.ResourceType.Type, "virtualMachineScaleSets") {
+		spec.ScaleSetName = resourceID.Parent.Name
+		spec.InstanceID = resourceID.Name
+		return nil
+	}
+	spec.VMName = resourceID.Name
+	return nil
+}
+
+// SetMachineRunCommandStatus updates the status of a machine targeted by the AzureMachineRunCommand.
+// The start time of the previous status is kept if the new status does not have one.
+func (s *RunCommandScope) SetMachineRunCommandStatus(status infrav1exp.MachineRunCommandStatus) {
+	for i, m := range s.AzureMachineRunCommand.Status.Machines {
+		if m.Name != status.Name {
+			continue
+		}
+		if status.StartTime == nil {
+			status.StartTime = m.StartTime
+		}
+		if status.StartTime == nil && status.State != infrav1exp.RunCommandStatePending {
+			now := metav1.Now()
+			status.StartTime = &now
+		}
+		s.AzureMachineRunCommand.Status.Machines[i] = status
+		return
+	}
+}
+
+// IsCompleted returns true if the targeted machines were resolved and the script finished running on all of them.
+func (s *RunCommandScope) IsCompleted() bool {
+	if !s.AzureMachineRunCommand.Status.TargetsResolved {
+		return false
+	}
+	for _, m := range s.AzureMachineRunCommand.Status.Machines {
+		if !m.State.IsFinished() {
+			return false
+		}
+	}
+	return true
+}
+
+// updateCompletedCondition sets the RunCommandCompleted condition from the states of the targeted machines.
+func (s *RunCommandScope) updateCompletedCondition() {
+	machines := s.AzureMachineRunCommand.Status.Machines
+	if !s.AzureMachineRunCommand.Status.TargetsResolved {
+		conditions.MarkFalse(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition, infrav1.RunCommandRunningReason, clusterv1.ConditionSeverityInfo, "resolving the targeted machines")
+		return
+	}
+	if len(machines) == 0 {
+		conditions.MarkFalse(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition, infrav1.NoMachinesFoundReason, clusterv1.ConditionSeverityWarning, "no provisioned machine matches the targeted machines")
+		return
+	}
+
+	var finished, failed int
+	for _, m := range machines {
+		if m.State.IsFinished() {
+			finished++
+		}
+		if m.State == infrav1exp.RunCommandStateFailed || m.State == infrav1exp.RunCommandStateTimedOut {
+			failed++
+		}
+	}
+
+	switch {
+	case finished < len(machines):
+		conditions.MarkFalse(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition, infrav1.RunCommandRunningReason, clusterv1.ConditionSeverityInfo, "%d of %d machines completed", finished, len(machines))
+	case failed > 0:
+		conditions.MarkFalse(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition, infrav1.RunCommandFailedReason, clusterv1.ConditionSeverityError, "script failed on %d of %d machines", failed, len(machines))
+	default:
+		conditions.MarkTrue(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition)
+	}
+}
+
+// SetLongRunningOperationState will set the future on the AzureMachineRunCommand status to allow the resource to continue
+// in the next reconciliation.
+func (s *RunCommandScope) SetLongRunningOperationState(future *infrav1.Future) {
+	futures.Set(s.AzureMachineRunCommand, future)
+}
+
+// GetLongRunningOperationState will get the future on the AzureMachineRunCommand status.
+func (s *RunCommandScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	return futures.Get(s.AzureMachineRunCommand, name, service, futureType)
+}
+
+// DeleteLongRunningOperationState will delete the future from the AzureMachineRunCommand status.
+func (s *RunCommandScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	futures.Delete(s.AzureMachineRunCommand, name, service, futureType)
+}
+
+// UpdateDeleteStatus updates a condition on the AzureMachineRunCommand status after a DELETE operation.
+func (s *RunCommandScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	switch {
+	case err == nil:
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
+	case azure.IsOperationNotDoneError(err):
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
+	default:
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+	}
+}
+
+// UpdatePutStatus updates a condition on the AzureMachineRunCommand status after a PUT operation.
+func (s *RunCommandScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	switch {
+	case err == nil:
+		conditions.MarkTrue(s.AzureMachineRunCommand, condition)
+	case azure.IsOperationNotDoneError(err):
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
+	default:
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+	}
+}
+
+// UpdatePatchStatus updates a condition on the AzureMachineRunCommand status after a PATCH operation.
+func (s *RunCommandScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	switch {
+	case err == nil:
+		conditions.MarkTrue(s.AzureMachineRunCommand, condition)
+	case azure.IsOperationNotDoneError(err):
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
+	default:
+		conditions.MarkFalse(s.AzureMachineRunCommand, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+	}
+}
+
+// PatchObject persists the AzureMachineRunCommand spec and status.
+func (s *RunCommandScope) PatchObject(ctx context.Context) error {
+	s.updateCompletedCondition()
+
+	return s.patchHelper.Patch(
+		ctx,
+		s.AzureMachineRunCommand,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			infrav1.RunCommandCompletedCondition,
+		}})
+}
+
+// Close updates the state of AzureMachineRunCommand.
+func (s *RunCommandScope) Close(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.RunCommandScope.Close")
+	defer done()
+
+	return s.PatchObject(ctx)
+}
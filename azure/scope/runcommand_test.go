@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/runcommands"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	fakeVMProviderID       = "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/"
+	fakeScaleSetProviderID = "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss/virtualMachines/"
+)
+
+func TestRunCommandScope_ResolveTargets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	clusterLabels := map[string]string{clusterv1.ClusterNameLabel: "my-cluster", "role": "worker"}
+	objects := []client.Object{
+		&infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-b", Namespace: "default", Labels: clusterLabels},
+			Spec:       infrav1.AzureMachineSpec{ProviderID: pointer.String(fakeVMProviderID + "machine-b")},
+		},
+		&infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-a", Namespace: "default", Labels: clusterLabels},
+			Spec:       infrav1.AzureMachineSpec{ProviderID: pointer.String(fakeVMProviderID + "machine-a")},
+		},
+		&infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-c", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "my-cluster"}},
+			Spec:       infrav1.AzureMachineSpec{ProviderID: pointer.String(fakeVMProviderID + "machine-c")},
+		},
+		&infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-unprovisioned", Namespace: "default", Labels: clusterLabels},
+		},
+		&infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-other-cluster", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "other", "role": "worker"}},
+			Spec:       infrav1.AzureMachineSpec{ProviderID: pointer.String(fakeVMProviderID + "machine-other-cluster")},
+		},
+		&infrav1exp.AzureMachinePoolMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool-machine-0", Namespace: "default", Labels: clusterLabels},
+			Spec:       infrav1exp.AzureMachinePoolMachineSpec{ProviderID: fakeScaleSetProviderID + "0"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     infrav1exp.AzureMachineRunCommandSpec
+		expected []infrav1exp.MachineRunCommandStatus
+	}{
+		{
+			name: "machines by name",
+			spec: infrav1exp.AzureMachineRunCommandSpec{
+				ClusterName:  "my-cluster",
+				TargetKind:   infrav1exp.RunCommandTargetAzureMachine,
+				MachineNames: []string{"machine-c", "machine-unprovisioned", "machine-other-cluster"},
+			},
+			expected: []infrav1exp.MachineRunCommandStatus{
+				{Name: "machine-c", ProviderID: fakeVMProviderID + "machine-c", State: infrav1exp.RunCommandStatePending},
+			},
+		},
+		{
+			name: "machines by selector",
+			spec: infrav1exp.AzureMachineRunCommandSpec{
+				ClusterName: "my-cluster",
+				TargetKind:  infrav1exp.RunCommandTargetAzureMachine,
+				Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			},
+			expected: []infrav1exp.MachineRunCommandStatus{
+				{Name: "machine-a", ProviderID: fakeVMProviderID + "machine-a", State: infrav1exp.RunCommandStatePending},
+				{Name: "machine-b", ProviderID: fakeVMProviderID + "machine-b", State: infrav1exp.RunCommandStatePending},
+			},
+		},
+		{
+			name: "machine pool machines by selector",
+			spec: infrav1exp.AzureMachineRunCommandSpec{
+				ClusterName: "my-cluster",
+				TargetKind:  infrav1exp.RunCommandTargetAzureMachinePoolMachine,
+				Selector:    &metav1.LabelSelector{},
+			},
+			expected: []infrav1exp.MachineRunCommandStatus{
+				{Name: "pool-machine-0", ProviderID: fakeScaleSetProviderID + "0", State: infrav1exp.RunCommandStatePending},
+			},
+		},
+		{
+			name: "no machine matches",
+			spec: infrav1exp.AzureMachineRunCommandSpec{
+				ClusterName:  "my-cluster",
+				TargetKind:   infrav1exp.RunCommandTargetAzureMachine,
+				MachineNames: []string{"machine-unprovisioned"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			runCommand := &infrav1exp.AzureMachineRunCommand{
+				ObjectMeta: metav1.ObjectMeta{Name: "collect-logs", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+			s := &RunCommandScope{
+				AzureMachineRunCommand: runCommand,
+				client:                 fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			}
+			g.Expect(s.ResolveTargets(context.TODO())).To(Succeed())
+			g.Expect(runCommand.Status.Machines).To(Equal(tc.expected))
+			g.Expect(runCommand.Status.TargetsResolved).To(BeTrue())
+		})
+	}
+}
+
+func TestRunCommandScope_RunCommandSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	runCommand := &infrav1exp.AzureMachineRunCommand{
+		ObjectMeta: metav1.ObjectMeta{Name: "collect-logs", Namespace: "default"},
+		Spec: infrav1exp.AzureMachineRunCommandSpec{
+			Script:         "echo hello",
+			MaxConcurrency: 2,
+		},
+		Status: infrav1exp.AzureMachineRunCommandStatus{
+			TargetsResolved: true,
+			Machines: []infrav1exp.MachineRunCommandStatus{
+				{Name: "machine-a", ProviderID: fakeVMProviderID + "machine-a", State: infrav1exp.RunCommandStateSucceeded},
+				{Name: "machine-b", ProviderID: fakeVMProviderID + "machine-b", State: infrav1exp.RunCommandStateRunning},
+				{Name: "pool-machine-0", ProviderID: fakeScaleSetProviderID + "0", State: infrav1exp.RunCommandStatePending},
+				{Name: "pool-machine-1", ProviderID: fakeScaleSetProviderID + "1", State: infrav1exp.RunCommandStatePending},
+			},
+		},
+	}
+	s := &RunCommandScope{
+		ClusterScoper: &ClusterScope{
+			AzureCluster: &infrav1.AzureCluster{Spec: infrav1.AzureClusterSpec{AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "westus"}}},
+		},
+		AzureMachineRunCommand: runCommand,
+	}
+
+	g.Expect(s.RunCommandSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&runcommands.RunCommandSpec{
+			Name:          "collect-logs-machine-b",
+			MachineName:   "machine-b",
+			ProviderID:    fakeVMProviderID + "machine-b",
+			ResourceGroup: "my-rg",
+			Location:      "westus",
+			VMName:        "machine-b",
+			Script:        "echo hello",
+			Timeout:       600,
+		},
+		&runcommands.RunCommandSpec{
+			Name:          "collect-logs-pool-machine-0",
+			MachineName:   "pool-machine-0",
+			ProviderID:    fakeScaleSetProviderID + "0",
+			ResourceGroup: "my-rg",
+			Location:      "westus",
+			ScaleSetName:  "my-vmss",
+			InstanceID:    "0",
+			Script:        "echo hello",
+			Timeout:       600,
+		},
+	}))
+	g.Expect(s.StartedRunCommandSpecs()).To(HaveLen(2))
+
+	s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{Name: "pool-machine-0", State: infrav1exp.RunCommandStateRunning})
+	g.Expect(runCommand.Status.Machines[2].StartTime).NotTo(BeNil())
+	g.Expect(s.IsCompleted()).To(BeFalse())
+}
+
+func TestRunCommandScope_UpdateCompletedCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		machines       []infrav1exp.MachineRunCommandStatus
+		unresolved     bool
+		expectedStatus bool
+		expectedReason string
+	}{
+		{
+			name:           "targets not resolved yet",
+			unresolved:     true,
+			expectedReason: infrav1.RunCommandRunningReason,
+		},
+		{
+			name:           "no machines",
+			expectedReason: infrav1.NoMachinesFoundReason,
+		},
+		{
+			name: "still running",
+			machines: []infrav1exp.MachineRunCommandStatus{
+				{Name: "machine-a", State: infrav1exp.RunCommandStateSucceeded},
+				{Name: "machine-b", State: infrav1exp.RunCommandStatePending},
+			},
+			expectedReason: infrav1.RunCommandRunningReason,
+		},
+		{
+			name: "failed",
+			machines: []infrav1exp.MachineRunCommandStatus{
+				{Name: "machine-a", State: infrav1exp.RunCommandStateSucceeded},
+				{Name: "machine-b", State: infrav1exp.RunCommandStateTimedOut},
+			},
+			expectedReason: infrav1.RunCommandFailedReason,
+		},
+		{
+			name: "succeeded",
+			machines: []infrav1exp.MachineRunCommandStatus{
+				{Name: "machine-a", State: infrav1exp.RunCommandStateSucceeded},
+			},
+			expectedStatus: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &RunCommandScope{
+				AzureMachineRunCommand: &infrav1exp.AzureMachineRunCommand{
+					Status: infrav1exp.AzureMachineRunCommandStatus{Machines: tc.machines, TargetsResolved: !tc.unresolved},
+				},
+			}
+			s.updateCompletedCondition()
+			g.Expect(conditions.IsTrue(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition)).To(Equal(tc.expectedStatus))
+			g.Expect(conditions.GetReason(s.AzureMachineRunCommand, infrav1.RunCommandCompletedCondition)).To(Equal(tc.expectedReason))
+		})
+	}
+}
@@ -222,6 +222,11 @@ func getFailedMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePo
 		// ready status, with provisioning state Succeeded, and not marked for delete
 		if v.Status.ProvisioningState != nil && *v.Status.ProvisioningState == infrav1.Failed {
 			machines = append(machines, v)
+			continue
+		}
+		// machines marked as failed by the AzureMachinePoolMachine controller, e.g. unavailable for too long
+		if v.Status.FailureReason != nil && v.DeletionTimestamp.IsZero() {
+			machines = append(machines, v)
 		}
 	}
 
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups/mock_applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeControlPlaneASGSpec = ASGSpec{
+		Name:          "control-plane",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeNodeASGSpec = ASGSpec{
+		Name:          "node",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcileApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no application security groups are specified",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.ASGSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ASGSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeASGSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "first application security group creation fails, second is in progress",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ASGSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeASGSpec, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockASGScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no application security groups are specified",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.ASGSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ASGSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				r.DeleteResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNodeASGSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "application security group deletion in progress",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ASGSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				r.DeleteResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNodeASGSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, notDoneError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockASGScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
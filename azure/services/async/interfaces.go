@@ -47,6 +47,11 @@ type TagsGetter interface {
 	GetAtScope(ctx context.Context, scope string) (result resources.TagsResource, err error)
 }
 
+// TagsUpdater is an interface that can update a tags resource.
+type TagsUpdater interface {
+	UpdateAtScope(ctx context.Context, scope string, parameters resources.TagsPatchResource) (result resources.TagsResource, err error)
+}
+
 // Creator is a client that can create or update a resource asynchronously.
 type Creator interface {
 	FutureHandler
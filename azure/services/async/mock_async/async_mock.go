@@ -258,6 +258,44 @@ func (mr *MockTagsGetterMockRecorder) GetAtScope(ctx, scope interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAtScope", reflect.TypeOf((*MockTagsGetter)(nil).GetAtScope), ctx, scope)
 }
 
+// MockTagsUpdater is a mock of TagsUpdater interface.
+type MockTagsUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsUpdaterMockRecorder
+}
+
+// MockTagsUpdaterMockRecorder is the mock recorder for MockTagsUpdater.
+type MockTagsUpdaterMockRecorder struct {
+	mock *MockTagsUpdater
+}
+
+// NewMockTagsUpdater creates a new mock instance.
+func NewMockTagsUpdater(ctrl *gomock.Controller) *MockTagsUpdater {
+	mock := &MockTagsUpdater{ctrl: ctrl}
+	mock.recorder = &MockTagsUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsUpdater) EXPECT() *MockTagsUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateAtScope mocks base method.
+func (m *MockTagsUpdater) UpdateAtScope(ctx context.Context, scope string, parameters resources.TagsPatchResource) (resources.TagsResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAtScope", ctx, scope, parameters)
+	ret0, _ := ret[0].(resources.TagsResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAtScope indicates an expected call of UpdateAtScope.
+func (mr *MockTagsUpdaterMockRecorder) UpdateAtScope(ctx, scope, parameters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAtScope", reflect.TypeOf((*MockTagsUpdater)(nil).UpdateAtScope), ctx, scope, parameters)
+}
+
 // MockCreator is a mock of Creator interface.
 type MockCreator struct {
 	ctrl     *gomock.Controller
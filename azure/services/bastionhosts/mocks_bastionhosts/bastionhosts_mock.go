@@ -23,6 +23,7 @@ package mock_bastionhosts
 import (
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -109,6 +110,20 @@ func (mr *MockBastionScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockBastionScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockBastionScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockBastionScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockBastionScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockBastionScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -443,6 +458,20 @@ func (mr *MockBastionScopeMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockBastionScope)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockBastionScope) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockBastionScopeMockRecorder) OutboundIPv6PoolName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockBastionScope)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockBastionScope) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -471,6 +500,34 @@ func (mr *MockBastionScopeMockRecorder) OutboundPoolName(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockBastionScope)(nil).OutboundPoolName), arg0)
 }
 
+// PrivateDNSNodeRecordsZoneID mocks base method.
+func (m *MockBastionScope) PrivateDNSNodeRecordsZoneID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSNodeRecordsZoneID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PrivateDNSNodeRecordsZoneID indicates an expected call of PrivateDNSNodeRecordsZoneID.
+func (mr *MockBastionScopeMockRecorder) PrivateDNSNodeRecordsZoneID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSNodeRecordsZoneID", reflect.TypeOf((*MockBastionScope)(nil).PrivateDNSNodeRecordsZoneID))
+}
+
+// PublicIPPrefixID mocks base method.
+func (m *MockBastionScope) PublicIPPrefixID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicIPPrefixID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PublicIPPrefixID indicates an expected call of PublicIPPrefixID.
+func (mr *MockBastionScopeMockRecorder) PublicIPPrefixID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPPrefixID", reflect.TypeOf((*MockBastionScope)(nil).PublicIPPrefixID))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockBastionScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -565,6 +622,20 @@ func (mr *MockBastionScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockBastionScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockBastionScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockBastionScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockBastionScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockBastionScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
@@ -30,16 +30,23 @@ import (
 
 // AzureBastionSpec defines the specification for azure bastion feature.
 type AzureBastionSpec struct {
-	Name            string
-	ResourceGroup   string
-	Location        string
-	ClusterName     string
-	SubnetID        string
-	PublicIPID      string
-	Sku             infrav1.BastionHostSkuName
-	EnableTunneling bool
+	Name                string
+	ResourceGroup       string
+	Location            string
+	ClusterName         string
+	SubnetID            string
+	PublicIPID          string
+	Sku                 infrav1.BastionHostSkuName
+	EnableTunneling     bool
+	ScaleUnits          *int32
+	EnableIPConnect     bool
+	EnableShareableLink bool
+	EnableFileCopy      bool
 }
 
+// defaultScaleUnits is the number of scale units Azure assigns to a Standard SKU bastion host when none are specified.
+const defaultScaleUnits = 2
+
 // AzureBastionSpecInput defines the required inputs to construct an azure bastion spec.
 type AzureBastionSpecInput struct {
 	SubnetName   string
@@ -65,11 +72,14 @@ func (s *AzureBastionSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the bastion host.
 func (s *AzureBastionSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.BastionHost); !ok {
+		existingBastionHost, ok := existing.(network.BastionHost)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.BastionHost", existing)
 		}
-		// bastion host already exists
-		return nil, nil
+		if s.isUpToDate(existingBastionHost) {
+			// bastion host already exists with the desired SKU and features
+			return nil, nil
+		}
 	}
 
 	bastionHostIPConfigName := fmt.Sprintf("%s-%s", s.Name, "bastionIP")
@@ -87,8 +97,12 @@ func (s *AzureBastionSpec) Parameters(ctx context.Context, existing interface{})
 			Name: network.BastionHostSkuName(s.Sku),
 		},
 		BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
-			EnableTunneling: pointer.Bool(s.EnableTunneling),
-			DNSName:         pointer.String(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name))),
+			ScaleUnits:          s.scaleUnits(),
+			EnableTunneling:     pointer.Bool(s.EnableTunneling),
+			EnableIPConnect:     pointer.Bool(s.EnableIPConnect),
+			EnableShareableLink: pointer.Bool(s.EnableShareableLink),
+			EnableFileCopy:      pointer.Bool(s.EnableFileCopy),
+			DNSName:             pointer.String(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name))),
 			IPConfigurations: &[]network.BastionHostIPConfiguration{
 				{
 					Name: pointer.String(bastionHostIPConfigName),
@@ -106,3 +120,29 @@ func (s *AzureBastionSpec) Parameters(ctx context.Context, existing interface{})
 		},
 	}, nil
 }
+
+// scaleUnits returns the scale units of the bastion host, which can only be set with the Standard SKU.
+func (s *AzureBastionSpec) scaleUnits() *int32 {
+	if s.Sku != infrav1.StandardBastionHostSku {
+		return nil
+	}
+	return pointer.Int32(pointer.Int32Deref(s.ScaleUnits, defaultScaleUnits))
+}
+
+// isUpToDate returns true if the existing bastion host has the desired SKU, scale units and features.
+func (s *AzureBastionSpec) isUpToDate(existing network.BastionHost) bool {
+	if existing.Sku == nil || !strings.EqualFold(string(existing.Sku.Name), string(s.Sku)) {
+		return false
+	}
+	props := existing.BastionHostPropertiesFormat
+	if props == nil {
+		return false
+	}
+	if s.Sku == infrav1.StandardBastionHostSku && pointer.Int32Deref(props.ScaleUnits, defaultScaleUnits) != *s.scaleUnits() {
+		return false
+	}
+	return pointer.BoolDeref(props.EnableTunneling, false) == s.EnableTunneling &&
+		pointer.BoolDeref(props.EnableIPConnect, false) == s.EnableIPConnect &&
+		pointer.BoolDeref(props.EnableShareableLink, false) == s.EnableShareableLink &&
+		pointer.BoolDeref(props.EnableFileCopy, false) == s.EnableFileCopy
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastionhosts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	standardBastionSpec := func() *AzureBastionSpec {
+		return &AzureBastionSpec{
+			Name:                "my-bastion",
+			ResourceGroup:       "my-rg",
+			Location:            "westus",
+			ClusterName:         "my-cluster",
+			SubnetID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/AzureBastionSubnet",
+			PublicIPID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-bastion-pip",
+			Sku:                 infrav1.StandardBastionHostSku,
+			ScaleUnits:          pointer.Int32(4),
+			EnableTunneling:     true,
+			EnableIPConnect:     true,
+			EnableShareableLink: true,
+			EnableFileCopy:      true,
+		}
+	}
+	standardBastionHost := func() network.BastionHost {
+		return network.BastionHost{
+			Name: pointer.String("my-bastion"),
+			Sku:  &network.Sku{Name: network.BastionHostSkuNameStandard},
+			BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+				ScaleUnits:          pointer.Int32(4),
+				EnableTunneling:     pointer.Bool(true),
+				EnableIPConnect:     pointer.Bool(true),
+				EnableShareableLink: pointer.Bool(true),
+				EnableFileCopy:      pointer.Bool(true),
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *AzureBastionSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "basic bastion host does not exist",
+			spec: &AzureBastionSpec{
+				Name: "my-bastion",
+				Sku:  infrav1.BasicBastionHostSku,
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				bastionHost := result.(network.BastionHost)
+				g.Expect(bastionHost.Sku.Name).To(Equal(network.BastionHostSkuNameBasic))
+				g.Expect(bastionHost.ScaleUnits).To(BeNil())
+				g.Expect(bastionHost.EnableTunneling).To(Equal(pointer.Bool(false)))
+			},
+		},
+		{
+			name: "standard bastion host does not exist",
+			spec: standardBastionSpec(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				bastionHost := result.(network.BastionHost)
+				g.Expect(bastionHost.Sku.Name).To(Equal(network.BastionHostSkuNameStandard))
+				g.Expect(bastionHost.ScaleUnits).To(Equal(pointer.Int32(4)))
+				g.Expect(bastionHost.EnableTunneling).To(Equal(pointer.Bool(true)))
+				g.Expect(bastionHost.EnableIPConnect).To(Equal(pointer.Bool(true)))
+				g.Expect(bastionHost.EnableShareableLink).To(Equal(pointer.Bool(true)))
+				g.Expect(bastionHost.EnableFileCopy).To(Equal(pointer.Bool(true)))
+			},
+		},
+		{
+			name: "standard bastion host without scale units defaults to 2 scale units",
+			spec: func() *AzureBastionSpec {
+				spec := standardBastionSpec()
+				spec.ScaleUnits = nil
+				return spec
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				g.Expect(result.(network.BastionHost).ScaleUnits).To(Equal(pointer.Int32(2)))
+			},
+		},
+		{
+			name:     "bastion host already exists with the desired SKU and features",
+			spec:     standardBastionSpec(),
+			existing: standardBastionHost(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "bastion host already exists with the default scale units",
+			spec: func() *AzureBastionSpec {
+				spec := standardBastionSpec()
+				spec.ScaleUnits = nil
+				return spec
+			}(),
+			existing: func() network.BastionHost {
+				bastionHost := standardBastionHost()
+				bastionHost.ScaleUnits = nil
+				return bastionHost
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "bastion host is upgraded to the standard SKU",
+			spec: standardBastionSpec(),
+			existing: network.BastionHost{
+				Name:                        pointer.String("my-bastion"),
+				Sku:                         &network.Sku{Name: network.BastionHostSkuNameBasic},
+				BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				g.Expect(result.(network.BastionHost).Sku.Name).To(Equal(network.BastionHostSkuNameStandard))
+			},
+		},
+		{
+			name: "bastion host scale units are updated",
+			spec: func() *AzureBastionSpec {
+				spec := standardBastionSpec()
+				spec.ScaleUnits = pointer.Int32(10)
+				return spec
+			}(),
+			existing: standardBastionHost(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				g.Expect(result.(network.BastionHost).ScaleUnits).To(Equal(pointer.Int32(10)))
+			},
+		},
+		{
+			name: "bastion host features are updated",
+			spec: func() *AzureBastionSpec {
+				spec := standardBastionSpec()
+				spec.EnableShareableLink = false
+				return spec
+			}(),
+			existing: standardBastionHost(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				g.Expect(result.(network.BastionHost).EnableShareableLink).To(Equal(pointer.Bool(false)))
+			},
+		},
+		{
+			name:          "existing is not a bastion host",
+			spec:          standardBastionSpec(),
+			existing:      network.NatGateway{},
+			expectedError: "network.NatGateway is not a network.BastionHost",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
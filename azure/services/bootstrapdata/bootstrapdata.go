@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "bootstrapdata"
+
+// BootstrapDataScope defines the scope interface for a bootstrap data service.
+type BootstrapDataScope interface {
+	azure.Authorizer
+	BootstrapDataBlobSpec() *azure.BootstrapDataBlobSpec
+	BootstrapDataBlobState() infrav1.BootstrapDataBlobState
+	SetBootstrapDataBlobState(infrav1.BootstrapDataBlobState)
+	SetBootstrapDataStub(string)
+	HasNodeRef() bool
+	ProviderID() string
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope BootstrapDataScope
+	client
+}
+
+// New creates a new service.
+func New(scope BootstrapDataScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile stages the bootstrap data in Azure Blob Storage and points the VM's customData at it.
+// The blob is uploaded once, and the stub is only built until the VM is created since its customData cannot change
+// afterwards. Once the machine's Node has joined the cluster, the bootstrap data is no longer needed and the blob is
+// deleted.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.BootstrapDataBlobSpec()
+	if spec == nil || s.Scope.BootstrapDataBlobState() == infrav1.BootstrapDataBlobDeleted {
+		return nil
+	}
+
+	if s.Scope.HasNodeRef() {
+		log.V(2).Info("deleting bootstrap data blob of bootstrapped machine", "blob", spec.BlobName)
+		if err := s.DeleteBlob(ctx, *spec); err != nil {
+			return errors.Wrap(err, "failed to delete bootstrap data blob")
+		}
+		s.Scope.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobDeleted)
+		return nil
+	}
+
+	if s.Scope.ProviderID() != "" {
+		// The VM already exists and fetches the bootstrap data with the stub it was created with.
+		return nil
+	}
+
+	if s.Scope.BootstrapDataBlobState() != infrav1.BootstrapDataBlobUploaded {
+		log.V(2).Info("uploading bootstrap data blob", "storageAccount", spec.StorageAccountName, "container", spec.ContainerName, "blob", spec.BlobName)
+		if err := s.UploadBlob(ctx, *spec, spec.Data); err != nil {
+			return errors.Wrap(err, "failed to upload bootstrap data blob")
+		}
+		s.Scope.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobUploaded)
+	}
+
+	url, err := s.BlobURL(ctx, *spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get bootstrap data blob URL")
+	}
+
+	stub, err := Stub(*spec, url)
+	if err != nil {
+		return azure.WithTerminalError(errors.Wrap(err, "failed to build bootstrap data stub"))
+	}
+	s.Scope.SetBootstrapDataStub(stub)
+
+	return nil
+}
+
+// Delete deletes the bootstrap data blob.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.BootstrapDataBlobSpec()
+	if spec == nil || s.Scope.BootstrapDataBlobState() == infrav1.BootstrapDataBlobDeleted {
+		return nil
+	}
+
+	if err := s.DeleteBlob(ctx, *spec); err != nil {
+		return errors.Wrap(err, "failed to delete bootstrap data blob")
+	}
+	s.Scope.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobDeleted)
+	return nil
+}
+
+// IsManaged returns always returns true as the bootstrap data blob is always created by CAPZ.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapdata/mock_bootstrapdata"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeBlobSpec = azure.BootstrapDataBlobSpec{
+		StorageAccountName: "bootstrapdata",
+		ContainerName:      "my-cluster",
+		BlobName:           "my-vm",
+		AccessMethod:       infrav1.BootstrapDataAccessMethodSAS,
+		SASExpiry:          DefaultSASExpiry,
+		Data:               []byte("#cloud-config\n"),
+	}
+	fakeBlobURL     = "https://bootstrapdata.blob.core.windows.net/my-cluster/my-vm?sig=secret"
+	internalError   = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	ignitionBlob    = azure.BootstrapDataBlobSpec{Format: IgnitionFormat, AccessMethod: infrav1.BootstrapDataAccessMethodManagedIdentity, Data: []byte("{")}
+	ignitionBlobURL = "https://bootstrapdata.blob.core.windows.net/my-cluster/my-vm"
+)
+
+func TestReconcileBootstrapData(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder)
+	}{
+		{
+			name:          "noop if bootstrap data storage is not configured",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				s.BootstrapDataBlobSpec().Return(nil)
+			},
+		},
+		{
+			name:          "upload bootstrap data and set stub",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobState(""))
+				s.HasNodeRef().Return(false)
+				s.ProviderID().Return("")
+				m.UploadBlob(gomockinternal.AContext(), fakeBlobSpec, fakeBlobSpec.Data).Return(nil)
+				s.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobUploaded)
+				m.BlobURL(gomockinternal.AContext(), fakeBlobSpec).Return(fakeBlobURL, nil)
+				s.SetBootstrapDataStub("#include\n" + fakeBlobURL + "\n")
+			},
+		},
+		{
+			name:          "set stub without uploading bootstrap data again",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobUploaded)
+				s.HasNodeRef().Return(false)
+				s.ProviderID().Return("")
+				m.BlobURL(gomockinternal.AContext(), fakeBlobSpec).Return(fakeBlobURL, nil)
+				s.SetBootstrapDataStub("#include\n" + fakeBlobURL + "\n")
+			},
+		},
+		{
+			name:          "noop once the VM is created",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobUploaded)
+				s.HasNodeRef().Return(false)
+				s.ProviderID().Return("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+			},
+		},
+		{
+			name:          "delete bootstrap data once the node has joined",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobUploaded)
+				s.HasNodeRef().Return(true)
+				m.DeleteBlob(gomockinternal.AContext(), fakeBlobSpec).Return(nil)
+				s.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobDeleted)
+			},
+		},
+		{
+			name:          "noop once the bootstrap data is deleted",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobDeleted)
+			},
+		},
+		{
+			name:          "fail to upload bootstrap data",
+			expectedError: "failed to upload bootstrap data blob: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobState(""))
+				s.HasNodeRef().Return(false)
+				s.ProviderID().Return("")
+				m.UploadBlob(gomockinternal.AContext(), fakeBlobSpec, fakeBlobSpec.Data).Return(internalError)
+			},
+		},
+		{
+			name:          "fail to get bootstrap data blob URL",
+			expectedError: "failed to get bootstrap data blob URL: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobUploaded)
+				s.HasNodeRef().Return(false)
+				s.ProviderID().Return("")
+				m.BlobURL(gomockinternal.AContext(), fakeBlobSpec).Return("", internalError)
+			},
+		},
+		{
+			name:          "fail to build stub from invalid Ignition bootstrap data",
+			expectedError: "reconcile error that cannot be recovered occurred: failed to build bootstrap data stub: failed to parse Ignition bootstrap data: unexpected end of JSON input. Object will not be requeued",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := ignitionBlob
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().AnyTimes().Return(infrav1.BootstrapDataBlobState(""))
+				s.HasNodeRef().Return(false)
+				s.ProviderID().Return("")
+				m.UploadBlob(gomockinternal.AContext(), ignitionBlob, ignitionBlob.Data).Return(nil)
+				s.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobUploaded)
+				m.BlobURL(gomockinternal.AContext(), ignitionBlob).Return(ignitionBlobURL, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteBootstrapData(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder)
+	}{
+		{
+			name:          "noop if bootstrap data storage is not configured",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				s.BootstrapDataBlobSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete bootstrap data",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().Return(infrav1.BootstrapDataBlobUploaded)
+				m.DeleteBlob(gomockinternal.AContext(), fakeBlobSpec).Return(nil)
+				s.SetBootstrapDataBlobState(infrav1.BootstrapDataBlobDeleted)
+			},
+		},
+		{
+			name:          "noop if bootstrap data is already deleted",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().Return(infrav1.BootstrapDataBlobDeleted)
+			},
+		},
+		{
+			name:          "fail to delete bootstrap data",
+			expectedError: "failed to delete bootstrap data blob: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder) {
+				spec := fakeBlobSpec
+				s.BootstrapDataBlobSpec().Return(&spec)
+				s.BootstrapDataBlobState().Return(infrav1.BootstrapDataBlobUploaded)
+				m.DeleteBlob(gomockinternal.AContext(), fakeBlobSpec).Return(internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
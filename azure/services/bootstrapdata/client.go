@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// sasClockSkew is subtracted from the start time of generated shared access signatures to tolerate clock skew
+// between the management cluster and Azure Storage.
+const sasClockSkew = 5 * time.Minute
+
+// client wraps go-sdk.
+type client interface {
+	UploadBlob(context.Context, azure.BootstrapDataBlobSpec, []byte) error
+	BlobURL(context.Context, azure.BootstrapDataBlobSpec) (string, error)
+	DeleteBlob(context.Context, azure.BootstrapDataBlobSpec) error
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	credential       azcore.TokenCredential
+	cloudEnvironment string
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new bootstrap data client authenticated with the Azure AD token credential of the scope.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		credential:       auth.Token(),
+		cloudEnvironment: auth.CloudEnvironment(),
+	}
+}
+
+// UploadBlob uploads the bootstrap data to the blob described by the spec, creating the container if needed.
+func (ac *azureClient) UploadBlob(ctx context.Context, spec azure.BootstrapDataBlobSpec, data []byte) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.azureClient.UploadBlob")
+	defer done()
+
+	blobClient, err := ac.blobClient(spec)
+	if err != nil {
+		return err
+	}
+
+	if _, err := blobClient.CreateContainer(ctx, spec.ContainerName, nil); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return errors.Wrapf(err, "failed to create container %s", spec.ContainerName)
+	}
+
+	if _, err := blobClient.UploadBuffer(ctx, spec.ContainerName, spec.BlobName, data, nil); err != nil {
+		return errors.Wrapf(err, "failed to upload blob %s", spec.BlobName)
+	}
+	return nil
+}
+
+// BlobURL returns the URL the VM should use to fetch the blob described by the spec. With the SAS access method,
+// the URL carries a read-only user delegation SAS signed with a key issued to the controller's Azure AD identity.
+func (ac *azureClient) BlobURL(ctx context.Context, spec azure.BootstrapDataBlobSpec) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.azureClient.BlobURL")
+	defer done()
+
+	blobClient, err := ac.blobClient(spec)
+	if err != nil {
+		return "", err
+	}
+
+	blobURL := runtime.JoinPaths(blobClient.URL(), spec.ContainerName, spec.BlobName)
+	if spec.AccessMethod == infrav1.BootstrapDataAccessMethodManagedIdentity {
+		return blobURL, nil
+	}
+
+	now := time.Now().UTC()
+	start := now.Add(-sasClockSkew)
+	expiry := now.Add(spec.SASExpiry)
+	delegationCredential, err := blobClient.ServiceClient().GetUserDelegationCredential(ctx, service.KeyInfo{
+		Start:  to.Ptr(start.Format(sas.TimeFormat)),
+		Expiry: to.Ptr(expiry.Format(sas.TimeFormat)),
+	}, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get user delegation key for storage account %s", spec.StorageAccountName)
+	}
+
+	queryParams, err := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		StartTime:     start,
+		ExpiryTime:    expiry,
+		Permissions:   (&sas.BlobPermissions{Read: true}).String(),
+		ContainerName: spec.ContainerName,
+		BlobName:      spec.BlobName,
+	}.SignWithUserDelegation(delegationCredential)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to generate shared access signature for blob %s", spec.BlobName)
+	}
+	return blobURL + "?" + queryParams.Encode(), nil
+}
+
+// DeleteBlob deletes the blob described by the spec if it exists.
+func (ac *azureClient) DeleteBlob(ctx context.Context, spec azure.BootstrapDataBlobSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapdata.azureClient.DeleteBlob")
+	defer done()
+
+	blobClient, err := ac.blobClient(spec)
+	if err != nil {
+		return err
+	}
+
+	if _, err := blobClient.DeleteBlob(ctx, spec.ContainerName, spec.BlobName, nil); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+		return errors.Wrapf(err, "failed to delete blob %s", spec.BlobName)
+	}
+	return nil
+}
+
+// blobClient returns a client of the Blob service of the storage account described by the spec.
+func (ac *azureClient) blobClient(spec azure.BootstrapDataBlobSpec) (*azblob.Client, error) {
+	env, err := azureautorest.EnvironmentFromName(ac.cloudEnvironment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get Azure environment %s", ac.cloudEnvironment)
+	}
+
+	serviceURL := fmt.Sprintf("https://%s.blob.%s/", spec.StorageAccountName, env.StorageEndpointSuffix)
+	blobClient, err := azblob.NewClient(serviceURL, ac.credential, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create blob client for storage account %s", spec.StorageAccountName)
+	}
+	return blobClient, nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../bootstrapdata.go
+
+// Package mock_bootstrapdata is a generated GoMock package.
+package mock_bootstrapdata
+
+import (
+	reflect "reflect"
+
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// MockBootstrapDataScope is a mock of BootstrapDataScope interface.
+type MockBootstrapDataScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockBootstrapDataScopeMockRecorder
+}
+
+// MockBootstrapDataScopeMockRecorder is the mock recorder for MockBootstrapDataScope.
+type MockBootstrapDataScopeMockRecorder struct {
+	mock *MockBootstrapDataScope
+}
+
+// NewMockBootstrapDataScope creates a new mock instance.
+func NewMockBootstrapDataScope(ctrl *gomock.Controller) *MockBootstrapDataScope {
+	mock := &MockBootstrapDataScope{ctrl: ctrl}
+	mock.recorder = &MockBootstrapDataScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBootstrapDataScope) EXPECT() *MockBootstrapDataScopeMockRecorder {
+	return m.recorder
+}
+
+// Authorizer mocks base method.
+func (m *MockBootstrapDataScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockBootstrapDataScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockBootstrapDataScope)(nil).Authorizer))
+}
+
+// BaseURI mocks base method.
+func (m *MockBootstrapDataScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockBootstrapDataScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockBootstrapDataScope)(nil).BaseURI))
+}
+
+// BootstrapDataBlobSpec mocks base method.
+func (m *MockBootstrapDataScope) BootstrapDataBlobSpec() *azure.BootstrapDataBlobSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapDataBlobSpec")
+	ret0, _ := ret[0].(*azure.BootstrapDataBlobSpec)
+	return ret0
+}
+
+// BootstrapDataBlobSpec indicates an expected call of BootstrapDataBlobSpec.
+func (mr *MockBootstrapDataScopeMockRecorder) BootstrapDataBlobSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapDataBlobSpec", reflect.TypeOf((*MockBootstrapDataScope)(nil).BootstrapDataBlobSpec))
+}
+
+// BootstrapDataBlobState mocks base method.
+func (m *MockBootstrapDataScope) BootstrapDataBlobState() v1beta1.BootstrapDataBlobState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapDataBlobState")
+	ret0, _ := ret[0].(v1beta1.BootstrapDataBlobState)
+	return ret0
+}
+
+// BootstrapDataBlobState indicates an expected call of BootstrapDataBlobState.
+func (mr *MockBootstrapDataScopeMockRecorder) BootstrapDataBlobState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapDataBlobState", reflect.TypeOf((*MockBootstrapDataScope)(nil).BootstrapDataBlobState))
+}
+
+// ClientID mocks base method.
+func (m *MockBootstrapDataScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockBootstrapDataScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockBootstrapDataScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockBootstrapDataScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockBootstrapDataScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockBootstrapDataScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockBootstrapDataScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockBootstrapDataScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockBootstrapDataScope)(nil).CloudEnvironment))
+}
+
+// HasNodeRef mocks base method.
+func (m *MockBootstrapDataScope) HasNodeRef() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNodeRef")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNodeRef indicates an expected call of HasNodeRef.
+func (mr *MockBootstrapDataScopeMockRecorder) HasNodeRef() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNodeRef", reflect.TypeOf((*MockBootstrapDataScope)(nil).HasNodeRef))
+}
+
+// HashKey mocks base method.
+func (m *MockBootstrapDataScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockBootstrapDataScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockBootstrapDataScope)(nil).HashKey))
+}
+
+// ProviderID mocks base method.
+func (m *MockBootstrapDataScope) ProviderID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProviderID indicates an expected call of ProviderID.
+func (mr *MockBootstrapDataScopeMockRecorder) ProviderID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderID", reflect.TypeOf((*MockBootstrapDataScope)(nil).ProviderID))
+}
+
+// SetBootstrapDataBlobState mocks base method.
+func (m *MockBootstrapDataScope) SetBootstrapDataBlobState(arg0 v1beta1.BootstrapDataBlobState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBootstrapDataBlobState", arg0)
+}
+
+// SetBootstrapDataBlobState indicates an expected call of SetBootstrapDataBlobState.
+func (mr *MockBootstrapDataScopeMockRecorder) SetBootstrapDataBlobState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootstrapDataBlobState", reflect.TypeOf((*MockBootstrapDataScope)(nil).SetBootstrapDataBlobState), arg0)
+}
+
+// SetBootstrapDataStub mocks base method.
+func (m *MockBootstrapDataScope) SetBootstrapDataStub(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBootstrapDataStub", arg0)
+}
+
+// SetBootstrapDataStub indicates an expected call of SetBootstrapDataStub.
+func (mr *MockBootstrapDataScopeMockRecorder) SetBootstrapDataStub(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootstrapDataStub", reflect.TypeOf((*MockBootstrapDataScope)(nil).SetBootstrapDataStub), arg0)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockBootstrapDataScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockBootstrapDataScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockBootstrapDataScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockBootstrapDataScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockBootstrapDataScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockBootstrapDataScope)(nil).TenantID))
+}
+
+// Token mocks base method.
+func (m *MockBootstrapDataScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockBootstrapDataScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockBootstrapDataScope)(nil).Token))
+}
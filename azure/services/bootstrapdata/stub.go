@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+const (
+	// DefaultSASExpiry is the default validity period of the shared access signature granting access to the bootstrap data.
+	DefaultSASExpiry = time.Hour
+	// IgnitionFormat is the bootstrap data format value for Ignition configs.
+	IgnitionFormat = "ignition"
+	// defaultIgnitionVersion is the Ignition spec version used for the stub when the bootstrap data does not declare one.
+	defaultIgnitionVersion = "3.1.0"
+)
+
+// Stub returns the customData stub instructing the VM to fetch its bootstrap data from the given URL.
+func Stub(spec azure.BootstrapDataBlobSpec, url string) (string, error) {
+	if spec.Format == IgnitionFormat {
+		return ignitionStub(spec.Data, url)
+	}
+
+	// cloud-init's include file format fetches the URL and processes its content as user data,
+	// but cannot authenticate the request.
+	if spec.AccessMethod == infrav1.BootstrapDataAccessMethodManagedIdentity {
+		return "", errors.Errorf("access method %s is only supported with Ignition bootstrap data", infrav1.BootstrapDataAccessMethodManagedIdentity)
+	}
+	return fmt.Sprintf("#include\n%s\n", url), nil
+}
+
+// ignitionConfig is the subset of an Ignition config needed to build a stub replacing the config with a remote one.
+type ignitionConfig struct {
+	Ignition ignitionSection `json:"ignition"`
+}
+
+type ignitionSection struct {
+	Version string                 `json:"version"`
+	Config  *ignitionConfigSection `json:"config,omitempty"`
+}
+
+type ignitionConfigSection struct {
+	Replace ignitionResource `json:"replace"`
+}
+
+type ignitionResource struct {
+	Source string `json:"source"`
+}
+
+// ignitionStub returns an Ignition config replacing itself with the config located at the given URL.
+// The stub uses the same spec version as the bootstrap data so that it is understood by the same Ignition release.
+func ignitionStub(data []byte, url string) (string, error) {
+	var config ignitionConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", errors.Wrap(err, "failed to parse Ignition bootstrap data")
+	}
+
+	version := config.Ignition.Version
+	if version == "" {
+		version = defaultIgnitionVersion
+	}
+	stub := ignitionConfig{
+		Ignition: ignitionSection{
+			Version: version,
+			Config: &ignitionConfigSection{
+				Replace: ignitionResource{Source: url},
+			},
+		},
+	}
+
+	out, err := json.Marshal(stub)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal Ignition stub")
+	}
+	return string(out), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+func TestStub(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          azure.BootstrapDataBlobSpec
+		url           string
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "cloud-config with SAS",
+			spec: azure.BootstrapDataBlobSpec{
+				AccessMethod: infrav1.BootstrapDataAccessMethodSAS,
+				Format:       "cloud-config",
+				Data:         []byte("#cloud-config\nruncmd: []\n"),
+			},
+			url:      "https://account.blob.core.windows.net/cluster/vm?sig=secret",
+			expected: "#include\nhttps://account.blob.core.windows.net/cluster/vm?sig=secret\n",
+		},
+		{
+			name: "cloud-config with managed identity",
+			spec: azure.BootstrapDataBlobSpec{
+				AccessMethod: infrav1.BootstrapDataAccessMethodManagedIdentity,
+				Format:       "cloud-config",
+				Data:         []byte("#cloud-config\nruncmd: []\n"),
+			},
+			url:           "https://account.blob.core.windows.net/cluster/vm",
+			expectedError: "access method ManagedIdentity is only supported with Ignition bootstrap data",
+		},
+		{
+			name: "ignition keeps the spec version of the bootstrap data",
+			spec: azure.BootstrapDataBlobSpec{
+				AccessMethod: infrav1.BootstrapDataAccessMethodManagedIdentity,
+				Format:       IgnitionFormat,
+				Data:         []byte(`{"ignition":{"version":"2.3.0"},"storage":{"files":[]}}`),
+			},
+			url:      "https://account.blob.core.windows.net/cluster/vm",
+			expected: `{"ignition":{"version":"2.3.0","config":{"replace":{"source":"https://account.blob.core.windows.net/cluster/vm"}}}}`,
+		},
+		{
+			name: "ignition without a spec version",
+			spec: azure.BootstrapDataBlobSpec{
+				AccessMethod: infrav1.BootstrapDataAccessMethodSAS,
+				Format:       IgnitionFormat,
+				Data:         []byte(`{}`),
+			},
+			url:      "https://account.blob.core.windows.net/cluster/vm?sig=secret",
+			expected: `{"ignition":{"version":"3.1.0","config":{"replace":{"source":"https://account.blob.core.windows.net/cluster/vm?sig=secret"}}}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			stub, err := Stub(tc.spec, tc.url)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(stub).To(Equal(tc.expected))
+			}
+		})
+	}
+}
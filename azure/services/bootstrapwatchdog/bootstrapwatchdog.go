@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapwatchdog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "bootstrapwatchdog"
+
+// DefaultTimeout is the default amount of time to wait for a running machine to get a NodeRef.
+const DefaultTimeout = 20 * time.Minute
+
+// BootstrapWatchdogScope defines the scope interface for a bootstrap watchdog service.
+type BootstrapWatchdogScope interface {
+	azure.Authorizer
+	BootstrapWatchdogSpec() *azure.BootstrapWatchdogSpec
+	HasNodeRef() bool
+	IsBootstrapFailed() bool
+	SetBootstrapFailed(reason, message, logTail string)
+	ClearBootstrapFailed()
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope BootstrapWatchdogScope
+	client
+}
+
+// New creates a new service.
+func New(scope BootstrapWatchdogScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile checks whether a running machine got a NodeRef within the configured timeout.
+// If it did not, the boot diagnostics serial log is inspected for known bootstrap error signatures
+// and the machine is marked as failed to bootstrap.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "bootstrapwatchdog.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.BootstrapWatchdogSpec()
+	if spec == nil {
+		return nil
+	}
+
+	if s.Scope.HasNodeRef() {
+		s.Scope.ClearBootstrapFailed()
+		return nil
+	}
+
+	// The failure has already been reported, there is no need to download the serial log again.
+	if s.Scope.IsBootstrapFailed() {
+		return nil
+	}
+
+	if spec.RunningSince.IsZero() || time.Since(spec.RunningSince) < spec.Timeout {
+		return nil
+	}
+
+	log.V(2).Info("machine did not bootstrap in time, inspecting boot diagnostics serial log", "vm", spec.VMName, "timeout", spec.Timeout)
+	serialLog, err := s.GetSerialConsoleLog(ctx, spec.ResourceGroup, spec.VMName)
+	if err != nil {
+		// Not being able to retrieve the serial log must not block the reconciliation of the machine,
+		// the timeout is reported regardless.
+		log.Error(err, "failed to retrieve boot diagnostics serial log", "vm", spec.VMName)
+		s.Scope.SetBootstrapFailed(infrav1.BootstrapTimeoutReason, fmt.Sprintf("machine did not get a NodeRef within %s and the boot diagnostics serial log could not be retrieved: %s", spec.Timeout, err.Error()), "")
+		return nil
+	}
+
+	reason, signature := detectFailure(serialLog)
+	message := fmt.Sprintf("machine did not get a NodeRef within %s", spec.Timeout)
+	if signature != "" {
+		message = fmt.Sprintf("%s: %s", message, signature)
+	}
+	s.Scope.SetBootstrapFailed(reason, message, logTail(serialLog))
+
+	return nil
+}
+
+// Delete is a no-op as the bootstrap watchdog does not create any Azure resource.
+func (s *Service) Delete(ctx context.Context) error {
+	return nil
+}
+
+// IsManaged returns always returns true as the bootstrap watchdog does not operate on pre-existing resources.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapwatchdog
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapwatchdog/mock_bootstrapwatchdog"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeExpiredSpec = azure.BootstrapWatchdogSpec{
+		ResourceGroup: "my-rg",
+		VMName:        "my-vm",
+		Timeout:       DefaultTimeout,
+		RunningSince:  time.Now().Add(-time.Hour),
+	}
+	fakeRunningSpec = azure.BootstrapWatchdogSpec{
+		ResourceGroup: "my-rg",
+		VMName:        "my-vm",
+		Timeout:       DefaultTimeout,
+		RunningSince:  time.Now(),
+	}
+	fakeKubeadmLog = []byte("[  OK  ] Started Initial cloud-init job.\n" +
+		"cloud-init[1234]: error execution phase preflight: [preflight] Some fatal errors occurred:\n" +
+		"cloud-init[1234]: 2023-05-01 10:00:00,000 - cc_scripts_user.py[WARNING]: Failed to run module scripts-user\n")
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileBootstrapWatchdog(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder)
+	}{
+		{
+			name:          "noop if bootstrap watchdog is not enabled",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				s.BootstrapWatchdogSpec().Return(nil)
+			},
+		},
+		{
+			name:          "clear failure once the node has joined",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				spec := fakeExpiredSpec
+				s.BootstrapWatchdogSpec().Return(&spec)
+				s.HasNodeRef().Return(true)
+				s.ClearBootstrapFailed()
+			},
+		},
+		{
+			name:          "noop if failure was already reported",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				spec := fakeExpiredSpec
+				s.BootstrapWatchdogSpec().Return(&spec)
+				s.HasNodeRef().Return(false)
+				s.IsBootstrapFailed().Return(true)
+			},
+		},
+		{
+			name:          "noop if VM is not running yet",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				s.BootstrapWatchdogSpec().Return(&azure.BootstrapWatchdogSpec{Timeout: DefaultTimeout})
+				s.HasNodeRef().Return(false)
+				s.IsBootstrapFailed().Return(false)
+			},
+		},
+		{
+			name:          "noop if timeout has not expired",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				spec := fakeRunningSpec
+				s.BootstrapWatchdogSpec().Return(&spec)
+				s.HasNodeRef().Return(false)
+				s.IsBootstrapFailed().Return(false)
+			},
+		},
+		{
+			name:          "report failure with detected error signature",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				spec := fakeExpiredSpec
+				s.BootstrapWatchdogSpec().Return(&spec)
+				s.HasNodeRef().Return(false)
+				s.IsBootstrapFailed().Return(false)
+				m.GetSerialConsoleLog(gomockinternal.AContext(), "my-rg", "my-vm").Return(fakeKubeadmLog, nil)
+				s.SetBootstrapFailed(infrav1.KubeadmFailedReason,
+					"machine did not get a NodeRef within 20m0s: error execution phase preflight: [preflight] Some fatal errors occurred:",
+					logTail(fakeKubeadmLog))
+			},
+		},
+		{
+			name:          "report timeout if serial log cannot be retrieved",
+			expectedError: "",
+			expect: func(s *mock_bootstrapwatchdog.MockBootstrapWatchdogScopeMockRecorder, m *mock_bootstrapwatchdog.MockclientMockRecorder) {
+				spec := fakeExpiredSpec
+				s.BootstrapWatchdogSpec().Return(&spec)
+				s.HasNodeRef().Return(false)
+				s.IsBootstrapFailed().Return(false)
+				m.GetSerialConsoleLog(gomockinternal.AContext(), "my-rg", "my-vm").Return(nil, internalError)
+				s.SetBootstrapFailed(infrav1.BootstrapTimeoutReason,
+					"machine did not get a NodeRef within 20m0s and the boot diagnostics serial log could not be retrieved: #: Internal Server Error: StatusCode=500",
+					"")
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapwatchdog.NewMockBootstrapWatchdogScope(mockCtrl)
+			clientMock := mock_bootstrapwatchdog.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
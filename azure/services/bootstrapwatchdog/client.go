@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapwatchdog
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// sasURIExpirationTimeInMinutes is the lifetime of the SAS URI returned to download the serial log.
+const sasURIExpirationTimeInMinutes = 5
+
+// client wraps go-sdk.
+type client interface {
+	GetSerialConsoleLog(ctx context.Context, resourceGroupName, vmName string) ([]byte, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	virtualmachines compute.VirtualMachinesClient
+	httpClient      *http.Client
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new bootstrap watchdog client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newVirtualMachinesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{
+		virtualmachines: c,
+		httpClient:      http.DefaultClient,
+	}
+}
+
+// newVirtualMachinesClient creates a new VM client from subscription ID.
+func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachinesClient {
+	vmClient := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&vmClient.Client, authorizer)
+	return vmClient
+}
+
+// GetSerialConsoleLog retrieves the end of the boot diagnostics serial console log of a virtual machine.
+func (ac *azureClient) GetSerialConsoleLog(ctx context.Context, resourceGroupName, vmName string) ([]byte, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootstrapwatchdog.azureClient.GetSerialConsoleLog")
+	defer done()
+
+	result, err := ac.virtualmachines.RetrieveBootDiagnosticsData(ctx, resourceGroupName, vmName, pointer.Int32(sasURIExpirationTimeInMinutes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve boot diagnostics data")
+	}
+	if result.SerialConsoleLogBlobURI == nil || *result.SerialConsoleLogBlobURI == "" {
+		return nil, errors.New("boot diagnostics serial console log is not available")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *result.SerialConsoleLogBlobURI, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create serial console log request")
+	}
+	resp, err := ac.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download serial console log")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download serial console log: unexpected status code %d", resp.StatusCode)
+	}
+
+	serialLog, err := readTail(resp.Body, maxSerialLogBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read serial console log")
+	}
+	return serialLog, nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../bootstrapwatchdog.go
+
+// Package mock_bootstrapwatchdog is a generated GoMock package.
+package mock_bootstrapwatchdog
+
+import (
+	reflect "reflect"
+
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "github.com/golang/mock/gomock"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// MockBootstrapWatchdogScope is a mock of BootstrapWatchdogScope interface.
+type MockBootstrapWatchdogScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockBootstrapWatchdogScopeMockRecorder
+}
+
+// MockBootstrapWatchdogScopeMockRecorder is the mock recorder for MockBootstrapWatchdogScope.
+type MockBootstrapWatchdogScopeMockRecorder struct {
+	mock *MockBootstrapWatchdogScope
+}
+
+// NewMockBootstrapWatchdogScope creates a new mock instance.
+func NewMockBootstrapWatchdogScope(ctrl *gomock.Controller) *MockBootstrapWatchdogScope {
+	mock := &MockBootstrapWatchdogScope{ctrl: ctrl}
+	mock.recorder = &MockBootstrapWatchdogScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBootstrapWatchdogScope) EXPECT() *MockBootstrapWatchdogScopeMockRecorder {
+	return m.recorder
+}
+
+// Authorizer mocks base method.
+func (m *MockBootstrapWatchdogScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).Authorizer))
+}
+
+// BaseURI mocks base method.
+func (m *MockBootstrapWatchdogScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).BaseURI))
+}
+
+// BootstrapWatchdogSpec mocks base method.
+func (m *MockBootstrapWatchdogScope) BootstrapWatchdogSpec() *azure.BootstrapWatchdogSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapWatchdogSpec")
+	ret0, _ := ret[0].(*azure.BootstrapWatchdogSpec)
+	return ret0
+}
+
+// BootstrapWatchdogSpec indicates an expected call of BootstrapWatchdogSpec.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) BootstrapWatchdogSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapWatchdogSpec", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).BootstrapWatchdogSpec))
+}
+
+// ClearBootstrapFailed mocks base method.
+func (m *MockBootstrapWatchdogScope) ClearBootstrapFailed() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearBootstrapFailed")
+}
+
+// ClearBootstrapFailed indicates an expected call of ClearBootstrapFailed.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) ClearBootstrapFailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearBootstrapFailed", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).ClearBootstrapFailed))
+}
+
+// ClientID mocks base method.
+func (m *MockBootstrapWatchdogScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockBootstrapWatchdogScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockBootstrapWatchdogScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).CloudEnvironment))
+}
+
+// HasNodeRef mocks base method.
+func (m *MockBootstrapWatchdogScope) HasNodeRef() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNodeRef")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNodeRef indicates an expected call of HasNodeRef.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) HasNodeRef() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNodeRef", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).HasNodeRef))
+}
+
+// HashKey mocks base method.
+func (m *MockBootstrapWatchdogScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).HashKey))
+}
+
+// IsBootstrapFailed mocks base method.
+func (m *MockBootstrapWatchdogScope) IsBootstrapFailed() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBootstrapFailed")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBootstrapFailed indicates an expected call of IsBootstrapFailed.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) IsBootstrapFailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBootstrapFailed", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).IsBootstrapFailed))
+}
+
+// SetBootstrapFailed mocks base method.
+func (m *MockBootstrapWatchdogScope) SetBootstrapFailed(reason, message, logTail string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBootstrapFailed", reason, message, logTail)
+}
+
+// SetBootstrapFailed indicates an expected call of SetBootstrapFailed.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) SetBootstrapFailed(reason, message, logTail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootstrapFailed", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).SetBootstrapFailed), reason, message, logTail)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockBootstrapWatchdogScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockBootstrapWatchdogScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).TenantID))
+}
+
+// Token mocks base method.
+func (m *MockBootstrapWatchdogScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockBootstrapWatchdogScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockBootstrapWatchdogScope)(nil).Token))
+}
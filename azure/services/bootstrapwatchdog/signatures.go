@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapwatchdog
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+const (
+	// maxSerialLogBytes is the size of the end of the serial log inspected for error signatures, as bootstrap failures are
+	// logged right before the VM stops making progress. It bounds the memory used to check a machine.
+	maxSerialLogBytes = 256 * 1024
+	// maxLogTailBytes is the maximum size of the serial log tail reported in events, which are limited to 1 KiB.
+	maxLogTailBytes = 768
+	// maxSignatureLength is the maximum length of the error signature reported in the BootstrapFailed condition.
+	maxSignatureLength = 256
+)
+
+// errorSignature associates a pattern found in the serial log with the reason of the bootstrap failure.
+type errorSignature struct {
+	reason  string
+	pattern *regexp.Regexp
+}
+
+// errorSignatures are ordered by priority: a failed kubeadm command also makes cloud-init report a failure,
+// so the more specific kubeadm signature wins.
+var errorSignatures = []errorSignature{
+	{
+		reason:  infrav1.KubeadmFailedReason,
+		pattern: regexp.MustCompile(`error execution phase .*|\[ERROR [^\]]+\].*`),
+	},
+	{
+		reason:  infrav1.IgnitionFailedReason,
+		pattern: regexp.MustCompile(`Ignition failed.*|ignition\[\d+\]: .*(failed|error).*`),
+	},
+	{
+		reason:  infrav1.CloudInitFailedReason,
+		pattern: regexp.MustCompile(`Failed to run module .*|Failed running /var/lib/cloud/.*|cloud-init\[\d+\]: .*(ERROR|Traceback).*`),
+	},
+}
+
+// ansiEscape matches the terminal escape sequences commonly found in serial console output.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+
+// detectFailure returns the reason and the matching log line of the most specific bootstrap error signature
+// found in the serial log. If no known signature is found, BootstrapTimeoutReason and an empty line are returned.
+func detectFailure(serialLog []byte) (string, string) {
+	cleaned := sanitize(serialLog)
+	for _, signature := range errorSignatures {
+		matches := signature.pattern.FindAllString(cleaned, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		// The last match is the closest to the failure.
+		line := strings.TrimSpace(matches[len(matches)-1])
+		if len(line) > maxSignatureLength {
+			line = line[:maxSignatureLength]
+		}
+		return signature.reason, line
+	}
+	return infrav1.BootstrapTimeoutReason, ""
+}
+
+// logTail returns the last lines of the serial log, up to maxLogTailBytes.
+func logTail(serialLog []byte) string {
+	cleaned := strings.TrimSpace(sanitize(serialLog))
+	if len(cleaned) <= maxLogTailBytes {
+		return cleaned
+	}
+	tail := cleaned[len(cleaned)-maxLogTailBytes:]
+	// Drop the first, partial line.
+	if i := strings.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	return tail
+}
+
+// readTail reads r to the end and returns its last n bytes, without holding more than twice that amount in memory.
+func readTail(r io.Reader, n int) ([]byte, error) {
+	buf := make([]byte, 0, 2*n)
+	chunk := make([]byte, 32*1024)
+	for {
+		read, err := r.Read(chunk)
+		buf = append(buf, chunk[:read]...)
+		if len(buf) > n {
+			buf = append(buf[:0], buf[len(buf)-n:]...)
+		}
+		if errors.Is(err, io.EOF) {
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sanitize strips terminal escape sequences and carriage returns from the serial log.
+func sanitize(serialLog []byte) string {
+	cleaned := ansiEscape.ReplaceAll(serialLog, nil)
+	return string(bytes.ReplaceAll(cleaned, []byte("\r"), nil))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapwatchdog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestDetectFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		serialLog     string
+		wantReason    string
+		wantSignature string
+	}{
+		{
+			name:          "no known signature",
+			serialLog:     "[  OK  ] Reached target Multi-User System.\n",
+			wantReason:    infrav1.BootstrapTimeoutReason,
+			wantSignature: "",
+		},
+		{
+			name: "kubeadm signature takes precedence over cloud-init",
+			serialLog: "cloud-init[1234]: [ERROR FileAvailable--etc-kubernetes-manifests-kube-apiserver.yaml]: file already exists\r\n" +
+				"cloud-init[1234]: 2023-05-01 10:00:00,000 - cc_scripts_user.py[WARNING]: Failed to run module scripts-user\r\n",
+			wantReason:    infrav1.KubeadmFailedReason,
+			wantSignature: "[ERROR FileAvailable--etc-kubernetes-manifests-kube-apiserver.yaml]: file already exists",
+		},
+		{
+			name:          "cloud-init signature",
+			serialLog:     "cloud-init[1234]: 2023-05-01 10:00:00,000 - util.py[WARNING]: Failed running /var/lib/cloud/instance/scripts/runcmd [1]\n",
+			wantReason:    infrav1.CloudInitFailedReason,
+			wantSignature: "Failed running /var/lib/cloud/instance/scripts/runcmd [1]",
+		},
+		{
+			name:          "Ignition signature with terminal escape sequences",
+			serialLog:     "\x1b[0;1;31mIgnition failed: failed to fetch config\x1b[0m\n",
+			wantReason:    infrav1.IgnitionFailedReason,
+			wantSignature: "Ignition failed: failed to fetch config",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			reason, signature := detectFailure([]byte(tt.serialLog))
+			g.Expect(reason).To(Equal(tt.wantReason))
+			g.Expect(signature).To(Equal(tt.wantSignature))
+		})
+	}
+}
+
+func TestLogTail(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(logTail([]byte("line 1\r\nline 2\n"))).To(Equal("line 1\nline 2"))
+
+	longLog := strings.Repeat("0123456789\n", 100)
+	tail := logTail([]byte(longLog))
+	g.Expect(len(tail)).To(BeNumerically("<=", maxLogTailBytes))
+	g.Expect(tail).To(HavePrefix("0123456789\n"))
+	g.Expect(tail).To(HaveSuffix("0123456789"))
+}
+
+func TestReadTail(t *testing.T) {
+	g := NewWithT(t)
+
+	tail, err := readTail(strings.NewReader("short log"), 16)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(tail)).To(Equal("short log"))
+
+	longLog := bytes.Repeat([]byte("0123456789"), 10000)
+	tail, err = readTail(bytes.NewReader(longLog), 1000)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tail).To(Equal(longLog[len(longLog)-1000:]))
+}
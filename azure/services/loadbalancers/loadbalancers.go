@@ -27,11 +27,12 @@ import (
 )
 
 const (
-	serviceName           = "loadbalancers"
-	httpsProbe            = "HTTPSProbe"
-	httpsProbeRequestPath = "/readyz"
-	lbRuleHTTPS           = "LBRuleHTTPS"
-	outboundNAT           = "OutboundNATAllProtocols"
+	serviceName = "loadbalancers"
+	httpsProbe  = "HTTPSProbe"
+	lbRuleHTTPS = "LBRuleHTTPS"
+	outboundNAT = "OutboundNATAllProtocols"
+	// outboundNATIPv6 is the outbound rule of the IPv6 frontend IPs.
+	outboundNATIPv6 = "OutboundNATAllProtocols-ipv6"
 )
 
 // LBScope defines the scope interface for a load balancer service.
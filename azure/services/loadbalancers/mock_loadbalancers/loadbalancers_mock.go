@@ -23,6 +23,7 @@ package mock_loadbalancers
 import (
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -109,6 +110,20 @@ func (mr *MockLBScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockLBScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockLBScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockLBScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockLBScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockLBScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -443,6 +458,20 @@ func (mr *MockLBScopeMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockLBScope)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockLBScope) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockLBScopeMockRecorder) OutboundIPv6PoolName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockLBScope)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockLBScope) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -471,6 +500,34 @@ func (mr *MockLBScopeMockRecorder) OutboundPoolName(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockLBScope)(nil).OutboundPoolName), arg0)
 }
 
+// PrivateDNSNodeRecordsZoneID mocks base method.
+func (m *MockLBScope) PrivateDNSNodeRecordsZoneID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSNodeRecordsZoneID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PrivateDNSNodeRecordsZoneID indicates an expected call of PrivateDNSNodeRecordsZoneID.
+func (mr *MockLBScopeMockRecorder) PrivateDNSNodeRecordsZoneID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSNodeRecordsZoneID", reflect.TypeOf((*MockLBScope)(nil).PrivateDNSNodeRecordsZoneID))
+}
+
+// PublicIPPrefixID mocks base method.
+func (m *MockLBScope) PublicIPPrefixID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicIPPrefixID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PublicIPPrefixID indicates an expected call of PublicIPPrefixID.
+func (mr *MockLBScopeMockRecorder) PublicIPPrefixID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPPrefixID", reflect.TypeOf((*MockLBScope)(nil).PublicIPPrefixID))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockLBScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -565,6 +622,20 @@ func (mr *MockLBScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockLBScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockLBScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockLBScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockLBScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockLBScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
@@ -18,6 +18,7 @@ package loadbalancers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
@@ -29,23 +30,28 @@ import (
 
 // LBSpec defines the specification for a Load Balancer.
 type LBSpec struct {
-	Name                 string
-	ResourceGroup        string
-	SubscriptionID       string
-	ClusterName          string
-	Location             string
-	ExtendedLocation     *infrav1.ExtendedLocationSpec
-	Role                 string
-	Type                 infrav1.LBType
-	SKU                  infrav1.SKU
-	VNetName             string
-	VNetResourceGroup    string
-	SubnetName           string
-	BackendPoolName      string
-	FrontendIPConfigs    []infrav1.FrontendIP
-	APIServerPort        int32
-	IdleTimeoutInMinutes *int32
-	AdditionalTags       map[string]string
+	Name                   string
+	ResourceGroup          string
+	SubscriptionID         string
+	ClusterName            string
+	Location               string
+	ExtendedLocation       *infrav1.ExtendedLocationSpec
+	Role                   string
+	Type                   infrav1.LBType
+	SKU                    infrav1.SKU
+	VNetName               string
+	VNetResourceGroup      string
+	SubnetName             string
+	BackendPoolName        string
+	FrontendIPConfigs      []infrav1.FrontendIP
+	APIServerPort          int32
+	IdleTimeoutInMinutes   *int32
+	HealthProbe            *infrav1.LoadBalancerHealthProbe
+	AdditionalRules        []infrav1.LoadBalancingRule
+	AllocatedOutboundPorts *int32
+	IPv6FrontendIPConfigs  []infrav1.FrontendIP
+	IPv6BackendPoolName    string
+	AdditionalTags         map[string]string
 }
 
 // ResourceName returns the name of the load balancer.
@@ -113,17 +119,30 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 
 		outboundRules = *existingLB.OutboundRules
 		for _, rule := range getOutboundRules(*s, wantedFrontendIDs) {
-			if !outboundRuleExists(outboundRules, rule) {
+			i := outboundRuleIndex(outboundRules, rule)
+			switch {
+			case i == -1:
 				update = true
 				outboundRules = append(outboundRules, rule)
+			case (s.AllocatedOutboundPorts != nil || pointer.StringDeref(rule.Name, "") == outboundNATIPv6) && !outboundRuleEqual(outboundRules[i], rule):
+				// Outbound rules are only updated in place when their SNAT ports are sized automatically,
+				// or when IPv6 frontend IPs are added to the IPv6 outbound rule.
+				update = true
+				outboundRules[i] = rule
 			}
 		}
 
 		probes = *existingLB.Probes
 		for _, probe := range getProbes(*s) {
-			if !probeExists(probes, probe) {
+			i := probeIndex(probes, probe)
+			switch {
+			case i == -1:
 				update = true
 				probes = append(probes, probe)
+			case s.HealthProbe != nil && !probeEqual(probes[i], probe):
+				// Probes are only updated in place when their settings are configured explicitly.
+				update = true
+				probes[i] = probe
 			}
 		}
 
@@ -190,6 +209,17 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []n
 			ID: pointer.String(azure.FrontendIPConfigID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, ipConfig.Name)),
 		})
 	}
+	// The IPv6 frontend IPs are only used by the IPv6 outbound rule, so their IDs are not returned.
+	for _, ipConfig := range lbSpec.IPv6FrontendIPConfigs {
+		frontendIPConfigurations = append(frontendIPConfigurations, network.FrontendIPConfiguration{
+			FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+				PublicIPAddress: &network.PublicIPAddress{
+					ID: pointer.String(azure.PublicIPID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, ipConfig.PublicIP.Name)),
+				},
+			},
+			Name: pointer.String(ipConfig.Name),
+		})
+	}
 	return frontendIPConfigurations, frontendIDs
 }
 
@@ -197,12 +227,13 @@ func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []networ
 	if lbSpec.Type == infrav1.Internal {
 		return []network.OutboundRule{}
 	}
-	return []network.OutboundRule{
+	rules := []network.OutboundRule{
 		{
 			Name: pointer.String(outboundNAT),
 			OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
 				Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
 				IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
+				AllocatedOutboundPorts:   lbSpec.AllocatedOutboundPorts,
 				FrontendIPConfigurations: &frontendIDs,
 				BackendAddressPool: &network.SubResource{
 					ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
@@ -210,6 +241,28 @@ func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []networ
 			},
 		},
 	}
+	if lbSpec.IPv6BackendPoolName != "" && len(lbSpec.IPv6FrontendIPConfigs) > 0 {
+		// An outbound rule cannot mix IP versions, so the IPv6 addresses egress through a rule of their own
+		// with the default SNAT ports allocation.
+		ipv6FrontendIDs := make([]network.SubResource, len(lbSpec.IPv6FrontendIPConfigs))
+		for i, ipConfig := range lbSpec.IPv6FrontendIPConfigs {
+			ipv6FrontendIDs[i] = network.SubResource{
+				ID: pointer.String(azure.FrontendIPConfigID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, ipConfig.Name)),
+			}
+		}
+		rules = append(rules, network.OutboundRule{
+			Name: pointer.String(outboundNATIPv6),
+			OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+				Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
+				IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
+				FrontendIPConfigurations: &ipv6FrontendIDs,
+				BackendAddressPool: &network.SubResource{
+					ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.IPv6BackendPoolName)),
+				},
+			},
+		})
+	}
+	return rules
 }
 
 func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.LoadBalancingRule {
@@ -220,7 +273,7 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 		if len(frontendIDs) != 0 {
 			frontendIPConfig = frontendIDs[0]
 		}
-		return []network.LoadBalancingRule{
+		rules := []network.LoadBalancingRule{
 			{
 				Name: pointer.String(lbRuleHTTPS),
 				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
@@ -241,52 +294,156 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 				},
 			},
 		}
+		for _, additionalRule := range lbSpec.AdditionalRules {
+			rule := network.LoadBalancingRule{
+				Name: pointer.String(additionalRule.Name),
+				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+					DisableOutboundSnat:     pointer.Bool(true),
+					Protocol:                network.TransportProtocol(additionalRule.Protocol),
+					FrontendPort:            pointer.Int32(additionalRule.FrontendPort),
+					BackendPort:             pointer.Int32(additionalRule.BackendPort),
+					IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
+					EnableFloatingIP:        pointer.Bool(false),
+					LoadDistribution:        network.LoadDistributionDefault,
+					FrontendIPConfiguration: &frontendIPConfig,
+					BackendAddressPool: &network.SubResource{
+						ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+					},
+				},
+			}
+			if additionalRule.Protocol == infrav1.LoadBalancingRuleProtocolTCP {
+				rule.Probe = &network.SubResource{
+					ID: pointer.String(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, ruleProbeName(additionalRule.Name))),
+				}
+			}
+			rules = append(rules, rule)
+		}
+		return rules
 	}
 	return []network.LoadBalancingRule{}
 }
 
 func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
-	return []network.BackendAddressPool{
+	pools := []network.BackendAddressPool{
 		{
 			Name: pointer.String(lbSpec.BackendPoolName),
 		},
 	}
+	if lbSpec.IPv6BackendPoolName != "" {
+		pools = append(pools, network.BackendAddressPool{
+			Name: pointer.String(lbSpec.IPv6BackendPoolName),
+		})
+	}
+	return pools
 }
 
 func getProbes(lbSpec LBSpec) []network.Probe {
 	if lbSpec.Role == infrav1.APIServerRole {
-		return []network.Probe{
+		healthProbe := infrav1.LoadBalancerHealthProbe{
+			RequestPath:       infrav1.DefaultAPIServerProbeRequestPath,
+			IntervalInSeconds: pointer.Int32(infrav1.DefaultAPIServerProbeIntervalInSeconds),
+			NumberOfProbes:    pointer.Int32(infrav1.DefaultAPIServerProbeNumberOfProbes),
+		}
+		if lbSpec.HealthProbe != nil {
+			if lbSpec.HealthProbe.RequestPath != "" {
+				healthProbe.RequestPath = lbSpec.HealthProbe.RequestPath
+			}
+			if lbSpec.HealthProbe.IntervalInSeconds != nil {
+				healthProbe.IntervalInSeconds = lbSpec.HealthProbe.IntervalInSeconds
+			}
+			if lbSpec.HealthProbe.NumberOfProbes != nil {
+				healthProbe.NumberOfProbes = lbSpec.HealthProbe.NumberOfProbes
+			}
+		}
+		probes := []network.Probe{
 			{
 				Name: pointer.String(httpsProbe),
 				ProbePropertiesFormat: &network.ProbePropertiesFormat{
 					Protocol:          network.ProbeProtocolHTTPS,
 					Port:              pointer.Int32(lbSpec.APIServerPort),
-					RequestPath:       pointer.String(httpsProbeRequestPath),
-					IntervalInSeconds: pointer.Int32(15),
-					NumberOfProbes:    pointer.Int32(4),
+					RequestPath:       pointer.String(healthProbe.RequestPath),
+					IntervalInSeconds: healthProbe.IntervalInSeconds,
+					NumberOfProbes:    healthProbe.NumberOfProbes,
 				},
 			},
 		}
+		// UDP rules cannot be probed, TCP rules are probed on their backend port.
+		for _, rule := range lbSpec.AdditionalRules {
+			if rule.Protocol != infrav1.LoadBalancingRuleProtocolTCP {
+				continue
+			}
+			probes = append(probes, network.Probe{
+				Name: pointer.String(ruleProbeName(rule.Name)),
+				ProbePropertiesFormat: &network.ProbePropertiesFormat{
+					Protocol:          network.ProbeProtocolTCP,
+					Port:              pointer.Int32(rule.BackendPort),
+					IntervalInSeconds: healthProbe.IntervalInSeconds,
+					NumberOfProbes:    healthProbe.NumberOfProbes,
+				},
+			})
+		}
+		return probes
 	}
 	return []network.Probe{}
 }
 
-func probeExists(probes []network.Probe, probe network.Probe) bool {
-	for _, p := range probes {
+func ruleProbeName(ruleName string) string {
+	return ruleName + "Probe"
+}
+
+func probeIndex(probes []network.Probe, probe network.Probe) int {
+	for i, p := range probes {
 		if pointer.StringDeref(p.Name, "") == pointer.StringDeref(probe.Name, "") {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
 }
 
-func outboundRuleExists(rules []network.OutboundRule, rule network.OutboundRule) bool {
-	for _, r := range rules {
+func probeEqual(p, probe network.Probe) bool {
+	if p.ProbePropertiesFormat == nil || probe.ProbePropertiesFormat == nil {
+		return p.ProbePropertiesFormat == probe.ProbePropertiesFormat
+	}
+	return p.Protocol == probe.Protocol &&
+		pointer.Int32Equal(p.Port, probe.Port) &&
+		pointer.StringDeref(p.RequestPath, "") == pointer.StringDeref(probe.RequestPath, "") &&
+		pointer.Int32Equal(p.IntervalInSeconds, probe.IntervalInSeconds) &&
+		pointer.Int32Equal(p.NumberOfProbes, probe.NumberOfProbes)
+}
+
+func outboundRuleIndex(rules []network.OutboundRule, rule network.OutboundRule) int {
+	for i, r := range rules {
 		if pointer.StringDeref(r.Name, "") == pointer.StringDeref(rule.Name, "") {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
+}
+
+func outboundRuleEqual(r, rule network.OutboundRule) bool {
+	if r.OutboundRulePropertiesFormat == nil || rule.OutboundRulePropertiesFormat == nil {
+		return r.OutboundRulePropertiesFormat == rule.OutboundRulePropertiesFormat
+	}
+	// No SNAT ports allocation is the default allocation, which Azure may report as 0.
+	if pointer.Int32Deref(r.AllocatedOutboundPorts, 0) != pointer.Int32Deref(rule.AllocatedOutboundPorts, 0) {
+		return false
+	}
+	var frontendIDs, wantedFrontendIDs []network.SubResource
+	if r.FrontendIPConfigurations != nil {
+		frontendIDs = *r.FrontendIPConfigurations
+	}
+	if rule.FrontendIPConfigurations != nil {
+		wantedFrontendIDs = *rule.FrontendIPConfigurations
+	}
+	if len(frontendIDs) != len(wantedFrontendIDs) {
+		return false
+	}
+	for i := range frontendIDs {
+		if !strings.EqualFold(pointer.StringDeref(frontendIDs[i].ID, ""), pointer.StringDeref(wantedFrontendIDs[i].ID, "")) {
+			return false
+		}
+	}
+	return true
 }
 
 func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressPool) bool {
@@ -136,6 +136,172 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "public API load balancer with a custom health probe and additional rules",
+			spec: func() *LBSpec {
+				spec := fakePublicAPILBSpec
+				spec.HealthProbe = &infrav1.LoadBalancerHealthProbe{
+					RequestPath:       "/livez",
+					IntervalInSeconds: pointer.Int32(5),
+					NumberOfProbes:    pointer.Int32(2),
+				}
+				spec.AdditionalRules = []infrav1.LoadBalancingRule{
+					{Name: "konnectivity", Protocol: infrav1.LoadBalancingRuleProtocolTCP, FrontendPort: 8132, BackendPort: 8132},
+					{Name: "registration", Protocol: infrav1.LoadBalancingRuleProtocolUDP, FrontendPort: 9345, BackendPort: 9345},
+				}
+				return &spec
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.Probes).To(HaveLen(2))
+				g.Expect((*lb.Probes)[0].ProbePropertiesFormat).To(Equal(&network.ProbePropertiesFormat{
+					Protocol:          network.ProbeProtocolHTTPS,
+					Port:              pointer.Int32(6443),
+					RequestPath:       pointer.String("/livez"),
+					IntervalInSeconds: pointer.Int32(5),
+					NumberOfProbes:    pointer.Int32(2),
+				}))
+				g.Expect((*lb.Probes)[1].Name).To(Equal(pointer.String("konnectivityProbe")))
+				g.Expect((*lb.Probes)[1].Protocol).To(Equal(network.ProbeProtocolTCP))
+				g.Expect((*lb.Probes)[1].Port).To(Equal(pointer.Int32(8132)))
+				g.Expect(*lb.LoadBalancingRules).To(HaveLen(3))
+				konnectivity := (*lb.LoadBalancingRules)[1]
+				g.Expect(konnectivity.Name).To(Equal(pointer.String("konnectivity")))
+				g.Expect(konnectivity.FrontendPort).To(Equal(pointer.Int32(8132)))
+				g.Expect(konnectivity.FrontendIPConfiguration.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd")))
+				g.Expect(konnectivity.Probe.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/konnectivityProbe")))
+				registration := (*lb.LoadBalancingRules)[2]
+				g.Expect(registration.Protocol).To(Equal(network.TransportProtocolUDP))
+				g.Expect(registration.Probe).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "load balancer exists with an outdated probe and a custom health probe",
+			spec: func() *LBSpec {
+				spec := fakePublicAPILBSpec
+				spec.HealthProbe = &infrav1.LoadBalancerHealthProbe{NumberOfProbes: pointer.Int32(2)}
+				return &spec
+			}(),
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				probes := *result.(network.LoadBalancer).Probes
+				g.Expect(probes).To(HaveLen(1))
+				g.Expect(probes[0].NumberOfProbes).To(Equal(pointer.Int32(2)))
+				g.Expect(probes[0].IntervalInSeconds).To(Equal(pointer.Int32(15)))
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists with outdated allocated outbound ports",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.FrontendIPConfigs = append(spec.FrontendIPConfigs, infrav1.FrontendIP{
+					Name:     "my-cluster-frontEnd-2",
+					PublicIP: &infrav1.PublicIPSpec{Name: "outbound-publicip-2"},
+				})
+				spec.AllocatedOutboundPorts = pointer.Int32(2048)
+				return &spec
+			}(),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.FrontendIPConfigurations).To(HaveLen(2))
+				g.Expect(*lb.OutboundRules).To(HaveLen(1))
+				rule := (*lb.OutboundRules)[0]
+				g.Expect(rule.AllocatedOutboundPorts).To(Equal(pointer.Int32(2048)))
+				g.Expect(*rule.FrontendIPConfigurations).To(Equal([]network.SubResource{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd")},
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd-2")},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists with expected allocated outbound ports",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.AllocatedOutboundPorts = pointer.Int32(1024)
+				return &spec
+			}(),
+			existing: func() network.LoadBalancer {
+				lb := newDefaultNodeOutboundLB()
+				(*lb.OutboundRules)[0].AllocatedOutboundPorts = pointer.Int32(1024)
+				return lb
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists without the IPv6 frontend IPs",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.IPv6FrontendIPConfigs = []infrav1.FrontendIP{
+					{Name: "my-cluster-frontEnd-ipv6", PublicIP: &infrav1.PublicIPSpec{Name: "outbound-publicip-ipv6"}},
+				}
+				spec.IPv6BackendPoolName = "my-cluster-outboundBackendPool-ipv6"
+				return &spec
+			}(),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.FrontendIPConfigurations).To(HaveLen(2))
+				g.Expect((*lb.FrontendIPConfigurations)[1].PublicIPAddress.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/outbound-publicip-ipv6")))
+				g.Expect(*lb.BackendAddressPools).To(HaveLen(2))
+				g.Expect((*lb.BackendAddressPools)[1].Name).To(Equal(pointer.String("my-cluster-outboundBackendPool-ipv6")))
+				g.Expect(*lb.OutboundRules).To(HaveLen(2))
+				rule := (*lb.OutboundRules)[1]
+				g.Expect(rule.Name).To(Equal(pointer.String("OutboundNATAllProtocols-ipv6")))
+				g.Expect(rule.AllocatedOutboundPorts).To(BeNil())
+				g.Expect(*rule.FrontendIPConfigurations).To(Equal([]network.SubResource{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd-ipv6")},
+				}))
+				g.Expect(rule.BackendAddressPool.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/backendAddressPools/my-cluster-outboundBackendPool-ipv6")))
+				// The IPv4 outbound rule keeps the IPv4 frontend IPs only.
+				g.Expect(*(*lb.OutboundRules)[0].FrontendIPConfigurations).To(HaveLen(1))
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists with the IPv6 frontend IPs",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.IPv6FrontendIPConfigs = []infrav1.FrontendIP{
+					{Name: "my-cluster-frontEnd-ipv6", PublicIP: &infrav1.PublicIPSpec{Name: "outbound-publicip-ipv6"}},
+				}
+				spec.IPv6BackendPoolName = "my-cluster-outboundBackendPool-ipv6"
+				return &spec
+			}(),
+			existing: func() network.LoadBalancer {
+				lb := newDefaultNodeOutboundLB()
+				*lb.FrontendIPConfigurations = append(*lb.FrontendIPConfigurations, network.FrontendIPConfiguration{
+					Name: pointer.String("my-cluster-frontEnd-ipv6"),
+				})
+				*lb.BackendAddressPools = append(*lb.BackendAddressPools, network.BackendAddressPool{
+					Name: pointer.String("my-cluster-outboundBackendPool-ipv6"),
+				})
+				*lb.OutboundRules = append(*lb.OutboundRules, network.OutboundRule{
+					Name: pointer.String("OutboundNATAllProtocols-ipv6"),
+					OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+						AllocatedOutboundPorts: pointer.Int32(0),
+						FrontendIPConfigurations: &[]network.SubResource{
+							{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd-ipv6")},
+						},
+					},
+				})
+				return lb
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 		{
 			name:     "load balancer exists with missing outbound rules",
 			spec:     &fakePublicAPILBSpec,
@@ -287,7 +453,7 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 					ProbePropertiesFormat: &network.ProbePropertiesFormat{
 						Protocol:          network.ProbeProtocolHTTPS,
 						Port:              pointer.Int32(6443),
-						RequestPath:       pointer.String(httpsProbeRequestPath),
+						RequestPath:       pointer.String(infrav1.DefaultAPIServerProbeRequestPath),
 						IntervalInSeconds: pointer.Int32(15),
 						NumberOfProbes:    numProbes, // Add to verify that Probes aren't overwritten on update
 					},
@@ -368,7 +534,7 @@ func newDefaultInternalAPIServerLB() network.LoadBalancer {
 					ProbePropertiesFormat: &network.ProbePropertiesFormat{
 						Protocol:          network.ProbeProtocolHTTPS,
 						Port:              pointer.Int32(6443),
-						RequestPath:       pointer.String(httpsProbeRequestPath),
+						RequestPath:       pointer.String(infrav1.DefaultAPIServerProbeRequestPath),
 						IntervalInSeconds: pointer.Int32(15),
 						NumberOfProbes:    pointer.Int32(4),
 					},
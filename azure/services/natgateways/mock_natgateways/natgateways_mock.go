@@ -23,6 +23,7 @@ package mock_natgateways
 import (
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -109,6 +110,20 @@ func (mr *MockNatGatewayScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockNatGatewayScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNatGatewayScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNatGatewayScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNatGatewayScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockNatGatewayScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -443,6 +458,20 @@ func (mr *MockNatGatewayScopeMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockNatGatewayScope)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockNatGatewayScope) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockNatGatewayScopeMockRecorder) OutboundIPv6PoolName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockNatGatewayScope)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockNatGatewayScope) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -471,6 +500,34 @@ func (mr *MockNatGatewayScopeMockRecorder) OutboundPoolName(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockNatGatewayScope)(nil).OutboundPoolName), arg0)
 }
 
+// PrivateDNSNodeRecordsZoneID mocks base method.
+func (m *MockNatGatewayScope) PrivateDNSNodeRecordsZoneID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSNodeRecordsZoneID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PrivateDNSNodeRecordsZoneID indicates an expected call of PrivateDNSNodeRecordsZoneID.
+func (mr *MockNatGatewayScopeMockRecorder) PrivateDNSNodeRecordsZoneID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSNodeRecordsZoneID", reflect.TypeOf((*MockNatGatewayScope)(nil).PrivateDNSNodeRecordsZoneID))
+}
+
+// PublicIPPrefixID mocks base method.
+func (m *MockNatGatewayScope) PublicIPPrefixID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicIPPrefixID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PublicIPPrefixID indicates an expected call of PublicIPPrefixID.
+func (mr *MockNatGatewayScopeMockRecorder) PublicIPPrefixID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPPrefixID", reflect.TypeOf((*MockNatGatewayScope)(nil).PublicIPPrefixID))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockNatGatewayScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -577,6 +634,20 @@ func (mr *MockNatGatewayScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockNatGatewayScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockNatGatewayScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockNatGatewayScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockNatGatewayScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockNatGatewayScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
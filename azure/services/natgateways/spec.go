@@ -34,6 +34,7 @@ type NatGatewaySpec struct {
 	SubscriptionID string
 	Location       string
 	NatGatewayIP   infrav1.PublicIPSpec
+	Zone           string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
 }
@@ -86,6 +87,10 @@ func (s *NatGatewaySpec) Parameters(ctx context.Context, existing interface{}) (
 		})),
 	}
 
+	if s.Zone != "" {
+		natGatewayToCreate.Zones = &[]string{s.Zone}
+	}
+
 	return natGatewayToCreate, nil
 }
 
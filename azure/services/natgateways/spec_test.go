@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natgateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *NatGatewaySpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "NAT gateway does not exist",
+			spec: &NatGatewaySpec{
+				Name:           "my-natgw",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				Location:       "westus",
+				ClusterName:    "my-cluster",
+				NatGatewayIP:   infrav1.PublicIPSpec{Name: "pip-my-natgw"},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				natGateway := result.(network.NatGateway)
+				g.Expect(natGateway.Zones).To(BeNil())
+				g.Expect(*natGateway.PublicIPAddresses).To(Equal([]network.SubResource{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-natgw")},
+				}))
+			},
+		},
+		{
+			name: "zonal NAT gateway does not exist",
+			spec: &NatGatewaySpec{
+				Name:           "my-natgw-1",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				Location:       "westus",
+				ClusterName:    "my-cluster",
+				NatGatewayIP:   infrav1.PublicIPSpec{Name: "pip-my-natgw-1"},
+				Zone:           "1",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).Zones).To(Equal(&[]string{"1"}))
+			},
+		},
+		{
+			name: "NAT gateway already exists with the public IP",
+			spec: &NatGatewaySpec{
+				Name:         "my-natgw",
+				NatGatewayIP: infrav1.PublicIPSpec{Name: "pip-my-natgw"},
+			},
+			existing: network.NatGateway{
+				Name: pointer.String("my-natgw"),
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses: &[]network.SubResource{
+						{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-natgw")},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing is not a NAT gateway",
+			spec: &NatGatewaySpec{
+				Name: "my-natgw",
+			},
+			existing:      network.RouteTable{},
+			expectedError: "network.RouteTable is not a network.NatGateway",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
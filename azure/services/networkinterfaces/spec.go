@@ -31,30 +31,32 @@ import (
 
 // NICSpec defines the specification for a Network Interface.
 type NICSpec struct {
-	Name                      string
-	ResourceGroup             string
-	Location                  string
-	ExtendedLocation          *infrav1.ExtendedLocationSpec
-	SubscriptionID            string
-	MachineName               string
-	SubnetName                string
-	VNetName                  string
-	VNetResourceGroup         string
-	StaticIPAddress           string
-	PublicLBName              string
-	PublicLBAddressPoolName   string
-	PublicLBNATRuleName       string
-	InternalLBName            string
-	InternalLBAddressPoolName string
-	PublicIPName              string
-	AcceleratedNetworking     *bool
-	IPv6Enabled               bool
-	EnableIPForwarding        bool
-	SKU                       *resourceskus.SKU
-	DNSServers                []string
-	AdditionalTags            infrav1.Tags
-	ClusterName               string
-	IPConfigs                 []IPConfig
+	Name                        string
+	ResourceGroup               string
+	Location                    string
+	ExtendedLocation            *infrav1.ExtendedLocationSpec
+	SubscriptionID              string
+	MachineName                 string
+	SubnetName                  string
+	VNetName                    string
+	VNetResourceGroup           string
+	StaticIPAddress             string
+	PublicLBName                string
+	PublicLBAddressPoolName     string
+	PublicLBIPv6AddressPoolName string
+	PublicLBNATRuleName         string
+	InternalLBName              string
+	InternalLBAddressPoolName   string
+	PublicIPName                string
+	AcceleratedNetworking       *bool
+	IPv6Enabled                 bool
+	EnableIPForwarding          bool
+	SKU                         *resourceskus.SKU
+	DNSServers                  []string
+	AdditionalTags              infrav1.Tags
+	ClusterName                 string
+	IPConfigs                   []IPConfig
+	ApplicationSecurityGroups   []string
 }
 
 // IPConfig defines the specification for an IP address configuration.
@@ -197,10 +199,30 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 				Subnet:                  &network.Subnet{ID: subnet.ID},
 			},
 		}
+		if s.PublicLBName != "" && s.PublicLBIPv6AddressPoolName != "" {
+			ipv6Config.LoadBalancerBackendAddressPools = &[]network.BackendAddressPool{
+				{
+					ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBIPv6AddressPoolName)),
+				},
+			}
+		}
 
 		ipConfigurations = append(ipConfigurations, ipv6Config)
 	}
 
+	if len(s.ApplicationSecurityGroups) > 0 {
+		// All the IP configurations of a network interface must be associated with the same application security groups.
+		asgs := make([]network.ApplicationSecurityGroup, len(s.ApplicationSecurityGroups))
+		for i, name := range s.ApplicationSecurityGroups {
+			asgs[i] = network.ApplicationSecurityGroup{
+				ID: pointer.String(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+			}
+		}
+		for i := range ipConfigurations {
+			ipConfigurations[i].ApplicationSecurityGroups = &asgs
+		}
+	}
+
 	return network.Interface{
 		Location:         pointer.String(s.Location),
 		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
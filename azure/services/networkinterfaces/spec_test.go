@@ -162,6 +162,20 @@ var (
 		ClusterName:           "my-cluster",
 	}
 
+	fakeApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                      "my-net-interface",
+		ResourceGroup:             "my-rg",
+		Location:                  "fake-location",
+		SubscriptionID:            "123",
+		MachineName:               "azure-test1",
+		SubnetName:                "my-subnet",
+		VNetName:                  "my-vnet",
+		IPv6Enabled:               true,
+		VNetResourceGroup:         "my-rg",
+		AcceleratedNetworking:     pointer.Bool(false),
+		ClusterName:               "my-cluster",
+		ApplicationSecurityGroups: []string{"node", "web"},
+	}
 	fakeControlPlaneCustomDNSSettingsNICSpec = NICSpec{
 		Name:                      "my-net-interface",
 		ResourceGroup:             "my-rg",
@@ -478,6 +492,29 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get parameters for network interface ipv6 with an IPv6 outbound backend pool",
+			spec: func() *NICSpec {
+				spec := fakeIpv6NICSpec
+				spec.PublicLBAddressPoolName = "my-public-lb-outboundBackendPool"
+				spec.PublicLBIPv6AddressPoolName = "my-public-lb-outboundBackendPool-ipv6"
+				return &spec
+			}(),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				ipConfigs := *result.(network.Interface).IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				g.Expect(*ipConfigs[0].LoadBalancerBackendAddressPools).To(Equal([]network.BackendAddressPool{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-outboundBackendPool")},
+				}))
+				g.Expect(ipConfigs[1].PrivateIPAddressVersion).To(Equal(network.IPVersion("IPv6")))
+				g.Expect(*ipConfigs[1].LoadBalancerBackendAddressPools).To(Equal([]network.BackendAddressPool{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-outboundBackendPool-ipv6")},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface default ipconfig",
 			spec:     &fakeDefaultIPconfigNICSpec,
@@ -668,6 +705,23 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for network interface associated with application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				ipConfigs := *result.(network.Interface).IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				for _, ipConfig := range ipConfigs {
+					g.Expect(*ipConfig.ApplicationSecurityGroups).To(Equal([]network.ApplicationSecurityGroup{
+						{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/node")},
+						{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web")},
+					}))
+				}
+			},
+			expectedError: "",
+		},
 	}
 	format.MaxLength = 10000
 	for _, tc := range testcases {
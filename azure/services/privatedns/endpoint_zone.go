@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatedns
+
+import (
+	"context"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// EndpointZoneServiceName is the name of the service reconciling the private DNS zones of private endpoints.
+const EndpointZoneServiceName = "privateendpointdns"
+
+// EndpointZoneScope defines the scope interface for the private DNS zones of private endpoints.
+type EndpointZoneScope interface {
+	azure.ClusterDescriber
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	PrivateEndpointDNSZoneSpecs() (zonesSpec, linksSpec []azure.ResourceSpecGetter)
+}
+
+// EndpointZoneService provides operations on the private DNS zones of private endpoints.
+type EndpointZoneService struct {
+	Scope              EndpointZoneScope
+	TagsGetter         async.TagsGetter
+	zoneReconciler     async.Reconciler
+	vnetLinkReconciler async.Reconciler
+}
+
+// NewEndpointZoneService creates a new service for the private DNS zones of private endpoints.
+func NewEndpointZoneService(scope EndpointZoneScope) *EndpointZoneService {
+	zoneClient := newPrivateZonesClient(scope)
+	vnetLinkClient := newVirtualNetworkLinksClient(scope)
+	return &EndpointZoneService{
+		Scope:              scope,
+		TagsGetter:         tags.NewClient(scope),
+		zoneReconciler:     async.New(scope, zoneClient, zoneClient),
+		vnetLinkReconciler: async.New(scope, vnetLinkClient, vnetLinkClient),
+	}
+}
+
+// Name returns the service name.
+func (s *EndpointZoneService) Name() string {
+	return EndpointZoneServiceName
+}
+
+// Reconcile creates the private DNS zones of the private endpoints, unless they already exist, and links them to the vnet.
+func (s *EndpointZoneService) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.EndpointZoneService.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	zones, links := s.Scope.PrivateEndpointDNSZoneSpecs()
+	if len(zones) == 0 {
+		return nil
+	}
+
+	// We go through the list of zones to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var result error
+	for _, zoneSpec := range zones {
+		zoneLinks := linksOfZone(zoneSpec, links)
+		svc := s.zoneService(zoneSpec, zoneLinks)
+		_, err := svc.reconcileZone(ctx, zoneSpec)
+		if err == nil {
+			_, err = svc.reconcileLinks(ctx, zoneLinks)
+		}
+		if err != nil && (!azure.IsOperationNotDoneError(err) || result == nil) {
+			result = err
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, result)
+	return result
+}
+
+// Delete deletes the vnet links and the private DNS zones of the private endpoints that are owned by the cluster.
+func (s *EndpointZoneService) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.EndpointZoneService.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	zones, links := s.Scope.PrivateEndpointDNSZoneSpecs()
+	if len(zones) == 0 {
+		return nil
+	}
+
+	// We go through the list of zones to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var result error
+	for _, zoneSpec := range zones {
+		zoneLinks := linksOfZone(zoneSpec, links)
+		svc := s.zoneService(zoneSpec, zoneLinks)
+		_, err := svc.deleteLinks(ctx, zoneLinks)
+		if err == nil && !isExistingZone(zoneSpec) {
+			_, err = svc.deleteZone(ctx, zoneSpec)
+		}
+		if err != nil && (!azure.IsOperationNotDoneError(err) || result == nil) {
+			result = err
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, result)
+	return result
+}
+
+// IsManaged returns always returns true as the ownership of each private DNS zone and vnet link is checked during reconciliation.
+func (s *EndpointZoneService) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// zoneService returns a private DNS service scoped to a single private DNS zone of the private endpoints,
+// so that the zone and its links are reconciled the same way as the private DNS zone of the cluster.
+func (s *EndpointZoneService) zoneService(zoneSpec azure.ResourceSpecGetter, links []azure.ResourceSpecGetter) *Service {
+	return &Service{
+		name:               EndpointZoneServiceName,
+		Scope:              &endpointZoneScope{EndpointZoneScope: s.Scope, zoneSpec: zoneSpec, linksSpec: links},
+		TagsGetter:         s.TagsGetter,
+		zoneReconciler:     s.zoneReconciler,
+		vnetLinkReconciler: s.vnetLinkReconciler,
+	}
+}
+
+// linksOfZone returns the vnet links of a private DNS zone.
+func linksOfZone(zoneSpec azure.ResourceSpecGetter, links []azure.ResourceSpecGetter) []azure.ResourceSpecGetter {
+	zoneLinks := make([]azure.ResourceSpecGetter, 0, 1)
+	for _, link := range links {
+		if link.OwnerResourceName() == zoneSpec.ResourceName() {
+			zoneLinks = append(zoneLinks, link)
+		}
+	}
+	return zoneLinks
+}
+
+// endpointZoneScope exposes a single private DNS zone of the private endpoints as a private DNS Scope.
+type endpointZoneScope struct {
+	EndpointZoneScope
+	zoneSpec  azure.ResourceSpecGetter
+	linksSpec []azure.ResourceSpecGetter
+}
+
+// PrivateDNSSpec returns the private DNS zone and its vnet links.
+func (s *endpointZoneScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linksSpec, recordsSpec []azure.ResourceSpecGetter) {
+	return s.zoneSpec, s.linksSpec, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatedns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns/mock_privatedns"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeEndpointZone = ZoneSpec{
+		Name:          "privatelink.blob.core.windows.net",
+		ResourceGroup: resourceGroup,
+		ClusterName:   clusterName,
+	}
+
+	fakeEndpointZoneLink = LinkSpec{
+		Name:              linkName1,
+		ZoneName:          "privatelink.blob.core.windows.net",
+		SubscriptionID:    subscriptionID,
+		VNetResourceGroup: vnetResourceGroup,
+		VNetName:          vnetName,
+		ResourceGroup:     resourceGroup,
+		ClusterName:       clusterName,
+	}
+)
+
+func TestReconcileEndpointZones(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder)
+	}{
+		{
+			name:          "no private endpoint DNS zones",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return(nil, nil)
+			},
+		},
+		{
+			name:          "create private endpoint DNS zone and link",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeEndpointZone}, []azure.ResourceSpecGetter{fakeEndpointZoneLink})
+				s.SubscriptionID().Return("123").Times(2)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", resourceGroup, fakeEndpointZone.Name)).Return(resources.TagsResource{}, notFoundError)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", resourceGroup, fakeEndpointZone.Name, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeEndpointZone, EndpointZoneServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeEndpointZoneLink, EndpointZoneServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, nil)
+			},
+		},
+		{
+			name:          "reuse a private endpoint DNS zone that is not owned by the cluster",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeEndpointZone}, []azure.ResourceSpecGetter{fakeEndpointZoneLink})
+				s.SubscriptionID().Return("123").Times(2)
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", resourceGroup, fakeEndpointZone.Name)).Return(resources.TagsResource{}, nil)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", resourceGroup, fakeEndpointZone.Name, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeEndpointZoneLink, EndpointZoneServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, nil)
+			},
+		},
+		{
+			name:          "link an existing private endpoint DNS zone",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeExistingZone}, []azure.ResourceSpecGetter{fakeExistingZoneLink})
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("dns-sub", "dns-rg", zoneName)).Return(resources.TagsResource{}, nil)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingZoneLink, EndpointZoneServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, nil)
+			},
+		},
+		{
+			name:          "zone creation fails while the other zones are reconciled",
+			expectedError: "this is an error",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeEndpointZone, fakeExistingZone}, []azure.ResourceSpecGetter{fakeEndpointZoneLink, fakeExistingZoneLink})
+				s.SubscriptionID().Return("123")
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", resourceGroup, fakeEndpointZone.Name)).Return(resources.TagsResource{}, notFoundError)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeEndpointZone, EndpointZoneServiceName).Return(nil, errFake)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("dns-sub", "dns-rg", zoneName)).Return(resources.TagsResource{}, nil)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingZoneLink, EndpointZoneServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockEndpointZoneScope(mockCtrl)
+			zoneReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			vnetLinkReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), zoneReconcilerMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
+
+			s := &EndpointZoneService{
+				Scope:              scopeMock,
+				zoneReconciler:     zoneReconcilerMock,
+				vnetLinkReconciler: vnetLinkReconcilerMock,
+				TagsGetter:         tagsGetterMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteEndpointZones(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder)
+	}{
+		{
+			name:          "no private endpoint DNS zones",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return(nil, nil)
+			},
+		},
+		{
+			name:          "delete owned private endpoint DNS zone and link",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeEndpointZone}, []azure.ResourceSpecGetter{fakeEndpointZoneLink})
+				s.SubscriptionID().Return("123").Times(2)
+				s.ClusterName().Return(clusterName).Times(2)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", resourceGroup, fakeEndpointZone.Name, linkName1)).Return(managedTags, nil)
+				l.DeleteResource(gomockinternal.AContext(), fakeEndpointZoneLink, EndpointZoneServiceName).Return(nil)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", resourceGroup, fakeEndpointZone.Name)).Return(managedTags, nil)
+				z.DeleteResource(gomockinternal.AContext(), fakeEndpointZone, EndpointZoneServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, nil)
+			},
+		},
+		{
+			name:          "keep a reused private endpoint DNS zone that is not owned by the cluster",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeEndpointZone}, []azure.ResourceSpecGetter{fakeEndpointZoneLink})
+				s.SubscriptionID().Return("123").Times(2)
+				s.ClusterName().Return(clusterName).Times(2)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", resourceGroup, fakeEndpointZone.Name, linkName1)).Return(managedTags, nil)
+				l.DeleteResource(gomockinternal.AContext(), fakeEndpointZoneLink, EndpointZoneServiceName).Return(nil)
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", resourceGroup, fakeEndpointZone.Name)).Return(resources.TagsResource{}, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, nil)
+			},
+		},
+		{
+			name:          "only delete the link of an existing private endpoint DNS zone",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeExistingZone}, []azure.ResourceSpecGetter{fakeExistingZoneLink})
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(managedTags, nil)
+				l.DeleteResource(gomockinternal.AContext(), fakeExistingZoneLink, EndpointZoneServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, nil)
+			},
+		},
+		{
+			name:          "zone is not deleted while its link is being deleted",
+			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
+			expect: func(s *mock_privatedns.MockEndpointZoneScopeMockRecorder, z, l *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateEndpointDNSZoneSpecs().Return([]azure.ResourceSpecGetter{fakeEndpointZone}, []azure.ResourceSpecGetter{fakeEndpointZoneLink})
+				s.SubscriptionID().Return("123")
+				s.ClusterName().Return(clusterName)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", resourceGroup, fakeEndpointZone.Name, linkName1)).Return(managedTags, nil)
+				l.DeleteResource(gomockinternal.AContext(), fakeEndpointZoneLink, EndpointZoneServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointDNSZonesReadyCondition, EndpointZoneServiceName, notDoneError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockEndpointZoneScope(mockCtrl)
+			zoneReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			vnetLinkReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), zoneReconcilerMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
+
+			s := &EndpointZoneService{
+				Scope:              scopeMock,
+				zoneReconciler:     zoneReconcilerMock,
+				vnetLinkReconciler: vnetLinkReconcilerMock,
+				TagsGetter:         tagsGetterMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 
 // azureVirtualNetworkLinksClient contains the Azure go-sdk Client for virtual network links.
 type azureVirtualNetworkLinksClient struct {
+	auth      azure.Authorizer
 	vnetlinks privatedns.VirtualNetworkLinksClient
 }
 
@@ -39,10 +40,22 @@ func newVirtualNetworkLinksClient(auth azure.Authorizer) *azureVirtualNetworkLin
 	linksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(auth.BaseURI(), auth.SubscriptionID())
 	azure.SetAutoRestClientDefaults(&linksClient.Client, auth.Authorizer())
 	return &azureVirtualNetworkLinksClient{
+		auth:      auth,
 		vnetlinks: linksClient,
 	}
 }
 
+// linksClient returns the virtual network links client for the subscription of the zone of the link.
+func (avc *azureVirtualNetworkLinksClient) linksClient(spec azure.ResourceSpecGetter) privatedns.VirtualNetworkLinksClient {
+	link, ok := spec.(LinkSpec)
+	if !ok || link.ZoneSubscriptionID == "" || link.ZoneSubscriptionID == avc.vnetlinks.SubscriptionID {
+		return avc.vnetlinks
+	}
+	linksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(avc.auth.BaseURI(), link.ZoneSubscriptionID)
+	azure.SetAutoRestClientDefaults(&linksClient.Client, avc.auth.Authorizer())
+	return linksClient
+}
+
 // CreateOrUpdateAsync creates or updates a virtual network link asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
@@ -55,7 +68,8 @@ func (avc *azureVirtualNetworkLinksClient) CreateOrUpdateAsync(ctx context.Conte
 		return nil, nil, errors.Errorf("%T is not a privatedns.VirtualNetworkLink", parameters)
 	}
 
-	createFuture, err := avc.vnetlinks.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), link, "", "")
+	linksClient := avc.linksClient(spec)
+	createFuture, err := linksClient.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), link, "", "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -63,13 +77,13 @@ func (avc *azureVirtualNetworkLinksClient) CreateOrUpdateAsync(ctx context.Conte
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = createFuture.WaitForCompletionRef(ctx, avc.vnetlinks.Client)
+	err = createFuture.WaitForCompletionRef(ctx, linksClient.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
 		return nil, &createFuture, err
 	}
-	result, err = createFuture.Result(avc.vnetlinks)
+	result, err = createFuture.Result(linksClient)
 	// if the operation completed, return a nil future
 	return result, nil, err
 }
@@ -78,7 +92,7 @@ func (avc *azureVirtualNetworkLinksClient) CreateOrUpdateAsync(ctx context.Conte
 func (avc *azureVirtualNetworkLinksClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureVirtualNetworkLinksClient.Get")
 	defer done()
-	link, err := avc.vnetlinks.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	link, err := avc.linksClient(spec).Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 	if err != nil {
 		return privatedns.VirtualNetworkLink{}, err
 	}
@@ -92,7 +106,8 @@ func (avc *azureVirtualNetworkLinksClient) DeleteAsync(ctx context.Context, spec
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureVirtualNetworkLinksClient.DeleteAsync")
 	defer done()
 
-	deleteFuture, err := avc.vnetlinks.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), "")
+	linksClient := avc.linksClient(spec)
+	deleteFuture, err := linksClient.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), "")
 	if err != nil {
 		return nil, err
 	}
@@ -100,13 +115,13 @@ func (avc *azureVirtualNetworkLinksClient) DeleteAsync(ctx context.Context, spec
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = deleteFuture.WaitForCompletionRef(ctx, avc.vnetlinks.Client)
+	err = deleteFuture.WaitForCompletionRef(ctx, linksClient.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
 		return &deleteFuture, err
 	}
-	_, err = deleteFuture.Result(avc.vnetlinks)
+	_, err = deleteFuture.Result(linksClient)
 	// if the operation completed, return a nil future.
 	return nil, err
 }
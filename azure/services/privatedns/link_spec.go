@@ -37,6 +37,9 @@ type LinkSpec struct {
 	ResourceGroup     string
 	ClusterName       string
 	AdditionalTags    infrav1.Tags
+
+	// ZoneSubscriptionID is the subscription of the zone, when it is not the subscription of the cluster.
+	ZoneSubscriptionID string
 }
 
 // ResourceName returns the name of the virtual network link.
@@ -18,4 +18,8 @@ limitations under the License.
 //
 //go:generate ../../../../hack/tools/bin/mockgen -destination privatedns_mock.go -package mock_privatedns -source ../privatedns.go Scope
 //go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt privatedns_mock.go > _privatedns_mock.go && mv _privatedns_mock.go privatedns_mock.go"
+//go:generate ../../../../hack/tools/bin/mockgen -destination node_record_mock.go -package mock_privatedns -source ../node_record.go NodeRecordScope
+//go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt node_record_mock.go > _node_record_mock.go && mv _node_record_mock.go node_record_mock.go"
+//go:generate ../../../../hack/tools/bin/mockgen -destination endpoint_zone_mock.go -package mock_privatedns -source ../endpoint_zone.go EndpointZoneScope
+//go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt endpoint_zone_mock.go > _endpoint_zone_mock.go && mv _endpoint_zone_mock.go endpoint_zone_mock.go"
 package mock_privatedns
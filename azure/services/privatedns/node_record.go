@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatedns
+
+import (
+	"context"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const nodeRecordServiceName = "privatednsnoderecord"
+
+// NodeRecordScope defines the scope interface for the private DNS record of a machine.
+type NodeRecordScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	PrivateDNSNodeRecordSpec() azure.ResourceSpecGetter
+}
+
+// NodeRecordService provides operations on the record of a machine in the private DNS zone of its cluster.
+type NodeRecordService struct {
+	Scope NodeRecordScope
+	async.Reconciler
+	async.Getter
+}
+
+// NewNodeRecordService creates a new service maintaining the private DNS record of a machine.
+func NewNodeRecordService(scope NodeRecordScope) *NodeRecordService {
+	recordSetsClient := newRecordSetsClient(scope)
+	return &NodeRecordService{
+		Scope:      scope,
+		Reconciler: async.New(scope, recordSetsClient, recordSetsClient),
+		Getter:     recordSetsClient,
+	}
+}
+
+// Name returns the service name.
+func (s *NodeRecordService) Name() string {
+	return nodeRecordServiceName
+}
+
+// Reconcile idempotently creates or updates the record of the machine.
+func (s *NodeRecordService) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.NodeRecordService.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.PrivateDNSNodeRecordSpec()
+	if spec == nil {
+		return nil
+	}
+
+	_, err := s.CreateOrUpdateResource(ctx, spec, nodeRecordServiceName)
+	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, err)
+	return err
+}
+
+// Delete deletes the record of the machine if it was created by CAPZ.
+func (s *NodeRecordService) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.NodeRecordService.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.PrivateDNSNodeRecordSpec()
+	if spec == nil {
+		return nil
+	}
+
+	err := deleteOwnedRecord(ctx, s.Getter, s.Reconciler, spec, nodeRecordServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, err)
+	return err
+}
+
+// IsManaged returns always returns true as CAPZ only manages the record it created for the machine.
+func (s *NodeRecordService) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatedns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns/mock_privatedns"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var fakeNodeRecord = RecordSpec{
+	Record:         infrav1.AddressRecord{Hostname: "my-node", IP: "10.1.0.4"},
+	ZoneName:       zoneName,
+	ResourceGroup:  "dns-rg",
+	SubscriptionID: "dns-sub",
+	ClusterName:    clusterName,
+}
+
+func TestReconcileNodeRecord(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if the machine has no node record",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create the node record",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(fakeNodeRecord)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeNodeRecord, nodeRecordServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to create the node record",
+			expectedError: "this is an error",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(fakeNodeRecord)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeNodeRecord, nodeRecordServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockNodeRecordScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &NodeRecordService{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteNodeRecord(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name:          "noop if the machine has no node record",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, _ *mock_async.MockGetterMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete the node record owned by the cluster",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(fakeNodeRecord)
+				g.Get(gomockinternal.AContext(), fakeNodeRecord).Return(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{Metadata: managedTags.Properties.Tags},
+				}, nil)
+				r.DeleteResource(gomockinternal.AContext(), fakeNodeRecord, nodeRecordServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, nil)
+			},
+		},
+		{
+			name:          "node record not owned by the cluster is not deleted",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(fakeNodeRecord)
+				g.Get(gomockinternal.AContext(), fakeNodeRecord).Return(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{},
+				}, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, nil)
+			},
+		},
+		{
+			name:          "node record already deleted",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockNodeRecordScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.PrivateDNSNodeRecordSpec().Return(fakeNodeRecord)
+				g.Get(gomockinternal.AContext(), fakeNodeRecord).Return(nil, notFoundError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, nodeRecordServiceName, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockNodeRecordScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT(), getterMock.EXPECT())
+
+			s := &NodeRecordService{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+				Getter:     getterMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -29,7 +29,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "privatedns"
+// ServiceName is the name of this service.
+const ServiceName = "privatedns"
 
 // Scope defines the scope interface for a private dns service.
 type Scope interface {
@@ -41,11 +42,13 @@ type Scope interface {
 
 // Service provides operations on Azure resources.
 type Service struct {
+	name               string
 	Scope              Scope
 	TagsGetter         async.TagsGetter
 	zoneReconciler     async.Reconciler
 	vnetLinkReconciler async.Reconciler
 	recordReconciler   async.Reconciler
+	recordGetter       async.Getter
 }
 
 // New creates a new private dns service.
@@ -55,17 +58,19 @@ func New(scope Scope) *Service {
 	recordSetsClient := newRecordSetsClient(scope)
 	tagsClient := tags.NewClient(scope)
 	return &Service{
+		name:               ServiceName,
 		Scope:              scope,
 		TagsGetter:         tagsClient,
 		zoneReconciler:     async.New(scope, zoneClient, zoneClient),
 		vnetLinkReconciler: async.New(scope, vnetLinkClient, vnetLinkClient),
 		recordReconciler:   async.New(scope, recordSetsClient, recordSetsClient),
+		recordGetter:       recordSetsClient,
 	}
 }
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return s.name
 }
 
 // Reconcile creates or updates the private zone, links it to the vnet, and creates DNS records.
@@ -83,7 +88,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	managed, err := s.reconcileZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
@@ -91,14 +96,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	managed, err = s.reconcileLinks(ctx, links)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
 	}
 
 	err = s.reconcileRecords(ctx, records)
-	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	return err
 }
 
@@ -110,23 +115,30 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
-	zoneSpec, links, _ := s.Scope.PrivateDNSSpec()
+	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
 	if zoneSpec == nil {
 		return nil
 	}
 
 	managed, err := s.deleteLinks(ctx, links)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
 	}
 
+	if isExistingZone(zoneSpec) {
+		// The records of an existing zone outlive the cluster, so the records created for the cluster are deleted one by one.
+		err = s.deleteRecords(ctx, records)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
+		return err
+	}
+
 	managed, err = s.deleteZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	}
 
 	return err
@@ -135,7 +147,7 @@ func (s *Service) Delete(ctx context.Context) error {
 // isVnetLinkManaged returns true if the vnet link has an owned tag with the cluster name as value,
 // meaning that the vnet link lifecycle is managed.
 func (s *Service) isVnetLinkManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
-	scope := azure.VirtualNetworkLinkID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	scope := azure.VirtualNetworkLinkID(s.zoneSubscriptionID(spec), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 	result, err := s.TagsGetter.GetAtScope(ctx, scope)
 	if err != nil {
 		return false, err
@@ -158,7 +170,7 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 		return false, errors.Errorf("no private dns zone spec available")
 	}
 
-	scope := azure.PrivateDNSZoneID(s.Scope.SubscriptionID(), zoneSpec.ResourceGroupName(), zoneSpec.ResourceName())
+	scope := azure.PrivateDNSZoneID(s.zoneSubscriptionID(zoneSpec), zoneSpec.ResourceGroupName(), zoneSpec.ResourceName())
 	result, err := s.TagsGetter.GetAtScope(ctx, scope)
 	if err != nil {
 		return false, err
@@ -172,3 +184,24 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	tags := converters.MapToTags(tagsMap)
 	return tags.HasOwned(s.Scope.ClusterName()), nil
 }
+
+// zoneSubscriptionID returns the subscription of the private DNS zone of a zone or vnet link spec.
+func (s *Service) zoneSubscriptionID(spec azure.ResourceSpecGetter) string {
+	switch spec := spec.(type) {
+	case ZoneSpec:
+		if spec.SubscriptionID != "" {
+			return spec.SubscriptionID
+		}
+	case LinkSpec:
+		if spec.ZoneSubscriptionID != "" {
+			return spec.ZoneSubscriptionID
+		}
+	}
+	return s.Scope.SubscriptionID()
+}
+
+// isExistingZone returns true if the zone is referenced by its resource ID rather than created by CAPZ.
+func isExistingZone(zoneSpec azure.ResourceSpecGetter) bool {
+	zone, ok := zoneSpec.(ZoneSpec)
+	return ok && zone.Existing
+}
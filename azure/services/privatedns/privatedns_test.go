@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
@@ -80,6 +81,33 @@ var (
 		ResourceGroup: resourceGroup,
 	}
 
+	fakeExistingZone = ZoneSpec{
+		Name:           zoneName,
+		ResourceGroup:  "dns-rg",
+		ClusterName:    clusterName,
+		SubscriptionID: "dns-sub",
+		Existing:       true,
+	}
+
+	fakeExistingZoneLink = LinkSpec{
+		Name:               linkName1,
+		ZoneName:           zoneName,
+		SubscriptionID:     subscriptionID,
+		VNetResourceGroup:  vnetResourceGroup,
+		VNetName:           vnetName,
+		ResourceGroup:      "dns-rg",
+		ClusterName:        clusterName,
+		ZoneSubscriptionID: "dns-sub",
+	}
+
+	fakeExistingZoneRecord = RecordSpec{
+		Record:         infrav1.AddressRecord{Hostname: "apiserver", IP: "10.0.0.100"},
+		ZoneName:       zoneName,
+		ResourceGroup:  "dns-rg",
+		SubscriptionID: "dns-sub",
+		ClusterName:    clusterName,
+	}
+
 	managedTags = resources.TagsResource{
 		Properties: &resources.Tags{
 			Tags: map[string]*string{
@@ -123,13 +151,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -141,8 +169,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -154,8 +182,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -173,11 +201,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -195,11 +223,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, errFake)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, errFake)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -217,11 +245,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -239,11 +267,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, notDoneError)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, notDoneError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -262,10 +290,10 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, nil)
 				s.ClusterName().Return(clusterName)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -283,12 +311,12 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -306,13 +334,39 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
+			},
+		},
+		{
+			name:          "existing zone in another subscription is not updated, links and records are created in it",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord}).Times(2)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("dns-sub", "dns-rg", zoneName)).Return(resources.TagsResource{}, nil)
+				s.ClusterName().Return(clusterName)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, notFoundError)
+
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingZoneLink, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingZoneRecord, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "existing zone is not found",
+			expectedError: "failed to get existing private DNS zone my-zone in resource group dns-rg: " + notFoundError.Error(),
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord}).Times(2)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("dns-sub", "dns-rg", zoneName)).Return(resources.TagsResource{}, notFoundError)
 			},
 		},
 	}
@@ -334,6 +388,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 			tc.expect(scopeMock.EXPECT(), zoneReconcilerMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), recordReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
 
 			s := &Service{
+				name:               ServiceName,
 				Scope:              scopeMock,
 				zoneReconciler:     zoneReconcilerMock,
 				vnetLinkReconciler: vnetLinkReconcilerMock,
@@ -375,22 +430,22 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -425,16 +480,16 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -447,14 +502,14 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(notDoneError)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -467,14 +522,14 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(errFake)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -487,23 +542,23 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(notDoneError)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(notDoneError)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -516,23 +571,23 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(errFake)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(errFake)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
@@ -553,6 +608,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 			tc.expect(scopeMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), zoneReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
 
 			s := &Service{
+				name:               ServiceName,
 				Scope:              scopeMock,
 				zoneReconciler:     zoneReconcilerMock,
 				vnetLinkReconciler: vnetLinkReconcilerMock,
@@ -569,3 +625,113 @@ func TestDeletePrivateDNS(t *testing.T) {
 		})
 	}
 }
+
+func TestDeletePrivateDNSExistingZone(t *testing.T) {
+	ownedRecord := privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			Metadata: managedTags.Properties.Tags,
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatedns.MockScopeMockRecorder, lr, rr *mock_async.MockReconcilerMockRecorder, rg *mock_async.MockGetterMockRecorder, tg *mock_async.MockTagsGetterMockRecorder)
+	}{
+		{
+			name:          "links and records owned by the cluster are deleted, the zone is not",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, rr *mock_async.MockReconcilerMockRecorder, rg *mock_async.MockGetterMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord})
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(managedTags, nil)
+				s.ClusterName().Return(clusterName)
+				lr.DeleteResource(gomockinternal.AContext(), fakeExistingZoneLink, ServiceName).Return(nil)
+
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord).Return(ownedRecord, nil)
+				rr.DeleteResource(gomockinternal.AContext(), fakeExistingZoneRecord, ServiceName).Return(nil)
+
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "records not owned by the cluster are not deleted",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, rr *mock_async.MockReconcilerMockRecorder, rg *mock_async.MockGetterMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord})
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, nil)
+				s.ClusterName().Return(clusterName)
+
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord).Return(privatedns.RecordSet{RecordSetProperties: &privatedns.RecordSetProperties{}}, nil)
+
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "records already deleted are skipped",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, rr *mock_async.MockReconcilerMockRecorder, rg *mock_async.MockGetterMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord})
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				s.DeleteLongRunningOperationState(linkName1, ServiceName, infrav1.DeleteFuture)
+
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord).Return(nil, notFoundError)
+
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "record deletion fails",
+			expectedError: "this is an error",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, rr *mock_async.MockReconcilerMockRecorder, rg *mock_async.MockGetterMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord})
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("dns-sub", "dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				s.DeleteLongRunningOperationState(linkName1, ServiceName, infrav1.DeleteFuture)
+
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord).Return(ownedRecord, nil)
+				rr.DeleteResource(gomockinternal.AContext(), fakeExistingZoneRecord, ServiceName).Return(errFake)
+
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockScope(mockCtrl)
+			vnetLinkReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			recordReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			recordGetterMock := mock_async.NewMockGetter(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), recordReconcilerMock.EXPECT(), recordGetterMock.EXPECT(), tagsGetterMock.EXPECT())
+
+			s := &Service{
+				name:               ServiceName,
+				Scope:              scopeMock,
+				vnetLinkReconciler: vnetLinkReconcilerMock,
+				recordReconciler:   recordReconcilerMock,
+				recordGetter:       recordGetterMock,
+				TagsGetter:         tagsGetterMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
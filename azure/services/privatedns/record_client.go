@@ -23,11 +23,13 @@ import (
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // azureRecordsClient contains the Azure go-sdk Client for record sets.
 type azureRecordsClient struct {
+	auth       azure.Authorizer
 	recordsets privatedns.RecordSetsClient
 }
 
@@ -36,10 +38,30 @@ func newRecordSetsClient(auth azure.Authorizer) *azureRecordsClient {
 	recordsClient := privatedns.NewRecordSetsClientWithBaseURI(auth.BaseURI(), auth.SubscriptionID())
 	azure.SetAutoRestClientDefaults(&recordsClient.Client, auth.Authorizer())
 	return &azureRecordsClient{
+		auth:       auth,
 		recordsets: recordsClient,
 	}
 }
 
+// recordSetsClient returns the record sets client for the subscription of the zone of the record set.
+func (arc *azureRecordsClient) recordSetsClient(spec azure.ResourceSpecGetter) privatedns.RecordSetsClient {
+	record, ok := spec.(RecordSpec)
+	if !ok || record.SubscriptionID == "" || record.SubscriptionID == arc.recordsets.SubscriptionID {
+		return arc.recordsets
+	}
+	recordsClient := privatedns.NewRecordSetsClientWithBaseURI(arc.auth.BaseURI(), record.SubscriptionID)
+	azure.SetAutoRestClientDefaults(&recordsClient.Client, arc.auth.Authorizer())
+	return recordsClient
+}
+
+// recordType returns the type of the record set of the spec.
+func recordType(spec azure.ResourceSpecGetter) privatedns.RecordType {
+	if record, ok := spec.(RecordSpec); ok {
+		return converters.GetRecordType(record.Record.IP)
+	}
+	return privatedns.A
+}
+
 // CreateOrUpdateAsync creates or updates a record asynchronously.
 // Creating a record set is not a long running operation, so we don't ever return a future.
 func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
@@ -63,7 +85,7 @@ func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azu
 		recordType = privatedns.AAAA
 	}
 
-	recordSet, err := arc.recordsets.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType, spec.ResourceName(), set, "", "")
+	recordSet, err := arc.recordSetsClient(spec).CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType, spec.ResourceName(), set, "", "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,14 +93,26 @@ func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azu
 	return recordSet, nil, err
 }
 
-// Get gets the specified record set. Noop for records.
+// Get gets the specified record set.
 func (arc *azureRecordsClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.Get")
+	defer done()
+
+	recordSet, err := arc.recordSetsClient(spec).Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType(spec), spec.ResourceName())
+	if err != nil {
+		return nil, err
+	}
+	return recordSet, nil
 }
 
-// DeleteAsync deletes a record asynchronously. Noop for records.
+// DeleteAsync deletes a record.
+// Deleting a record set is not a long running operation, so we don't ever return a future.
 func (arc *azureRecordsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.DeleteAsync")
+	defer done()
+
+	_, err = arc.recordSetsClient(spec).Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType(spec), spec.ResourceName(), "")
+	return nil, err
 }
 
 // IsDone returns true if the long-running operation has completed. Noop for records.
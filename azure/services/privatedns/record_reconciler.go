@@ -19,7 +19,10 @@ package privatedns
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -33,7 +36,7 @@ func (s *Service) reconcileRecords(ctx context.Context, records []azure.Resource
 	// If multiple errors occur, we return the most pressing one.
 	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	for _, recordSpec := range records {
-		if _, err := s.recordReconciler.CreateOrUpdateResource(ctx, recordSpec, serviceName); err != nil {
+		if _, err := s.recordReconciler.CreateOrUpdateResource(ctx, recordSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resErr == nil {
 				resErr = err
 			}
@@ -42,3 +45,44 @@ func (s *Service) reconcileRecords(ctx context.Context, records []azure.Resource
 
 	return resErr
 }
+
+func (s *Service) deleteRecords(ctx context.Context, records []azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.deleteRecords")
+	defer done()
+
+	var resErr error
+
+	// We go through the list of records to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	for _, recordSpec := range records {
+		if err := deleteOwnedRecord(ctx, s.recordGetter, s.recordReconciler, recordSpec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	return resErr
+}
+
+// deleteOwnedRecord deletes a record set if it exists and is owned by the cluster of the spec.
+func deleteOwnedRecord(ctx context.Context, getter async.Getter, reconciler async.Reconciler, spec azure.ResourceSpecGetter, serviceName string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.deleteOwnedRecord")
+	defer done()
+
+	existing, err := getter.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get record set %s in private DNS zone %s", spec.ResourceName(), spec.OwnerResourceName())
+	}
+
+	record, ok := spec.(RecordSpec)
+	if set, isSet := existing.(privatedns.RecordSet); !ok || !isSet || !isRecordOwned(set, record.ClusterName) {
+		log.V(2).Info("Skipping deletion of record set not created by CAPZ", "record set", spec.ResourceName(), "private dns zone", spec.OwnerResourceName())
+		return nil
+	}
+
+	return reconciler.DeleteResource(ctx, spec, serviceName)
+}
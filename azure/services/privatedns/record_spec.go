@@ -26,11 +26,23 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
+// DefaultRecordTTL is the default time to live in seconds of the record sets.
+const DefaultRecordTTL = 300
+
 // RecordSpec defines the specification for a record set.
 type RecordSpec struct {
 	Record        infrav1.AddressRecord
 	ZoneName      string
 	ResourceGroup string
+	// TTL is the time to live of the record set in seconds. Defaults to DefaultRecordTTL.
+	TTL int64
+
+	// SubscriptionID is the subscription of the zone, when it is not the subscription of the cluster.
+	SubscriptionID string
+	// ClusterName is set for record sets that must be tracked individually, i.e. the record sets of a zone
+	// CAPZ does not manage and the records of the nodes. Such record sets are tagged as owned by the cluster,
+	// and existing record sets not owned by the cluster are never taken over.
+	ClusterName string
 }
 
 // ResourceName returns the name of a record set.
@@ -51,15 +63,30 @@ func (s RecordSpec) ResourceGroupName() string {
 // Parameters returns the parameters for a record set.
 func (s RecordSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(privatedns.RecordSet); !ok {
+		existingSet, ok := existing.(privatedns.RecordSet)
+		if !ok {
 			return nil, errors.Errorf("%T is not a privatedns.RecordSet", existing)
 		}
+		if s.ClusterName != "" {
+			if !isRecordOwned(existingSet, s.ClusterName) {
+				return nil, errors.Errorf("record set %s already exists in private DNS zone %s and is not owned by the cluster", s.Record.Hostname, s.ZoneName)
+			}
+			if s.isUpToDate(existingSet) {
+				return nil, nil
+			}
+		}
 	}
 	set := privatedns.RecordSet{
 		RecordSetProperties: &privatedns.RecordSetProperties{
-			TTL: pointer.Int64(300),
+			TTL: pointer.Int64(s.ttl()),
 		},
 	}
+	if s.ClusterName != "" {
+		set.Metadata = converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+		}))
+	}
 	recordType := converters.GetRecordType(s.Record.IP)
 	switch recordType {
 	case privatedns.A:
@@ -76,3 +103,37 @@ func (s RecordSpec) Parameters(ctx context.Context, existing interface{}) (param
 
 	return set, nil
 }
+
+// isUpToDate returns true if the record set has the TTL and the address of the spec.
+func (s RecordSpec) isUpToDate(set privatedns.RecordSet) bool {
+	properties := set.RecordSetProperties
+	if properties == nil || pointer.Int64Deref(properties.TTL, 0) != s.ttl() {
+		return false
+	}
+	switch converters.GetRecordType(s.Record.IP) {
+	case privatedns.A:
+		return properties.ARecords != nil && len(*properties.ARecords) == 1 &&
+			pointer.StringDeref((*properties.ARecords)[0].Ipv4Address, "") == s.Record.IP
+	case privatedns.AAAA:
+		return properties.AaaaRecords != nil && len(*properties.AaaaRecords) == 1 &&
+			pointer.StringDeref((*properties.AaaaRecords)[0].Ipv6Address, "") == s.Record.IP
+	default:
+		return false
+	}
+}
+
+// ttl returns the time to live of the record set, or the default one when the spec has none.
+func (s RecordSpec) ttl() int64 {
+	if s.TTL == 0 {
+		return DefaultRecordTTL
+	}
+	return s.TTL
+}
+
+// isRecordOwned returns true if the record set was created by CAPZ for the cluster.
+func isRecordOwned(set privatedns.RecordSet, clusterName string) bool {
+	if set.RecordSetProperties == nil {
+		return false
+	}
+	return converters.MapToTags(set.Metadata).HasOwned(clusterName)
+}
@@ -38,6 +38,18 @@ var (
 		ZoneName:      "my-zone",
 		ResourceGroup: "my-rg",
 	}
+
+	ownedRecordSpec = RecordSpec{
+		Record:         infrav1.AddressRecord{Hostname: "my-node", IP: "10.1.0.4"},
+		ZoneName:       "my-zone",
+		ResourceGroup:  "dns-rg",
+		SubscriptionID: "dns-sub",
+		ClusterName:    "my-cluster",
+	}
+
+	ownedRecordMetadata = map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+	}
 )
 
 func TestRecordSpec_ResourceName(t *testing.T) {
@@ -97,6 +109,105 @@ func TestRecordSpec_Parameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name:          "new private dns record owned by the cluster",
+			expectedError: "",
+			spec:          ownedRecordSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						Metadata: ownedRecordMetadata,
+						TTL:      pointer.Int64(300),
+						ARecords: &[]privatedns.ARecord{
+							{
+								Ipv4Address: pointer.String("10.1.0.4"),
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:          "up to date private dns record owned by the cluster",
+			expectedError: "",
+			spec:          ownedRecordSpec,
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					Metadata: ownedRecordMetadata,
+					TTL:      pointer.Int64(300),
+					ARecords: &[]privatedns.ARecord{{Ipv4Address: pointer.String("10.1.0.4")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "private dns record owned by the cluster with another address",
+			expectedError: "",
+			spec:          ownedRecordSpec,
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					Metadata: ownedRecordMetadata,
+					TTL:      pointer.Int64(300),
+					ARecords: &[]privatedns.ARecord{{Ipv4Address: pointer.String("10.1.0.5")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(privatedns.RecordSet{}))
+				g.Expect(*result.(privatedns.RecordSet).ARecords).To(Equal([]privatedns.ARecord{{Ipv4Address: pointer.String("10.1.0.4")}}))
+			},
+		},
+		{
+			name:          "up to date private dns record owned by the cluster with a custom TTL",
+			expectedError: "",
+			spec: func() RecordSpec {
+				spec := ownedRecordSpec
+				spec.TTL = 60
+				return spec
+			}(),
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					Metadata: ownedRecordMetadata,
+					TTL:      pointer.Int64(60),
+					ARecords: &[]privatedns.ARecord{{Ipv4Address: pointer.String("10.1.0.4")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "private dns record owned by the cluster with another TTL",
+			expectedError: "",
+			spec: func() RecordSpec {
+				spec := ownedRecordSpec
+				spec.TTL = 60
+				return spec
+			}(),
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					Metadata: ownedRecordMetadata,
+					TTL:      pointer.Int64(300),
+					ARecords: &[]privatedns.ARecord{{Ipv4Address: pointer.String("10.1.0.4")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(privatedns.RecordSet{}))
+				g.Expect(result.(privatedns.RecordSet).TTL).To(Equal(pointer.Int64(60)))
+			},
+		},
+		{
+			name:          "private dns record not owned by the cluster",
+			expectedError: "record set my-node already exists in private DNS zone my-zone and is not owned by the cluster",
+			spec:          ownedRecordSpec,
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					TTL:      pointer.Int64(3600),
+					ARecords: &[]privatedns.ARecord{{Ipv4Address: pointer.String("10.1.0.4")}},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -30,6 +30,13 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 	defer done()
 
 	managed, err = s.IsManaged(ctx)
+	if isExistingZone(zoneSpec) {
+		// An existing zone is never created nor updated, it only has to exist.
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get existing private DNS zone %s in resource group %s", zoneSpec.ResourceName(), zoneSpec.ResourceGroupName())
+		}
+		return false, nil
+	}
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			managed = true
@@ -46,7 +53,7 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 		return managed, nil
 	}
 
-	_, err = s.zoneReconciler.CreateOrUpdateResource(ctx, zoneSpec, serviceName)
+	_, err = s.zoneReconciler.CreateOrUpdateResource(ctx, zoneSpec, s.Name())
 	return managed, err
 }
 
@@ -59,7 +66,7 @@ func (s *Service) deleteZone(ctx context.Context, zoneSpec azure.ResourceSpecGet
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(zoneSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
+			s.Scope.DeleteLongRunningOperationState(zoneSpec.ResourceName(), s.Name(), infrav1.DeleteFuture)
 			return managed, nil
 		}
 		return managed, errors.Wrapf(err, "could not get private DNS zone state of %s in resource group %s", zoneSpec.ResourceName(), zoneSpec.ResourceGroupName())
@@ -74,6 +81,6 @@ func (s *Service) deleteZone(ctx context.Context, zoneSpec azure.ResourceSpecGet
 	managed = true
 
 	// Delete the private DNS zone, which also deletes all records
-	err = s.zoneReconciler.DeleteResource(ctx, zoneSpec, serviceName)
+	err = s.zoneReconciler.DeleteResource(ctx, zoneSpec, s.Name())
 	return managed, err
 }
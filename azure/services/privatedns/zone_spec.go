@@ -33,6 +33,12 @@ type ZoneSpec struct {
 	ResourceGroup  string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+
+	// SubscriptionID is the subscription of the zone. Defaults to the subscription of the cluster.
+	SubscriptionID string
+	// Existing is true if the zone is referenced by its resource ID rather than created by CAPZ.
+	// An existing zone must exist, and is never modified nor deleted.
+	Existing bool
 }
 
 // ResourceName returns the name of the private dns zone.
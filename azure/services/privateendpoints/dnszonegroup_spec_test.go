@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var (
+	blobZoneID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+	vaultZoneID = "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"
+)
+
+func TestPrivateDNSZoneGroupSpecParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *PrivateDNSZoneGroupSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "PrivateDNSZoneGroup doesn't exist",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:                "default",
+				PrivateEndpointName: "my-private-endpoint",
+				ResourceGroup:       "my-rg",
+				PrivateDNSZoneIDs:   []string{blobZoneID},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateDNSZoneGroup{
+					Name: pointer.String("default"),
+					PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+						PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+							{
+								Name: pointer.String("privatelink-blob-core-windows-net"),
+								PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+									PrivateDNSZoneID: pointer.String(blobZoneID),
+								},
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "PrivateDNSZoneGroup already exists with the same zones",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:                "default",
+				PrivateEndpointName: "my-private-endpoint",
+				ResourceGroup:       "my-rg",
+				PrivateDNSZoneIDs:   []string{blobZoneID, vaultZoneID},
+			},
+			existing: network.PrivateDNSZoneGroup{
+				Name: pointer.String("default"),
+				PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+					PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+						{
+							Name: pointer.String("privatelink-vaultcore-azure-net"),
+							PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+								PrivateDNSZoneID: pointer.String("/subscriptions/456/resourceGroups/DNS-RG/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"),
+							},
+						},
+						{
+							Name: pointer.String("privatelink-blob-core-windows-net"),
+							PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+								PrivateDNSZoneID: pointer.String(blobZoneID),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "PrivateDNSZoneGroup already exists with other zones",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:                "default",
+				PrivateEndpointName: "my-private-endpoint",
+				ResourceGroup:       "my-rg",
+				PrivateDNSZoneIDs:   []string{vaultZoneID},
+			},
+			existing: network.PrivateDNSZoneGroup{
+				Name: pointer.String("default"),
+				PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+					PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+						{
+							Name: pointer.String("privatelink-blob-core-windows-net"),
+							PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+								PrivateDNSZoneID: pointer.String(blobZoneID),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateDNSZoneGroup{
+					Name: pointer.String("default"),
+					PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+						PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+							{
+								Name: pointer.String("privatelink-vaultcore-azure-net"),
+								PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+									PrivateDNSZoneID: pointer.String(vaultZoneID),
+								},
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing is not a PrivateDNSZoneGroup",
+			spec:          &PrivateDNSZoneGroupSpec{Name: "default", PrivateDNSZoneIDs: []string{blobZoneID}},
+			existing:      network.PrivateEndpoint{},
+			expectedError: "network.PrivateEndpoint is not a network.PrivateDNSZoneGroup",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -40,14 +40,17 @@ type PrivateEndpointScope interface {
 type Service struct {
 	Scope PrivateEndpointScope
 	async.Reconciler
+	zoneGroupReconciler async.Reconciler
 }
 
 // New creates a new service.
 func New(scope PrivateEndpointScope) *Service {
 	Client := newClient(scope)
+	zoneGroupsClient := newDNSZoneGroupsClient(scope)
 	return &Service{
-		Scope:      scope,
-		Reconciler: async.New(scope, Client, Client),
+		Scope:               scope,
+		Reconciler:          async.New(scope, Client, Client),
+		zoneGroupReconciler: async.New(scope, zoneGroupsClient, zoneGroupsClient),
 	}
 }
 
@@ -81,6 +84,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
+			continue
+		}
+		// The private DNS zone group is a child resource of the private endpoint, so it can only be reconciled once the private endpoint exists.
+		// It is deleted along with the private endpoint.
+		if err := s.reconcilePrivateDNSZoneGroup(ctx, privateEndpointSpec); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
 		}
 	}
 
@@ -119,6 +130,20 @@ func (s *Service) Delete(ctx context.Context) error {
 	return result
 }
 
+// reconcilePrivateDNSZoneGroup creates or updates the private DNS zone group of a private endpoint, if it has one.
+func (s *Service) reconcilePrivateDNSZoneGroup(ctx context.Context, privateEndpointSpec azure.ResourceSpecGetter) error {
+	spec, ok := privateEndpointSpec.(*PrivateEndpointSpec)
+	if !ok {
+		return nil
+	}
+	zoneGroupSpec := spec.PrivateDNSZoneGroupSpec()
+	if zoneGroupSpec == nil {
+		return nil
+	}
+	_, err := s.zoneGroupReconciler.CreateOrUpdateResource(ctx, zoneGroupSpec, ServiceName)
+	return err
+}
+
 // IsManaged returns always returns true as CAPZ does not support BYO private endpoints.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
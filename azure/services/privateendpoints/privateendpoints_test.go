@@ -60,6 +60,20 @@ var (
 		PrivateIPAddresses:            []string{"10.0.0.1"},
 	}
 
+	fakePrivateEndpointWithDNSZone = PrivateEndpointSpec{
+		Name:                          "fake-private-endpoint4",
+		PrivateLinkServiceConnections: []PrivateLinkServiceConnection{{PrivateLinkServiceID: "testPl", GroupIDs: []string{"blob"}}},
+		SubnetID:                      "mySubnet",
+		ResourceGroup:                 "my-rg",
+		PrivateDNSZoneIDs:             []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"},
+	}
+	fakePrivateDNSZoneGroup = PrivateDNSZoneGroupSpec{
+		Name:                "default",
+		PrivateEndpointName: "fake-private-endpoint4",
+		ResourceGroup:       "my-rg",
+		PrivateDNSZoneIDs:   []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"},
+	}
+
 	emptyPrivateEndpointSpec = PrivateEndpointSpec{}
 	fakePrivateEndpointSpecs = []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2, &fakePrivateEndpoint3, &emptyPrivateEndpointSpec}
 
@@ -101,6 +115,35 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
 			},
 		},
+		{
+			name:          "create a private endpoint with a private DNS zone group",
+			expectedError: "",
+			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointWithDNSZone})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointWithDNSZone, ServiceName).Return(&fakePrivateEndpointWithDNSZone, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateDNSZoneGroup, ServiceName).Return(nil, nil)
+				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "private DNS zone group is not created until the private endpoint is created",
+			expectedError: "operation type  on Azure resource / is not done",
+			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointWithDNSZone})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointWithDNSZone, ServiceName).Return(nil, notDoneError)
+				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "return error when creating a private DNS zone group fails",
+			expectedError: internalError.Error(),
+			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointWithDNSZone})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointWithDNSZone, ServiceName).Return(&fakePrivateEndpointWithDNSZone, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateDNSZoneGroup, ServiceName).Return(nil, internalError)
+				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+			},
+		},
 		{
 			name:          "return error when creating a private endpoint using an empty spec",
 			expectedError: internalError.Error(),
@@ -147,8 +190,9 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
 
 			s := &Service{
-				Scope:      scopeMock,
-				Reconciler: asyncMock,
+				Scope:               scopeMock,
+				Reconciler:          asyncMock,
+				zoneGroupReconciler: asyncMock,
 			}
 
 			err := s.Reconcile(context.TODO())
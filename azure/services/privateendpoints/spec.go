@@ -52,8 +52,14 @@ type PrivateEndpointSpec struct {
 	PrivateLinkServiceConnections []PrivateLinkServiceConnection
 	AdditionalTags                infrav1.Tags
 	ClusterName                   string
+
+	// PrivateDNSZoneIDs are the private DNS zones registered in the private DNS zone group of the private endpoint.
+	PrivateDNSZoneIDs []string
 }
 
+// privateDNSZoneGroupName is the name of the private DNS zone group of a private endpoint.
+const privateDNSZoneGroupName = "default"
+
 // ResourceName returns the name of the private endpoint.
 func (s *PrivateEndpointSpec) ResourceName() string {
 	return s.Name
@@ -69,6 +75,20 @@ func (s *PrivateEndpointSpec) OwnerResourceName() string {
 	return ""
 }
 
+// PrivateDNSZoneGroupSpec returns the spec of the private DNS zone group of the private endpoint, or nil if the
+// private endpoint has no private DNS zone.
+func (s *PrivateEndpointSpec) PrivateDNSZoneGroupSpec() *PrivateDNSZoneGroupSpec {
+	if len(s.PrivateDNSZoneIDs) == 0 {
+		return nil
+	}
+	return &PrivateDNSZoneGroupSpec{
+		Name:                privateDNSZoneGroupName,
+		PrivateEndpointName: s.Name,
+		ResourceGroup:       s.ResourceGroup,
+		PrivateDNSZoneIDs:   s.PrivateDNSZoneIDs,
+	}
+}
+
 // Parameters returns the parameters for the PrivateEndpointSpec.
 func (s *PrivateEndpointSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
 	_, log, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Parameters")
@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateips
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "privateips"
+
+// claimRequeueAfter is how long to wait before checking again whether an IPAddressClaim has been fulfilled.
+const claimRequeueAfter = 15 * time.Second
+
+// PrivateIPScope defines the scope interface for a private IPs service.
+type PrivateIPScope interface {
+	PrivateIPSpecs() []*PrivateIPSpec
+	SetPrivateIPAddress(nicName, address string)
+	GetClient() client.Client
+}
+
+// Service provides the static private IP addresses of network interfaces drawn from pools.
+type Service struct {
+	Scope PrivateIPScope
+}
+
+// New creates a new service.
+func New(scope PrivateIPScope) *Service {
+	return &Service{
+		Scope: scope,
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile claims or allocates the static private IP address of each network interface requesting one from a pool,
+// and records it in the scope for the network interface to be created with it.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privateips.Service.Reconcile")
+	defer done()
+
+	for _, spec := range s.Scope.PrivateIPSpecs() {
+		var address string
+		var err error
+		if spec.PoolRef != nil {
+			address, err = s.claimAddress(ctx, spec)
+		} else {
+			address, err = s.allocateFromSubnetPool(ctx, spec)
+		}
+		if err != nil {
+			return err
+		}
+		log.V(4).Info("using static private IP address", "networkInterface", spec.NICName, "address", address)
+		s.Scope.SetPrivateIPAddress(spec.NICName, address)
+	}
+
+	return nil
+}
+
+// Delete releases the static private IP addresses of the network interfaces.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateips.Service.Delete")
+	defer done()
+
+	for _, spec := range s.Scope.PrivateIPSpecs() {
+		if spec.PoolRef != nil {
+			claim := &ipamv1.IPAddressClaim{ObjectMeta: metav1.ObjectMeta{Name: spec.ClaimName, Namespace: spec.Namespace}}
+			if err := s.Scope.GetClient().Delete(ctx, claim); err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to delete IPAddressClaim %s", spec.ClaimName)
+			}
+			continue
+		}
+
+		addresses, err := s.allocatedAddresses(ctx, spec)
+		if err != nil {
+			return err
+		}
+		for i := range addresses {
+			if err := s.Scope.GetClient().Delete(ctx, &addresses[i]); err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to delete IPAddress %s", addresses[i].Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsManaged returns always returns true as the claims and addresses are always created by CAPZ.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// claimAddress creates the IPAddressClaim of the network interface if it doesn't exist and returns the address it was fulfilled with.
+func (s *Service) claimAddress(ctx context.Context, spec *PrivateIPSpec) (string, error) {
+	c := s.Scope.GetClient()
+	claim := &ipamv1.IPAddressClaim{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: spec.Namespace, Name: spec.ClaimName}, claim)
+	switch {
+	case apierrors.IsNotFound(err):
+		claim = &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            spec.ClaimName,
+				Namespace:       spec.Namespace,
+				Labels:          map[string]string{clusterv1.ClusterNameLabel: spec.ClusterName},
+				OwnerReferences: []metav1.OwnerReference{spec.Owner},
+			},
+			Spec: ipamv1.IPAddressClaimSpec{
+				PoolRef: *spec.PoolRef,
+			},
+		}
+		if err := c.Create(ctx, claim); err != nil {
+			return "", errors.Wrapf(err, "failed to create IPAddressClaim %s", spec.ClaimName)
+		}
+	case err != nil:
+		return "", errors.Wrapf(err, "failed to get IPAddressClaim %s", spec.ClaimName)
+	}
+
+	if claim.Status.AddressRef.Name == "" {
+		return "", azure.WithTransientError(errors.Errorf("waiting for IPAddressClaim %s to be fulfilled", spec.ClaimName), claimRequeueAfter)
+	}
+
+	address := &ipamv1.IPAddress{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: spec.Namespace, Name: claim.Status.AddressRef.Name}, address); err != nil {
+		return "", errors.Wrapf(err, "failed to get IPAddress %s of IPAddressClaim %s", claim.Status.AddressRef.Name, spec.ClaimName)
+	}
+	return address.Spec.Address, nil
+}
+
+// allocateFromSubnetPool returns the address allocated to the network interface from the static IP pool of its subnet,
+// allocating the lowest free one if it has none yet. Each allocated address is recorded by an IPAddress named after it,
+// so that two machines allocating the same address concurrently can't both create it.
+func (s *Service) allocateFromSubnetPool(ctx context.Context, spec *PrivateIPSpec) (string, error) {
+	if spec.SubnetPool == nil {
+		return "", errors.Errorf("subnet %s of network interface %s has no static IP pool", spec.SubnetName, spec.NICName)
+	}
+	start, err := netip.ParseAddr(spec.SubnetPool.Start)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the start of the static IP pool of subnet %s", spec.SubnetName)
+	}
+	end, err := netip.ParseAddr(spec.SubnetPool.End)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the end of the static IP pool of subnet %s", spec.SubnetName)
+	}
+
+	addresses, err := s.poolAddresses(ctx, spec)
+	if err != nil {
+		return "", err
+	}
+	if allocated := claimAddresses(addresses, spec.ClaimName); len(allocated) > 0 {
+		return allocated[0].Spec.Address, nil
+	}
+	used := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		used[address.Spec.Address] = true
+	}
+
+	for addr := start; addr.IsValid() && !end.Less(addr); addr = addr.Next() {
+		if used[addr.String()] {
+			continue
+		}
+		address := &ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            ipAddressName(spec.ClusterName, addr),
+				Namespace:       spec.Namespace,
+				Labels:          map[string]string{clusterv1.ClusterNameLabel: spec.ClusterName},
+				OwnerReferences: []metav1.OwnerReference{spec.Owner},
+			},
+			Spec: ipamv1.IPAddressSpec{
+				ClaimRef: corev1.LocalObjectReference{Name: spec.ClaimName},
+				PoolRef:  spec.subnetPoolRef(),
+				Address:  addr.String(),
+				Prefix:   spec.SubnetPrefix,
+			},
+		}
+		err := s.Scope.GetClient().Create(ctx, address)
+		if apierrors.IsAlreadyExists(err) {
+			// Another machine allocated this address concurrently.
+			continue
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create IPAddress %s", address.Name)
+		}
+		return address.Spec.Address, nil
+	}
+
+	return "", errors.Errorf("no free IP address left in the static IP pool %s-%s of subnet %s", spec.SubnetPool.Start, spec.SubnetPool.End, spec.SubnetName)
+}
+
+// allocatedAddresses returns the IPAddresses allocated to the network interface from the static IP pool of its subnet.
+func (s *Service) allocatedAddresses(ctx context.Context, spec *PrivateIPSpec) ([]ipamv1.IPAddress, error) {
+	addresses, err := s.poolAddresses(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return claimAddresses(addresses, spec.ClaimName), nil
+}
+
+// claimAddresses returns the addresses allocated to a claim, lowest first. A claim is only ever allocated a single address,
+// but sorting them makes sure the same one is always used should an earlier reconcile have left another one behind.
+func claimAddresses(addresses []ipamv1.IPAddress, claimName string) []ipamv1.IPAddress {
+	var claimed []ipamv1.IPAddress
+	for _, address := range addresses {
+		if address.Spec.ClaimRef.Name == claimName {
+			claimed = append(claimed, address)
+		}
+	}
+	sort.SliceStable(claimed, func(i, j int) bool {
+		a, errA := netip.ParseAddr(claimed[i].Spec.Address)
+		b, errB := netip.ParseAddr(claimed[j].Spec.Address)
+		if errA != nil || errB != nil {
+			return claimed[i].Name < claimed[j].Name
+		}
+		return a.Less(b)
+	})
+	return claimed
+}
+
+// poolAddresses returns the IPAddresses allocated from the static IP pools of the subnets of the cluster.
+// IPAddresses are not cached by the manager's client, so that the address allocated to a network interface by a previous
+// reconcile is always listed, even before the cache would catch up with its creation.
+func (s *Service) poolAddresses(ctx context.Context, spec *PrivateIPSpec) ([]ipamv1.IPAddress, error) {
+	list := &ipamv1.IPAddressList{}
+	if err := s.Scope.GetClient().List(ctx, list, client.InNamespace(spec.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: spec.ClusterName}); err != nil {
+		return nil, errors.Wrap(err, "failed to list IPAddresses")
+	}
+
+	poolRef := spec.subnetPoolRef()
+	var addresses []ipamv1.IPAddress
+	for _, address := range list.Items {
+		ref := address.Spec.PoolRef
+		if ref.APIGroup != nil && *ref.APIGroup == *poolRef.APIGroup && ref.Kind == poolRef.Kind && ref.Name == poolRef.Name {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+// ipAddressName returns the name of the IPAddress recording the allocation of an address from the static IP pools of a cluster.
+func ipAddressName(clusterName string, addr netip.Addr) string {
+	return fmt.Sprintf("%s-%s", clusterName, strings.NewReplacer(".", "-", ":", "-").Replace(addr.String()))
+}
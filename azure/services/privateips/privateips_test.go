@@ -0,0 +1,316 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateips
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	fakePoolRef = &corev1.TypedLocalObjectReference{
+		APIGroup: pointer.String("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "test-pool",
+	}
+	fakeOwner = metav1.OwnerReference{
+		APIVersion: infrav1.GroupVersion.String(),
+		Kind:       "AzureMachine",
+		Name:       "test-machine",
+	}
+)
+
+// fakeScope is a PrivateIPScope recording the addresses set for the network interfaces.
+type fakeScope struct {
+	specs     []*PrivateIPSpec
+	client    client.Client
+	addresses map[string]string
+}
+
+func (s *fakeScope) PrivateIPSpecs() []*PrivateIPSpec {
+	return s.specs
+}
+
+func (s *fakeScope) SetPrivateIPAddress(nicName, address string) {
+	s.addresses[nicName] = address
+}
+
+func (s *fakeScope) GetClient() client.Client {
+	return s.client
+}
+
+func newFakeScope(g *WithT, specs []*PrivateIPSpec, objects ...client.Object) *fakeScope {
+	scheme := runtime.NewScheme()
+	g.Expect(ipamv1.AddToScheme(scheme)).To(Succeed())
+	return &fakeScope{
+		specs:     specs,
+		client:    fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		addresses: map[string]string{},
+	}
+}
+
+func fakeClaimSpec() *PrivateIPSpec {
+	return &PrivateIPSpec{
+		NICName:     "test-machine-nic",
+		ClaimName:   "test-machine-nic",
+		Namespace:   "default",
+		ClusterName: "test-cluster",
+		Owner:       fakeOwner,
+		PoolRef:     fakePoolRef,
+		SubnetName:  "node-subnet",
+	}
+}
+
+func fakeSubnetPoolSpec(claimName string) *PrivateIPSpec {
+	return &PrivateIPSpec{
+		NICName:     claimName,
+		ClaimName:   claimName,
+		Namespace:   "default",
+		ClusterName: "test-cluster",
+		Owner:       fakeOwner,
+		SubnetName:  "node-subnet",
+		SubnetPool: &infrav1.PrivateIPAddressRange{
+			Start: "10.1.0.10",
+			End:   "10.1.0.12",
+		},
+		SubnetPrefix: 16,
+	}
+}
+
+func fakeSubnetPoolAddress(claimName, address string) *ipamv1.IPAddress {
+	addr := &ipamv1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName + "-address",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+		},
+		Spec: ipamv1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claimName},
+			PoolRef:  fakeSubnetPoolSpec(claimName).subnetPoolRef(),
+			Address:  address,
+			Prefix:   16,
+		},
+	}
+	return addr
+}
+
+func TestReconcilePrivateIPs(t *testing.T) {
+	noPoolSpec := fakeSubnetPoolSpec("test-machine-nic")
+	noPoolSpec.SubnetPool = nil
+
+	testcases := []struct {
+		name              string
+		specs             []*PrivateIPSpec
+		objects           []client.Object
+		expectedError     string
+		expectedAddresses map[string]string
+		verify            func(g *WithT, c client.Client)
+	}{
+		{
+			name:              "noop if no private IP specs are found",
+			expectedError:     "",
+			expectedAddresses: map[string]string{},
+		},
+		{
+			name:              "IPAddressClaim is created and waited for",
+			specs:             []*PrivateIPSpec{fakeClaimSpec()},
+			expectedError:     "waiting for IPAddressClaim test-machine-nic to be fulfilled",
+			expectedAddresses: map[string]string{},
+			verify: func(g *WithT, c client.Client) {
+				claim := &ipamv1.IPAddressClaim{}
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-machine-nic"}, claim)).To(Succeed())
+				g.Expect(claim.Spec.PoolRef).To(Equal(*fakePoolRef))
+				g.Expect(claim.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "test-cluster"))
+				g.Expect(claim.OwnerReferences).To(ConsistOf(fakeOwner))
+			},
+		},
+		{
+			name:  "address of a fulfilled IPAddressClaim is used",
+			specs: []*PrivateIPSpec{fakeClaimSpec()},
+			objects: []client.Object{
+				&ipamv1.IPAddressClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine-nic", Namespace: "default"},
+					Spec:       ipamv1.IPAddressClaimSpec{PoolRef: *fakePoolRef},
+					Status:     ipamv1.IPAddressClaimStatus{AddressRef: corev1.LocalObjectReference{Name: "test-address"}},
+				},
+				&ipamv1.IPAddress{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-address", Namespace: "default"},
+					Spec:       ipamv1.IPAddressSpec{Address: "10.1.0.20", Prefix: 16},
+				},
+			},
+			expectedError:     "",
+			expectedAddresses: map[string]string{"test-machine-nic": "10.1.0.20"},
+		},
+		{
+			name:              "lowest free address of the subnet pool is allocated",
+			specs:             []*PrivateIPSpec{fakeSubnetPoolSpec("test-machine-nic")},
+			objects:           []client.Object{fakeSubnetPoolAddress("other-machine-nic", "10.1.0.10")},
+			expectedError:     "",
+			expectedAddresses: map[string]string{"test-machine-nic": "10.1.0.11"},
+			verify: func(g *WithT, c client.Client) {
+				address := &ipamv1.IPAddress{}
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-cluster-10-1-0-11"}, address)).To(Succeed())
+				g.Expect(address.Spec.ClaimRef.Name).To(Equal("test-machine-nic"))
+				g.Expect(address.Spec.Prefix).To(Equal(16))
+				g.Expect(address.OwnerReferences).To(ConsistOf(fakeOwner))
+			},
+		},
+		{
+			name:  "address allocated concurrently by another machine is skipped",
+			specs: []*PrivateIPSpec{fakeSubnetPoolSpec("test-machine-nic")},
+			objects: []client.Object{
+				&ipamv1.IPAddress{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-10-1-0-10", Namespace: "default"},
+				},
+			},
+			expectedError:     "",
+			expectedAddresses: map[string]string{"test-machine-nic": "10.1.0.11"},
+		},
+		{
+			name:          "addresses are allocated for several network interfaces",
+			specs:         []*PrivateIPSpec{fakeSubnetPoolSpec("test-machine-nic-0"), fakeSubnetPoolSpec("test-machine-nic-1")},
+			expectedError: "",
+			expectedAddresses: map[string]string{
+				"test-machine-nic-0": "10.1.0.10",
+				"test-machine-nic-1": "10.1.0.11",
+			},
+		},
+		{
+			name:              "address already allocated from the subnet pool is reused",
+			specs:             []*PrivateIPSpec{fakeSubnetPoolSpec("test-machine-nic")},
+			objects:           []client.Object{fakeSubnetPoolAddress("test-machine-nic", "10.1.0.12")},
+			expectedError:     "",
+			expectedAddresses: map[string]string{"test-machine-nic": "10.1.0.12"},
+		},
+		{
+			name:  "lowest of the addresses left allocated to the network interface is reused",
+			specs: []*PrivateIPSpec{fakeSubnetPoolSpec("test-machine-nic")},
+			objects: []client.Object{
+				fakeSubnetPoolAddress("test-machine-nic", "10.1.0.12"),
+				func() client.Object {
+					address := fakeSubnetPoolAddress("test-machine-nic", "10.1.0.11")
+					address.Name = "test-machine-nic-leftover"
+					return address
+				}(),
+			},
+			expectedError:     "",
+			expectedAddresses: map[string]string{"test-machine-nic": "10.1.0.11"},
+			verify: func(g *WithT, c client.Client) {
+				list := &ipamv1.IPAddressList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(2))
+			},
+		},
+		{
+			name:  "subnet pool is exhausted",
+			specs: []*PrivateIPSpec{fakeSubnetPoolSpec("test-machine-nic")},
+			objects: []client.Object{
+				fakeSubnetPoolAddress("machine-0-nic", "10.1.0.10"),
+				fakeSubnetPoolAddress("machine-1-nic", "10.1.0.11"),
+				fakeSubnetPoolAddress("machine-2-nic", "10.1.0.12"),
+			},
+			expectedError:     "no free IP address left in the static IP pool 10.1.0.10-10.1.0.12 of subnet node-subnet",
+			expectedAddresses: map[string]string{},
+		},
+		{
+			name:              "subnet has no static IP pool",
+			specs:             []*PrivateIPSpec{noPoolSpec},
+			expectedError:     "subnet node-subnet of network interface test-machine-nic has no static IP pool",
+			expectedAddresses: map[string]string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scope := newFakeScope(g, tc.specs, tc.objects...)
+
+			err := New(scope).Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(scope.addresses).To(Equal(tc.expectedAddresses))
+			if tc.verify != nil {
+				tc.verify(g, scope.client)
+			}
+		})
+	}
+}
+
+func TestReconcilePrivateIPsWaitingIsTransient(t *testing.T) {
+	g := NewWithT(t)
+
+	scope := newFakeScope(g, []*PrivateIPSpec{fakeClaimSpec()})
+
+	err := New(scope).Reconcile(context.TODO())
+	var reconcileErr azure.ReconcileError
+	g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+	g.Expect(reconcileErr.IsTransient()).To(BeTrue())
+	g.Expect(reconcileErr.RequeueAfter()).To(Equal(claimRequeueAfter))
+}
+
+func TestDeletePrivateIPs(t *testing.T) {
+	g := NewWithT(t)
+
+	scope := newFakeScope(g,
+		[]*PrivateIPSpec{
+			fakeClaimSpec(),
+			fakeSubnetPoolSpec("test-machine-nic-1"),
+			fakeSubnetPoolSpec("test-machine-nic-2"),
+		},
+		&ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-machine-nic", Namespace: "default"},
+			Spec:       ipamv1.IPAddressClaimSpec{PoolRef: *fakePoolRef},
+		},
+		fakeSubnetPoolAddress("test-machine-nic-1", "10.1.0.10"),
+		fakeSubnetPoolAddress("other-machine-nic", "10.1.0.11"),
+		func() client.Object {
+			address := fakeSubnetPoolAddress("test-machine-nic-1", "10.1.0.12")
+			address.Name = "test-machine-nic-1-leftover"
+			return address
+		}(),
+	)
+
+	g.Expect(New(scope).Delete(context.TODO())).To(Succeed())
+
+	err := scope.client.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-machine-nic"}, &ipamv1.IPAddressClaim{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = scope.client.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-machine-nic-1-address"}, &ipamv1.IPAddress{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = scope.client.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-machine-nic-1-leftover"}, &ipamv1.IPAddress{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(scope.client.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "other-machine-nic-address"}, &ipamv1.IPAddress{})).To(Succeed())
+}
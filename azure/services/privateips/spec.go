@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateips
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// PrivateIPSpec defines the specification for the static private IP address of a network interface drawn from a pool.
+type PrivateIPSpec struct {
+	// NICName is the name of the network interface the address is for.
+	NICName string
+	// ClaimName is the name of the IPAddressClaim of the address. Addresses allocated from the static IP pool of a subnet
+	// reference it as their claim without the claim being created.
+	ClaimName   string
+	Namespace   string
+	ClusterName string
+	// Owner is the owner of the IPAddressClaim or IPAddress, which is garbage collected along with it.
+	Owner metav1.OwnerReference
+	// PoolRef is a reference to the Cluster API IPAM pool the address is claimed from.
+	// When nil, the address is allocated from the static IP pool of the subnet.
+	PoolRef    *corev1.TypedLocalObjectReference
+	SubnetName string
+	// SubnetPool is the static IP pool of the subnet, if any.
+	SubnetPool *infrav1.PrivateIPAddressRange
+	// SubnetPrefix is the prefix length of the subnet, recorded in the allocated IPAddresses.
+	SubnetPrefix int
+}
+
+// subnetPoolRef returns the reference recorded as the pool of the addresses allocated from the static IP pools of the
+// subnets of the cluster.
+func (s *PrivateIPSpec) subnetPoolRef() corev1.TypedLocalObjectReference {
+	group := clusterv1.GroupVersion.Group
+	return corev1.TypedLocalObjectReference{
+		APIGroup: &group,
+		Kind:     "Cluster",
+		Name:     s.ClusterName,
+	}
+}
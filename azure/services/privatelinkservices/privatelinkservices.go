@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "privatelinkservices"
+
+// PrivateLinkServiceScope defines the scope interface for a private link service.
+type PrivateLinkServiceScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	PrivateLinkServiceSpec() azure.ResourceSpecGetter
+	SetPrivateLinkServiceStatus(*infrav1.PrivateLinkServiceStatus)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PrivateLinkServiceScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PrivateLinkServiceScope) *Service {
+	Client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, Client, Client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates a private link service and records its private endpoint connections.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.PrivateLinkServiceSpec()
+	if spec == nil {
+		return nil
+	}
+
+	result, err := s.CreateOrUpdateResource(ctx, spec, ServiceName)
+	if err == nil && result != nil {
+		pls, ok := result.(network.PrivateLinkService)
+		if !ok {
+			err = errors.Errorf("%T is not a network.PrivateLinkService", result)
+		} else {
+			s.Scope.SetPrivateLinkServiceStatus(privateLinkServiceStatus(pls))
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, err)
+	return err
+}
+
+// Delete deletes the private link service.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.PrivateLinkServiceSpec()
+	if spec == nil {
+		return nil
+	}
+
+	err := s.DeleteResource(ctx, spec, ServiceName)
+	if err == nil {
+		s.Scope.SetPrivateLinkServiceStatus(nil)
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, err)
+	return err
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO private link services.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// privateLinkServiceStatus converts a private link service to its status in the AzureCluster.
+func privateLinkServiceStatus(pls network.PrivateLinkService) *infrav1.PrivateLinkServiceStatus {
+	status := &infrav1.PrivateLinkServiceStatus{
+		ID: pointer.StringDeref(pls.ID, ""),
+	}
+	if pls.PrivateLinkServiceProperties == nil {
+		return status
+	}
+
+	status.Alias = pointer.StringDeref(pls.Alias, "")
+	if pls.PrivateEndpointConnections == nil {
+		return status
+	}
+	for _, connection := range *pls.PrivateEndpointConnections {
+		c := infrav1.PrivateEndpointConnection{
+			Name: pointer.StringDeref(connection.Name, ""),
+		}
+		if props := connection.PrivateEndpointConnectionProperties; props != nil {
+			if props.PrivateEndpoint != nil {
+				c.PrivateEndpointID = pointer.StringDeref(props.PrivateEndpoint.ID, "")
+			}
+			if state := props.PrivateLinkServiceConnectionState; state != nil {
+				c.Status = pointer.StringDeref(state.Status, "")
+				c.Description = pointer.StringDeref(state.Description, "")
+			}
+		}
+		status.PrivateEndpointConnections = append(status.PrivateEndpointConnections, c)
+	}
+	return status
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices/mock_privatelinkservices"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePrivateLinkServiceSpec = PrivateLinkServiceSpec{
+		Name:                 "my-lb-pls",
+		ResourceGroup:        "my-rg",
+		SubscriptionID:       "123",
+		Location:             "westus",
+		LoadBalancerName:     "my-lb",
+		FrontendIPConfigName: "my-lb-frontEnd",
+		VNetResourceGroup:    "my-rg",
+		VNetName:             "my-vnet",
+		NATSubnetName:        "my-subnet",
+		NATIPConfigurations:  []infrav1.PrivateLinkServiceNATIPConfiguration{{Name: "my-lb-pls-natip-0"}},
+		ClusterName:          "my-cluster",
+	}
+
+	fakePrivateLinkService = network.PrivateLinkService{
+		ID:   pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-lb-pls"),
+		Name: pointer.String("my-lb-pls"),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			Alias: pointer.String("my-lb-pls.1234.westus.azure.privatelinkservice"),
+			PrivateEndpointConnections: &[]network.PrivateEndpointConnection{
+				{
+					Name: pointer.String("my-lb-pls.consumer"),
+					PrivateEndpointConnectionProperties: &network.PrivateEndpointConnectionProperties{
+						PrivateEndpoint: &network.PrivateEndpoint{ID: pointer.String("/subscriptions/456/resourceGroups/consumer-rg/providers/Microsoft.Network/privateEndpoints/consumer")},
+						PrivateLinkServiceConnectionState: &network.PrivateLinkServiceConnectionState{
+							Status:      pointer.String("Approved"),
+							Description: pointer.String("Auto-approved"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcilePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no private link service spec is found",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create a private link service and record its private endpoint connections",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(fakePrivateLinkService, nil)
+				s.SetPrivateLinkServiceStatus(&infrav1.PrivateLinkServiceStatus{
+					ID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-lb-pls",
+					Alias: "my-lb-pls.1234.westus.azure.privatelinkservice",
+					PrivateEndpointConnections: []infrav1.PrivateEndpointConnection{
+						{
+							Name:              "my-lb-pls.consumer",
+							PrivateEndpointID: "/subscriptions/456/resourceGroups/consumer-rg/providers/Microsoft.Network/privateEndpoints/consumer",
+							Status:            "Approved",
+							Description:       "Auto-approved",
+						},
+					},
+				})
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to create a private link service",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "private link service creation in progress",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, notDoneError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinkservices.NewMockPrivateLinkServiceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no private link service spec is found",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete a private link service",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(nil)
+				s.SetPrivateLinkServiceStatus(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to delete a private link service",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinkservices.NewMockPrivateLinkServiceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// PrivateLinkServiceSpec defines the specification for a private link service.
+type PrivateLinkServiceSpec struct {
+	Name                      string
+	ResourceGroup             string
+	SubscriptionID            string
+	Location                  string
+	LoadBalancerName          string
+	FrontendIPConfigName      string
+	VNetResourceGroup         string
+	VNetName                  string
+	NATSubnetName             string
+	NATIPConfigurations       []infrav1.PrivateLinkServiceNATIPConfiguration
+	VisibilitySubscriptions   []string
+	AutoApprovalSubscriptions []string
+	EnableProxyProtocol       *bool
+	ClusterName               string
+	AdditionalTags            infrav1.Tags
+}
+
+// ResourceName returns the name of the private link service.
+func (s *PrivateLinkServiceSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateLinkServiceSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private link services.
+func (s *PrivateLinkServiceSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private link service.
+func (s *PrivateLinkServiceSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Parameters")
+	defer done()
+
+	subnetID := azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, s.NATSubnetName)
+	ipConfigurations := make([]network.PrivateLinkServiceIPConfiguration, 0, len(s.NATIPConfigurations))
+	for i, natIPConfig := range s.NATIPConfigurations {
+		properties := &network.PrivateLinkServiceIPConfigurationProperties{
+			Subnet:                    &network.Subnet{ID: pointer.String(subnetID)},
+			PrivateIPAllocationMethod: network.Dynamic,
+			Primary:                   pointer.Bool(i == 0),
+		}
+		if natIPConfig.PrivateIPAddress != "" {
+			properties.PrivateIPAllocationMethod = network.Static
+			properties.PrivateIPAddress = pointer.String(natIPConfig.PrivateIPAddress)
+		}
+		ipConfigurations = append(ipConfigurations, network.PrivateLinkServiceIPConfiguration{
+			Name: pointer.String(natIPConfig.Name),
+			PrivateLinkServiceIPConfigurationProperties: properties,
+		})
+	}
+
+	// Copy the subscription lists so they are never nil, which lets them be compared to the existing ones.
+	visibility := append([]string{}, s.VisibilitySubscriptions...)
+	autoApproval := append([]string{}, s.AutoApprovalSubscriptions...)
+
+	newPrivateLinkService := network.PrivateLinkService{
+		Name:     pointer.String(s.Name),
+		Location: pointer.String(s.Location),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					ID: pointer.String(azure.FrontendIPConfigID(s.SubscriptionID, s.ResourceGroup, s.LoadBalancerName, s.FrontendIPConfigName)),
+				},
+			},
+			IPConfigurations: &ipConfigurations,
+			Visibility: &network.PrivateLinkServicePropertiesVisibility{
+				Subscriptions: &visibility,
+			},
+			AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{
+				Subscriptions: &autoApproval,
+			},
+			EnableProxyProtocol: pointer.Bool(pointer.BoolDeref(s.EnableProxyProtocol, false)),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}
+
+	if existing != nil {
+		existingPLS, ok := existing.(network.PrivateLinkService)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateLinkService", existing)
+		}
+
+		diff := cmp.Diff(normalizePrivateLinkService(existingPLS), newPrivateLinkService)
+		if diff == "" {
+			// PrivateLinkService is up-to-date, nothing to do
+			log.V(4).Info("no changes found between user-updated spec and existing spec")
+			return nil, nil
+		}
+		log.V(4).Info("found a diff between the desired spec and the existing private link service", "difference", diff)
+	}
+
+	return newPrivateLinkService, nil
+}
+
+// normalizePrivateLinkService returns a copy of the existing private link service that only has the fields set by
+// Parameters, so it can be compared to the desired private link service.
+func normalizePrivateLinkService(existing network.PrivateLinkService) network.PrivateLinkService {
+	normalized := network.PrivateLinkService{
+		Name:     existing.Name,
+		Location: existing.Location,
+		Tags:     existing.Tags,
+	}
+	if existing.PrivateLinkServiceProperties == nil {
+		return normalized
+	}
+
+	frontendIPConfigs := []network.FrontendIPConfiguration{}
+	if existing.LoadBalancerFrontendIPConfigurations != nil {
+		for _, frontendIPConfig := range *existing.LoadBalancerFrontendIPConfigurations {
+			frontendIPConfigs = append(frontendIPConfigs, network.FrontendIPConfiguration{ID: frontendIPConfig.ID})
+		}
+	}
+
+	ipConfigurations := []network.PrivateLinkServiceIPConfiguration{}
+	if existing.IPConfigurations != nil {
+		for _, ipConfig := range *existing.IPConfigurations {
+			normalizedIPConfig := network.PrivateLinkServiceIPConfiguration{Name: ipConfig.Name}
+			if props := ipConfig.PrivateLinkServiceIPConfigurationProperties; props != nil {
+				normalizedIPConfig.PrivateLinkServiceIPConfigurationProperties = &network.PrivateLinkServiceIPConfigurationProperties{
+					PrivateIPAllocationMethod: props.PrivateIPAllocationMethod,
+					Primary:                   props.Primary,
+				}
+				if props.Subnet != nil {
+					normalizedIPConfig.Subnet = &network.Subnet{ID: props.Subnet.ID}
+				}
+				// Dynamically allocated addresses are chosen by Azure, so they are not part of the desired state.
+				if props.PrivateIPAllocationMethod == network.Static {
+					normalizedIPConfig.PrivateIPAddress = props.PrivateIPAddress
+				}
+			}
+			ipConfigurations = append(ipConfigurations, normalizedIPConfig)
+		}
+	}
+
+	visibility := []string{}
+	if existing.Visibility != nil && existing.Visibility.Subscriptions != nil {
+		visibility = *existing.Visibility.Subscriptions
+	}
+	autoApproval := []string{}
+	if existing.AutoApproval != nil && existing.AutoApproval.Subscriptions != nil {
+		autoApproval = *existing.AutoApproval.Subscriptions
+	}
+
+	normalized.PrivateLinkServiceProperties = &network.PrivateLinkServiceProperties{
+		LoadBalancerFrontendIPConfigurations: &frontendIPConfigs,
+		IPConfigurations:                     &ipConfigurations,
+		Visibility:                           &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &visibility},
+		AutoApproval:                         &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &autoApproval},
+		EnableProxyProtocol:                  pointer.Bool(pointer.BoolDeref(existing.EnableProxyProtocol, false)),
+	}
+	return normalized
+}
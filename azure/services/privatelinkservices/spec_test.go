@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	desired := func() network.PrivateLinkService {
+		params, err := fakePrivateLinkServiceSpec.Parameters(context.TODO(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return params.(network.PrivateLinkService)
+	}
+
+	testcases := []struct {
+		name     string
+		spec     *PrivateLinkServiceSpec
+		existing interface{}
+		expect   func(g *WithT, result interface{})
+		wantErr  string
+	}{
+		{
+			name:     "new private link service",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateLinkService{}))
+				pls := result.(network.PrivateLinkService)
+				g.Expect(*pls.Name).To(Equal("my-lb-pls"))
+				g.Expect(*pls.LoadBalancerFrontendIPConfigurations).To(Equal([]network.FrontendIPConfiguration{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb/frontendIPConfigurations/my-lb-frontEnd")},
+				}))
+				g.Expect(*pls.IPConfigurations).To(Equal([]network.PrivateLinkServiceIPConfiguration{
+					{
+						Name: pointer.String("my-lb-pls-natip-0"),
+						PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+							Subnet:                    &network.Subnet{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+							PrivateIPAllocationMethod: network.Dynamic,
+							Primary:                   pointer.Bool(true),
+						},
+					},
+				}))
+				g.Expect(pls.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", pointer.String("owned")))
+			},
+		},
+		{
+			name: "static NAT IP configurations and subscription lists",
+			spec: &PrivateLinkServiceSpec{
+				Name:                      "my-lb-pls",
+				NATIPConfigurations:       []infrav1.PrivateLinkServiceNATIPConfiguration{{Name: "natip-0", PrivateIPAddress: "10.0.0.50"}, {Name: "natip-1"}},
+				VisibilitySubscriptions:   []string{"sub-1", "sub-2"},
+				AutoApprovalSubscriptions: []string{"sub-1"},
+				EnableProxyProtocol:       pointer.Bool(true),
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				pls := result.(network.PrivateLinkService)
+				ipConfigs := *pls.IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				g.Expect(ipConfigs[0].PrivateIPAllocationMethod).To(Equal(network.Static))
+				g.Expect(ipConfigs[0].PrivateIPAddress).To(Equal(pointer.String("10.0.0.50")))
+				g.Expect(ipConfigs[0].Primary).To(Equal(pointer.Bool(true)))
+				g.Expect(ipConfigs[1].PrivateIPAllocationMethod).To(Equal(network.Dynamic))
+				g.Expect(ipConfigs[1].Primary).To(Equal(pointer.Bool(false)))
+				g.Expect(*pls.Visibility.Subscriptions).To(Equal([]string{"sub-1", "sub-2"}))
+				g.Expect(*pls.AutoApproval.Subscriptions).To(Equal([]string{"sub-1"}))
+				g.Expect(*pls.EnableProxyProtocol).To(BeTrue())
+			},
+		},
+		{
+			name: "existing private link service is up to date",
+			spec: &fakePrivateLinkServiceSpec,
+			existing: func() network.PrivateLinkService {
+				existing := desired()
+				existing.ID = pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-lb-pls")
+				existing.Alias = pointer.String("my-lb-pls.1234.westus.azure.privatelinkservice")
+				existing.ProvisioningState = network.ProvisioningStateSucceeded
+				(*existing.IPConfigurations)[0].PrivateIPAddress = pointer.String("10.0.0.7")
+				existing.Visibility.Subscriptions = nil
+				existing.AutoApproval = nil
+				existing.PrivateEndpointConnections = fakePrivateLinkService.PrivateEndpointConnections
+				return existing
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing private link service has a different visibility",
+			spec: &fakePrivateLinkServiceSpec,
+			existing: func() network.PrivateLinkService {
+				existing := desired()
+				existing.Visibility.Subscriptions = &[]string{"*"}
+				return existing
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateLinkService{}))
+				g.Expect(*result.(network.PrivateLinkService).Visibility.Subscriptions).To(BeEmpty())
+			},
+		},
+		{
+			name:     "existing is not a private link service",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: "wrong type",
+			wantErr:  "string is not a network.PrivateLinkService",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
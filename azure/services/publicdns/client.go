@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicdns
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	ListByType(context.Context, azure.ResourceSpecGetter, dns.RecordType) ([]dns.RecordSet, error)
+	Get(context.Context, azure.ResourceSpecGetter) (result interface{}, err error)
+	CreateOrUpdateAsync(context.Context, azure.ResourceSpecGetter, interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
+	DeleteAsync(context.Context, azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
+	IsDone(context.Context, azureautorest.FutureAPI) (isDone bool, err error)
+	Result(context.Context, azureautorest.FutureAPI, string) (result interface{}, err error)
+}
+
+// azureClient contains the Azure go-sdk Client for the record sets of public DNS zones.
+// The zone may be in another subscription than the cluster, so the record sets client is created for the subscription of each record set.
+type azureClient struct {
+	auth azure.Authorizer
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new record sets client.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{auth: auth}
+}
+
+// recordSets returns a record sets client for the subscription of the record set spec.
+func (ac *azureClient) recordSets(spec azure.ResourceSpecGetter) (dns.RecordSetsClient, *RecordSpec, error) {
+	recordSpec, ok := spec.(*RecordSpec)
+	if !ok {
+		return dns.RecordSetsClient{}, nil, errors.Errorf("%T is not a RecordSpec", spec)
+	}
+
+	subscriptionID := recordSpec.SubscriptionID
+	if subscriptionID == "" {
+		subscriptionID = ac.auth.SubscriptionID()
+	}
+	recordSetsClient := dns.NewRecordSetsClientWithBaseURI(ac.auth.BaseURI(), subscriptionID)
+	azure.SetAutoRestClientDefaults(&recordSetsClient.Client, ac.auth.Authorizer())
+	return recordSetsClient, recordSpec, nil
+}
+
+// ListByType lists the record sets of a type in the zone of the record set spec.
+func (ac *azureClient) ListByType(ctx context.Context, spec azure.ResourceSpecGetter, recordType dns.RecordType) ([]dns.RecordSet, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicdns.azureClient.ListByType")
+	defer done()
+
+	recordSets, recordSpec, err := ac.recordSets(spec)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := recordSets.ListByTypeComplete(ctx, recordSpec.ResourceGroup, recordSpec.ZoneName, recordType, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []dns.RecordSet
+	for iter.NotDone() {
+		sets = append(sets, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not iterate record sets")
+		}
+	}
+	return sets, nil
+}
+
+// Get gets the specified record set.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicdns.azureClient.Get")
+	defer done()
+
+	recordSets, recordSpec, err := ac.recordSets(spec)
+	if err != nil {
+		return nil, err
+	}
+	return recordSets.Get(ctx, recordSpec.ResourceGroup, recordSpec.ZoneName, recordSpec.Name, recordSpec.Type)
+}
+
+// CreateOrUpdateAsync creates or updates a record set.
+// Creating a record set is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicdns.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	set, ok := parameters.(dns.RecordSet)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a dns.RecordSet", parameters)
+	}
+
+	recordSets, recordSpec, err := ac.recordSets(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err = recordSets.CreateOrUpdate(ctx, recordSpec.ResourceGroup, recordSpec.ZoneName, recordSpec.Name, recordSpec.Type, set, "", "")
+	return result, nil, err
+}
+
+// DeleteAsync deletes a record set.
+// Deleting a record set is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicdns.azureClient.DeleteAsync")
+	defer done()
+
+	recordSets, recordSpec, err := ac.recordSets(spec)
+	if err != nil {
+		return nil, err
+	}
+	_, err = recordSets.Delete(ctx, recordSpec.ResourceGroup, recordSpec.ZoneName, recordSpec.Name, recordSpec.Type, "")
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed. Noop for record sets.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	return true, nil
+}
+
+// Result fetches the result of a long-running operation future. Noop for record sets.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	return nil, nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicdns
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "publicdns"
+
+// PublicDNSScope defines the scope interface for a public DNS service.
+type PublicDNSScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ClusterName() string
+	APIServerDNSRecordSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PublicDNSScope
+	client
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PublicDNSScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		client:     client,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the API server record sets, and deletes the CNAME record sets
+// of the cluster that are no longer in the spec.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicdns.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.APIServerDNSRecordSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of record sets to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resErr error
+	for _, spec := range specs {
+		if _, err := s.CreateOrUpdateResource(ctx, spec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	if resErr == nil {
+		resErr = s.deleteStaleCNAMEs(ctx, specs)
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.APIServerDNSReadyCondition, ServiceName, resErr)
+	return resErr
+}
+
+// deleteStaleCNAMEs deletes the CNAME record sets created for the cluster in the zone that are no longer in the spec.
+func (s *Service) deleteStaleCNAMEs(ctx context.Context, specs []azure.ResourceSpecGetter) error {
+	zoneSpec, ok := specs[0].(*RecordSpec)
+	if !ok {
+		return errors.Errorf("%T is not a RecordSpec", specs[0])
+	}
+
+	wanted := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		wanted[spec.ResourceName()] = true
+	}
+
+	sets, err := s.client.ListByType(ctx, zoneSpec, dns.CNAME)
+	if err != nil {
+		return errors.Wrap(err, "failed to list CNAME record sets")
+	}
+	for _, set := range sets {
+		name := pointer.StringDeref(set.Name, "")
+		if wanted[name] || !IsOwned(set, s.Scope.ClusterName()) || converters.MapToTags(set.Metadata).GetRole() != infrav1.APIServerRole {
+			continue
+		}
+		stale := &RecordSpec{
+			Name:           name,
+			Type:           dns.CNAME,
+			ZoneName:       zoneSpec.ZoneName,
+			ResourceGroup:  zoneSpec.ResourceGroup,
+			SubscriptionID: zoneSpec.SubscriptionID,
+			ClusterName:    zoneSpec.ClusterName,
+		}
+		if err := s.DeleteResource(ctx, stale, ServiceName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete deletes the API server record sets created for the cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicdns.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.APIServerDNSRecordSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of record sets to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var resErr error
+	for _, spec := range specs {
+		existing, err := s.client.Get(ctx, spec)
+		if azure.ResourceNotFound(err) {
+			continue
+		} else if err != nil {
+			resErr = errors.Wrapf(err, "failed to get record set %s", spec.ResourceName())
+			continue
+		}
+		if set, ok := existing.(dns.RecordSet); !ok || !IsOwned(set, s.Scope.ClusterName()) {
+			log.V(2).Info("Skipping deletion of record set not created by CAPZ", "record set", spec.ResourceName())
+			continue
+		}
+		if err := s.DeleteResource(ctx, spec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.APIServerDNSReadyCondition, ServiceName, resErr)
+	return resErr
+}
+
+// IsManaged returns always returns true as CAPZ only manages the record sets it created.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicdns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicdns/mock_publicdns"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeARecordSpec = RecordSpec{
+		Name:             "api",
+		Type:             dns.A,
+		ZoneName:         "example.com",
+		ResourceGroup:    "dns-rg",
+		SubscriptionID:   "dns-sub",
+		TTL:              300,
+		ClusterName:      "my-cluster",
+		TargetResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-cluster-api-pip",
+	}
+	fakeCNAMERecordSpec = RecordSpec{
+		Name:           "kube",
+		Type:           dns.CNAME,
+		ZoneName:       "example.com",
+		ResourceGroup:  "dns-rg",
+		SubscriptionID: "dns-sub",
+		TTL:            300,
+		ClusterName:    "my-cluster",
+		CNAME:          "api.example.com",
+	}
+
+	ownedMetadata = map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+		"sigs.k8s.io_cluster-api-provider-azure_role":               pointer.String(infrav1.APIServerRole),
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcilePublicDNS(t *testing.T) {
+	staleCNAMESpec := RecordSpec{
+		Name:           "old",
+		Type:           dns.CNAME,
+		ZoneName:       "example.com",
+		ResourceGroup:  "dns-rg",
+		SubscriptionID: "dns-sub",
+		ClusterName:    "my-cluster",
+	}
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, m *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no API server DNS is configured",
+			expectedError: "",
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, _ *mock_publicdns.MockclientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "create the record sets and delete stale CNAMEs of the cluster",
+			expectedError: "",
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, m *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return([]azure.ResourceSpecGetter{&fakeARecordSpec, &fakeCNAMERecordSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeARecordSpec, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeCNAMERecordSpec, ServiceName).Return(nil, nil)
+				s.ClusterName().Return("my-cluster").AnyTimes()
+				m.ListByType(gomockinternal.AContext(), &fakeARecordSpec, dns.CNAME).Return([]dns.RecordSet{
+					{Name: pointer.String("kube"), RecordSetProperties: &dns.RecordSetProperties{Metadata: ownedMetadata}},
+					{Name: pointer.String("old"), RecordSetProperties: &dns.RecordSetProperties{Metadata: ownedMetadata}},
+					{Name: pointer.String("www"), RecordSetProperties: &dns.RecordSetProperties{}},
+				}, nil)
+				r.DeleteResource(gomockinternal.AContext(), &staleCNAMESpec, ServiceName).Return(nil)
+				s.UpdatePutStatus(infrav1.APIServerDNSReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "record set creation in progress does not delete stale CNAMEs",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, _ *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return([]azure.ResourceSpecGetter{&fakeARecordSpec, &fakeCNAMERecordSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeARecordSpec, ServiceName).Return(nil, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeCNAMERecordSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.APIServerDNSReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "error creating a record set takes precedence over creation in progress",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, _ *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return([]azure.ResourceSpecGetter{&fakeARecordSpec, &fakeCNAMERecordSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeARecordSpec, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeCNAMERecordSpec, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.APIServerDNSReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicdns.NewMockPublicDNSScope(mockCtrl)
+			clientMock := mock_publicdns.NewMockclient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				client:     clientMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePublicDNS(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, m *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no API server DNS is configured",
+			expectedError: "",
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, _ *mock_publicdns.MockclientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "delete only the record sets owned by the cluster",
+			expectedError: "",
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, m *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return([]azure.ResourceSpecGetter{&fakeARecordSpec, &fakeCNAMERecordSpec})
+				s.ClusterName().Return("my-cluster").AnyTimes()
+				m.Get(gomockinternal.AContext(), &fakeARecordSpec).Return(dns.RecordSet{RecordSetProperties: &dns.RecordSetProperties{Metadata: ownedMetadata}}, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeARecordSpec, ServiceName).Return(nil)
+				m.Get(gomockinternal.AContext(), &fakeCNAMERecordSpec).Return(dns.RecordSet{RecordSetProperties: &dns.RecordSetProperties{}}, nil)
+				s.UpdateDeleteStatus(infrav1.APIServerDNSReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "skip record sets that no longer exist",
+			expectedError: "",
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, m *mock_publicdns.MockclientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return([]azure.ResourceSpecGetter{&fakeARecordSpec})
+				m.Get(gomockinternal.AContext(), &fakeARecordSpec).Return(nil, notFoundError)
+				s.UpdateDeleteStatus(infrav1.APIServerDNSReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "record set deletion in progress",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_publicdns.MockPublicDNSScopeMockRecorder, m *mock_publicdns.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerDNSRecordSpecs().Return([]azure.ResourceSpecGetter{&fakeARecordSpec})
+				s.ClusterName().Return("my-cluster").AnyTimes()
+				m.Get(gomockinternal.AContext(), &fakeARecordSpec).Return(dns.RecordSet{RecordSetProperties: &dns.RecordSetProperties{Metadata: ownedMetadata}}, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeARecordSpec, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.APIServerDNSReadyCondition, ServiceName, notDoneError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicdns.NewMockPublicDNSScope(mockCtrl)
+			clientMock := mock_publicdns.NewMockclient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				client:     clientMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicdns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// RecordSpec defines the specification for a record set of a public DNS zone.
+type RecordSpec struct {
+	Name           string
+	Type           dns.RecordType
+	ZoneName       string
+	ResourceGroup  string
+	SubscriptionID string
+	TTL            int64
+	ClusterName    string
+
+	// TargetResourceID is the Azure resource ID of the public IP an A or AAAA record set is an alias of.
+	TargetResourceID string
+	// CNAME is the FQDN a CNAME record set points to.
+	CNAME string
+}
+
+// ResourceName returns the name of the record set relative to its zone.
+func (s *RecordSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the zone.
+func (s *RecordSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the zone.
+func (s *RecordSpec) OwnerResourceName() string {
+	return s.ZoneName
+}
+
+// Parameters returns the parameters for the record set.
+func (s *RecordSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	if existing != nil {
+		existingSet, ok := existing.(dns.RecordSet)
+		if !ok {
+			return nil, errors.Errorf("%T is not a dns.RecordSet", existing)
+		}
+
+		// Record sets of the zone that were not created by CAPZ are never taken over.
+		if !IsOwned(existingSet, s.ClusterName) {
+			return nil, errors.Errorf("%s record set %s already exists in DNS zone %s and is not owned by the cluster", s.Type, s.Name, s.ZoneName)
+		}
+		if s.isUpToDate(existingSet) {
+			return nil, nil
+		}
+	}
+
+	properties := &dns.RecordSetProperties{
+		Metadata: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Role:        pointer.String(infrav1.APIServerRole),
+		})),
+		TTL: pointer.Int64(s.TTL),
+	}
+	switch s.Type {
+	case dns.A, dns.AAAA:
+		properties.TargetResource = &dns.SubResource{ID: pointer.String(s.TargetResourceID)}
+	case dns.CNAME:
+		properties.CnameRecord = &dns.CnameRecord{Cname: pointer.String(s.CNAME)}
+	default:
+		return nil, errors.Errorf("unsupported record type %s", s.Type)
+	}
+
+	return dns.RecordSet{RecordSetProperties: properties}, nil
+}
+
+// isUpToDate returns true if the record set has the TTL and the target of the spec.
+func (s *RecordSpec) isUpToDate(set dns.RecordSet) bool {
+	properties := set.RecordSetProperties
+	if properties == nil || pointer.Int64Deref(properties.TTL, 0) != s.TTL {
+		return false
+	}
+	switch s.Type {
+	case dns.A, dns.AAAA:
+		return properties.TargetResource != nil && strings.EqualFold(pointer.StringDeref(properties.TargetResource.ID, ""), s.TargetResourceID)
+	case dns.CNAME:
+		return properties.CnameRecord != nil && strings.EqualFold(pointer.StringDeref(properties.CnameRecord.Cname, ""), s.CNAME)
+	default:
+		return false
+	}
+}
+
+// IsOwned returns true if the record set was created by CAPZ for the cluster.
+func IsOwned(set dns.RecordSet, clusterName string) bool {
+	if set.RecordSetProperties == nil {
+		return false
+	}
+	return converters.MapToTags(set.Metadata).HasOwned(clusterName)
+}
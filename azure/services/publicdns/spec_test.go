@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicdns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *RecordSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new A record set is an alias of the public IP",
+			spec:     &fakeARecordSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(dns.RecordSet{
+					RecordSetProperties: &dns.RecordSetProperties{
+						Metadata:       ownedMetadata,
+						TTL:            pointer.Int64(300),
+						TargetResource: &dns.SubResource{ID: pointer.String(fakeARecordSpec.TargetResourceID)},
+					},
+				}))
+			},
+		},
+		{
+			name:     "new CNAME record set points to the API server record",
+			spec:     &fakeCNAMERecordSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(dns.RecordSet{
+					RecordSetProperties: &dns.RecordSetProperties{
+						Metadata:    ownedMetadata,
+						TTL:         pointer.Int64(300),
+						CnameRecord: &dns.CnameRecord{Cname: pointer.String("api.example.com")},
+					},
+				}))
+			},
+		},
+		{
+			name: "up to date record set is not updated",
+			spec: &fakeCNAMERecordSpec,
+			existing: dns.RecordSet{
+				RecordSetProperties: &dns.RecordSetProperties{
+					Metadata:    ownedMetadata,
+					TTL:         pointer.Int64(300),
+					CnameRecord: &dns.CnameRecord{Cname: pointer.String("API.example.com")},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "record set with a different TTL is updated",
+			spec: &fakeARecordSpec,
+			existing: dns.RecordSet{
+				RecordSetProperties: &dns.RecordSetProperties{
+					Metadata:       ownedMetadata,
+					TTL:            pointer.Int64(3600),
+					TargetResource: &dns.SubResource{ID: pointer.String(fakeARecordSpec.TargetResourceID)},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(dns.RecordSet{}))
+				g.Expect(result.(dns.RecordSet).TTL).To(Equal(pointer.Int64(300)))
+			},
+		},
+		{
+			name: "record set not owned by the cluster is not taken over",
+			spec: &fakeARecordSpec,
+			existing: dns.RecordSet{
+				RecordSetProperties: &dns.RecordSetProperties{
+					TTL:      pointer.Int64(300),
+					ARecords: &[]dns.ARecord{{Ipv4Address: pointer.String("1.2.3.4")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "A record set api already exists in DNS zone example.com and is not owned by the cluster",
+		},
+		{
+			name:     "existing is not a record set",
+			spec:     &fakeARecordSpec,
+			existing: "not a record set",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not a dns.RecordSet",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "publicipprefixes"
+
+// PublicIPPrefixScope defines the scope interface for a public IP prefix service.
+type PublicIPPrefixScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	PublicIPPrefixSpec() azure.ResourceSpecGetter
+	IsPublicIPPrefixManaged() bool
+	SetPublicIPPrefixStatus(*infrav1.PublicIPPrefixStatus)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PublicIPPrefixScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PublicIPPrefixScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the public IP prefix and records its allocated prefix.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.PublicIPPrefixSpec()
+	if spec == nil {
+		return nil
+	}
+
+	result, err := s.CreateOrUpdateResource(ctx, spec, ServiceName)
+	if err == nil && result != nil {
+		prefix, ok := result.(network.PublicIPPrefix)
+		if !ok {
+			err = errors.Errorf("%T is not a network.PublicIPPrefix", result)
+		} else {
+			s.Scope.SetPublicIPPrefixStatus(&infrav1.PublicIPPrefixStatus{
+				ID:       pointer.StringDeref(prefix.ID, ""),
+				IPPrefix: publicIPPrefixCIDR(prefix),
+			})
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, err)
+	return err
+}
+
+// Delete deletes the public IP prefix if it is managed by CAPZ.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.PublicIPPrefixSpec()
+	if spec == nil {
+		return nil
+	}
+
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping public IP prefix deletion for a pre-existing public IP prefix")
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check if the public IP prefix is managed")
+	}
+
+	err := s.DeleteResource(ctx, spec, ServiceName)
+	if err == nil {
+		s.Scope.SetPublicIPPrefixStatus(nil)
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, err)
+	return err
+}
+
+// IsManaged returns true if the public IP prefix is created by CAPZ.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.IsManaged")
+	defer done()
+
+	return s.Scope.IsPublicIPPrefixManaged(), nil
+}
+
+// publicIPPrefixCIDR returns the prefix allocated to a public IP prefix, if any.
+func publicIPPrefixCIDR(prefix network.PublicIPPrefix) string {
+	if prefix.PublicIPPrefixPropertiesFormat == nil {
+		return ""
+	}
+	return pointer.StringDeref(prefix.IPPrefix, "")
+}
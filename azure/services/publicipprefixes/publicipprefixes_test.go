@@ -0,0 +1,203 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes/mock_publicipprefixes"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePublicIPPrefixSpec = PublicIPPrefixSpec{
+		Name:          "my-cluster-pip-prefix",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+		Location:      "westus",
+		PrefixLength:  28,
+		Managed:       true,
+	}
+
+	fakePublicIPPrefix = network.PublicIPPrefix{
+		ID:   pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-cluster-pip-prefix"),
+		Name: pointer.String("my-cluster-pip-prefix"),
+		PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+			PrefixLength: pointer.Int32(28),
+			IPPrefix:     pointer.String("20.1.2.0/28"),
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcilePublicIPPrefix(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no public IP prefix spec is found",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create a public IP prefix and record its allocated prefix",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(&fakePublicIPPrefixSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPPrefixSpec, ServiceName).Return(fakePublicIPPrefix, nil)
+				s.SetPublicIPPrefixStatus(&infrav1.PublicIPPrefixStatus{
+					ID:       "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-cluster-pip-prefix",
+					IPPrefix: "20.1.2.0/28",
+				})
+				s.UpdatePutStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to create a public IP prefix",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(&fakePublicIPPrefixSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPPrefixSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "public IP prefix creation in progress",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(&fakePublicIPPrefixSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPPrefixSpec, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, notDoneError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePublicIPPrefix(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no public IP prefix spec is found",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(nil)
+			},
+		},
+		{
+			name:          "noop if the public IP prefix is pre-existing",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(&fakePublicIPPrefixSpec)
+				s.IsPublicIPPrefixManaged().Return(false)
+			},
+		},
+		{
+			name:          "delete a public IP prefix",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(&fakePublicIPPrefixSpec)
+				s.IsPublicIPPrefixManaged().Return(true)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPPrefixSpec, ServiceName).Return(nil)
+				s.SetPublicIPPrefixStatus(nil)
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to delete a public IP prefix",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpec().Return(&fakePublicIPPrefixSpec)
+				s.IsPublicIPPrefixManaged().Return(true)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPPrefixSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
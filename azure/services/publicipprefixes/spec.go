@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PublicIPPrefixSpec defines the specification for a public IP prefix.
+type PublicIPPrefixSpec struct {
+	Name           string
+	ResourceGroup  string
+	ClusterName    string
+	Location       string
+	PrefixLength   int32
+	FailureDomains []string
+	AdditionalTags infrav1.Tags
+	// Managed is false for a pre-existing public IP prefix, which CAPZ never creates.
+	Managed bool
+}
+
+// ResourceName returns the name of the public IP prefix.
+func (s *PublicIPPrefixSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PublicIPPrefixSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for public IP prefixes.
+func (s *PublicIPPrefixSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the public IP prefix.
+func (s *PublicIPPrefixSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(network.PublicIPPrefix); !ok {
+			return nil, errors.Errorf("%T is not a network.PublicIPPrefix", existing)
+		}
+		// public IP prefix already exists
+		return nil, nil
+	}
+
+	if !s.Managed {
+		return nil, errors.Errorf("public IP prefix %s not found in resource group %s", s.Name, s.ResourceGroup)
+	}
+
+	return network.PublicIPPrefix{
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+		Location: pointer.String(s.Location),
+		PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+			PublicIPAddressVersion: network.IPVersionIPv4,
+			PrefixLength:           pointer.Int32(s.PrefixLength),
+		},
+		Zones: &s.FailureDomains,
+	}, nil
+}
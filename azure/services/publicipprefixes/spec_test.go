@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          PublicIPPrefixSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "noop if public IP prefix exists",
+			spec:     fakePublicIPPrefixSpec,
+			existing: fakePublicIPPrefix,
+			expected: nil,
+		},
+		{
+			name: "public IP prefix managed by CAPZ",
+			spec: PublicIPPrefixSpec{
+				Name:           "my-cluster-pip-prefix",
+				ResourceGroup:  "my-rg",
+				ClusterName:    "my-cluster",
+				Location:       "westus",
+				PrefixLength:   30,
+				FailureDomains: []string{"1", "2", "3"},
+				Managed:        true,
+			},
+			existing: nil,
+			expected: network.PublicIPPrefix{
+				Tags: map[string]*string{
+					"Name": pointer.String("my-cluster-pip-prefix"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				},
+				Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+				Location: pointer.String("westus"),
+				PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+					PublicIPAddressVersion: network.IPVersionIPv4,
+					PrefixLength:           pointer.Int32(30),
+				},
+				Zones: &[]string{"1", "2", "3"},
+			},
+		},
+		{
+			name: "pre-existing public IP prefix not found",
+			spec: PublicIPPrefixSpec{
+				Name:          "byo-prefix",
+				ResourceGroup: "network-rg",
+				ClusterName:   "my-cluster",
+				Location:      "westus",
+			},
+			existing:      nil,
+			expectedError: "public IP prefix byo-prefix not found in resource group network-rg",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+		})
+	}
+}
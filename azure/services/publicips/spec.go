@@ -39,6 +39,7 @@ type PublicIPSpec struct {
 	FailureDomains   []string
 	AdditionalTags   infrav1.Tags
 	IPTags           []infrav1.IPTag
+	PublicIPPrefixID string
 }
 
 // ResourceName returns the name of the public IP.
@@ -80,6 +81,12 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 		}
 	}
 
+	// only allocate the public IP from a public IP prefix if there is one specified, as prefixes are IPv4 only
+	var publicIPPrefix *network.SubResource
+	if s.PublicIPPrefixID != "" && !s.IsIPv6 {
+		publicIPPrefix = &network.SubResource{ID: pointer.String(s.PublicIPPrefixID)}
+	}
+
 	return network.PublicIPAddress{
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
@@ -96,6 +103,7 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
 			DNSSettings:              dnsSettings,
 			IPTags:                   converters.IPTagsToSDK(s.IPTags),
+			PublicIPPrefix:           publicIPPrefix,
 		},
 		Zones: &s.FailureDomains,
 	}, nil
@@ -50,6 +50,17 @@ var (
 		FailureDomains: []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
 	}
 
+	fakePublicIPSpecWithPrefix = PublicIPSpec{
+		Name:        "my-publicip-3",
+		Location:    "centralIndia",
+		ClusterName: "my-cluster",
+		AdditionalTags: infrav1.Tags{
+			"foo": "bar",
+		},
+		FailureDomains:   []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+		PublicIPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+	}
+
 	fakePublicIPWithDNS = network.PublicIPAddress{
 		Name:     pointer.String("my-publicip"),
 		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
@@ -86,6 +97,25 @@ var (
 		Zones: &[]string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
 	}
 
+	fakePublicIPWithPrefix = network.PublicIPAddress{
+		Name:     pointer.String("my-publicip-3"),
+		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+		Location: pointer.String("centralIndia"),
+		Tags: map[string]*string{
+			"Name": pointer.String("my-publicip-3"),
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+			"foo": pointer.String("bar"),
+		},
+		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			PublicIPAddressVersion:   network.IPVersionIPv4,
+			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+			PublicIPPrefix: &network.SubResource{
+				ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			},
+		},
+		Zones: &[]string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+	}
+
 	fakePublicIPIpv6 = network.PublicIPAddress{
 		Name:     pointer.String("my-publicip-ipv6"),
 		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
@@ -136,6 +166,13 @@ func TestParameters(t *testing.T) {
 			expected:      fakePublicIPWithoutDNS,
 			expectedError: "",
 		},
+		{
+			name:          "public ipv4 address allocated from a public IP prefix",
+			existing:      nil,
+			spec:          fakePublicIPSpecWithPrefix,
+			expected:      fakePublicIPWithPrefix,
+			expectedError: "",
+		},
 		{
 			name:          "public ipv6 address with dns",
 			existing:      nil,
@@ -21,6 +21,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/component-base/featuregate"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
@@ -50,13 +51,29 @@ type AvailabilityStatusFilterer interface {
 type Service struct {
 	Scope ResourceHealthScope
 	client
+	featureGate       featuregate.Feature
+	failOnUnavailable bool
 }
 
-// New creates a new service.
+// New creates a new service reporting the availability status of an AKS cluster.
+// An unavailable resource fails the reconciliation.
 func New(scope ResourceHealthScope) *Service {
 	return &Service{
-		Scope:  scope,
-		client: newClient(scope),
+		Scope:             scope,
+		client:            newClient(scope),
+		featureGate:       feature.AKSResourceHealth,
+		failOnUnavailable: true,
+	}
+}
+
+// NewMachineService creates a new service reporting the availability status of a VM or a scale set VM.
+// An unavailable VM, or a failure to get its availability status, is only reported in the AzureResourceAvailable
+// condition, so it does not block the reconciliation of the machine.
+func NewMachineService(scope ResourceHealthScope) *Service {
+	return &Service{
+		Scope:       scope,
+		client:      newClient(scope),
+		featureGate: feature.MachineResourceHealth,
 	}
 }
 
@@ -70,15 +87,25 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourcehealth.Service.Reconcile")
 	defer done()
 
-	if !feature.Gates.Enabled(feature.AKSResourceHealth) {
+	if !feature.Gates.Enabled(s.featureGate) {
 		conditions.Delete(s.Scope.AvailabilityStatusResource(), infrav1.AzureResourceAvailableCondition)
 		return nil
 	}
 
 	resource := s.Scope.AvailabilityStatusResourceURI()
+	if resource == "" {
+		// The resource has not been created yet.
+		return nil
+	}
 	availStatus, err := s.GetByResource(ctx, resource)
 	if err != nil {
-		return errors.Wrapf(err, "failed to get availability status for resource %s", resource)
+		err = errors.Wrapf(err, "failed to get availability status for resource %s", resource)
+		if s.failOnUnavailable {
+			return err
+		}
+		log.Error(err, "failed to get availability status")
+		conditions.MarkUnknown(s.Scope.AvailabilityStatusResource(), infrav1.AzureResourceAvailableCondition, infrav1.AvailabilityStatusUnknownReason, "failed to get availability status: %s", err.Error())
+		return nil
 	}
 	log.V(2).Info("got availability status for resource", "resource", resource, "status", availStatus)
 
@@ -89,7 +116,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	conditions.Set(s.Scope.AvailabilityStatusResource(), cond)
 
-	if cond.Status == corev1.ConditionFalse {
+	if cond.Status != corev1.ConditionTrue && s.failOnUnavailable {
 		return errors.Errorf("resource is not available: %s", cond.Message)
 	}
 
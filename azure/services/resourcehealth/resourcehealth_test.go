@@ -38,6 +38,7 @@ func TestReconcileResourceHealth(t *testing.T) {
 		name            string
 		featureDisabled bool
 		filterEnabled   bool
+		machine         bool
 		expect          func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder, f *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder)
 		expectedError   string
 	}{
@@ -45,7 +46,7 @@ func TestReconcileResourceHealth(t *testing.T) {
 			name: "available resource",
 			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder, _ *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder) {
 				s.AvailabilityStatusResource().Times(1)
-				s.AvailabilityStatusResourceURI().Times(1)
+				s.AvailabilityStatusResourceURI().Times(1).Return("myURI")
 				m.GetByResource(gomockinternal.AContext(), gomock.Any()).Times(1).Return(resourcehealth.AvailabilityStatus{
 					Properties: &resourcehealth.AvailabilityStatusProperties{
 						AvailabilityState: resourcehealth.AvailabilityStateValuesAvailable,
@@ -58,7 +59,7 @@ func TestReconcileResourceHealth(t *testing.T) {
 			name: "unavailable resource",
 			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder, _ *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder) {
 				s.AvailabilityStatusResource().Times(1)
-				s.AvailabilityStatusResourceURI().Times(1)
+				s.AvailabilityStatusResourceURI().Times(1).Return("myURI")
 				m.GetByResource(gomockinternal.AContext(), gomock.Any()).Times(1).Return(resourcehealth.AvailabilityStatus{
 					Properties: &resourcehealth.AvailabilityStatusProperties{
 						AvailabilityState: resourcehealth.AvailabilityStateValuesUnavailable,
@@ -81,7 +82,7 @@ func TestReconcileResourceHealth(t *testing.T) {
 			filterEnabled: true,
 			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder, f *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder) {
 				s.AvailabilityStatusResource().Times(1)
-				s.AvailabilityStatusResourceURI().Times(1)
+				s.AvailabilityStatusResourceURI().Times(1).Return("myURI")
 				m.GetByResource(gomockinternal.AContext(), gomock.Any()).Times(1).Return(resourcehealth.AvailabilityStatus{
 					Properties: &resourcehealth.AvailabilityStatusProperties{
 						AvailabilityState: resourcehealth.AvailabilityStateValuesUnavailable,
@@ -93,6 +94,39 @@ func TestReconcileResourceHealth(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:    "unavailable VM",
+			machine: true,
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder, _ *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder) {
+				s.AvailabilityStatusResource().Times(1)
+				s.AvailabilityStatusResourceURI().Times(1).Return("myURI")
+				m.GetByResource(gomockinternal.AContext(), gomock.Any()).Times(1).Return(resourcehealth.AvailabilityStatus{
+					Properties: &resourcehealth.AvailabilityStatusProperties{
+						AvailabilityState: resourcehealth.AvailabilityStateValuesUnavailable,
+						Summary:           pointer.String("summary"),
+					},
+				}, nil)
+			},
+			expectedError: "",
+		},
+		{
+			name:    "VM API error",
+			machine: true,
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder, _ *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder) {
+				s.AvailabilityStatusResource().Times(1)
+				s.AvailabilityStatusResourceURI().Times(1).Return("myURI")
+				m.GetByResource(gomockinternal.AContext(), gomock.Any()).Times(1).Return(resourcehealth.AvailabilityStatus{}, errors.New("some API error"))
+			},
+			expectedError: "",
+		},
+		{
+			name:    "VM not created yet",
+			machine: true,
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, _ *mock_resourcehealth.MockclientMockRecorder, _ *mock_resourcehealth.MockAvailabilityStatusFiltererMockRecorder) {
+				s.AvailabilityStatusResourceURI().Times(1).Return("")
+			},
+			expectedError: "",
+		},
 		{
 			name:            "feature disabled",
 			featureDisabled: true,
@@ -115,8 +149,14 @@ func TestReconcileResourceHealth(t *testing.T) {
 			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), filtererMock.EXPECT())
 
 			s := &Service{
-				Scope:  scopeMock,
-				client: clientMock,
+				Scope:             scopeMock,
+				client:            clientMock,
+				featureGate:       feature.AKSResourceHealth,
+				failOnUnavailable: true,
+			}
+			if tc.machine {
+				s.featureGate = feature.MachineResourceHealth
+				s.failOnUnavailable = false
 			}
 			if tc.filterEnabled {
 				s.Scope = struct {
@@ -125,7 +165,7 @@ func TestReconcileResourceHealth(t *testing.T) {
 				}{scopeMock, filtererMock}
 			}
 
-			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.AKSResourceHealth, !tc.featureDisabled)()
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, s.featureGate, !tc.featureDisabled)()
 
 			err := s.Reconcile(context.TODO())
 
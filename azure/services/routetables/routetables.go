@@ -35,6 +35,7 @@ type RouteTableScope interface {
 	azure.AsyncStatusUpdater
 	RouteTableSpecs() []azure.ResourceSpecGetter
 	IsVnetManaged() bool
+	UpdateAnnotationJSON(string, map[string]interface{}) error
 }
 
 // Service provides operations on azure resources.
@@ -90,6 +91,20 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
+	// Record the routes applied to each route table once they are all reconciled, so that the routes later removed
+	// from the spec can be told apart from the routes added by other components.
+	if resErr == nil {
+		lastApplied := make(map[string]interface{}, len(specs))
+		for _, rtSpec := range specs {
+			if spec, ok := rtSpec.(*RouteTableSpec); ok {
+				lastApplied[spec.Name] = spec.routeNames()
+			}
+		}
+		if err := s.Scope.UpdateAnnotationJSON(azure.RouteTableRoutesLastAppliedAnnotation, lastApplied); err != nil {
+			resErr = errors.Wrap(err, "failed to update the last applied routes annotation")
+		}
+	}
+
 	s.Scope.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, resErr)
 	return resErr
 }
@@ -46,6 +46,16 @@ var (
 		Location:      "fake-location",
 		ClusterName:   "test-cluster",
 	}
+	fakeRTWithRoutes = RouteTableSpec{
+		Name:          "test-rt-3",
+		ResourceGroup: "test-rg",
+		Location:      "fake-location",
+		ClusterName:   "test-cluster",
+		Routes: []RouteSpec{
+			{Name: "to-onprem", AddressPrefix: "192.168.0.0/16", NextHopType: "VirtualNetworkGateway"},
+			{Name: "to-firewall", AddressPrefix: "172.16.0.0/12", NextHopType: "VirtualAppliance", NextHopIPAddress: "10.0.255.4"},
+		},
+	}
 	errFake      = errors.New("this is an error")
 	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
 )
@@ -73,9 +83,37 @@ func TestReconcileRouteTables(t *testing.T) {
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.RouteTableRoutesLastAppliedAnnotation, map[string]interface{}{
+					"test-rt-1": []string{},
+					"test-rt-2": []string{},
+				}).Return(nil)
 				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "routes applied to the route tables are recorded",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRTWithRoutes})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRTWithRoutes, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.RouteTableRoutesLastAppliedAnnotation, map[string]interface{}{
+					"test-rt-3": []string{"to-onprem", "to-firewall"},
+				}).Return(nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "recording the routes applied to the route tables fails",
+			expectedError: "failed to update the last applied routes annotation: " + errFake.Error(),
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRTWithRoutes})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRTWithRoutes, serviceName).Return(nil, nil)
+				s.UpdateAnnotationJSON(azure.RouteTableRoutesLastAppliedAnnotation, gomock.Any()).Return(errFake)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, gomock.Any())
+			},
+		},
 		{
 			name:          "first route table create fails",
 			expectedError: errFake.Error(),
@@ -33,6 +33,18 @@ type RouteTableSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	Routes         []RouteSpec
+	// StaleRouteNames are the names of the routes previously applied by CAPZ that are no longer in Routes,
+	// which are deleted from the route table.
+	StaleRouteNames []string
+}
+
+// RouteSpec defines the specification for a route managed by CAPZ in a route table.
+type RouteSpec struct {
+	Name             string
+	AddressPrefix    string
+	NextHopType      string
+	NextHopIPAddress string
 }
 
 // ResourceName returns the name of the route table.
@@ -53,16 +65,33 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.RouteTable); !ok {
+		existingRouteTable, ok := existing.(network.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.RouteTable", existing)
 		}
-		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// Only the routes managed by CAPZ are reconciled, routes added by other components (e.g. the cloud-controller-manager) are kept as is.
+		var existingRoutes []network.Route
+		if existingRouteTable.RouteTablePropertiesFormat != nil && existingRouteTable.Routes != nil {
+			existingRoutes = *existingRouteTable.Routes
+		}
+		routes, changed := s.mergeRoutes(existingRoutes)
+		if !changed {
+			// route table is up to date, nothing to do
+			return nil, nil
+		}
+		existingRouteTable.Routes = &routes
+		return existingRouteTable, nil
+	}
+
+	routes := make([]network.Route, 0, len(s.Routes))
+	for _, route := range s.Routes {
+		routes = append(routes, route.toRoute())
 	}
 	return network.RouteTable{
-		Location:                   pointer.String(s.Location),
-		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
+		Location: pointer.String(s.Location),
+		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+			Routes: &routes,
+		},
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -71,3 +100,80 @@ func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (
 		})),
 	}, nil
 }
+
+// mergeRoutes returns the existing routes updated with the routes in the spec and without the stale routes,
+// and whether any of them changed.
+func (s *RouteTableSpec) mergeRoutes(existing []network.Route) ([]network.Route, bool) {
+	var changed bool
+	routes := make([]network.Route, 0, len(existing))
+	for _, route := range existing {
+		if s.isStale(pointer.StringDeref(route.Name, "")) {
+			changed = true
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	for _, want := range s.Routes {
+		found := false
+		for i, route := range routes {
+			if pointer.StringDeref(route.Name, "") != want.Name {
+				continue
+			}
+			found = true
+			if !want.matches(route) {
+				routes[i] = want.toRoute()
+				changed = true
+			}
+			break
+		}
+		if !found {
+			routes = append(routes, want.toRoute())
+			changed = true
+		}
+	}
+	return routes, changed
+}
+
+// isStale returns true if the route with the given name was previously applied by CAPZ and is no longer in the spec.
+func (s *RouteTableSpec) isStale(name string) bool {
+	for _, staleName := range s.StaleRouteNames {
+		if staleName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// routeNames returns the names of the routes in the spec.
+func (s *RouteTableSpec) routeNames() []string {
+	names := make([]string, 0, len(s.Routes))
+	for _, route := range s.Routes {
+		names = append(names, route.Name)
+	}
+	return names
+}
+
+// matches returns true if the route has the address prefix and next hop of the spec.
+func (r RouteSpec) matches(route network.Route) bool {
+	if route.RoutePropertiesFormat == nil {
+		return false
+	}
+	return pointer.StringDeref(route.AddressPrefix, "") == r.AddressPrefix &&
+		string(route.NextHopType) == r.NextHopType &&
+		pointer.StringDeref(route.NextHopIPAddress, "") == r.NextHopIPAddress
+}
+
+func (r RouteSpec) toRoute() network.Route {
+	route := network.Route{
+		Name: pointer.String(r.Name),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix: pointer.String(r.AddressPrefix),
+			NextHopType:   network.RouteNextHopType(r.NextHopType),
+		},
+	}
+	if r.NextHopIPAddress != "" {
+		route.NextHopIPAddress = pointer.String(r.NextHopIPAddress)
+	}
+	return route
+}
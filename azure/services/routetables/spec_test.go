@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var (
+	egressRoute = RouteSpec{
+		Name:             "default-egress",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      string(network.RouteNextHopTypeVirtualAppliance),
+		NextHopIPAddress: "10.2.0.4",
+	}
+	ccmRoute = network.Route{
+		Name: pointer.String("k8s-node-0"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    pointer.String("192.168.0.0/24"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: pointer.String("10.1.0.4"),
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *RouteTableSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "route table does not exist",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				ResourceGroup: "test-group",
+				Location:      "test-location",
+				ClusterName:   "my-cluster",
+				Routes:        []RouteSpec{egressRoute},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				routeTable := result.(network.RouteTable)
+				g.Expect(routeTable.Location).To(Equal(pointer.String("test-location")))
+				g.Expect(*routeTable.Routes).To(Equal([]network.Route{egressRoute.toRoute()}))
+			},
+		},
+		{
+			name: "route table already exists with all routes present",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				ResourceGroup: "test-group",
+				Location:      "test-location",
+				ClusterName:   "my-cluster",
+				Routes:        []RouteSpec{egressRoute},
+			},
+			existing: network.RouteTable{
+				Name: pointer.String("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{ccmRoute, egressRoute.toRoute()},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table already exists without routes in the spec",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				ResourceGroup: "test-group",
+				Location:      "test-location",
+				ClusterName:   "my-cluster",
+			},
+			existing: network.RouteTable{
+				Name: pointer.String("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{ccmRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table already exists but is missing a route",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				ResourceGroup: "test-group",
+				Location:      "test-location",
+				ClusterName:   "my-cluster",
+				Routes:        []RouteSpec{egressRoute},
+			},
+			existing: network.RouteTable{
+				Name:     pointer.String("test-rt"),
+				Location: pointer.String("test-location"),
+				Etag:     pointer.String("fake-etag"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{ccmRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				routeTable := result.(network.RouteTable)
+				g.Expect(routeTable.Etag).To(Equal(pointer.String("fake-etag")))
+				g.Expect(*routeTable.Routes).To(Equal([]network.Route{ccmRoute, egressRoute.toRoute()}))
+			},
+		},
+		{
+			name: "route table already exists with an outdated route",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				ResourceGroup: "test-group",
+				Location:      "test-location",
+				ClusterName:   "my-cluster",
+				Routes:        []RouteSpec{egressRoute},
+			},
+			existing: network.RouteTable{
+				Name: pointer.String("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						{
+							Name: pointer.String("default-egress"),
+							RoutePropertiesFormat: &network.RoutePropertiesFormat{
+								AddressPrefix:    pointer.String("0.0.0.0/0"),
+								NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+								NextHopIPAddress: pointer.String("10.2.0.5"),
+							},
+						},
+						ccmRoute,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				routeTable := result.(network.RouteTable)
+				g.Expect(*routeTable.Routes).To(Equal([]network.Route{egressRoute.toRoute(), ccmRoute}))
+			},
+		},
+		{
+			name: "route table already exists with a route removed from the spec",
+			spec: &RouteTableSpec{
+				Name:            "test-rt",
+				ResourceGroup:   "test-group",
+				Location:        "test-location",
+				ClusterName:     "my-cluster",
+				Routes:          []RouteSpec{egressRoute},
+				StaleRouteNames: []string{"to-onprem"},
+			},
+			existing: network.RouteTable{
+				Name: pointer.String("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						egressRoute.toRoute(),
+						{
+							Name: pointer.String("to-onprem"),
+							RoutePropertiesFormat: &network.RoutePropertiesFormat{
+								AddressPrefix: pointer.String("192.168.0.0/16"),
+								NextHopType:   network.RouteNextHopTypeVirtualNetworkGateway,
+							},
+						},
+						ccmRoute,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				routeTable := result.(network.RouteTable)
+				g.Expect(*routeTable.Routes).To(Equal([]network.Route{egressRoute.toRoute(), ccmRoute}))
+			},
+		},
+		{
+			name: "route table already exists without the routes removed from the spec",
+			spec: &RouteTableSpec{
+				Name:            "test-rt",
+				ResourceGroup:   "test-group",
+				Location:        "test-location",
+				ClusterName:     "my-cluster",
+				Routes:          []RouteSpec{egressRoute},
+				StaleRouteNames: []string{"to-onprem"},
+			},
+			existing: network.RouteTable{
+				Name: pointer.String("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{egressRoute.toRoute(), ccmRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing is not a route table",
+			spec: &RouteTableSpec{
+				Name: "test-rt",
+			},
+			existing:      network.SecurityGroup{},
+			expectedError: "network.SecurityGroup is not a network.RouteTable",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runcommands
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// instanceViewGetter gets the instance view of run commands.
+type instanceViewGetter interface {
+	GetInstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (*compute.VirtualMachineRunCommandInstanceView, error)
+}
+
+// azureClient contains the Azure go-sdk Clients.
+type azureClient struct {
+	vmRunCommands     compute.VirtualMachineRunCommandsClient
+	scaleSetVMCommand compute.VirtualMachineScaleSetVMRunCommandsClient
+}
+
+// newClient creates a new run commands client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	vmRunCommands := compute.NewVirtualMachineRunCommandsClientWithBaseURI(auth.BaseURI(), auth.SubscriptionID())
+	azure.SetAutoRestClientDefaults(&vmRunCommands.Client, auth.Authorizer())
+	scaleSetVMCommand := compute.NewVirtualMachineScaleSetVMRunCommandsClientWithBaseURI(auth.BaseURI(), auth.SubscriptionID())
+	azure.SetAutoRestClientDefaults(&scaleSetVMCommand.Client, auth.Authorizer())
+	return &azureClient{
+		vmRunCommands:     vmRunCommands,
+		scaleSetVMCommand: scaleSetVMCommand,
+	}
+}
+
+// Get returns the specified run command, including its instance view.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.AzureClient.Get")
+	defer done()
+
+	return ac.get(ctx, spec)
+}
+
+// GetInstanceView returns the instance view of the specified run command, or nil if it is not available yet.
+func (ac *azureClient) GetInstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (*compute.VirtualMachineRunCommandInstanceView, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.AzureClient.GetInstanceView")
+	defer done()
+
+	command, err := ac.get(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if command.VirtualMachineRunCommandProperties == nil {
+		return nil, nil
+	}
+	return command.InstanceView, nil
+}
+
+func (ac *azureClient) get(ctx context.Context, spec azure.ResourceSpecGetter) (compute.VirtualMachineRunCommand, error) {
+	rcSpec, ok := spec.(*RunCommandSpec)
+	if !ok {
+		return compute.VirtualMachineRunCommand{}, errors.Errorf("%T is not a RunCommandSpec", spec)
+	}
+
+	if rcSpec.ScaleSetName != "" {
+		return ac.scaleSetVMCommand.Get(ctx, rcSpec.ResourceGroup, rcSpec.ScaleSetName, rcSpec.InstanceID, rcSpec.Name, "instanceView")
+	}
+	return ac.vmRunCommands.GetByVirtualMachine(ctx, rcSpec.ResourceGroup, rcSpec.VMName, rcSpec.Name, "instanceView")
+}
+
+// CreateOrUpdateAsync creates a run command asynchronously.
+// It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.AzureClient.CreateOrUpdateAsync")
+	defer done()
+
+	rcSpec, ok := spec.(*RunCommandSpec)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a RunCommandSpec", spec)
+	}
+
+	command, ok := parameters.(compute.VirtualMachineRunCommand)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachineRunCommand", parameters)
+	}
+
+	var (
+		createFuture azureautorest.FutureAPI
+		resultFunc   func() (compute.VirtualMachineRunCommand, error)
+	)
+	if rcSpec.ScaleSetName != "" {
+		f, err := ac.scaleSetVMCommand.CreateOrUpdate(ctx, rcSpec.ResourceGroup, rcSpec.ScaleSetName, rcSpec.InstanceID, rcSpec.Name, command)
+		if err != nil {
+			return nil, nil, err
+		}
+		createFuture = &f
+		resultFunc = func() (compute.VirtualMachineRunCommand, error) { return f.Result(ac.scaleSetVMCommand) }
+	} else {
+		f, err := ac.vmRunCommands.CreateOrUpdate(ctx, rcSpec.ResourceGroup, rcSpec.VMName, rcSpec.Name, command)
+		if err != nil {
+			return nil, nil, err
+		}
+		createFuture = &f
+		resultFunc = func() (compute.VirtualMachineRunCommand, error) { return f.Result(ac.vmRunCommands) }
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = createFuture.WaitForCompletionRef(ctx, ac.vmRunCommands.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, createFuture, err
+	}
+	result, err = resultFunc()
+	// if the operation completed, return a nil future.
+	return result, nil, err
+}
+
+// DeleteAsync deletes a run command asynchronously. DeleteAsync sends a DELETE
+// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.AzureClient.DeleteAsync")
+	defer done()
+
+	rcSpec, ok := spec.(*RunCommandSpec)
+	if !ok {
+		return nil, errors.Errorf("%T is not a RunCommandSpec", spec)
+	}
+
+	var (
+		deleteFuture azureautorest.FutureAPI
+		resultFunc   func() (autorest.Response, error)
+	)
+	if rcSpec.ScaleSetName != "" {
+		f, err := ac.scaleSetVMCommand.Delete(ctx, rcSpec.ResourceGroup, rcSpec.ScaleSetName, rcSpec.InstanceID, rcSpec.Name)
+		if err != nil {
+			return nil, err
+		}
+		deleteFuture = &f
+		resultFunc = func() (autorest.Response, error) { return f.Result(ac.scaleSetVMCommand) }
+	} else {
+		f, err := ac.vmRunCommands.Delete(ctx, rcSpec.ResourceGroup, rcSpec.VMName, rcSpec.Name)
+		if err != nil {
+			return nil, err
+		}
+		deleteFuture = &f
+		resultFunc = func() (autorest.Response, error) { return f.Result(ac.vmRunCommands) }
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = deleteFuture.WaitForCompletionRef(ctx, ac.vmRunCommands.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return deleteFuture, err
+	}
+	_, err = resultFunc()
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.AzureClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.vmRunCommands)
+}
+
+// Result fetches the result of a long-running operation future.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "runcommands.AzureClient.Result")
+	defer done()
+
+	if future == nil {
+		return nil, errors.Errorf("cannot get result from nil future")
+	}
+
+	switch futureType {
+	case infrav1.PutFuture:
+		// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
+		// Scale set VM run commands have the same payload as VM run commands, so the VM future type is used for both.
+		var createFuture *compute.VirtualMachineRunCommandsCreateOrUpdateFuture
+		jsonData, err := future.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal future")
+		}
+		if err := json.Unmarshal(jsonData, &createFuture); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal future data")
+		}
+		return createFuture.Result(ac.vmRunCommands)
+
+	case infrav1.DeleteFuture:
+		// Delete does not return a result run command.
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("unknown future type %q", futureType)
+	}
+}
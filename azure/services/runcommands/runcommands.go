@@ -0,0 +1,205 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runcommands
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "runcommands"
+
+// maxOutputLength is the maximum length of the standard output or error of a script kept in the status.
+const maxOutputLength = 1024
+
+// RunCommandScope defines the scope interface for a run commands service.
+type RunCommandScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	RunCommandSpecs() []azure.ResourceSpecGetter
+	StartedRunCommandSpecs() []azure.ResourceSpecGetter
+	SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope RunCommandScope
+	async.Reconciler
+	instanceViewGetter
+}
+
+// New creates a new run commands service.
+func New(scope RunCommandScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:              scope,
+		Reconciler:         async.New(scope, client, client),
+		instanceViewGetter: client,
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile runs the script on the next batch of machines and records the results of the finished runs.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	// The run commands are created synchronously, so the long-running operation only completes once the script
+	// finished running on the machine. A failure to run the script on a machine does not stop the other machines,
+	// it is only reported in the status of that machine.
+	var resultErr error
+	for _, spec := range s.Scope.RunCommandSpecs() {
+		rcSpec, ok := spec.(*RunCommandSpec)
+		if !ok {
+			return errors.Errorf("%T is not a RunCommandSpec", spec)
+		}
+
+		_, err := s.CreateOrUpdateResource(ctx, rcSpec, serviceName)
+		if azure.IsOperationNotDoneError(err) {
+			s.Scope.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+				Name:       rcSpec.MachineName,
+				ProviderID: rcSpec.ProviderID,
+				State:      infrav1exp.RunCommandStateRunning,
+			})
+			if resultErr == nil {
+				resultErr = err
+			}
+			continue
+		}
+
+		status, err := s.machineStatus(ctx, rcSpec, err)
+		s.Scope.SetMachineRunCommandStatus(status)
+		if err != nil && (resultErr == nil || azure.IsOperationNotDoneError(resultErr)) {
+			resultErr = err
+		}
+	}
+
+	return resultErr
+}
+
+// machineStatus returns the status of a run command whose PUT operation completed. The instance view is preferred over
+// the error of the operation, as the operation also fails when the script exits with a non-zero code. If the instance
+// view cannot be fetched, the run command is kept running so that it is fetched again on the next reconciliation.
+func (s *Service) machineStatus(ctx context.Context, spec *RunCommandSpec, putErr error) (infrav1exp.MachineRunCommandStatus, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.Service.machineStatus")
+	defer done()
+
+	status := infrav1exp.MachineRunCommandStatus{
+		Name:       spec.MachineName,
+		ProviderID: spec.ProviderID,
+		State:      infrav1exp.RunCommandStateRunning,
+	}
+
+	instanceView, err := s.GetInstanceView(ctx, spec)
+	if err != nil && !azure.ResourceNotFound(err) {
+		return status, errors.Wrapf(err, "failed to get instance view of run command %s", spec.Name)
+	}
+	if instanceView != nil {
+		status = updateFromInstanceView(status, *instanceView)
+	}
+
+	if putErr != nil && !status.State.IsFinished() {
+		status.State = infrav1exp.RunCommandStateFailed
+		status.Error = tail(putErr.Error())
+	}
+	return status, nil
+}
+
+// Delete deletes the run commands created on the machines.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "runcommands.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	// We go through the list of RunCommandSpecs to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var result error
+	for _, spec := range s.Scope.StartedRunCommandSpecs() {
+		if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+	return result
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO run commands.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// updateFromInstanceView updates a machine run command status from the instance view of the run command.
+func updateFromInstanceView(status infrav1exp.MachineRunCommandStatus, instanceView compute.VirtualMachineRunCommandInstanceView) infrav1exp.MachineRunCommandStatus {
+	switch instanceView.ExecutionState {
+	case compute.ExecutionStateSucceeded:
+		status.State = infrav1exp.RunCommandStateSucceeded
+	case compute.ExecutionStateFailed, compute.ExecutionStateCanceled:
+		status.State = infrav1exp.RunCommandStateFailed
+	case compute.ExecutionStateTimedOut:
+		status.State = infrav1exp.RunCommandStateTimedOut
+	default:
+		status.State = infrav1exp.RunCommandStateRunning
+	}
+
+	status.ExitCode = instanceView.ExitCode
+	if status.State == infrav1exp.RunCommandStateSucceeded && status.ExitCode != nil && *status.ExitCode != 0 {
+		status.State = infrav1exp.RunCommandStateFailed
+	}
+	if instanceView.Output != nil {
+		status.Output = tail(*instanceView.Output)
+	}
+	if instanceView.Error != nil {
+		status.Error = tail(*instanceView.Error)
+	}
+	if status.Error == "" && instanceView.ExecutionMessage != nil && status.State != infrav1exp.RunCommandStateSucceeded {
+		status.Error = tail(*instanceView.ExecutionMessage)
+	}
+	if instanceView.StartTime != nil {
+		status.StartTime = &metav1.Time{Time: instanceView.StartTime.Time}
+	}
+	if instanceView.EndTime != nil {
+		status.EndTime = &metav1.Time{Time: instanceView.EndTime.Time}
+	}
+	return status
+}
+
+// tail returns the last maxOutputLength characters of s.
+func tail(s string) string {
+	if len(s) <= maxOutputLength {
+		return s
+	}
+	return s[len(s)-maxOutputLength:]
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runcommands
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/runcommands/mock_runcommands"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeVMRunCommandSpec = RunCommandSpec{
+		Name:          "collect-logs-machine-1",
+		MachineName:   "machine-1",
+		ProviderID:    "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/machine-1",
+		ResourceGroup: "my-rg",
+		Location:      "test-location",
+		VMName:        "machine-1",
+		Script:        "echo hello",
+		Timeout:       600,
+	}
+	fakeScaleSetVMRunCommandSpec = RunCommandSpec{
+		Name:          "collect-logs-machine-2",
+		MachineName:   "machine-2",
+		ProviderID:    "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss/virtualMachines/2",
+		ResourceGroup: "my-rg",
+		Location:      "test-location",
+		ScaleSetName:  "my-vmss",
+		InstanceID:    "2",
+		Script:        "echo hello",
+		Timeout:       600,
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcileRunCommands(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder)
+	}{
+		{
+			name:          "noop if there are no machines to run the script on",
+			expectedError: "",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "script succeeded on a VM",
+			expectedError: "",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(nil, nil)
+				v.GetInstanceView(gomockinternal.AContext(), &fakeVMRunCommandSpec).Return(&compute.VirtualMachineRunCommandInstanceView{
+					ExecutionState: compute.ExecutionStateSucceeded,
+					ExitCode:       pointer.Int32(0),
+					Output:         pointer.String("hello"),
+				}, nil)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-1",
+					ProviderID: fakeVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateSucceeded,
+					ExitCode:   pointer.Int32(0),
+					Output:     "hello",
+				})
+			},
+		},
+		{
+			name:          "script still running on a scale set VM",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeScaleSetVMRunCommandSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeScaleSetVMRunCommandSpec, serviceName).Return(nil, notDoneError)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-2",
+					ProviderID: fakeScaleSetVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateRunning,
+				})
+			},
+		},
+		{
+			name:          "script exited with a non-zero exit code and the other machine is still running",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec, &fakeScaleSetVMRunCommandSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(nil, internalError)
+				v.GetInstanceView(gomockinternal.AContext(), &fakeVMRunCommandSpec).Return(&compute.VirtualMachineRunCommandInstanceView{
+					ExecutionState: compute.ExecutionStateFailed,
+					ExitCode:       pointer.Int32(1),
+					Error:          pointer.String("command not found"),
+				}, nil)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-1",
+					ProviderID: fakeVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateFailed,
+					ExitCode:   pointer.Int32(1),
+					Error:      "command not found",
+				})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeScaleSetVMRunCommandSpec, serviceName).Return(nil, notDoneError)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-2",
+					ProviderID: fakeScaleSetVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateRunning,
+				})
+			},
+		},
+		{
+			name:          "script timed out",
+			expectedError: "",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(nil, internalError)
+				v.GetInstanceView(gomockinternal.AContext(), &fakeVMRunCommandSpec).Return(&compute.VirtualMachineRunCommandInstanceView{
+					ExecutionState:   compute.ExecutionStateTimedOut,
+					ExecutionMessage: pointer.String("execution timed out"),
+				}, nil)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-1",
+					ProviderID: fakeVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateTimedOut,
+					Error:      "execution timed out",
+				})
+			},
+		},
+		{
+			name:          "run command is kept running when its instance view cannot be fetched",
+			expectedError: "failed to get instance view of run command collect-logs-machine-1: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec, &fakeScaleSetVMRunCommandSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(nil, internalError)
+				v.GetInstanceView(gomockinternal.AContext(), &fakeVMRunCommandSpec).Return(nil, internalError)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-1",
+					ProviderID: fakeVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateRunning,
+				})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeScaleSetVMRunCommandSpec, serviceName).Return(nil, nil)
+				v.GetInstanceView(gomockinternal.AContext(), &fakeScaleSetVMRunCommandSpec).Return(nil, internalError)
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-2",
+					ProviderID: fakeScaleSetVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateRunning,
+				})
+			},
+		},
+		{
+			name:          "run command could not be created",
+			expectedError: "",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_runcommands.MockinstanceViewGetterMockRecorder) {
+				s.RunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(nil, internalError)
+				v.GetInstanceView(gomockinternal.AContext(), &fakeVMRunCommandSpec).Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found"))
+				s.SetMachineRunCommandStatus(infrav1exp.MachineRunCommandStatus{
+					Name:       "machine-1",
+					ProviderID: fakeVMRunCommandSpec.ProviderID,
+					State:      infrav1exp.RunCommandStateFailed,
+					Error:      internalError.Error(),
+				})
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_runcommands.NewMockRunCommandScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_runcommands.NewMockinstanceViewGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:              scopeMock,
+				Reconciler:         asyncMock,
+				instanceViewGetter: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteRunCommands(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "delete the run commands of the started machines",
+			expectedError: "",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.StartedRunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec, &fakeScaleSetVMRunCommandSpec})
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeScaleSetVMRunCommandSpec, serviceName).Return(nil)
+			},
+		},
+		{
+			name:          "error takes precedence over a delete in progress",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_runcommands.MockRunCommandScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.StartedRunCommandSpecs().Return([]azure.ResourceSpecGetter{&fakeVMRunCommandSpec, &fakeScaleSetVMRunCommandSpec})
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMRunCommandSpec, serviceName).Return(notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeScaleSetVMRunCommandSpec, serviceName).Return(internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_runcommands.NewMockRunCommandScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestParameters(t *testing.T) {
+	g := NewWithT(t)
+
+	params, err := fakeVMRunCommandSpec.Parameters(context.TODO(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	command, ok := params.(compute.VirtualMachineRunCommand)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(*command.Source.Script).To(Equal("echo hello"))
+	g.Expect(*command.AsyncExecution).To(BeFalse())
+	g.Expect(*command.TimeoutInSeconds).To(Equal(int32(600)))
+
+	params, err = fakeVMRunCommandSpec.Parameters(context.TODO(), compute.VirtualMachineRunCommand{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(params).To(BeNil())
+
+	_, err = fakeVMRunCommandSpec.Parameters(context.TODO(), errors.New("not a run command"))
+	g.Expect(err).To(HaveOccurred())
+}
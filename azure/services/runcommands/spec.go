@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runcommands
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+)
+
+// RunCommandSpec defines the specification for a managed run command on a VM or a scale set VM.
+type RunCommandSpec struct {
+	Name          string
+	MachineName   string
+	ProviderID    string
+	ResourceGroup string
+	Location      string
+	// VMName is the name of the VM the command runs on. It is empty when the command runs on a scale set VM.
+	VMName string
+	// ScaleSetName and InstanceID identify the scale set VM the command runs on.
+	ScaleSetName string
+	InstanceID   string
+	Script       string
+	Timeout      int32
+}
+
+// ResourceName returns the name of the run command.
+func (s *RunCommandSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *RunCommandSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the VM or scale set that owns this run command.
+func (s *RunCommandSpec) OwnerResourceName() string {
+	if s.ScaleSetName != "" {
+		return s.ScaleSetName
+	}
+	return s.VMName
+}
+
+// Parameters returns the parameters for the run command.
+func (s *RunCommandSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	if existing != nil {
+		if _, ok := existing.(compute.VirtualMachineRunCommand); !ok {
+			return nil, errors.Errorf("%T is not a compute.VirtualMachineRunCommand", existing)
+		}
+
+		// The run command already exists, a script is only ever run once.
+		return nil, nil
+	}
+
+	return compute.VirtualMachineRunCommand{
+		VirtualMachineRunCommandProperties: &compute.VirtualMachineRunCommandProperties{
+			Source: &compute.VirtualMachineRunCommandScriptSource{
+				Script: pointer.String(s.Script),
+			},
+			AsyncExecution:   pointer.Bool(false),
+			TimeoutInSeconds: pointer.Int32(s.Timeout),
+		},
+		Location: pointer.String(s.Location),
+	}, nil
+}
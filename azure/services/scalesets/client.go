@@ -40,6 +40,7 @@ type Client interface {
 	List(context.Context, string) ([]compute.VirtualMachineScaleSet, error)
 	ListInstances(context.Context, string, string) ([]compute.VirtualMachineScaleSetVM, error)
 	Get(context.Context, string, string) (compute.VirtualMachineScaleSet, error)
+	GetInstanceView(context.Context, string, string) (compute.VirtualMachineScaleSetInstanceView, error)
 	CreateOrUpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSet) (*infrav1.Future, error)
 	UpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error)
 	GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSet, error)
@@ -143,6 +144,14 @@ func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, vmssName stri
 	return ac.scalesets.Get(ctx, resourceGroupName, vmssName, "")
 }
 
+// GetInstanceView retrieves the instance view of a virtual machine scale set.
+func (ac *AzureClient) GetInstanceView(ctx context.Context, resourceGroupName, vmssName string) (compute.VirtualMachineScaleSetInstanceView, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.GetInstanceView")
+	defer done()
+
+	return ac.scalesets.GetInstanceView(ctx, resourceGroupName, vmssName)
+}
+
 // CreateOrUpdateAsync the operation to create or update a virtual machine scale set without waiting for the operation
 // to complete.
 func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupName, vmssName string, vmss compute.VirtualMachineScaleSet) (*infrav1.Future, error) {
@@ -98,6 +98,21 @@ func (mr *MockClientMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetInstanceView mocks base method.
+func (m *MockClient) GetInstanceView(arg0 context.Context, arg1, arg2 string) (compute.VirtualMachineScaleSetInstanceView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceView", arg0, arg1, arg2)
+	ret0, _ := ret[0].(compute.VirtualMachineScaleSetInstanceView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceView indicates an expected call of GetInstanceView.
+func (mr *MockClientMockRecorder) GetInstanceView(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceView", reflect.TypeOf((*MockClient)(nil).GetInstanceView), arg0, arg1, arg2)
+}
+
 // GetResultIfDone mocks base method.
 func (m *MockClient) GetResultIfDone(ctx context.Context, future *v1beta1.Future) (compute.VirtualMachineScaleSet, error) {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -234,6 +235,20 @@ func (mr *MockScaleSetScopeMockRecorder) ExtendedLocationType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendedLocationType", reflect.TypeOf((*MockScaleSetScope)(nil).ExtendedLocationType))
 }
 
+// ExtensionStatuses mocks base method.
+func (m *MockScaleSetScope) ExtensionStatuses() []v1beta1.ExtensionStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtensionStatuses")
+	ret0, _ := ret[0].([]v1beta1.ExtensionStatus)
+	return ret0
+}
+
+// ExtensionStatuses indicates an expected call of ExtensionStatuses.
+func (mr *MockScaleSetScopeMockRecorder) ExtensionStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtensionStatuses", reflect.TypeOf((*MockScaleSetScope)(nil).ExtensionStatuses))
+}
+
 // FailureDomains mocks base method.
 func (m *MockScaleSetScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -430,6 +445,18 @@ func (mr *MockScaleSetScopeMockRecorder) SetAnnotation(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAnnotation", reflect.TypeOf((*MockScaleSetScope)(nil).SetAnnotation), arg0, arg1)
 }
 
+// SetExtensionStatuses mocks base method.
+func (m *MockScaleSetScope) SetExtensionStatuses(arg0 []v1beta1.ExtensionStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetExtensionStatuses", arg0)
+}
+
+// SetExtensionStatuses indicates an expected call of SetExtensionStatuses.
+func (mr *MockScaleSetScopeMockRecorder) SetExtensionStatuses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExtensionStatuses", reflect.TypeOf((*MockScaleSetScope)(nil).SetExtensionStatuses), arg0)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockScaleSetScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -494,6 +521,20 @@ func (mr *MockScaleSetScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockScaleSetScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockScaleSetScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockScaleSetScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockScaleSetScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockScaleSetScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
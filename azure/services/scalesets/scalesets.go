@@ -25,6 +25,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	azprovider "sigs.k8s.io/cloud-provider-azure/pkg/provider"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
 	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const serviceName = "scalesets"
@@ -52,6 +54,8 @@ type (
 		SetAnnotation(string, string)
 		SetProviderID(string)
 		SetVMSSState(*azure.VMSS)
+		ExtensionStatuses() []infrav1.ExtensionStatus
+		SetExtensionStatuses([]infrav1.ExtensionStatus)
 		ReconcileReplicas(context.Context, *azure.VMSS) error
 		HasReplicasExternallyManaged(context.Context) bool
 		HasBootstrapDataChanges(context.Context) (bool, error)
@@ -99,6 +103,8 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	if future == nil {
 		future = s.Scope.GetLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PatchFuture)
 	}
+	// updated is true if the VMSS was created or updated by this or a previous reconciliation.
+	updated := future != nil
 
 	defer func() {
 		// save the updated state of the VMSS for the MachinePoolScope to use for updating K8s state
@@ -149,6 +155,7 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	// Try to get the VMSS to update status if we have created a long running operation. If the VMSS is still in a long
 	// running operation, getVirtualMachineScaleSetIfDone will return an azure.WithTransientError and requeue.
 	if future != nil {
+		updated = true
 		fetchedVMSS, err = s.getVirtualMachineScaleSetIfDone(ctx, future)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get VMSS %s after create or update", scaleSetSpec.Name)
@@ -159,13 +166,75 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	s.Scope.DeleteLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PutFuture)
 	s.Scope.DeleteLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PatchFuture)
 
-	// This also means that the VMSS extensions were successfully installed
+	// This also means that the VMSS extensions were successfully installed, though they may still fail on some instances.
+	// The instance view is only fetched again after the scale set was created or updated, e.g. scaled out, or while the
+	// bootstrap extension has not succeeded on all the instances.
 	// Note: we want to handle UpdatePutStatus when VMSSExtensions have an error when scalesets become an async service
+	if updated || !isBootstrapSucceeded(s.Scope.ExtensionStatuses()) {
+		if err := s.reconcileExtensionStatuses(ctx, scaleSetSpec.Name); err != nil {
+			err = errors.Wrap(err, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
+			s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, err)
+			return azure.WithTerminalError(err)
+		}
+	}
 	s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 
 	return nil
 }
 
+// isBootstrapSucceeded returns true if the CAPZ bootstrapping extension succeeded on all the scale set instances.
+func isBootstrapSucceeded(statuses []infrav1.ExtensionStatus) bool {
+	for _, status := range statuses {
+		if status.Name != azure.BootstrappingExtensionLinux && status.Name != azure.BootstrappingExtensionWindows {
+			continue
+		}
+		if len(status.Conditions) == 0 {
+			return false
+		}
+		for _, c := range status.Conditions {
+			if c.Status != corev1.ConditionTrue {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// reconcileExtensionStatuses summarizes the instance views of the VMSS extensions across the scale set instances,
+// and returns an error if the CAPZ bootstrapping extension failed on any instance.
+func (s *Service) reconcileExtensionStatuses(ctx context.Context, vmssName string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.reconcileExtensionStatuses")
+	defer done()
+
+	instanceView, err := s.Client.GetInstanceView(ctx, s.Scope.ResourceGroup(), vmssName)
+	if err != nil {
+		log.Error(err, "failed to get VMSS instance view", "vmss", vmssName)
+		return nil
+	}
+
+	var statuses []infrav1.ExtensionStatus
+	var bootstrapErr error
+	if instanceView.Extensions != nil {
+		for _, summary := range *instanceView.Extensions {
+			status := converters.SDKToVMSSExtensionStatus(summary)
+			statuses = append(statuses, status)
+			if status.Name != azure.BootstrappingExtensionLinux && status.Name != azure.BootstrappingExtensionWindows {
+				continue
+			}
+			for _, c := range status.Conditions {
+				if c.Severity == clusterv1.ConditionSeverityError {
+					bootstrapErr = errors.Errorf("bootstrap extension %s failed: %s: %s", status.Name, c.Type, c.Message)
+					break
+				}
+			}
+		}
+	}
+	s.Scope.SetExtensionStatuses(statuses)
+
+	return bootstrapErr
+}
+
 // Delete deletes a scale set asynchronously. Delete sends a DELETE request to Azure and if accepted without error,
 // the VMSS will be considered deleted. The actual delete in Azure may take longer, but should eventually complete.
 func (s *Service) Delete(ctx context.Context) error {
@@ -589,6 +658,13 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 				})
 		}
 	}
+	var ipv6BackendAddressPools []compute.SubResource
+	if vmssSpec.PublicLBName != "" && vmssSpec.PublicLBIPv6AddressPoolName != "" {
+		ipv6BackendAddressPools = append(ipv6BackendAddressPools,
+			compute.SubResource{
+				ID: pointer.String(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.PublicLBName, vmssSpec.PublicLBIPv6AddressPoolName)),
+			})
+	}
 	nicConfigs := []compute.VirtualMachineScaleSetNetworkConfiguration{}
 	for i, n := range vmssSpec.NetworkInterfaces {
 		nicConfig := compute.VirtualMachineScaleSetNetworkConfiguration{}
@@ -622,7 +698,8 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 			}
 			ipconfigs = append(ipconfigs, ipconfig)
 		}
-		if vmssSpec.IPv6Enabled {
+		// Only the network interfaces in a dual-stack subnet can get an IPv6 address.
+		if isIPv6Subnet(vmssSpec.IPv6SubnetNames, n.SubnetName) {
 			ipv6Config := compute.VirtualMachineScaleSetIPConfiguration{
 				Name: pointer.String("ipConfigv6"),
 				VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
@@ -633,8 +710,23 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 					},
 				},
 			}
+			if i == 0 && len(ipv6BackendAddressPools) > 0 {
+				ipv6Config.LoadBalancerBackendAddressPools = &ipv6BackendAddressPools
+			}
 			ipconfigs = append(ipconfigs, ipv6Config)
 		}
+		if len(vmssSpec.ApplicationSecurityGroups) > 0 {
+			// All the IP configurations of a network interface must be associated with the same application security groups.
+			asgs := make([]compute.SubResource, len(vmssSpec.ApplicationSecurityGroups))
+			for k, name := range vmssSpec.ApplicationSecurityGroups {
+				asgs[k] = compute.SubResource{
+					ID: pointer.String(azure.ApplicationSecurityGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), name)),
+				}
+			}
+			for k := range ipconfigs {
+				ipconfigs[k].ApplicationSecurityGroups = &asgs
+			}
+		}
 		if i == 0 {
 			ipconfigs[0].LoadBalancerBackendAddressPools = &backendAddressPools
 			nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.Primary = pointer.Bool(true)
@@ -645,6 +737,16 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 	return &nicConfigs
 }
 
+// isIPv6Subnet returns true if the subnet is one of the dual-stack subnets of the scale set.
+func isIPv6Subnet(ipv6SubnetNames []string, subnetName string) bool {
+	for _, name := range ipv6SubnetNames {
+		if name == subnetName {
+			return true
+		}
+	}
+	return false
+}
+
 // getVirtualMachineScaleSet provides information about a Virtual Machine Scale Set and its instances.
 func (s *Service) getVirtualMachineScaleSet(ctx context.Context, vmssName string) (*azure.VMSS, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.getVirtualMachineScaleSet")
@@ -25,6 +25,7 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
@@ -222,10 +223,40 @@ func TestReconcileVMSS(t *testing.T) {
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
 				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
 				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+				s.ExtensionStatuses().AnyTimes().Return(nil)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{}, nil)
+				s.SetExtensionStatuses(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
 			},
 		},
+		{
+			name:          "should fail when the bootstrap extension failed once the vmss is created",
+			expectedError: "reconcile error that cannot be recovered occurred: extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more: bootstrap extension CAPZ.Linux.Bootstrapping failed: ProvisioningState: 1 instance(s) failed. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				defaultSpec := newDefaultVMSSSpec()
+				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
+				createdVMSS := newDefaultVMSS("VM_SIZE")
+				instances := newDefaultInstances()
+
+				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
+				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
+				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{
+					Extensions: &[]compute.VirtualMachineScaleSetVMExtensionsSummary{
+						{
+							Name: pointer.String(azure.BootstrappingExtensionLinux),
+							StatusesSummary: &[]compute.VirtualMachineStatusCodeCount{
+								{Code: pointer.String("ProvisioningState/failed/1"), Count: pointer.Int32(1)},
+							},
+						},
+					},
+				}, nil)
+				s.SetExtensionStatuses(gomock.Len(1))
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomock.Not(gomock.Nil()))
+				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
+			},
+		},
 		{
 			name:          "Windows VMSS should not get patched",
 			expectedError: "",
@@ -238,6 +269,9 @@ func TestReconcileVMSS(t *testing.T) {
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
 				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
 				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+				s.ExtensionStatuses().AnyTimes().Return(nil)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{}, nil)
+				s.SetExtensionStatuses(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
 			},
@@ -291,6 +325,66 @@ func TestReconcileVMSS(t *testing.T) {
 				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_AN"), putFuture)
 			},
 		},
+		{
+			name:          "should start creating a dual-stack vmss with IPv6 IP configurations in the dual-stack subnets only",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_AN"
+				spec.NetworkInterfaces = []infrav1.NetworkInterface{
+					{
+						SubnetName:            "my-subnet",
+						PrivateIPConfigs:      1,
+						AcceleratedNetworking: pointer.Bool(false),
+					},
+					{
+						SubnetName:            "subnet2",
+						PrivateIPConfigs:      1,
+						AcceleratedNetworking: pointer.Bool(false),
+					},
+				}
+				spec.IPv6SubnetNames = []string{"my-subnet"}
+				spec.PublicLBIPv6AddressPoolName = "backendPool-ipv6"
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_AN")
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				nic1IPConfigs := append(*(*netConfigs)[0].IPConfigurations, compute.VirtualMachineScaleSetIPConfiguration{
+					Name: pointer.String("ipConfigv6"),
+					VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+						Primary:                 pointer.Bool(false),
+						PrivateIPAddressVersion: compute.IPVersionIPv6,
+						Subnet: &compute.APIEntityReference{
+							ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet"),
+						},
+						LoadBalancerBackendAddressPools: &[]compute.SubResource{{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/capz-lb/backendAddressPools/backendPool-ipv6")}},
+					},
+				})
+				(*netConfigs)[0].IPConfigurations = &nic1IPConfigs
+				*netConfigs = append(*netConfigs, compute.VirtualMachineScaleSetNetworkConfiguration{
+					Name: pointer.String("my-vmss-nic-1"),
+					VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+						EnableAcceleratedNetworking: pointer.Bool(false),
+						EnableIPForwarding:          pointer.Bool(true),
+						IPConfigurations: &[]compute.VirtualMachineScaleSetIPConfiguration{
+							{
+								Name: pointer.String("ipConfig0"),
+								VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+									Primary:                 pointer.Bool(true),
+									PrivateIPAddressVersion: compute.IPVersionIPv4,
+									Subnet: &compute.APIEntityReference{
+										ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/subnet2"),
+									},
+								},
+							},
+						},
+					},
+				})
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_AN"), putFuture)
+			},
+		},
 		{
 			name:          "should start creating vmss with custom networking when specified",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
@@ -775,6 +869,9 @@ func TestReconcileVMSS(t *testing.T) {
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
+				s.ExtensionStatuses().AnyTimes().Return(nil)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{}, nil)
+				s.SetExtensionStatuses(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 				s.Location().AnyTimes().Return("test-location")
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
@@ -802,6 +899,9 @@ func TestReconcileVMSS(t *testing.T) {
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
+				s.ExtensionStatuses().AnyTimes().Return(nil)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{}, nil)
+				s.SetExtensionStatuses(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 				s.Location().AnyTimes().Return("test-location")
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
@@ -828,6 +928,9 @@ func TestReconcileVMSS(t *testing.T) {
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
+				s.ExtensionStatuses().AnyTimes().Return(nil)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{}, nil)
+				s.SetExtensionStatuses(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 				s.Location().AnyTimes().Return("test-location")
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
@@ -849,6 +952,9 @@ func TestReconcileVMSS(t *testing.T) {
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
 				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
+				s.ExtensionStatuses().AnyTimes().Return(nil)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{}, nil)
+				s.SetExtensionStatuses(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 				s.Location().AnyTimes().Return("test-location")
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
@@ -982,6 +1088,128 @@ func TestDeleteVMSS(t *testing.T) {
 	}
 }
 
+func TestReconcileExtensionStatuses(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+	}{
+		{
+			name:          "bootstrap extension succeeded on all instances",
+			expectedError: "",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{
+					Extensions: &[]compute.VirtualMachineScaleSetVMExtensionsSummary{
+						{
+							Name: pointer.String(azure.BootstrappingExtensionLinux),
+							StatusesSummary: &[]compute.VirtualMachineStatusCodeCount{
+								{Code: pointer.String("ProvisioningState/succeeded"), Count: pointer.Int32(2)},
+							},
+						},
+					},
+				}, nil)
+				s.SetExtensionStatuses([]infrav1.ExtensionStatus{
+					{
+						Name:       azure.BootstrappingExtensionLinux,
+						Conditions: clusterv1.Conditions{{Type: "ProvisioningState", Status: corev1.ConditionTrue}},
+					},
+				})
+			},
+		},
+		{
+			name:          "bootstrap extension failed on an instance",
+			expectedError: "bootstrap extension CAPZ.Linux.Bootstrapping failed: ProvisioningState: 1 instance(s) failed",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(compute.VirtualMachineScaleSetInstanceView{
+					Extensions: &[]compute.VirtualMachineScaleSetVMExtensionsSummary{
+						{
+							Name: pointer.String(azure.BootstrappingExtensionLinux),
+							StatusesSummary: &[]compute.VirtualMachineStatusCodeCount{
+								{Code: pointer.String("ProvisioningState/succeeded"), Count: pointer.Int32(1)},
+								{Code: pointer.String("ProvisioningState/failed/1"), Count: pointer.Int32(1)},
+							},
+						},
+					},
+				}, nil)
+				s.SetExtensionStatuses(gomock.Len(1))
+			},
+		},
+		{
+			name:          "instance view cannot be retrieved",
+			expectedError: "",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				m.GetInstanceView(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSetInstanceView{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
+			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.reconcileExtensionStatuses(context.TODO(), defaultVMSSName)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestIsBootstrapSucceeded(t *testing.T) {
+	testcases := []struct {
+		name     string
+		statuses []infrav1.ExtensionStatus
+		expected bool
+	}{
+		{
+			name: "no extension status yet",
+		},
+		{
+			name: "bootstrap extension succeeded",
+			statuses: []infrav1.ExtensionStatus{
+				{Name: "someExtension", Conditions: clusterv1.Conditions{{Type: "ProvisioningState", Status: corev1.ConditionFalse}}},
+				{Name: azure.BootstrappingExtensionLinux, Conditions: clusterv1.Conditions{{Type: "ProvisioningState", Status: corev1.ConditionTrue}}},
+			},
+			expected: true,
+		},
+		{
+			name: "bootstrap extension failed on an instance",
+			statuses: []infrav1.ExtensionStatus{
+				{Name: azure.BootstrappingExtensionWindows, Conditions: clusterv1.Conditions{{Type: "ProvisioningState", Status: corev1.ConditionFalse, Severity: clusterv1.ConditionSeverityError}}},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(isBootstrapSucceeded(tc.statuses)).To(Equal(tc.expected))
+		})
+	}
+}
+
 func getFakeSkus() []compute.ResourceSku {
 	return []compute.ResourceSku{
 		{
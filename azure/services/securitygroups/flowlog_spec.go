@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroups
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// FlowLogSpec defines the specification for the Network Watcher flow log of a security group.
+type FlowLogSpec struct {
+	Name               string
+	ResourceGroup      string
+	NetworkWatcherName string
+	Location           string
+	ClusterName        string
+	SecurityGroupID    string
+	StorageAccountID   string
+	RetentionDays      int32
+	FormatVersion      int32
+	TrafficAnalytics   *infrav1.TrafficAnalytics
+	AdditionalTags     infrav1.Tags
+}
+
+// ResourceName returns the name of the flow log.
+func (s *FlowLogSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the Network Watcher.
+func (s *FlowLogSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the Network Watcher of the flow log.
+func (s *FlowLogSpec) OwnerResourceName() string {
+	return s.NetworkWatcherName
+}
+
+// Parameters returns the parameters for the flow log.
+func (s *FlowLogSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	flowLog := network.FlowLog{
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		FlowLogPropertiesFormat: &network.FlowLogPropertiesFormat{
+			TargetResourceID: pointer.String(s.SecurityGroupID),
+			StorageID:        pointer.String(s.StorageAccountID),
+			Enabled:          pointer.Bool(true),
+			RetentionPolicy: &network.RetentionPolicyParameters{
+				Days:    pointer.Int32(s.RetentionDays),
+				Enabled: pointer.Bool(s.RetentionDays > 0),
+			},
+			Format: &network.FlowLogFormatParameters{
+				Type:    network.FlowLogFormatTypeJSON,
+				Version: pointer.Int32(s.FormatVersion),
+			},
+			FlowAnalyticsConfiguration: s.trafficAnalytics(),
+		},
+	}
+
+	if existing != nil {
+		existingFlowLog, ok := existing.(network.FlowLog)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.FlowLog", existing)
+		}
+		if !isFlowLogOwned(existingFlowLog, s.ClusterName) {
+			// flow log was not created for this cluster, leave it alone
+			return nil, nil
+		}
+		if isFlowLogUpToDate(existingFlowLog, flowLog) {
+			// flow log is up-to-date, nothing to do
+			return nil, nil
+		}
+	}
+
+	return flowLog, nil
+}
+
+// trafficAnalytics returns the traffic analytics configuration of the flow log, which is disabled unless a workspace is set.
+func (s *FlowLogSpec) trafficAnalytics() *network.TrafficAnalyticsProperties {
+	if s.TrafficAnalytics == nil {
+		return &network.TrafficAnalyticsProperties{
+			NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+				Enabled: pointer.Bool(false),
+			},
+		}
+	}
+
+	region := s.TrafficAnalytics.WorkspaceRegion
+	if region == "" {
+		region = s.Location
+	}
+	return &network.TrafficAnalyticsProperties{
+		NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+			Enabled:                  pointer.Bool(true),
+			WorkspaceID:              pointer.String(s.TrafficAnalytics.WorkspaceID),
+			WorkspaceRegion:          pointer.String(region),
+			WorkspaceResourceID:      pointer.String(s.TrafficAnalytics.WorkspaceResourceID),
+			TrafficAnalyticsInterval: pointer.Int32(s.TrafficAnalytics.IntervalMinutes),
+		},
+	}
+}
+
+// isFlowLogOwned returns true if the flow log was created for the cluster.
+func isFlowLogOwned(flowLog network.FlowLog, clusterName string) bool {
+	return converters.MapToTags(flowLog.Tags).HasOwned(clusterName)
+}
+
+// isFlowLogUpToDate returns true if the existing flow log has the desired target, storage account, retention, format and traffic analytics.
+func isFlowLogUpToDate(existing, desired network.FlowLog) bool {
+	props, want := existing.FlowLogPropertiesFormat, desired.FlowLogPropertiesFormat
+	if props == nil {
+		return false
+	}
+	if !strings.EqualFold(pointer.StringDeref(props.TargetResourceID, ""), *want.TargetResourceID) ||
+		!strings.EqualFold(pointer.StringDeref(props.StorageID, ""), *want.StorageID) ||
+		!pointer.BoolDeref(props.Enabled, false) {
+		return false
+	}
+	if props.RetentionPolicy == nil ||
+		pointer.Int32Deref(props.RetentionPolicy.Days, 0) != *want.RetentionPolicy.Days ||
+		pointer.BoolDeref(props.RetentionPolicy.Enabled, false) != *want.RetentionPolicy.Enabled {
+		return false
+	}
+	if props.Format == nil || pointer.Int32Deref(props.Format.Version, 0) != *want.Format.Version {
+		return false
+	}
+
+	var analytics network.TrafficAnalyticsConfigurationProperties
+	if props.FlowAnalyticsConfiguration != nil && props.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration != nil {
+		analytics = *props.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration
+	}
+	wantAnalytics := want.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration
+	if !pointer.BoolDeref(wantAnalytics.Enabled, false) {
+		return !pointer.BoolDeref(analytics.Enabled, false)
+	}
+	return pointer.BoolDeref(analytics.Enabled, false) &&
+		strings.EqualFold(pointer.StringDeref(analytics.WorkspaceID, ""), *wantAnalytics.WorkspaceID) &&
+		strings.EqualFold(pointer.StringDeref(analytics.WorkspaceResourceID, ""), *wantAnalytics.WorkspaceResourceID) &&
+		strings.EqualFold(pointer.StringDeref(analytics.WorkspaceRegion, ""), *wantAnalytics.WorkspaceRegion) &&
+		pointer.Int32Deref(analytics.TrafficAnalyticsInterval, 0) == *wantAnalytics.TrafficAnalyticsInterval
+}
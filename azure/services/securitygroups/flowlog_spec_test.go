@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestFlowLogParameters(t *testing.T) {
+	trafficAnalytics := &infrav1.TrafficAnalytics{
+		WorkspaceResourceID: "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.OperationalInsights/workspaces/analytics",
+		WorkspaceID:         "4b1a3ad4-2e4f-4b8e-9e0f-3a1f5c6d7e8f",
+		IntervalMinutes:     60,
+	}
+	withAnalytics := func() *FlowLogSpec {
+		spec := fakeFlowLog
+		spec.RetentionDays = 30
+		spec.TrafficAnalytics = trafficAnalytics
+		return &spec
+	}
+	existingFlowLog := func() network.FlowLog {
+		params, err := withAnalytics().Parameters(context.TODO(), nil)
+		if err != nil {
+			panic(err)
+		}
+		return params.(network.FlowLog)
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *FlowLogSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "flow log does not exist",
+			spec: &fakeFlowLog,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.FlowLog{}))
+				flowLog := result.(network.FlowLog)
+				g.Expect(flowLog.Location).To(Equal(pointer.String("test-location")))
+				g.Expect(flowLog.TargetResourceID).To(Equal(pointer.String(fakeFlowLog.SecurityGroupID)))
+				g.Expect(flowLog.StorageID).To(Equal(pointer.String(fakeFlowLog.StorageAccountID)))
+				g.Expect(flowLog.Enabled).To(Equal(pointer.Bool(true)))
+				g.Expect(flowLog.RetentionPolicy).To(Equal(&network.RetentionPolicyParameters{Days: pointer.Int32(0), Enabled: pointer.Bool(false)}))
+				g.Expect(flowLog.Format).To(Equal(&network.FlowLogFormatParameters{Type: network.FlowLogFormatTypeJSON, Version: pointer.Int32(2)}))
+				g.Expect(flowLog.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration.Enabled).To(Equal(pointer.Bool(false)))
+				g.Expect(flowLog.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", pointer.String("owned")))
+			},
+		},
+		{
+			name: "flow log with traffic analytics does not exist",
+			spec: withAnalytics(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.FlowLog{}))
+				flowLog := result.(network.FlowLog)
+				g.Expect(flowLog.RetentionPolicy).To(Equal(&network.RetentionPolicyParameters{Days: pointer.Int32(30), Enabled: pointer.Bool(true)}))
+				g.Expect(flowLog.FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration).To(Equal(&network.TrafficAnalyticsConfigurationProperties{
+					Enabled:                  pointer.Bool(true),
+					WorkspaceID:              pointer.String(trafficAnalytics.WorkspaceID),
+					WorkspaceRegion:          pointer.String("test-location"),
+					WorkspaceResourceID:      pointer.String(trafficAnalytics.WorkspaceResourceID),
+					TrafficAnalyticsInterval: pointer.Int32(60),
+				}))
+			},
+		},
+		{
+			name:     "flow log already exists with the desired configuration",
+			spec:     withAnalytics(),
+			existing: existingFlowLog(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "flow log retention is updated",
+			spec: func() *FlowLogSpec {
+				spec := withAnalytics()
+				spec.RetentionDays = 90
+				return spec
+			}(),
+			existing: existingFlowLog(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.FlowLog{}))
+				g.Expect(result.(network.FlowLog).RetentionPolicy.Days).To(Equal(pointer.Int32(90)))
+			},
+		},
+		{
+			name: "flow log traffic analytics is disabled",
+			spec: func() *FlowLogSpec {
+				spec := withAnalytics()
+				spec.TrafficAnalytics = nil
+				return spec
+			}(),
+			existing: existingFlowLog(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.FlowLog{}))
+				g.Expect(result.(network.FlowLog).FlowAnalyticsConfiguration.NetworkWatcherFlowAnalyticsConfiguration.Enabled).To(Equal(pointer.Bool(false)))
+			},
+		},
+		{
+			name: "flow log is disabled",
+			spec: withAnalytics(),
+			existing: func() network.FlowLog {
+				flowLog := existingFlowLog()
+				flowLog.Enabled = pointer.Bool(false)
+				return flowLog
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.FlowLog{}))
+				g.Expect(result.(network.FlowLog).Enabled).To(Equal(pointer.Bool(true)))
+			},
+		},
+		{
+			name: "flow log of another cluster is not updated",
+			spec: withAnalytics(),
+			existing: func() network.FlowLog {
+				flowLog := existingFlowLog()
+				flowLog.Tags = map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned")}
+				flowLog.RetentionPolicy.Days = pointer.Int32(7)
+				return flowLog
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a flow log",
+			spec:          &fakeFlowLog,
+			existing:      network.SecurityGroup{},
+			expectedError: "network.SecurityGroup is not a network.FlowLog",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
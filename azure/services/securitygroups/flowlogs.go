@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// FlowLogsServiceName is the name of the service reconciling the Network Watcher flow logs of security groups.
+const FlowLogsServiceName = "nsgflowlogs"
+
+// FlowLogScope defines the scope interface for the flow logs of security groups.
+type FlowLogScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	NSGFlowLogSpecs() []azure.ResourceSpecGetter
+	IsVnetManaged() bool
+}
+
+// FlowLogsService provides operations on the Network Watcher flow logs of security groups.
+type FlowLogsService struct {
+	Scope  FlowLogScope
+	getter async.Getter
+	async.Reconciler
+}
+
+// NewFlowLogsService creates a new service for the flow logs of security groups.
+func NewFlowLogsService(scope FlowLogScope) *FlowLogsService {
+	client := newFlowLogsClient(scope)
+	return &FlowLogsService{
+		Scope:      scope,
+		getter:     client,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *FlowLogsService) Name() string {
+	return FlowLogsServiceName
+}
+
+// Reconcile idempotently creates or updates the flow logs of the security groups.
+func (s *FlowLogsService) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.FlowLogsService.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	// Only create the flow logs if the lifecycle of the security groups is managed by this controller.
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping network security group flow logs reconcile in custom VNet mode")
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check if security group flow logs are managed")
+	}
+
+	specs := s.Scope.NSGFlowLogSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var resErr error
+
+	// We go through the list of flow logs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	for _, flowLogSpec := range specs {
+		if _, err := s.CreateOrUpdateResource(ctx, flowLogSpec, FlowLogsServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, resErr)
+	return resErr
+}
+
+// Delete deletes the flow logs of the security groups.
+func (s *FlowLogsService) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.FlowLogsService.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	// Only delete the flow logs if the lifecycle of the security groups is managed by this controller.
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping network security group flow logs delete in custom VNet mode")
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check if security group flow logs are managed")
+	}
+
+	specs := s.Scope.NSGFlowLogSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var result error
+
+	// We go through the list of flow logs to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	for _, flowLogSpec := range specs {
+		if err := s.deleteOwnedFlowLog(ctx, flowLogSpec); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, result)
+	return result
+}
+
+// deleteOwnedFlowLog deletes a flow log if it exists and was created for the cluster.
+func (s *FlowLogsService) deleteOwnedFlowLog(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.FlowLogsService.deleteOwnedFlowLog")
+	defer done()
+
+	existing, err := s.getter.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get flow log %s in network watcher %s", spec.ResourceName(), spec.OwnerResourceName())
+	}
+
+	flowLogSpec, ok := spec.(*FlowLogSpec)
+	if flowLog, isFlowLog := existing.(network.FlowLog); !ok || !isFlowLog || !isFlowLogOwned(flowLog, flowLogSpec.ClusterName) {
+		log.V(2).Info("Skipping deletion of flow log not created for the cluster", "flow log", spec.ResourceName(), "network watcher", spec.OwnerResourceName())
+		return nil
+	}
+
+	return s.DeleteResource(ctx, spec, FlowLogsServiceName)
+}
+
+// IsManaged returns true if the lifecycles of the security groups, and therefore of their flow logs, are managed.
+func (s *FlowLogsService) IsManaged(ctx context.Context) (bool, error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "securitygroups.FlowLogsService.IsManaged")
+	defer done()
+
+	return s.Scope.IsVnetManaged(), nil
+}
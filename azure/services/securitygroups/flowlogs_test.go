@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroups
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups/mock_securitygroups"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeFlowLog = FlowLogSpec{
+		Name:               "test-nsg-flowlog",
+		ResourceGroup:      "NetworkWatcherRG",
+		NetworkWatcherName: "NetworkWatcher_test-location",
+		Location:           "test-location",
+		ClusterName:        "my-cluster",
+		SecurityGroupID:    "/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/networkSecurityGroups/test-nsg",
+		StorageAccountID:   "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+		FormatVersion:      2,
+	}
+	ownedFlowLog = network.FlowLog{
+		Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned")},
+	}
+	fakeFlowLog2 = FlowLogSpec{
+		Name:               "test-nsg-2-flowlog",
+		ResourceGroup:      "NetworkWatcherRG",
+		NetworkWatcherName: "NetworkWatcher_test-location",
+		Location:           "test-location",
+		ClusterName:        "my-cluster",
+		SecurityGroupID:    "/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/networkSecurityGroups/test-nsg-2",
+		StorageAccountID:   "/subscriptions/123/resourceGroups/logs-rg/providers/Microsoft.Storage/storageAccounts/flowlogs",
+		FormatVersion:      2,
+	}
+)
+
+func TestReconcileFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "create multiple flow logs succeeds, should return no error",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGFlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog, FlowLogsServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog2, FlowLogsServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, nil)
+			},
+		},
+		{
+			name:          "first flow log create fails, second flow log create not done, should return create error",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGFlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog, FlowLogsServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFlowLog2, FlowLogsServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, errFake)
+			},
+		},
+		{
+			name:          "flow logs are not configured, should do nothing",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGFlowLogSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "vnet is not managed, should skip reconcile",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(false)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_securitygroups.NewMockFlowLogScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &FlowLogsService{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "delete multiple flow logs succeeds, should return no error",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGFlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+				g.Get(gomockinternal.AContext(), &fakeFlowLog).Return(ownedFlowLog, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeFlowLog, FlowLogsServiceName).Return(nil)
+				g.Get(gomockinternal.AContext(), &fakeFlowLog2).Return(ownedFlowLog, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeFlowLog2, FlowLogsServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, nil)
+			},
+		},
+		{
+			name:          "flow log delete not done, should return not done error",
+			expectedError: notDoneError.Error(),
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGFlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog})
+				g.Get(gomockinternal.AContext(), &fakeFlowLog).Return(ownedFlowLog, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeFlowLog, FlowLogsServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "flow logs of another cluster or already deleted, should skip delete",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGFlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLog, &fakeFlowLog2})
+				g.Get(gomockinternal.AContext(), &fakeFlowLog).Return(network.FlowLog{
+					Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned")},
+				}, nil)
+				g.Get(gomockinternal.AContext(), &fakeFlowLog2).Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found"))
+				s.UpdateDeleteStatus(infrav1.NSGFlowLogsReadyCondition, FlowLogsServiceName, nil)
+			},
+		},
+		{
+			name:          "vnet is not managed, should skip delete",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockFlowLogScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.IsVnetManaged().Return(false)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_securitygroups.NewMockFlowLogScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &FlowLogsService{
+				Scope:      scopeMock,
+				getter:     getterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
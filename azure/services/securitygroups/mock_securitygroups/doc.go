@@ -18,4 +18,6 @@ limitations under the License.
 //
 //go:generate ../../../../hack/tools/bin/mockgen -destination securitygroups_mock.go -package mock_securitygroups -source ../securitygroups.go NSGScope
 //go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt securitygroups_mock.go > _securitygroups_mock.go && mv _securitygroups_mock.go securitygroups_mock.go"
+//go:generate ../../../../hack/tools/bin/mockgen -destination flowlogs_mock.go -package mock_securitygroups -source ../flowlogs.go FlowLogScope
+//go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt flowlogs_mock.go > _flowlogs_mock.go && mv _flowlogs_mock.go flowlogs_mock.go"
 package mock_securitygroups
@@ -168,6 +168,43 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG does not exist and a rule references application security groups",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					{
+						Name:                                 "allow_apiserver_from_nodes",
+						Description:                          "Allow nodes to reach the API server",
+						Priority:                             2201,
+						Protocol:                             infrav1.SecurityGroupProtocolTCP,
+						Direction:                            infrav1.SecurityRuleDirectionInbound,
+						SourcePorts:                          pointer.String("*"),
+						DestinationPorts:                     pointer.String("6443"),
+						SourceApplicationSecurityGroups:      []string{"node"},
+						DestinationApplicationSecurityGroups: []string{"control-plane"},
+					},
+				},
+				ResourceGroup:  "test-group",
+				SubscriptionID: "123",
+				ClusterName:    "my-cluster",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.SecurityGroup{}))
+				rules := *result.(network.SecurityGroup).SecurityRules
+				g.Expect(rules).To(HaveLen(1))
+				g.Expect(rules[0].SourceAddressPrefix).To(BeNil())
+				g.Expect(rules[0].DestinationAddressPrefix).To(BeNil())
+				g.Expect(*rules[0].SourceApplicationSecurityGroups).To(Equal([]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/node")},
+				}))
+				g.Expect(*rules[0].DestinationApplicationSecurityGroups).To(Equal([]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/control-plane")},
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
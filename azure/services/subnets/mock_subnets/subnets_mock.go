@@ -23,6 +23,7 @@ package mock_subnets
 import (
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -53,6 +54,20 @@ func (m *MockSubnetScope) EXPECT() *MockSubnetScopeMockRecorder {
 	return m.recorder
 }
 
+// AllocateSubnetCIDRs mocks base method.
+func (m *MockSubnetScope) AllocateSubnetCIDRs() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateSubnetCIDRs")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AllocateSubnetCIDRs indicates an expected call of AllocateSubnetCIDRs.
+func (mr *MockSubnetScopeMockRecorder) AllocateSubnetCIDRs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateSubnetCIDRs", reflect.TypeOf((*MockSubnetScope)(nil).AllocateSubnetCIDRs))
+}
+
 // Authorizer mocks base method.
 func (m *MockSubnetScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -231,6 +246,20 @@ func (mr *MockSubnetScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockSubnetScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockSubnetScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockSubnetScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockSubnetScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockSubnetScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
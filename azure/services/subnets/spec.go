@@ -41,6 +41,9 @@ type SubnetSpec struct {
 	Role              infrav1.SubnetRole
 	NatGatewayName    string
 	ServiceEndpoints  infrav1.ServiceEndpoints
+
+	// DisablePrivateLinkServiceNetworkPolicies is set on the subnet that a private link service allocates its NAT IP addresses from.
+	DisablePrivateLinkServiceNetworkPolicies bool
 }
 
 // ResourceName returns the name of the subnet.
@@ -83,9 +86,10 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 			newServiceEndpoints = append(newServiceEndpoints, network.ServiceEndpointPropertiesFormat{Service: pointer.String(se.Service), Locations: &se.Locations})
 		}
 
-		// Right now only serviceEndpoints are allowed to be updated. More to come later
+		// Right now only serviceEndpoints and private link service network policies are allowed to be updated. More to come later
 		diff := cmp.Diff(newServiceEndpoints, existingServiceEndpoints)
-		if diff == "" {
+		if diff == "" && (!s.DisablePrivateLinkServiceNetworkPolicies ||
+			existingSubnet.PrivateLinkServiceNetworkPolicies == network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled) {
 			// up to date, nothing to do
 			return nil, nil
 		}
@@ -130,6 +134,9 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 		serviceEndpoints = append(serviceEndpoints, network.ServiceEndpointPropertiesFormat{Service: pointer.String(se.Service), Locations: &se.Locations})
 	}
 	subnetProperties.ServiceEndpoints = &serviceEndpoints
+	if s.DisablePrivateLinkServiceNetworkPolicies {
+		subnetProperties.PrivateLinkServiceNetworkPolicies = network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled
+	}
 
 	return network.Subnet{
 		SubnetPropertiesFormat: &subnetProperties,
@@ -168,6 +168,47 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "disable private link service network policies on an existing subnet",
+			spec: &SubnetSpec{
+				Name:                                     "my-subnet-1",
+				CIDRs:                                    []string{"10.0.0.0/16"},
+				IsVNetManaged:                            true,
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: network.Subnet{
+				Name: pointer.String("my-subnet-1"),
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:                     pointer.String("10.0.0.0/16"),
+					PrivateLinkServiceNetworkPolicies: network.VirtualNetworkPrivateLinkServiceNetworkPoliciesEnabled,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Subnet{}))
+				g.Expect(result.(network.Subnet).PrivateLinkServiceNetworkPolicies).To(Equal(network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled))
+			},
+			expectedError: "",
+		},
+		{
+			name: "private link service network policies are already disabled",
+			spec: &SubnetSpec{
+				Name:                                     "my-subnet-1",
+				CIDRs:                                    []string{"10.0.0.0/16"},
+				IsVNetManaged:                            true,
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: network.Subnet{
+				Name: pointer.String("my-subnet-1"),
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:                     pointer.String("10.0.0.0/16"),
+					PrivateLinkServiceNetworkPolicies: network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
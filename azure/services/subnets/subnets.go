@@ -30,7 +30,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "subnets"
+// ServiceName is the name of this service.
+const ServiceName = "subnets"
 
 // SubnetScope defines the scope interface for a subnet service.
 type SubnetScope interface {
@@ -38,6 +39,7 @@ type SubnetScope interface {
 	azure.AsyncStatusUpdater
 	UpdateSubnetID(string, string)
 	UpdateSubnetCIDRs(string, []string)
+	AllocateSubnetCIDRs() error
 	IsVnetManaged() bool
 	SubnetSpecs() []azure.ResourceSpecGetter
 }
@@ -59,7 +61,7 @@ func New(scope SubnetScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a subnet.
@@ -70,6 +72,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
+	// CIDR blocks are allocated here rather than in the webhooks since the virtual network needs to be reconciled first
+	// to know whether it is managed.
+	if err := s.Scope.AllocateSubnetCIDRs(); err != nil {
+		return errors.Wrap(err, "failed to allocate subnet CIDR blocks")
+	}
+
 	specs := s.Scope.SubnetSpecs()
 	if len(specs) == 0 {
 		return nil
@@ -80,7 +88,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var resultErr error
 	for _, subnetSpec := range specs {
-		result, err := s.CreateOrUpdateResource(ctx, subnetSpec, serviceName)
+		result, err := s.CreateOrUpdateResource(ctx, subnetSpec, ServiceName)
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
 				resultErr = err
@@ -96,7 +104,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, resultErr)
+		s.Scope.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, resultErr)
 	}
 
 	return resultErr
@@ -127,14 +135,14 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	var result error
 	for _, subnetSpec := range specs {
-		if err := s.DeleteResource(ctx, subnetSpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, subnetSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
 
-	s.Scope.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, result)
+	s.Scope.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, result)
 	return result
 }
 
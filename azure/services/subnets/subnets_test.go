@@ -201,6 +201,7 @@ func TestReconcileSubnets(t *testing.T) {
 			name:          "noop if no subnet specs are found",
 			expectedError: "",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{})
 			},
 		},
@@ -208,41 +209,44 @@ func TestReconcileSubnets(t *testing.T) {
 			name:          "create subnet",
 			expectedError: "",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(fakeSubnet1, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, pointer.StringDeref(fakeSubnet1.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{pointer.StringDeref(fakeSubnet1.AddressPrefix, "")})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
 			name:          "create multiple subnets",
 			expectedError: "",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(fakeSubnet1, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, pointer.StringDeref(fakeSubnet1.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{pointer.StringDeref(fakeSubnet1.AddressPrefix, "")})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, ServiceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, pointer.StringDeref(fakeSubnet2.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{pointer.StringDeref(fakeSubnet2.AddressPrefix, "")})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
 			name:          "don't update ready condition when subnet not managed",
 			expectedError: "",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpecNotManaged})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpecNotManaged, serviceName).Return(fakeSubnetNotManaged, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpecNotManaged, ServiceName).Return(fakeSubnetNotManaged, nil)
 				s.UpdateSubnetID(fakeSubnetSpecNotManaged.Name, pointer.StringDeref(fakeSubnetNotManaged.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpecNotManaged.Name, []string{pointer.StringDeref(fakeSubnetNotManaged.AddressPrefix, "")})
 
@@ -253,66 +257,78 @@ func TestReconcileSubnets(t *testing.T) {
 			name:          "create ipv6 subnet",
 			expectedError: "",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, ServiceName).Return(fakeIpv6Subnet, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, pointer.StringDeref(fakeIpv6Subnet.ID, ""))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, azure.StringSlice(fakeIpv6Subnet.AddressPrefixes))
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
 			name:          "create multiple ipv6 subnets",
 			expectedError: "",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec, &fakeIpv6SubnetSpecCP})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, ServiceName).Return(fakeIpv6Subnet, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, pointer.StringDeref(fakeIpv6Subnet.ID, ""))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, azure.StringSlice(fakeIpv6Subnet.AddressPrefixes))
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpecCP, serviceName).Return(fakeIpv6SubnetCP, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpecCP, ServiceName).Return(fakeIpv6SubnetCP, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpecCP.Name, pointer.StringDeref(fakeIpv6SubnetCP.ID, ""))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpecCP.Name, azure.StringSlice(fakeIpv6SubnetCP.AddressPrefixes))
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
 			name:          "fail to create subnet",
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil, internalError)
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
 			name:          "create returns a non subnet",
 			expectedError: notASubnetErr.Error(),
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(notASubnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(notASubnet, nil)
 			},
 		},
 		{
 			name:          "fail to create subnets",
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs()
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil, internalError)
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, ServiceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, pointer.StringDeref(fakeSubnet2.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{pointer.StringDeref(fakeSubnet2.AddressPrefix, "")})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "fail to allocate subnet CIDR blocks",
+			expectedError: "failed to allocate subnet CIDR blocks: address space exhausted",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AllocateSubnetCIDRs().Return(errors.New("address space exhausted"))
 			},
 		},
 	}
@@ -366,9 +382,9 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -377,9 +393,9 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeCtrlPlaneSubnetSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeCtrlPlaneSubnetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeCtrlPlaneSubnetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -395,8 +411,8 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -23,6 +23,7 @@ package mock_tags
 import (
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -206,6 +207,20 @@ func (mr *MockTagScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockTagScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockTagScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockTagScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockTagScope)(nil).Token))
+}
+
 // UpdateAnnotationJSON mocks base method.
 func (m *MockTagScope) UpdateAnnotationJSON(arg0 string, arg1 map[string]interface{}) error {
 	m.ctrl.T.Helper()
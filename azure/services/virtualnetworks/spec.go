@@ -18,10 +18,13 @@ package virtualnetworks
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
@@ -34,8 +37,23 @@ type VNetSpec struct {
 	ExtendedLocation *infrav1.ExtendedLocationSpec
 	ClusterName      string
 	AdditionalTags   infrav1.Tags
+
+	DDoSProtectionPlanID string
+	DNSServers           []string
+	Shared               bool
+	// ClearDDoSProtectionPlan and ClearDNSServers are set when the DDoS protection plan or the DNS servers
+	// previously applied by CAPZ were removed from the spec, in which case they are cleared on the vnet.
+	ClearDDoSProtectionPlan bool
+	ClearDNSServers         bool
 }
 
+// vnetSettingsDDoSProtectionPlanIDKey and vnetSettingsDNSServersKey are the keys of the settings in the
+// VNetSettingsLastAppliedAnnotation.
+const (
+	vnetSettingsDDoSProtectionPlanIDKey = "ddosProtectionPlanID"
+	vnetSettingsDNSServersKey           = "dnsServers"
+)
+
 // ResourceName returns the name of the vnet.
 func (s *VNetSpec) ResourceName() string {
 	return s.Name
@@ -54,23 +72,130 @@ func (s *VNetSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the vnet.
 func (s *VNetSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
 	if existing != nil {
-		// vnet already exists, nothing to update.
-		return nil, nil
+		existingVnet, ok := existing.(network.VirtualNetwork)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.VirtualNetwork", existing)
+		}
+
+		// Only the settings of vnets owned by this cluster are updated, a BYO vnet is left untouched.
+		if !converters.MapToTags(existingVnet.Tags).HasOwned(s.ClusterName) ||
+			(len(s.MissingSettings(existingVnet)) == 0 && !s.hasSettingsToClear(existingVnet)) {
+			return nil, nil
+		}
+
+		// Update the existing vnet in place so its subnets and peerings are sent back unchanged.
+		if existingVnet.VirtualNetworkPropertiesFormat == nil {
+			existingVnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{}
+		}
+		s.setSettings(existingVnet.VirtualNetworkPropertiesFormat)
+		return existingVnet, nil
+	}
+
+	role := infrav1.CommonRole
+	if s.Shared {
+		role = infrav1.SharedVnetRole
+	}
+	properties := &network.VirtualNetworkPropertiesFormat{
+		AddressSpace: &network.AddressSpace{
+			AddressPrefixes: &s.CIDRs,
+		},
 	}
+	s.setSettings(properties)
+
 	return network.VirtualNetwork{
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
 			Name:        pointer.String(s.Name),
-			Role:        pointer.String(infrav1.CommonRole),
+			Role:        pointer.String(role),
 			Additional:  s.AdditionalTags,
 		})),
-		Location:         pointer.String(s.Location),
-		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
-		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
-			AddressSpace: &network.AddressSpace{
-				AddressPrefixes: &s.CIDRs,
-			},
-		},
+		Location:                       pointer.String(s.Location),
+		ExtendedLocation:               converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
+		VirtualNetworkPropertiesFormat: properties,
 	}, nil
 }
+
+// MissingSettings returns the DDoS protection plan and DNS server settings of the spec that are not applied to the vnet.
+// Settings left empty in the spec are not managed and never reported as missing.
+func (s *VNetSpec) MissingSettings(vnet network.VirtualNetwork) []string {
+	var missing []string
+	properties := vnet.VirtualNetworkPropertiesFormat
+	if properties == nil {
+		properties = &network.VirtualNetworkPropertiesFormat{}
+	}
+
+	if s.DDoSProtectionPlanID != "" {
+		if !pointer.BoolDeref(properties.EnableDdosProtection, false) || properties.DdosProtectionPlan == nil ||
+			!strings.EqualFold(pointer.StringDeref(properties.DdosProtectionPlan.ID, ""), s.DDoSProtectionPlanID) {
+			missing = append(missing, "DDoS protection plan "+s.DDoSProtectionPlanID)
+		}
+	}
+
+	if len(s.DNSServers) > 0 {
+		var dnsServers []string
+		if properties.DhcpOptions != nil {
+			dnsServers = azure.StringSlice(properties.DhcpOptions.DNSServers)
+		}
+		if strings.Join(dnsServers, ",") != strings.Join(s.DNSServers, ",") {
+			missing = append(missing, "DNS servers "+strings.Join(s.DNSServers, ", "))
+		}
+	}
+
+	return missing
+}
+
+// hasSettingsToClear returns true if the vnet still has a DDoS protection plan or DNS servers removed from the spec.
+func (s *VNetSpec) hasSettingsToClear(vnet network.VirtualNetwork) bool {
+	properties := vnet.VirtualNetworkPropertiesFormat
+	if properties == nil {
+		return false
+	}
+	if s.ClearDDoSProtectionPlan && (pointer.BoolDeref(properties.EnableDdosProtection, false) || properties.DdosProtectionPlan != nil) {
+		return true
+	}
+	return s.ClearDNSServers && properties.DhcpOptions != nil && len(azure.StringSlice(properties.DhcpOptions.DNSServers)) > 0
+}
+
+// LastAppliedSettings returns the DDoS protection plan and the DNS servers of the spec, in the format of the
+// VNetSettingsLastAppliedAnnotation.
+func (s *VNetSpec) LastAppliedSettings() map[string]interface{} {
+	return map[string]interface{}{
+		vnetSettingsDDoSProtectionPlanIDKey: s.DDoSProtectionPlanID,
+		vnetSettingsDNSServersKey:           s.DNSServers,
+	}
+}
+
+// SetSettingsToClear sets the settings to clear from the settings last applied by CAPZ, as found in the
+// VNetSettingsLastAppliedAnnotation, which are no longer in the spec.
+func (s *VNetSpec) SetSettingsToClear(lastApplied map[string]interface{}) {
+	ddosProtectionPlanID, _ := lastApplied[vnetSettingsDDoSProtectionPlanIDKey].(string)
+	s.ClearDDoSProtectionPlan = ddosProtectionPlanID != "" && s.DDoSProtectionPlanID == ""
+	dnsServers, _ := lastApplied[vnetSettingsDNSServersKey].([]interface{})
+	s.ClearDNSServers = len(dnsServers) > 0 && len(s.DNSServers) == 0
+}
+
+// setSettings sets the DDoS protection plan and the DNS servers of the spec on the vnet properties.
+func (s *VNetSpec) setSettings(properties *network.VirtualNetworkPropertiesFormat) {
+	if s.ClearDDoSProtectionPlan {
+		properties.EnableDdosProtection = pointer.Bool(false)
+		properties.DdosProtectionPlan = nil
+	}
+	if s.ClearDNSServers {
+		properties.DhcpOptions = &network.DhcpOptions{
+			DNSServers: &[]string{},
+		}
+	}
+	if s.DDoSProtectionPlanID != "" {
+		properties.EnableDdosProtection = pointer.Bool(true)
+		properties.DdosProtectionPlan = &network.SubResource{
+			ID: pointer.String(s.DDoSProtectionPlanID),
+		}
+	}
+	if len(s.DNSServers) > 0 {
+		dnsServers := append([]string{}, s.DNSServers...)
+		properties.DhcpOptions = &network.DhcpOptions{
+			DNSServers: &dnsServers,
+		}
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	managedVnet := func(properties *network.VirtualNetworkPropertiesFormat) network.VirtualNetwork {
+		return network.VirtualNetwork{
+			Name: pointer.String("test-vnet"),
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+			},
+			VirtualNetworkPropertiesFormat: properties,
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *VNetSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new vnet with DDoS protection plan and DNS servers",
+			spec:     &fakeVNetSpecWithSettings,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(*vnet.AddressSpace.AddressPrefixes).To(Equal([]string{"10.0.0.0/8"}))
+				g.Expect(vnet.EnableDdosProtection).To(Equal(pointer.Bool(true)))
+				g.Expect(vnet.DdosProtectionPlan.ID).To(Equal(pointer.String(fakeDDoSProtectionPlanID)))
+				g.Expect(*vnet.DhcpOptions.DNSServers).To(Equal([]string{"10.0.0.4", "10.0.0.5"}))
+			},
+		},
+		{
+			name:     "new vnet without settings",
+			spec:     &fakeVNetSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(vnet.EnableDdosProtection).To(BeNil())
+				g.Expect(vnet.DdosProtectionPlan).To(BeNil())
+				g.Expect(vnet.DhcpOptions).To(BeNil())
+			},
+		},
+		{
+			name:     "new shared vnet",
+			spec:     &VNetSpec{ResourceGroup: "test-group", Name: "test-vnet", CIDRs: []string{"10.0.0.0/8"}, ClusterName: "test-cluster", Shared: true},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(vnet.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_role", pointer.String(infrav1.SharedVnetRole)))
+				g.Expect(vnet.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster", pointer.String("owned")))
+			},
+		},
+		{
+			name: "managed vnet is updated in place",
+			spec: &fakeVNetSpecWithSettings,
+			existing: managedVnet(&network.VirtualNetworkPropertiesFormat{
+				AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+				Subnets:      &[]network.Subnet{{Name: pointer.String("test-subnet")}},
+				DhcpOptions:  &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4"}},
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(*vnet.Subnets).To(Equal([]network.Subnet{{Name: pointer.String("test-subnet")}}))
+				g.Expect(vnet.EnableDdosProtection).To(Equal(pointer.Bool(true)))
+				g.Expect(vnet.DdosProtectionPlan.ID).To(Equal(pointer.String(fakeDDoSProtectionPlanID)))
+				g.Expect(*vnet.DhcpOptions.DNSServers).To(Equal([]string{"10.0.0.4", "10.0.0.5"}))
+			},
+		},
+		{
+			name: "managed vnet is up to date",
+			spec: &fakeVNetSpecWithSettings,
+			existing: managedVnet(&network.VirtualNetworkPropertiesFormat{
+				EnableDdosProtection: pointer.Bool(true),
+				DdosProtectionPlan:   &network.SubResource{ID: pointer.String("/subscriptions/123/resourcegroups/test-group/providers/Microsoft.Network/ddosProtectionPlans/test-plan")},
+				DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4", "10.0.0.5"}},
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managed vnet settings removed from the spec are cleared",
+			spec: &VNetSpec{
+				Name:                    "test-vnet",
+				ClusterName:             "test-cluster",
+				ClearDDoSProtectionPlan: true,
+				ClearDNSServers:         true,
+			},
+			existing: managedVnet(&network.VirtualNetworkPropertiesFormat{
+				EnableDdosProtection: pointer.Bool(true),
+				DdosProtectionPlan:   &network.SubResource{ID: pointer.String(fakeDDoSProtectionPlanID)},
+				DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4", "10.0.0.5"}},
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(vnet.EnableDdosProtection).To(Equal(pointer.Bool(false)))
+				g.Expect(vnet.DdosProtectionPlan).To(BeNil())
+				g.Expect(*vnet.DhcpOptions.DNSServers).To(BeEmpty())
+			},
+		},
+		{
+			name: "managed vnet settings removed from the spec are already cleared",
+			spec: &VNetSpec{
+				Name:                    "test-vnet",
+				ClusterName:             "test-cluster",
+				ClearDDoSProtectionPlan: true,
+				ClearDNSServers:         true,
+			},
+			existing: managedVnet(&network.VirtualNetworkPropertiesFormat{
+				EnableDdosProtection: pointer.Bool(false),
+				DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{}},
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "custom vnet is not updated",
+			spec:     &fakeVNetSpecWithSettings,
+			existing: customVnet,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a vnet",
+			spec:          &fakeVNetSpec,
+			existing:      "wrong type",
+			expectedError: "string is not a network.VirtualNetwork",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestSetSettingsToClear(t *testing.T) {
+	testcases := []struct {
+		name                      string
+		spec                      VNetSpec
+		lastApplied               string
+		expectClearDDoSProtection bool
+		expectClearDNSServers     bool
+	}{
+		{
+			name:        "nothing applied before",
+			spec:        fakeVNetSpec,
+			lastApplied: `{}`,
+		},
+		{
+			name:        "applied settings are still in the spec",
+			spec:        fakeVNetSpecWithSettings,
+			lastApplied: `{"ddosProtectionPlanID":"` + fakeDDoSProtectionPlanID + `","dnsServers":["10.0.0.4"]}`,
+		},
+		{
+			name:                      "applied settings were removed from the spec",
+			spec:                      fakeVNetSpec,
+			lastApplied:               `{"ddosProtectionPlanID":"` + fakeDDoSProtectionPlanID + `","dnsServers":["10.0.0.4"]}`,
+			expectClearDDoSProtection: true,
+			expectClearDNSServers:     true,
+		},
+		{
+			name:        "cleared settings were recorded",
+			spec:        fakeVNetSpec,
+			lastApplied: `{"ddosProtectionPlanID":"","dnsServers":null}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			lastApplied := map[string]interface{}{}
+			g.Expect(json.Unmarshal([]byte(tc.lastApplied), &lastApplied)).To(Succeed())
+			spec := tc.spec
+			spec.SetSettingsToClear(lastApplied)
+			g.Expect(spec.ClearDDoSProtectionPlan).To(Equal(tc.expectClearDDoSProtection))
+			g.Expect(spec.ClearDNSServers).To(Equal(tc.expectClearDNSServers))
+		})
+	}
+}
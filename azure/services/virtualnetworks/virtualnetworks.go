@@ -18,8 +18,12 @@ package virtualnetworks
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -29,9 +33,11 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-const serviceName = "virtualnetworks"
+// ServiceName is the name of this service.
+const ServiceName = "virtualnetworks"
 
 // VNetScope defines the scope interface for a virtual network service.
 type VNetScope interface {
@@ -42,6 +48,9 @@ type VNetScope interface {
 	ClusterName() string
 	IsVnetManaged() bool
 	UpdateSubnetCIDRs(string, []string)
+	SetConditionTrue(clusterv1.ConditionType)
+	SetConditionFalse(clusterv1.ConditionType, string, clusterv1.ConditionSeverity, string)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
 }
 
 // Service provides operations on Azure resources.
@@ -50,6 +59,7 @@ type Service struct {
 	async.Reconciler
 	async.Getter
 	async.TagsGetter
+	async.TagsUpdater
 }
 
 // New creates a new service.
@@ -57,16 +67,17 @@ func New(scope VNetScope) *Service {
 	client := newClient(scope)
 	tagsClient := tags.NewClient(scope)
 	return &Service{
-		Scope:      scope,
-		Getter:     client,
-		TagsGetter: tagsClient,
-		Reconciler: async.New(scope, client, client),
+		Scope:       scope,
+		Getter:      client,
+		TagsGetter:  tagsClient,
+		TagsUpdater: tagsClient,
+		Reconciler:  async.New(scope, client, client),
 	}
 }
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a virtual network.
@@ -82,12 +93,16 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, serviceName)
+	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, ServiceName)
+	managed := s.Scope.IsVnetManaged()
 	if err == nil && result != nil {
 		existingVnet, ok := result.(network.VirtualNetwork)
 		if !ok {
 			return errors.Errorf("%T is not a network.VirtualNetwork", result)
 		}
+		if err := s.joinSharedVnet(ctx, vnetSpec, &existingVnet); err != nil {
+			return errors.Wrap(err, "failed to join shared VNet")
+		}
 		vnet := s.Scope.Vnet()
 		vnet.ID = pointer.StringDeref(existingVnet.ID, "")
 		vnet.Tags = converters.MapToTags(existingVnet.Tags)
@@ -106,15 +121,79 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				s.Scope.UpdateSubnetCIDRs(pointer.StringDeref(subnet.Name, ""), converters.GetSubnetAddresses(subnet))
 			}
 		}
+
+		if !managed {
+			s.reportMissingSettings(vnetSpec, existingVnet)
+		}
 	}
 
-	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, err)
+	if managed && err == nil {
+		err = s.updateLastAppliedSettings(vnetSpec)
+	}
+
+	if managed {
+		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	}
 
 	return err
 }
 
+// updateLastAppliedSettings records the DDoS protection plan and the DNS servers applied to a managed vnet,
+// so that they can be cleared once they are removed from the spec.
+func (s *Service) updateLastAppliedSettings(vnetSpec azure.ResourceSpecGetter) error {
+	spec, ok := vnetSpec.(*VNetSpec)
+	if !ok || (spec.DDoSProtectionPlanID == "" && len(spec.DNSServers) == 0 && !spec.ClearDDoSProtectionPlan && !spec.ClearDNSServers) {
+		return nil
+	}
+
+	if err := s.Scope.UpdateAnnotationJSON(azure.VNetSettingsLastAppliedAnnotation, spec.LastAppliedSettings()); err != nil {
+		return errors.Wrap(err, "failed to update the last applied vnet settings annotation")
+	}
+	return nil
+}
+
+// joinSharedVnet adds the owned tag of the cluster to a shared vnet created by another cluster,
+// so the vnet is managed by this cluster as well and is not deleted as long as this cluster uses it.
+func (s *Service) joinSharedVnet(ctx context.Context, vnetSpec azure.ResourceSpecGetter, existingVnet *network.VirtualNetwork) error {
+	spec, ok := vnetSpec.(*VNetSpec)
+	if !ok || !spec.Shared {
+		return nil
+	}
+
+	vnetTags := converters.MapToTags(existingVnet.Tags)
+	if vnetTags.HasOwned(s.Scope.ClusterName()) || vnetTags.GetRole() != infrav1.SharedVnetRole {
+		return nil
+	}
+
+	// The tag is merged rather than the vnet updated, so clusters joining the vnet concurrently don't overwrite each other's tags.
+	result, err := s.TagsUpdater.UpdateAtScope(ctx, azure.VNetID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()), resources.TagsPatchResource{
+		Operation:  resources.TagsPatchOperationMerge,
+		Properties: &resources.Tags{Tags: s.ownedTag()},
+	})
+	if err != nil {
+		return err
+	}
+	if result.Properties != nil {
+		existingVnet.Tags = result.Properties.Tags
+	}
+	return nil
+}
+
+// reportMissingSettings reports the DDoS protection plan and DNS server settings that are missing on a BYO vnet, as CAPZ does not update it.
+func (s *Service) reportMissingSettings(vnetSpec azure.ResourceSpecGetter, existingVnet network.VirtualNetwork) {
+	spec, ok := vnetSpec.(*VNetSpec)
+	if !ok || (spec.DDoSProtectionPlanID == "" && len(spec.DNSServers) == 0) {
+		return
+	}
+
+	if missing := spec.MissingSettings(existingVnet); len(missing) > 0 {
+		s.Scope.SetConditionFalse(infrav1.VNetSettingsConfiguredCondition, infrav1.VNetSettingsMissingReason, clusterv1.ConditionSeverityWarning,
+			fmt.Sprintf("custom vnet %s is missing %s", spec.Name, strings.Join(missing, " and ")))
+		return
+	}
+	s.Scope.SetConditionTrue(infrav1.VNetSettingsConfiguredCondition)
+}
+
 // Delete deletes the virtual network if it is managed by capz.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualnetworks.Service.Delete")
@@ -133,8 +212,8 @@ func (s *Service) Delete(ctx context.Context) error {
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
-			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
+			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			return nil
 		}
 		return errors.Wrap(err, "could not get VNet management state")
@@ -144,13 +223,63 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	err = s.DeleteResource(ctx, vnetSpec, serviceName)
-	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, err)
+	if spec, ok := vnetSpec.(*VNetSpec); ok && spec.Shared {
+		owners, err := s.releaseSharedVnet(ctx, spec)
+		if err != nil {
+			return errors.Wrap(err, "failed to release shared VNet")
+		}
+		if len(owners) > 0 {
+			log.Info("Skipping deletion of shared VNet still used by other clusters", "clusters", owners)
+			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			return nil
+		}
+	}
+
+	err = s.DeleteResource(ctx, vnetSpec, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	return err
 }
 
+// releaseSharedVnet removes the owned tag of the cluster from a shared vnet and returns the clusters still owning it.
+func (s *Service) releaseSharedVnet(ctx context.Context, spec *VNetSpec) ([]string, error) {
+	result, err := s.TagsUpdater.UpdateAtScope(ctx, azure.VNetID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()), resources.TagsPatchResource{
+		Operation:  resources.TagsPatchOperationDelete,
+		Properties: &resources.Tags{Tags: s.ownedTag()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+	return sharedVnetOwners(converters.MapToTags(tagsMap)), nil
+}
+
+// ownedTag returns the tag marking the vnet as owned by the cluster.
+func (s *Service) ownedTag() map[string]*string {
+	return map[string]*string{
+		infrav1.ClusterTagKey(s.Scope.ClusterName()): pointer.String(string(infrav1.ResourceLifecycleOwned)),
+	}
+}
+
+// sharedVnetOwners returns the names of the clusters owning a shared vnet.
+func sharedVnetOwners(vnetTags infrav1.Tags) []string {
+	var owners []string
+	for key, value := range vnetTags {
+		if strings.HasPrefix(key, infrav1.NameAzureProviderOwned) && value == string(infrav1.ResourceLifecycleOwned) {
+			owners = append(owners, strings.TrimPrefix(key, infrav1.NameAzureProviderOwned))
+		}
+	}
+	sort.Strings(owners)
+	return owners
+}
+
 // IsManaged returns true if the virtual network has an owned tag with the cluster name as value,
 // meaning that the vnet's lifecycle is managed.
+// A shared virtual network no longer owned by any cluster is managed by every cluster sharing it,
+// so it is still deleted if the deletion failed after the last cluster released it.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualnetworks.Service.IsManaged")
 	defer done()
@@ -172,5 +301,8 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	}
 
 	tags := converters.MapToTags(tagsMap)
+	if vnetSpec, ok := spec.(*VNetSpec); ok && vnetSpec.Shared && tags.GetRole() == infrav1.SharedVnetRole {
+		return tags.HasOwned(s.Scope.ClusterName()) || len(sharedVnetOwners(tags)) == 0, nil
+	}
 	return tags.HasOwned(s.Scope.ClusterName()), nil
 }
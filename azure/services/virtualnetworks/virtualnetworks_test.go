@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks/mock_virtualnetworks"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -44,6 +45,18 @@ var (
 		AdditionalTags: map[string]string{"foo": "bar"},
 	}
 
+	fakeDDoSProtectionPlanID = "/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/ddosProtectionPlans/test-plan"
+
+	fakeVNetSpecWithSettings = VNetSpec{
+		ResourceGroup:        "test-group",
+		Name:                 "test-vnet",
+		CIDRs:                []string{"10.0.0.0/8"},
+		Location:             "test-location",
+		ClusterName:          "test-cluster",
+		DDoSProtectionPlanID: fakeDDoSProtectionPlanID,
+		DNSServers:           []string{"10.0.0.4", "10.0.0.5"},
+	}
+
 	managedTags = resources.TagsResource{
 		Properties: &resources.Tags{
 			Tags: map[string]*string{
@@ -113,7 +126,7 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(false)
 			},
 		},
@@ -122,9 +135,50 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
+				s.IsVnetManaged().Return(true)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "managed vnet records the last applied settings",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Return(&fakeVNetSpecWithSettings)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpecWithSettings, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				s.UpdateAnnotationJSON(azure.VNetSettingsLastAppliedAnnotation, map[string]interface{}{
+					"ddosProtectionPlanID": fakeDDoSProtectionPlanID,
+					"dnsServers":           []string{"10.0.0.4", "10.0.0.5"},
+				}).Return(nil)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "managed vnet records the cleared settings",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				spec := fakeVNetSpec
+				spec.ClearDDoSProtectionPlan = true
+				s.VNetSpec().Return(&spec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &spec, ServiceName).Return(nil, nil)
+				s.IsVnetManaged().Return(true)
+				s.UpdateAnnotationJSON(azure.VNetSettingsLastAppliedAnnotation, map[string]interface{}{
+					"ddosProtectionPlanID": "",
+					"dnsServers":           []string(nil),
+				}).Return(nil)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to record the last applied settings of a managed vnet",
+			expectedError: "failed to update the last applied vnet settings annotation: " + internalError.Error(),
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Return(&fakeVNetSpecWithSettings)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpecWithSettings, ServiceName).Return(nil, nil)
+				s.IsVnetManaged().Return(true)
+				s.UpdateAnnotationJSON(azure.VNetSettingsLastAppliedAnnotation, gomock.Any()).Return(internalError)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, gomock.Any())
 			},
 		},
 		{
@@ -132,9 +186,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, internalError)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -142,11 +196,42 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(customVnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(customVnet, nil)
+				s.Vnet().Return(&infrav1.VnetSpec{})
+				s.UpdateSubnetCIDRs("test-subnet", []string{"subnet-cidr"})
+				s.UpdateSubnetCIDRs("test-subnet-2", []string{"subnet-cidr-1", "subnet-cidr-2"})
+				s.IsVnetManaged().Return(false)
+			},
+		},
+		{
+			name:          "custom vnet is missing the DDoS protection plan and DNS servers",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Return(&fakeVNetSpecWithSettings)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpecWithSettings, ServiceName).Return(customVnet, nil)
 				s.Vnet().Return(&infrav1.VnetSpec{})
 				s.UpdateSubnetCIDRs("test-subnet", []string{"subnet-cidr"})
 				s.UpdateSubnetCIDRs("test-subnet-2", []string{"subnet-cidr-1", "subnet-cidr-2"})
 				s.IsVnetManaged().Return(false)
+				s.SetConditionFalse(infrav1.VNetSettingsConfiguredCondition, infrav1.VNetSettingsMissingReason, clusterv1.ConditionSeverityWarning,
+					"custom vnet test-vnet is missing DDoS protection plan "+fakeDDoSProtectionPlanID+" and DNS servers 10.0.0.4, 10.0.0.5")
+			},
+		},
+		{
+			name:          "custom vnet has the DDoS protection plan and DNS servers",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				configuredVnet := customVnet
+				configuredVnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{
+					EnableDdosProtection: pointer.Bool(true),
+					DdosProtectionPlan:   &network.SubResource{ID: pointer.String(fakeDDoSProtectionPlanID)},
+					DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4", "10.0.0.5"}},
+				}
+				s.VNetSpec().Return(&fakeVNetSpecWithSettings)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpecWithSettings, ServiceName).Return(configuredVnet, nil)
+				s.Vnet().Return(&infrav1.VnetSpec{})
+				s.IsVnetManaged().Return(false)
+				s.SetConditionTrue(infrav1.VNetSettingsConfiguredCondition)
 			},
 		},
 	}
@@ -203,8 +288,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.VNetID("123", fakeVNetSpec.ResourceGroupName(), fakeVNetSpec.Name)).Return(managedTags, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -215,8 +300,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.VNetID("123", fakeVNetSpec.ResourceGroupName(), fakeVNetSpec.Name)).Return(managedTags, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -337,3 +422,253 @@ func TestIsVnetManaged(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileSharedVnet(t *testing.T) {
+	sharedVNetSpec := fakeVNetSpec
+	sharedVNetSpec.Shared = true
+	vnetID := azure.VNetID("123", sharedVNetSpec.ResourceGroupName(), sharedVNetSpec.Name)
+	sharedVnet := func(tags map[string]*string) network.VirtualNetwork {
+		return network.VirtualNetwork{
+			ID:   pointer.String(vnetID),
+			Name: pointer.String("test-vnet"),
+			Tags: tags,
+			VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+				AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+			},
+		}
+	}
+	joinedTags := map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_role":                  pointer.String(infrav1.SharedVnetRole),
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned"),
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster":  pointer.String("owned"),
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		wantVnetTags  infrav1.Tags
+		expect        func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder, vnet *infrav1.VnetSpec)
+	}{
+		{
+			name: "cluster joins a shared vnet created by another cluster",
+			wantVnetTags: infrav1.Tags{
+				"sigs.k8s.io_cluster-api-provider-azure_role":                  infrav1.SharedVnetRole,
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": "owned",
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster":  "owned",
+			},
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder, vnet *infrav1.VnetSpec) {
+				s.VNetSpec().Return(&sharedVNetSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &sharedVNetSpec, ServiceName).Return(sharedVnet(map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_role":                  pointer.String(infrav1.SharedVnetRole),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned"),
+				}), nil)
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.SubscriptionID().Return("123")
+				u.UpdateAtScope(gomockinternal.AContext(), vnetID, resources.TagsPatchResource{
+					Operation: resources.TagsPatchOperationMerge,
+					Properties: &resources.Tags{Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+					}},
+				}).Return(resources.TagsResource{Properties: &resources.Tags{Tags: joinedTags}}, nil)
+				s.Vnet().Return(vnet)
+				s.IsVnetManaged().Return(true)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name: "cluster already owns the shared vnet",
+			wantVnetTags: infrav1.Tags{
+				"sigs.k8s.io_cluster-api-provider-azure_role":                  infrav1.SharedVnetRole,
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": "owned",
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster":  "owned",
+			},
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder, vnet *infrav1.VnetSpec) {
+				s.VNetSpec().Return(&sharedVNetSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &sharedVNetSpec, ServiceName).Return(sharedVnet(joinedTags), nil)
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.Vnet().Return(vnet)
+				s.IsVnetManaged().Return(true)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name: "vnet not created as a shared vnet is not joined",
+			wantVnetTags: infrav1.Tags{
+				"sigs.k8s.io_cluster-api-provider-azure_role":                  infrav1.CommonRole,
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": "owned",
+			},
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder, vnet *infrav1.VnetSpec) {
+				s.VNetSpec().Return(&sharedVNetSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &sharedVNetSpec, ServiceName).Return(sharedVnet(map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_role":                  pointer.String(infrav1.CommonRole),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned"),
+				}), nil)
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.Vnet().Return(vnet)
+				s.IsVnetManaged().Return(false)
+			},
+		},
+		{
+			name:          "joining the shared vnet fails",
+			expectedError: "failed to join shared VNet",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder, vnet *infrav1.VnetSpec) {
+				s.VNetSpec().Return(&sharedVNetSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &sharedVNetSpec, ServiceName).Return(sharedVnet(map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_role":                  pointer.String(infrav1.SharedVnetRole),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned"),
+				}), nil)
+				s.IsVnetManaged().Return(true)
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.SubscriptionID().Return("123")
+				u.UpdateAtScope(gomockinternal.AContext(), vnetID, gomock.Any()).Return(resources.TagsResource{}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_virtualnetworks.NewMockVNetScope(mockCtrl)
+			tagsUpdaterMock := mock_async.NewMockTagsUpdater(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			vnet := &infrav1.VnetSpec{}
+
+			tc.expect(scopeMock.EXPECT(), tagsUpdaterMock.EXPECT(), reconcilerMock.EXPECT(), vnet)
+
+			s := &Service{
+				Scope:       scopeMock,
+				TagsUpdater: tagsUpdaterMock,
+				Reconciler:  reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(vnet.Tags).To(Equal(tc.wantVnetTags))
+			}
+		})
+	}
+}
+
+func TestDeleteSharedVnet(t *testing.T) {
+	sharedVNetSpec := fakeVNetSpec
+	sharedVNetSpec.Shared = true
+	vnetID := azure.VNetID("123", sharedVNetSpec.ResourceGroupName(), sharedVNetSpec.Name)
+	sharedTags := func(owners ...string) resources.TagsResource {
+		tags := map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_role": pointer.String(infrav1.SharedVnetRole),
+		}
+		for _, owner := range owners {
+			tags[infrav1.ClusterTagKey(owner)] = pointer.String("owned")
+		}
+		return resources.TagsResource{Properties: &resources.Tags{Tags: tags}}
+	}
+	release := resources.TagsPatchResource{
+		Operation: resources.TagsPatchOperationDelete,
+		Properties: &resources.Tags{Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+		}},
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name: "shared vnet still used by other clusters is released but not deleted",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Times(2).Return(&sharedVNetSpec)
+				s.SubscriptionID().Times(2).Return("123")
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				m.GetAtScope(gomockinternal.AContext(), vnetID).Return(sharedTags("test-cluster", "other-cluster"), nil)
+				u.UpdateAtScope(gomockinternal.AContext(), vnetID, release).Return(sharedTags("other-cluster"), nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name: "shared vnet is deleted with the last cluster using it",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Times(2).Return(&sharedVNetSpec)
+				s.SubscriptionID().Times(2).Return("123")
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				m.GetAtScope(gomockinternal.AContext(), vnetID).Return(sharedTags("test-cluster"), nil)
+				u.UpdateAtScope(gomockinternal.AContext(), vnetID, release).Return(sharedTags(), nil)
+				r.DeleteResource(gomockinternal.AContext(), &sharedVNetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name: "shared vnet released by all clusters is deleted",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Times(2).Return(&sharedVNetSpec)
+				s.SubscriptionID().Times(2).Return("123")
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				m.GetAtScope(gomockinternal.AContext(), vnetID).Return(sharedTags(), nil)
+				u.UpdateAtScope(gomockinternal.AContext(), vnetID, release).Return(sharedTags(), nil)
+				r.DeleteResource(gomockinternal.AContext(), &sharedVNetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name: "shared vnet not joined by the cluster is left untouched",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Times(2).Return(&sharedVNetSpec)
+				s.SubscriptionID().Return("123")
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				m.GetAtScope(gomockinternal.AContext(), vnetID).Return(sharedTags("other-cluster"), nil)
+			},
+		},
+		{
+			name:          "releasing the shared vnet fails",
+			expectedError: "failed to release shared VNet: " + internalError.Error(),
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, u *mock_async.MockTagsUpdaterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Times(2).Return(&sharedVNetSpec)
+				s.SubscriptionID().Times(2).Return("123")
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				m.GetAtScope(gomockinternal.AContext(), vnetID).Return(sharedTags("test-cluster", "other-cluster"), nil)
+				u.UpdateAtScope(gomockinternal.AContext(), vnetID, release).Return(resources.TagsResource{}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_virtualnetworks.NewMockVNetScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			tagsUpdaterMock := mock_async.NewMockTagsUpdater(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), tagsUpdaterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:       scopeMock,
+				TagsGetter:  tagsGetterMock,
+				TagsUpdater: tagsUpdaterMock,
+				Reconciler:  reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
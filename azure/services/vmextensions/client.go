@@ -30,6 +30,11 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// instanceViewGetter gets the instance view of VM extensions.
+type instanceViewGetter interface {
+	GetInstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (*compute.VirtualMachineExtensionInstanceView, error)
+}
+
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
 	vmextensions compute.VirtualMachineExtensionsClient
@@ -56,6 +61,21 @@ func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return ac.vmextensions.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), "")
 }
 
+// GetInstanceView returns the instance view of the specified virtual machine extension, or nil if it is not available yet.
+func (ac *azureClient) GetInstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (*compute.VirtualMachineExtensionInstanceView, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "vmextensions.AzureClient.GetInstanceView")
+	defer done()
+
+	extension, err := ac.vmextensions.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), "instanceView")
+	if err != nil {
+		return nil, err
+	}
+	if extension.VirtualMachineExtensionProperties == nil {
+		return nil, nil
+	}
+	return extension.InstanceView, nil
+}
+
 // CreateOrUpdateAsync creates or updates a VM extension asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
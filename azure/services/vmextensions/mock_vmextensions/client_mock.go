@@ -0,0 +1,68 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../client.go
+
+// Package mock_vmextensions is a generated GoMock package.
+package mock_vmextensions
+
+import (
+	context "context"
+	reflect "reflect"
+
+	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	gomock "github.com/golang/mock/gomock"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// MockinstanceViewGetter is a mock of instanceViewGetter interface.
+type MockinstanceViewGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockinstanceViewGetterMockRecorder
+}
+
+// MockinstanceViewGetterMockRecorder is the mock recorder for MockinstanceViewGetter.
+type MockinstanceViewGetterMockRecorder struct {
+	mock *MockinstanceViewGetter
+}
+
+// NewMockinstanceViewGetter creates a new mock instance.
+func NewMockinstanceViewGetter(ctrl *gomock.Controller) *MockinstanceViewGetter {
+	mock := &MockinstanceViewGetter{ctrl: ctrl}
+	mock.recorder = &MockinstanceViewGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinstanceViewGetter) EXPECT() *MockinstanceViewGetterMockRecorder {
+	return m.recorder
+}
+
+// GetInstanceView mocks base method.
+func (m *MockinstanceViewGetter) GetInstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (*compute.VirtualMachineExtensionInstanceView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceView", ctx, spec)
+	ret0, _ := ret[0].(*compute.VirtualMachineExtensionInstanceView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceView indicates an expected call of GetInstanceView.
+func (mr *MockinstanceViewGetterMockRecorder) GetInstanceView(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceView", reflect.TypeOf((*MockinstanceViewGetter)(nil).GetInstanceView), ctx, spec)
+}
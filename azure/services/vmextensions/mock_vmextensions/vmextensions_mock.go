@@ -23,6 +23,7 @@ package mock_vmextensions
 import (
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -135,6 +136,21 @@ func (mr *MockVMExtensionScopeMockRecorder) DeleteLongRunningOperationState(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockVMExtensionScope)(nil).DeleteLongRunningOperationState), arg0, arg1, arg2)
 }
 
+// ExtensionStatus mocks base method.
+func (m *MockVMExtensionScope) ExtensionStatus(name string) (v1beta1.ExtensionStatus, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtensionStatus", name)
+	ret0, _ := ret[0].(v1beta1.ExtensionStatus)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ExtensionStatus indicates an expected call of ExtensionStatus.
+func (mr *MockVMExtensionScopeMockRecorder) ExtensionStatus(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtensionStatus", reflect.TypeOf((*MockVMExtensionScope)(nil).ExtensionStatus), name)
+}
+
 // GetLongRunningOperationState mocks base method.
 func (m *MockVMExtensionScope) GetLongRunningOperationState(arg0, arg1, arg2 string) *v1beta1.Future {
 	m.ctrl.T.Helper()
@@ -163,6 +179,30 @@ func (mr *MockVMExtensionScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockVMExtensionScope)(nil).HashKey))
 }
 
+// RecordExtensionFailure mocks base method.
+func (m *MockVMExtensionScope) RecordExtensionFailure(name, output string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordExtensionFailure", name, output)
+}
+
+// RecordExtensionFailure indicates an expected call of RecordExtensionFailure.
+func (mr *MockVMExtensionScopeMockRecorder) RecordExtensionFailure(name, output interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordExtensionFailure", reflect.TypeOf((*MockVMExtensionScope)(nil).RecordExtensionFailure), name, output)
+}
+
+// SetExtensionStatus mocks base method.
+func (m *MockVMExtensionScope) SetExtensionStatus(arg0 v1beta1.ExtensionStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetExtensionStatus", arg0)
+}
+
+// SetExtensionStatus indicates an expected call of SetExtensionStatus.
+func (mr *MockVMExtensionScopeMockRecorder) SetExtensionStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExtensionStatus", reflect.TypeOf((*MockVMExtensionScope)(nil).SetExtensionStatus), arg0)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockVMExtensionScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -203,6 +243,20 @@ func (mr *MockVMExtensionScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockVMExtensionScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockVMExtensionScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockVMExtensionScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockVMExtensionScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockVMExtensionScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
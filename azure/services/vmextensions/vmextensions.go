@@ -18,36 +18,50 @@ package vmextensions
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const serviceName = "vmextensions"
 
+// maxOutputLength is the maximum length of the standard output or error of an extension kept for reporting in events.
+const maxOutputLength = 384
+
 // VMExtensionScope defines the scope interface for a vm extension service.
 type VMExtensionScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	VMExtensionSpecs() []azure.ResourceSpecGetter
+	ExtensionStatus(name string) (infrav1.ExtensionStatus, bool)
+	SetExtensionStatus(infrav1.ExtensionStatus)
+	RecordExtensionFailure(name, output string)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VMExtensionScope
 	async.Reconciler
+	instanceViewGetter
 }
 
 // New creates a new vm extension service.
 func New(scope VMExtensionScope) *Service {
 	client := newClient(scope)
 	return &Service{
-		Scope:      scope,
-		Reconciler: async.New(scope, client, client),
+		Scope:              scope,
+		Reconciler:         async.New(scope, client, client),
+		instanceViewGetter: client,
 	}
 }
 
@@ -72,7 +86,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// We go through the list of ExtensionSpecs to reconcile each one, independently of the result of the previous one.
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var resultErr error
+	// A failure reported in the instance view of the bootstrap extension takes precedence over any other error.
+	var resultErr, bootstrapErr error
 	for _, extensionSpec := range specs {
 		_, err := s.CreateOrUpdateResource(ctx, extensionSpec, serviceName)
 		if err != nil {
@@ -80,6 +95,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				resultErr = err
 			}
 		}
+		if azure.IsOperationNotDoneError(err) {
+			continue
+		}
+		if err := s.reconcileInstanceView(ctx, extensionSpec); err != nil && bootstrapErr == nil {
+			bootstrapErr = err
+		}
+	}
+	if bootstrapErr != nil {
+		resultErr = bootstrapErr
 	}
 
 	if azure.IsOperationNotDoneError(resultErr) {
@@ -92,6 +116,103 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return resultErr
 }
 
+// reconcileInstanceView fetches the instance view of a provisioned extension and maps it to the extension status.
+// Failures of the CAPZ bootstrapping extension are returned as terminal errors, so the machine is marked as failed
+// and can be remediated.
+func (s *Service) reconcileInstanceView(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "vmextensions.Service.reconcileInstanceView")
+	defer done()
+
+	name := spec.ResourceName()
+
+	// Once an extension has succeeded, there is no need to fetch its instance view again.
+	existing, ok := s.Scope.ExtensionStatus(name)
+	if ok && isSucceeded(existing) {
+		return nil
+	}
+
+	instanceView, err := s.GetInstanceView(ctx, spec)
+	if err != nil {
+		if !azure.ResourceNotFound(err) {
+			log.Error(err, "failed to get VM extension instance view", "extension", name)
+		}
+		return nil
+	}
+	if instanceView == nil {
+		return nil
+	}
+
+	status := converters.SDKToExtensionStatus(name, *instanceView)
+	s.Scope.SetExtensionStatus(status)
+
+	failure := failureMessage(status)
+	if failure == "" {
+		return nil
+	}
+	// The failure is only recorded when the extension starts failing, so that it is not reported on every reconciliation.
+	if !ok || failureMessage(existing) == "" {
+		s.Scope.RecordExtensionFailure(name, extensionOutput(*instanceView))
+	}
+
+	if name == azure.BootstrappingExtensionLinux || name == azure.BootstrappingExtensionWindows {
+		return azure.WithTerminalError(errors.Errorf("bootstrap extension %s failed: %s", name, failure))
+	}
+	return nil
+}
+
+// isSucceeded returns true if all the conditions of the extension status are true.
+func isSucceeded(status infrav1.ExtensionStatus) bool {
+	if len(status.Conditions) == 0 {
+		return false
+	}
+	for _, c := range status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
+// failureMessage returns the message of the first error condition of the extension status, or an empty string if the
+// extension did not fail.
+func failureMessage(status infrav1.ExtensionStatus) string {
+	for _, c := range status.Conditions {
+		if c.Severity == clusterv1.ConditionSeverityError {
+			return fmt.Sprintf("%s: %s", c.Type, c.Message)
+		}
+	}
+	return ""
+}
+
+// extensionOutput returns the standard output and error reported in the substatuses of an extension instance view.
+func extensionOutput(instanceView compute.VirtualMachineExtensionInstanceView) string {
+	if instanceView.Substatuses == nil {
+		return ""
+	}
+	var output []string
+	for _, substatus := range *instanceView.Substatuses {
+		if substatus.Code == nil || substatus.Message == nil || *substatus.Message == "" {
+			continue
+		}
+		switch {
+		case strings.Contains(*substatus.Code, "StdOut"):
+			output = append(output, "stdout: "+tail(*substatus.Message))
+		case strings.Contains(*substatus.Code, "StdErr"):
+			output = append(output, "stderr: "+tail(*substatus.Message))
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+// tail returns the end of the output of an extension, where errors are usually reported.
+func tail(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) <= maxOutputLength {
+		return output
+	}
+	return "..." + output[len(output)-maxOutputLength:]
+}
+
 // Delete is a no-op. VM Extensions will be deleted as part of VM deletion.
 func (s *Service) Delete(_ context.Context) error {
 	return nil
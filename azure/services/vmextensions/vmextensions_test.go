@@ -21,15 +21,20 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions/mock_vmextensions"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -58,6 +63,45 @@ var (
 	internalError        = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
 	extensionFailedError = errors.Wrapf(internalError, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
 
+	bootstrapExtensionSpec = VMExtensionSpec{
+		ExtensionSpec: azure.ExtensionSpec{
+			Name:      azure.BootstrappingExtensionLinux,
+			VMName:    "my-vm",
+			Publisher: "Microsoft.Azure.ContainerUpstream",
+			Version:   "1.0",
+		},
+		ResourceGroup: "my-rg",
+		Location:      "test-location",
+	}
+
+	succeededStatus = infrav1.ExtensionStatus{
+		Name:       "my-extension-1",
+		Conditions: clusterv1.Conditions{{Type: "ProvisioningState", Status: corev1.ConditionTrue}},
+	}
+
+	failedInstanceView = compute.VirtualMachineExtensionInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{
+				Code:    pointer.String("ProvisioningState/failed/1"),
+				Level:   compute.StatusLevelTypesError,
+				Message: pointer.String("Enable failed: exit status 1"),
+			},
+		},
+		Substatuses: &[]compute.InstanceViewStatus{
+			{
+				Code:    pointer.String("ComponentStatus/StdOut/succeeded"),
+				Level:   compute.StatusLevelTypesInfo,
+				Message: pointer.String("installing"),
+			},
+			{
+				Code:    pointer.String("ComponentStatus/StdErr/succeeded"),
+				Level:   compute.StatusLevelTypesInfo,
+				Message: pointer.String("exit status 1"),
+			},
+		},
+	}
+	bootstrapFailedError = errors.Wrapf(azure.WithTerminalError(errors.New("bootstrap extension CAPZ.Linux.Bootstrapping failed: ProvisioningState: Enable failed: exit status 1")), "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
+
 	notDoneError          = azure.NewOperationNotDoneError(&infrav1.Future{})
 	extensionNotDoneError = errors.Wrapf(notDoneError, "extension is still in provisioning state. This likely means that bootstrapping has not yet completed on the VM")
 )
@@ -66,30 +110,34 @@ func TestReconcileVMExtension(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder)
 	}{
 		{
 			name:          "extension is in succeeded state",
 			expectedError: "",
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec1.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec1).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "extension is in failed state",
 			expectedError: extensionFailedError.Error(),
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
+				s.ExtensionStatus(extensionSpec1.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec1).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
 			},
 		},
 		{
 			name:          "extension is still creating",
 			expectedError: extensionNotDoneError.Error(),
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, notDoneError)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionNotDoneError.Error()))
@@ -98,23 +146,79 @@ func TestReconcileVMExtension(t *testing.T) {
 		{
 			name:          "reconcile multiple extensions",
 			expectedError: "",
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1, &extensionSpec2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec1.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec1).Return(nil, nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec2, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec2.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec2).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "error creating the first extension",
 			expectedError: extensionFailedError.Error(),
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1, &extensionSpec2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
+				s.ExtensionStatus(extensionSpec1.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec1).Return(nil, nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec2, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec2.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec2).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
 			},
 		},
+		{
+			name:          "skip instance view of succeeded extension",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec1.Name).Return(succeededStatus, true)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "extension instance view reports a failure",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec1.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec1).Return(&failedInstanceView, nil)
+				s.SetExtensionStatus(gomock.Any())
+				s.RecordExtensionFailure(extensionSpec1.Name, "stdout: installing\nstderr: exit status 1")
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "extension that already failed is not recorded again",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.ExtensionStatus(extensionSpec1.Name).Return(converters.SDKToExtensionStatus(extensionSpec1.Name, failedInstanceView), true)
+				v.GetInstanceView(gomockinternal.AContext(), &extensionSpec1).Return(&failedInstanceView, nil)
+				s.SetExtensionStatus(gomock.Any())
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "bootstrap extension instance view reports a failure",
+			expectedError: bootstrapFailedError.Error(),
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, v *mock_vmextensions.MockinstanceViewGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&bootstrapExtensionSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &bootstrapExtensionSpec, serviceName).Return(nil, nil)
+				s.ExtensionStatus(bootstrapExtensionSpec.Name).Return(infrav1.ExtensionStatus{}, false)
+				v.GetInstanceView(gomockinternal.AContext(), &bootstrapExtensionSpec).Return(&failedInstanceView, nil)
+				s.SetExtensionStatus(gomock.Any())
+				s.RecordExtensionFailure(bootstrapExtensionSpec.Name, "stdout: installing\nstderr: exit status 1")
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(bootstrapFailedError.Error()))
+			},
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -126,12 +230,14 @@ func TestReconcileVMExtension(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_vmextensions.NewMockVMExtensionScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_vmextensions.NewMockinstanceViewGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
 
 			s := &Service{
-				Scope:      scopeMock,
-				Reconciler: asyncMock,
+				Scope:              scopeMock,
+				Reconciler:         asyncMock,
+				instanceViewGetter: clientMock,
 			}
 
 			err := s.Reconcile(context.TODO())
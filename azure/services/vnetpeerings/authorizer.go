@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnetpeerings
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+)
+
+const (
+	// authorizationHeader is the header that carries the token of the primary tenant of a request.
+	authorizationHeader = "Authorization"
+	// auxiliaryAuthorizationHeader is the header that carries the tokens of the auxiliary tenants of a request.
+	auxiliaryAuthorizationHeader = "x-ms-authorization-auxiliary"
+)
+
+// auxiliaryTenantAuthorizer is an autorest.Authorizer that authorizes requests in a primary tenant and adds tokens from
+// auxiliary tenants, which Azure requires to link resources of different tenants, e.g. to peer their virtual networks.
+type auxiliaryTenantAuthorizer struct {
+	primary   autorest.Authorizer
+	auxiliary []autorest.Authorizer
+}
+
+// newAuxiliaryTenantAuthorizer returns an authorizer that adds tokens from the auxiliary authorizers to the requests
+// authorized by the primary authorizer.
+func newAuxiliaryTenantAuthorizer(primary autorest.Authorizer, auxiliary ...autorest.Authorizer) autorest.Authorizer {
+	return &auxiliaryTenantAuthorizer{
+		primary:   primary,
+		auxiliary: auxiliary,
+	}
+}
+
+// WithAuthorization implements the autorest.Authorizer interface.
+func (a *auxiliaryTenantAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := a.primary.WithAuthorization()(p).Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			tokens := make([]string, 0, len(a.auxiliary))
+			for _, auxiliary := range a.auxiliary {
+				// Authorize a copy of the request to get the auxiliary tenant's token without altering the request.
+				req, err := auxiliary.WithAuthorization()(autorest.CreatePreparer()).Prepare(r.Clone(r.Context()))
+				if err != nil {
+					return r, errors.Wrap(err, "failed to get auxiliary tenant token")
+				}
+				tokens = append(tokens, req.Header.Get(authorizationHeader))
+			}
+			r.Header.Set(auxiliaryAuthorizationHeader, strings.Join(tokens, ", "))
+			return r, nil
+		})
+	}
+}
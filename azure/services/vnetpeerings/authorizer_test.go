@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnetpeerings
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+)
+
+// fakeAuthorizer is an autorest.Authorizer that authorizes requests with a fixed token.
+type fakeAuthorizer string
+
+func (a fakeAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return autorest.WithHeader(authorizationHeader, "Bearer "+string(a))
+}
+
+func TestAuxiliaryTenantAuthorizer(t *testing.T) {
+	g := NewWithT(t)
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, "https://management.azure.com/subscriptions/sub1", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	authorizer := newAuxiliaryTenantAuthorizer(fakeAuthorizer("primary"), fakeAuthorizer("remote"), fakeAuthorizer("other"))
+	req, err = autorest.Prepare(req, authorizer.WithAuthorization())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(req.Header.Get(authorizationHeader)).To(Equal("Bearer primary"))
+	g.Expect(req.Header.Get(auxiliaryAuthorizationHeader)).To(Equal("Bearer remote, Bearer other"))
+}
@@ -21,10 +21,13 @@ limitations under the License.
 package mock_vnetpeerings
 
 import (
+	context "context"
 	reflect "reflect"
 
+	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -163,6 +166,22 @@ func (mr *MockVnetPeeringScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockVnetPeeringScope)(nil).HashKey))
 }
 
+// RemoteVnetAuthorizer mocks base method.
+func (m *MockVnetPeeringScope) RemoteVnetAuthorizer(ctx context.Context, identityRef *v1.ObjectReference) (autorest.Authorizer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteVnetAuthorizer", ctx, identityRef)
+	ret0, _ := ret[0].(autorest.Authorizer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemoteVnetAuthorizer indicates an expected call of RemoteVnetAuthorizer.
+func (mr *MockVnetPeeringScopeMockRecorder) RemoteVnetAuthorizer(ctx, identityRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteVnetAuthorizer", reflect.TypeOf((*MockVnetPeeringScope)(nil).RemoteVnetAuthorizer), ctx, identityRef)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockVnetPeeringScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -175,6 +194,18 @@ func (mr *MockVnetPeeringScopeMockRecorder) SetLongRunningOperationState(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockVnetPeeringScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetVnetPeeringStatus mocks base method.
+func (m *MockVnetPeeringScope) SetVnetPeeringStatus(status v1beta1.VnetPeeringStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVnetPeeringStatus", status)
+}
+
+// SetVnetPeeringStatus indicates an expected call of SetVnetPeeringStatus.
+func (mr *MockVnetPeeringScopeMockRecorder) SetVnetPeeringStatus(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVnetPeeringStatus", reflect.TypeOf((*MockVnetPeeringScope)(nil).SetVnetPeeringStatus), status)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockVnetPeeringScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -203,6 +234,20 @@ func (mr *MockVnetPeeringScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockVnetPeeringScope)(nil).TenantID))
 }
 
+// Token mocks base method.
+func (m *MockVnetPeeringScope) Token() azcore.TokenCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(azcore.TokenCredential)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockVnetPeeringScopeMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockVnetPeeringScope)(nil).Token))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockVnetPeeringScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
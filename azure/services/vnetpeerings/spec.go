@@ -21,6 +21,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
@@ -33,6 +34,9 @@ type VnetPeeringSpec struct {
 	RemoteVnetName            string
 	PeeringName               string
 	SubscriptionID            string
+	SourceSubscriptionID      string
+	RemoteIdentityRef         *corev1.ObjectReference
+	Reverse                   bool
 	AllowForwardedTraffic     *bool
 	AllowGatewayTransit       *bool
 	AllowVirtualNetworkAccess *bool
@@ -57,10 +61,18 @@ func (s *VnetPeeringSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the virtual network peering.
 func (s *VnetPeeringSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.VirtualNetworkPeering); !ok {
+		existingPeering, ok := existing.(network.VirtualNetworkPeering)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.VnetPeering", existing)
 		}
 		// virtual network peering already exists
+		if existingPeering.VirtualNetworkPeeringPropertiesFormat != nil {
+			switch existingPeering.PeeringSyncLevel {
+			case network.VirtualNetworkPeeringLevelLocalNotInSync, network.VirtualNetworkPeeringLevelLocalAndRemoteNotInSync:
+				// Updating the peering syncs it with the current address space of the remote virtual network.
+				return existingPeering, nil
+			}
+		}
 		return nil, nil
 	}
 	vnetID := azure.VNetID(s.SubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
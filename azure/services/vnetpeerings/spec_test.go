@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnetpeerings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *VnetPeeringSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "new peering of a virtual network in another subscription",
+			spec: &VnetPeeringSpec{
+				PeeringName:          "vnet1-to-hub",
+				SourceVnetName:       "vnet1",
+				SourceResourceGroup:  "group1",
+				RemoteVnetName:       "hub",
+				RemoteResourceGroup:  "hub-group",
+				SubscriptionID:       "hub-sub",
+				SourceSubscriptionID: "sub1",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetworkPeering{}))
+				peering := result.(network.VirtualNetworkPeering)
+				g.Expect(peering.Name).To(Equal(pointer.String("vnet1-to-hub")))
+				g.Expect(peering.RemoteVirtualNetwork.ID).To(Equal(pointer.String("/subscriptions/hub-sub/resourceGroups/hub-group/providers/Microsoft.Network/virtualNetworks/hub")))
+			},
+		},
+		{
+			name: "existing peering in sync",
+			spec: &fakePeering1To2,
+			existing: network.VirtualNetworkPeering{
+				Name: pointer.String("vnet1-to-vnet2"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					PeeringState:     network.VirtualNetworkPeeringStateConnected,
+					PeeringSyncLevel: network.VirtualNetworkPeeringLevelFullyInSync,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing peering out of sync with the remote address space",
+			spec: &fakePeering1To2,
+			existing: network.VirtualNetworkPeering{
+				Name: pointer.String("vnet1-to-vnet2"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					PeeringState:     network.VirtualNetworkPeeringStateConnected,
+					PeeringSyncLevel: network.VirtualNetworkPeeringLevelLocalNotInSync,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetworkPeering{}))
+				g.Expect(result.(network.VirtualNetworkPeering).Name).To(Equal(pointer.String("vnet1-to-vnet2")))
+			},
+		},
+		{
+			name:          "existing is not a peering",
+			spec:          &fakePeering1To2,
+			existing:      "wrong type",
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "string is not a network.VnetPeering",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -18,7 +18,13 @@ package vnetpeerings
 
 import (
 	"context"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
@@ -34,21 +40,27 @@ type VnetPeeringScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	VnetPeeringSpecs() []azure.ResourceSpecGetter
+	RemoteVnetAuthorizer(ctx context.Context, identityRef *corev1.ObjectReference) (autorest.Authorizer, string, error)
+	SetVnetPeeringStatus(status infrav1.VnetPeeringStatus)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VnetPeeringScope
 	async.Reconciler
+	// newRemoteReconciler creates the reconciler of a peering that isn't managed in the cluster's subscription with the cluster's identity.
+	newRemoteReconciler func(ctx context.Context, spec *VnetPeeringSpec) (async.Reconciler, error)
 }
 
 // New creates a new service.
 func New(scope VnetPeeringScope) *Service {
 	Client := NewClient(scope)
-	return &Service{
+	s := &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, Client, Client),
 	}
+	s.newRemoteReconciler = s.remoteReconciler
+	return s
 }
 
 // Name returns the service name.
@@ -74,7 +86,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
 	for _, peeringSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, peeringSpec, ServiceName); err != nil {
+		reconciler, err := s.reconciler(ctx, peeringSpec)
+		if err == nil {
+			var peering interface{}
+			peering, err = reconciler.CreateOrUpdateResource(ctx, peeringSpec, ServiceName)
+			if existing, ok := peering.(network.VirtualNetworkPeering); ok && err == nil {
+				s.Scope.SetVnetPeeringStatus(peeringStatus(existing))
+			}
+		}
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -103,7 +123,11 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	var result error
 	for _, peeringSpec := range specs {
-		if err := s.DeleteResource(ctx, peeringSpec, ServiceName); err != nil {
+		reconciler, err := s.reconciler(ctx, peeringSpec)
+		if err == nil {
+			err = reconciler.DeleteResource(ctx, peeringSpec, ServiceName)
+		}
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -113,6 +137,50 @@ func (s *Service) Delete(ctx context.Context) error {
 	return result
 }
 
+// reconciler returns the reconciler of a peering. Peerings managed in another subscription or with the identity of
+// a remote virtual network get their own reconciler, so that their long-running operations are tracked with the same client.
+func (s *Service) reconciler(ctx context.Context, spec azure.ResourceSpecGetter) (async.Reconciler, error) {
+	peeringSpec, ok := spec.(*VnetPeeringSpec)
+	if !ok || peeringSpec.RemoteIdentityRef == nil && (peeringSpec.SourceSubscriptionID == "" || peeringSpec.SourceSubscriptionID == s.Scope.SubscriptionID()) {
+		return s.Reconciler, nil
+	}
+	return s.newRemoteReconciler(ctx, peeringSpec)
+}
+
+// remoteReconciler creates a reconciler whose client manages the peering in its source subscription. When the remote
+// virtual network is managed with an identity of another tenant, the requests carry a token from both tenants.
+func (s *Service) remoteReconciler(ctx context.Context, spec *VnetPeeringSpec) (async.Reconciler, error) {
+	authorizer := s.Scope.Authorizer()
+	if spec.RemoteIdentityRef != nil {
+		remoteAuthorizer, remoteTenantID, err := s.Scope.RemoteVnetAuthorizer(ctx, spec.RemoteIdentityRef)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get authorizer for the remote virtual network of peering %s", spec.PeeringName)
+		}
+		primary, auxiliary := authorizer, remoteAuthorizer
+		if spec.Reverse {
+			primary, auxiliary = remoteAuthorizer, authorizer
+		}
+		authorizer = primary
+		if !strings.EqualFold(remoteTenantID, s.Scope.TenantID()) {
+			authorizer = newAuxiliaryTenantAuthorizer(primary, auxiliary)
+		}
+	}
+	client := &AzureClient{newPeeringsClient(spec.SourceSubscriptionID, s.Scope.BaseURI(), authorizer)}
+	return async.New(s.Scope, client, client), nil
+}
+
+// peeringStatus returns the observed state of a virtual network peering.
+func peeringStatus(peering network.VirtualNetworkPeering) infrav1.VnetPeeringStatus {
+	status := infrav1.VnetPeeringStatus{
+		Name: pointer.StringDeref(peering.Name, ""),
+	}
+	if peering.VirtualNetworkPeeringPropertiesFormat != nil {
+		status.PeeringState = string(peering.PeeringState)
+		status.PeeringSyncLevel = string(peering.PeeringSyncLevel)
+	}
+	return status
+}
+
 // IsManaged returns always returns true as CAPZ does not support BYO VNet peering.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
@@ -22,12 +22,14 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings/mock_vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -248,6 +250,61 @@ func TestReconcileVnetPeerings(t *testing.T) {
 	}
 }
 
+func TestReconcileRemoteVnetPeerings(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+	asyncMock := mock_async.NewMockReconciler(mockCtrl)
+	remoteAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+	forwardPeering := VnetPeeringSpec{
+		PeeringName:          "vnet1-to-hub",
+		SourceVnetName:       "vnet1",
+		SourceResourceGroup:  "group1",
+		RemoteVnetName:       "hub",
+		RemoteResourceGroup:  "hub-group",
+		SubscriptionID:       "hub-sub",
+		SourceSubscriptionID: "sub1",
+	}
+	reversePeering := VnetPeeringSpec{
+		PeeringName:          "hub-to-vnet1",
+		SourceVnetName:       "hub",
+		SourceResourceGroup:  "hub-group",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SubscriptionID:       "sub1",
+		SourceSubscriptionID: "hub-sub",
+		Reverse:              true,
+	}
+	reversePeeringResult := network.VirtualNetworkPeering{
+		Name: pointer.String("hub-to-vnet1"),
+		VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+			PeeringState:     network.VirtualNetworkPeeringStateConnected,
+			PeeringSyncLevel: network.VirtualNetworkPeeringLevelFullyInSync,
+		},
+	}
+
+	scopeMock.EXPECT().VnetPeeringSpecs().Return([]azure.ResourceSpecGetter{&forwardPeering, &reversePeering})
+	scopeMock.EXPECT().SubscriptionID().Return("sub1").AnyTimes()
+	asyncMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), &forwardPeering, ServiceName).Return(nil, nil)
+	remoteAsyncMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), &reversePeering, ServiceName).Return(reversePeeringResult, nil)
+	scopeMock.EXPECT().SetVnetPeeringStatus(infrav1.VnetPeeringStatus{Name: "hub-to-vnet1", PeeringState: "Connected", PeeringSyncLevel: "FullyInSync"})
+	scopeMock.EXPECT().UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
+
+	s := &Service{
+		Scope:      scopeMock,
+		Reconciler: asyncMock,
+		newRemoteReconciler: func(ctx context.Context, spec *VnetPeeringSpec) (async.Reconciler, error) {
+			g.Expect(spec).To(Equal(&reversePeering))
+			return remoteAsyncMock, nil
+		},
+	}
+
+	g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+}
+
 func TestDeleteVnetPeerings(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -19,6 +19,7 @@ package azure
 import (
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -57,6 +58,7 @@ type ScaleSetSpec struct {
 	VNetResourceGroup            string
 	PublicLBName                 string
 	PublicLBAddressPoolName      string
+	PublicLBIPv6AddressPoolName  string
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
 	Identity                     infrav1.VMIdentity
@@ -68,8 +70,9 @@ type ScaleSetSpec struct {
 	FailureDomains               []string
 	VMExtensions                 []infrav1.VMExtension
 	NetworkInterfaces            []infrav1.NetworkInterface
-	IPv6Enabled                  bool
+	IPv6SubnetNames              []string
 	OrchestrationMode            infrav1.OrchestrationModeType
+	ApplicationSecurityGroups    []string
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -92,6 +95,28 @@ type ExtensionSpec struct {
 	ProtectedSettings map[string]string
 }
 
+// BootstrapDataBlobSpec defines the specification for a blob used to deliver bootstrap data.
+type BootstrapDataBlobSpec struct {
+	StorageAccountName string
+	ContainerName      string
+	BlobName           string
+	AccessMethod       infrav1.BootstrapDataAccessMethod
+	SASExpiry          time.Duration
+	// Format is the format of the bootstrap data, as set in the bootstrap data secret by the bootstrap provider.
+	Format string
+	// Data is the raw bootstrap data.
+	Data []byte
+}
+
+// BootstrapWatchdogSpec defines the specification for detecting bootstrap failures of a virtual machine.
+type BootstrapWatchdogSpec struct {
+	ResourceGroup string
+	VMName        string
+	Timeout       time.Duration
+	// RunningSince is the time the VM started running. It is the zero time if the VM is not running yet.
+	RunningSince time.Time
+}
+
 type (
 	// VMSSVM defines a VM in a virtual machine scale set.
 	VMSSVM struct {
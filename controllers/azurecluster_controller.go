@@ -28,10 +28,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	capifeature "sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -102,12 +106,36 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
 
+	// Add watches on the cluster's node groups to size the node outbound SNAT ports before nodes are added.
+	nodeGroupPredicates := []predicate.Predicate{
+		predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		predicates.ResourceNotPausedAndHasFilterLabel(log, acr.WatchFilterValue),
+	}
+	if err = c.Watch(
+		&source.Kind{Type: &clusterv1.MachineDeployment{}},
+		handler.EnqueueRequestsFromMapFunc(ClusterNodeGroupToAzureClusterMapFunc(ctx, mgr.GetClient(), log)),
+		nodeGroupPredicates...,
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for MachineDeployments")
+	}
+
+	if feature.Gates.Enabled(capifeature.MachinePool) {
+		if err = c.Watch(
+			&source.Kind{Type: &expv1.MachinePool{}},
+			handler.EnqueueRequestsFromMapFunc(ClusterNodeGroupToAzureClusterMapFunc(ctx, mgr.GetClient(), log)),
+			nodeGroupPredicates...,
+		); err != nil {
+			return errors.Wrap(err, "failed adding a watch for MachinePools")
+		}
+	}
+
 	return nil
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates;azuremachinetemplates/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;
@@ -168,6 +196,14 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "AzureClusterIdentity", deprecatedManagerCredsWarning)
 	}
 
+	for _, peering := range azureCluster.Spec.NetworkSpec.Vnet.Peerings {
+		if peering.IdentityRef != nil {
+			if err := EnsureClusterIdentity(ctx, acr.Client, azureCluster, peering.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
 		Client:       acr.Client,
@@ -301,5 +337,13 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		}
 	}
 
+	for _, peering := range azureCluster.Spec.NetworkSpec.Vnet.Peerings {
+		if peering.IdentityRef != nil {
+			if err := RemoveClusterIdentityFinalizer(ctx, acr.Client, azureCluster, peering.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	return reconcile.Result{}, nil
 }
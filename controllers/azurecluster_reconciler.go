@@ -22,12 +22,16 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicdns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
@@ -60,15 +64,21 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualnetworks.New(scope),
+			applicationsecuritygroups.New(scope),
 			securitygroups.New(scope),
+			securitygroups.NewFlowLogsService(scope),
 			routetables.New(scope),
+			publicipprefixes.New(scope),
 			publicips.New(scope),
 			natgateways.New(scope),
 			subnets.New(scope),
 			vnetpeerings.New(scope),
 			loadbalancers.New(scope),
+			privatelinkservices.New(scope),
 			privatedns.New(scope),
+			publicdns.New(scope),
 			bastionhosts.New(scope),
+			privatedns.NewEndpointZoneService(scope),
 			privateendpoints.New(scope),
 			tags.New(scope),
 		},
@@ -88,6 +98,9 @@ func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	s.scope.AzureCluster.SetBackendPoolNameDefault()
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
+	if err := s.scope.SetNodeOutboundSNATPorts(ctx); err != nil {
+		return errors.Wrap(err, "failed to size node outbound SNAT ports")
+	}
 
 	for _, service := range s.services {
 		if err := service.Reconcile(ctx); err != nil {
@@ -126,6 +139,34 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 		if err := vnetPeeringsSvc.Delete(ctx); err != nil {
 			return errors.Wrap(err, "failed to delete peerings")
 		}
+		// The public DNS zone of the API server records, existing private DNS zones, the Network Watcher of the security group flow logs
+		// and a shared vnet are not part of the resource group either.
+		// The cluster's subnets are removed from a shared vnet, which is only deleted along with the last cluster sharing it.
+		var outsideResourceGroup []string
+		if s.scope.AzureCluster.Spec.NetworkSpec.APIServerDNS != nil {
+			outsideResourceGroup = append(outsideResourceGroup, publicdns.ServiceName)
+		}
+		if s.scope.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneID != "" {
+			outsideResourceGroup = append(outsideResourceGroup, privatedns.ServiceName)
+		}
+		if s.scope.HasExistingPrivateEndpointDNSZones() {
+			outsideResourceGroup = append(outsideResourceGroup, privatedns.EndpointZoneServiceName)
+		}
+		if s.scope.AzureCluster.Spec.NetworkSpec.FlowLogs != nil {
+			outsideResourceGroup = append(outsideResourceGroup, securitygroups.FlowLogsServiceName)
+		}
+		if s.scope.Vnet().Shared {
+			outsideResourceGroup = append(outsideResourceGroup, subnets.ServiceName, virtualnetworks.ServiceName)
+		}
+		for _, name := range outsideResourceGroup {
+			svc, err := s.getService(name)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get %s service", name)
+			}
+			if err := svc.Delete(ctx); err != nil {
+				return errors.Wrapf(err, "failed to delete AzureCluster service %s", name)
+			}
+		}
 		// Delete the entire resource group directly.
 		if err := groupSvc.Delete(ctx); err != nil {
 			return errors.Wrap(err, "failed to delete resource group")
@@ -29,7 +29,12 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicdns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -101,6 +106,11 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 func TestAzureClusterServiceDelete(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
+		sharedVnet    bool
+		apiServerDNS  bool
+		existingZone  bool
+		privateZoneID string
+		flowLogs      bool
 		expect        func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
 	}{
 		"Resource Group is deleted successfully": {
@@ -115,6 +125,102 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 					grp.Delete(gomockinternal.AContext()).Return(nil))
 			},
 		},
+		"Resource Group is deleted successfully with a shared vnet": {
+			expectedError: "",
+			sharedVnet:    true,
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					one.Name().Return(virtualnetworks.ServiceName),
+					two.Name().Return(subnets.ServiceName),
+					two.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					one.Name().Return(virtualnetworks.ServiceName),
+					one.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"Resource Group is deleted successfully with API server records in a public DNS zone": {
+			expectedError: "",
+			apiServerDNS:  true,
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					one.Name().Return(virtualnetworks.ServiceName),
+					two.Name().Return(subnets.ServiceName),
+					three.Name().Return(publicdns.ServiceName),
+					three.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"Resource Group is deleted successfully with private endpoints in an existing private DNS zone": {
+			expectedError: "",
+			existingZone:  true,
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					one.Name().Return(virtualnetworks.ServiceName),
+					two.Name().Return(subnets.ServiceName),
+					three.Name().Return(privatedns.EndpointZoneServiceName),
+					three.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"Resource Group is deleted successfully with API server records in an existing private DNS zone": {
+			expectedError: "",
+			privateZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/corp.private",
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					one.Name().Return(virtualnetworks.ServiceName),
+					two.Name().Return(subnets.ServiceName),
+					three.Name().Return(privatedns.ServiceName),
+					three.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"Resource Group is deleted successfully with security group flow logs": {
+			expectedError: "",
+			flowLogs:      true,
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					one.Name().Return(securitygroups.FlowLogsServiceName),
+					one.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
 		"Error when checking if resource group is managed": {
 			expectedError: "failed to determine if the AzureCluster resource group is managed: an error happened",
 			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
@@ -177,9 +283,34 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 
 			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
 
+			azureCluster := &infrav1.AzureCluster{}
+			azureCluster.Spec.NetworkSpec.Vnet.Shared = tc.sharedVnet
+			if tc.apiServerDNS {
+				azureCluster.Spec.NetworkSpec.APIServerDNS = &infrav1.APIServerDNS{}
+			}
+			azureCluster.Spec.NetworkSpec.PrivateDNSZoneID = tc.privateZoneID
+			if tc.flowLogs {
+				azureCluster.Spec.NetworkSpec.FlowLogs = &infrav1.NSGFlowLogs{}
+			}
+			if tc.existingZone {
+				azureCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							PrivateEndpoints: infrav1.PrivateEndpoints{
+								{
+									Name: "my-pe",
+									PrivateDNSZone: &infrav1.PrivateEndpointDNSZone{
+										ID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net",
+									},
+								},
+							},
+						},
+					},
+				}
+			}
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
-					AzureCluster: &infrav1.AzureCluster{},
+					AzureCluster: azureCluster,
 				},
 				services: []azure.ServiceReconciler{
 					groupsMock,
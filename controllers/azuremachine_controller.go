@@ -131,6 +131,7 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses;ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile idempotently gets, creates, and updates a machine.
 func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -299,7 +300,11 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create azure machine service")
 	}
 
-	if err := ams.Reconcile(ctx); err != nil {
+	err = ams.Reconcile(ctx)
+	for _, failure := range machineScope.ExtensionFailures() {
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "VMExtensionFailed", "VM extension %s failed:\n%s", failure.Name, failure.Output)
+	}
+	if err != nil {
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
 		if errors.As(err, &azure.VMDeletedError{}) {
@@ -336,9 +341,30 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile AzureMachine")
 	}
 
+	if logTail := machineScope.BootstrapFailureLog(); logTail != "" {
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.BootstrapFailedReason, "boot diagnostics serial log tail:\n%s", logTail)
+	}
+
+	// A VM that stays unavailable because of a platform-initiated event is marked as failed, so that it can be
+	// remediated by a MachineHealthCheck.
+	remediate, resourceHealthRequeueAfter := machineScope.ResourceHealthRemediation()
+	if remediate {
+		cond := conditions.Get(machineScope.AzureMachine, infrav1.AzureResourceAvailableCondition)
+		err := errors.Errorf("VM has been unavailable since %s because of a platform-initiated event: %s", cond.LastTransitionTime.UTC().Format(time.RFC3339), cond.Message)
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "PlatformUnavailable", err.Error())
+		machineScope.SetFailureReason(capierrors.UpdateMachineError)
+		machineScope.SetFailureMessage(err)
+		machineScope.SetNotReady()
+		return reconcile.Result{}, nil
+	}
+
 	machineScope.SetReady()
 
-	return reconcile.Result{}, nil
+	requeueAfter := machineScope.BootstrapWatchdogRequeueAfter()
+	if resourceHealthRequeueAfter > 0 && (requeueAfter == 0 || resourceHealthRequeueAfter < requeueAfter) {
+		requeueAfter = resourceHealthRequeueAfter
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
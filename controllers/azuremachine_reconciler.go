@@ -23,10 +23,15 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapdata"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootstrapwatchdog"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcehealth"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
@@ -57,13 +62,18 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 		services: []azure.ServiceReconciler{
 			publicips.New(machineScope),
 			inboundnatrules.New(machineScope),
+			privateips.New(machineScope),
 			networkinterfaces.New(machineScope, cache),
 			availabilitysets.New(machineScope, cache),
 			disks.New(machineScope),
+			bootstrapdata.New(machineScope),
 			virtualmachines.New(machineScope),
+			privatedns.NewNodeRecordService(machineScope),
 			roleassignments.New(machineScope),
 			vmextensions.New(machineScope),
 			tags.New(machineScope),
+			bootstrapwatchdog.New(machineScope),
+			resourcehealth.NewMachineService(machineScope),
 		},
 		skuCache: cache,
 	}
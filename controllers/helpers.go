@@ -1023,3 +1023,47 @@ func MachinePoolToAzureManagedControlPlaneMapFunc(ctx context.Context, c client.
 		return nil
 	}
 }
+
+// ClusterNodeGroupToAzureClusterMapFunc returns a handler.MapFunc that watches for MachineDeployment and MachinePool
+// events and returns reconciliation requests for the AzureCluster of their cluster when its node outbound SNAT ports
+// are sized automatically.
+func ClusterNodeGroupToAzureClusterMapFunc(ctx context.Context, c client.Client, log logr.Logger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultMappingTimeout)
+		defer cancel()
+
+		clusterName, ok := o.GetLabels()[clusterv1.ClusterNameLabel]
+		if !ok {
+			return nil
+		}
+
+		cluster, err := util.GetClusterByName(ctx, c, o.GetNamespace(), clusterName)
+		if err != nil {
+			log.Error(err, "failed to get the owning cluster")
+			return nil
+		}
+
+		ref := cluster.Spec.InfrastructureRef
+		if ref == nil || ref.Kind != "AzureCluster" {
+			return nil
+		}
+
+		azureCluster := &infrav1.AzureCluster{}
+		key := client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}
+		if err := c.Get(ctx, key, azureCluster); err != nil {
+			log.Error(err, "failed to get AzureCluster")
+			return nil
+		}
+
+		lb := azureCluster.Spec.NetworkSpec.NodeOutboundLB
+		if lb == nil || lb.SNATPortsPerNode == nil {
+			return nil
+		}
+
+		return []reconcile.Request{
+			{
+				NamespacedName: key,
+			},
+		}
+	}
+}
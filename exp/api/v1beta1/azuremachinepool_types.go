@@ -103,6 +103,11 @@ type (
 		// The primary interface will be the first networkInterface specified (index 0) in the list.
 		// +optional
 		NetworkInterfaces []infrav1.NetworkInterface `json:"networkInterfaces,omitempty"`
+
+		// ApplicationSecurityGroups is a list of names of the cluster's application security groups the scale set's
+		// network interfaces are associated with, in addition to the ones matching the node role.
+		// +optional
+		ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
 	}
 
 	// AzureMachinePoolSpec defines the desired state of AzureMachinePool.
@@ -163,6 +168,12 @@ type (
 		// +optional
 		NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
 
+		// ResourceHealth configures how the Azure Resource Health availability status of the scale set VMs, reported in
+		// the AzureResourceAvailable condition of the AzureMachinePoolMachines, is acted upon. Requires the
+		// MachineResourceHealth feature flag.
+		// +optional
+		ResourceHealth *infrav1.MachineResourceHealth `json:"resourceHealth,omitempty"`
+
 		// OrchestrationMode specifies the orchestration mode for the Virtual Machine Scale Set
 		// +kubebuilder:default=Uniform
 		OrchestrationMode infrav1.OrchestrationModeType `json:"orchestrationMode,omitempty"`
@@ -308,6 +319,10 @@ type (
 		// next reconciliation loop.
 		// +optional
 		LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+
+		// Extensions reports the state of the VM extensions of the scale set, summarized across its instances.
+		// +optional
+		Extensions []infrav1.ExtensionStatus `json:"extensions,omitempty"`
 	}
 
 	// AzureMachinePoolInstanceStatus provides status information for each instance in the VMSS.
@@ -109,6 +109,7 @@ func (amp *AzureMachinePool) Validate(old runtime.Object, client client.Client)
 		amp.ValidateSystemAssignedIdentity(old),
 		amp.ValidateSystemAssignedIdentityRole,
 		amp.ValidateNetwork,
+		amp.ValidateResourceHealth,
 	}
 
 	var errs []error
@@ -126,6 +127,19 @@ func (amp *AzureMachinePool) ValidateNetwork() error {
 	if (amp.Spec.Template.NetworkInterfaces != nil) && len(amp.Spec.Template.NetworkInterfaces) > 0 && amp.Spec.Template.SubnetName != "" {
 		return errors.New("cannot set both NetworkInterfaces and machine SubnetName")
 	}
+	for _, nic := range amp.Spec.Template.NetworkInterfaces {
+		if nic.PrivateIPAddress != nil {
+			return errors.New("static private IP addresses are not supported for machine pools")
+		}
+	}
+	return nil
+}
+
+// ValidateResourceHealth validates the Azure Resource Health configuration of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidateResourceHealth() error {
+	if errs := infrav1.ValidateMachineResourceHealth(amp.Spec.ResourceHealth, field.NewPath("spec", "resourceHealth")); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	guuid "github.com/google/uuid"
@@ -202,6 +203,16 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with resource health remediation",
+			amp:     createMachinePoolWithResourceHealth(&infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: 10 * time.Minute}}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with negative resource health remediation delay",
+			amp:     createMachinePoolWithResourceHealth(&infrav1.MachineResourceHealth{RemediateAfter: &metav1.Duration{Duration: -time.Minute}}),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with valid legacy network configuration",
 			amp:     createMachinePoolWithNetworkConfig("testSubnet", []infrav1.NetworkInterface{}),
@@ -217,6 +228,11 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet"}}),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with static private IP address",
+			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet", PrivateIPAddress: &infrav1.PrivateIPAddressSpec{FromSubnetPool: true}}}),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with Flexible orchestration mode",
 			amp:     createMachinePoolWithOrchestrationMode(compute.OrchestrationModeFlexible),
@@ -628,6 +644,14 @@ func createMachinePoolWithStrategy(strategy AzureMachinePoolDeploymentStrategy)
 	}
 }
 
+func createMachinePoolWithResourceHealth(resourceHealth *infrav1.MachineResourceHealth) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			ResourceHealth: resourceHealth,
+		},
+	}
+}
+
 func createMachinePoolWithOrchestrationMode(mode compute.OrchestrationMode) *AzureMachinePool {
 	return &AzureMachinePool{
 		Spec: AzureMachinePoolSpec{
@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// AzureMachineRunCommandFinalizer allows the AzureMachineRunCommand controller to clean up the Azure run commands
+	// created on the targeted machines before removing it from the apiserver.
+	AzureMachineRunCommandFinalizer = "azuremachineruncommand.infrastructure.cluster.x-k8s.io"
+
+	// DefaultRunCommandTimeout is the default amount of time a script is allowed to run on each machine.
+	DefaultRunCommandTimeout = 10 * time.Minute
+
+	// MaxRunCommandTimeout is the maximum amount of time a script is allowed to run on each machine.
+	MaxRunCommandTimeout = 90 * time.Minute
+)
+
+// RunCommandTargetKind is the kind of the machines targeted by an AzureMachineRunCommand.
+type RunCommandTargetKind string
+
+const (
+	// RunCommandTargetAzureMachine targets AzureMachines.
+	RunCommandTargetAzureMachine RunCommandTargetKind = "AzureMachine"
+	// RunCommandTargetAzureMachinePoolMachine targets AzureMachinePoolMachines.
+	RunCommandTargetAzureMachinePoolMachine RunCommandTargetKind = "AzureMachinePoolMachine"
+)
+
+// RunCommandState is the state of an AzureMachineRunCommand on a single machine.
+type RunCommandState string
+
+const (
+	// RunCommandStatePending means the script has not been started on the machine yet.
+	RunCommandStatePending RunCommandState = "Pending"
+	// RunCommandStateRunning means the script is running on the machine.
+	RunCommandStateRunning RunCommandState = "Running"
+	// RunCommandStateSucceeded means the script exited with a zero exit code.
+	RunCommandStateSucceeded RunCommandState = "Succeeded"
+	// RunCommandStateFailed means the script exited with a non-zero exit code, or could not be run.
+	RunCommandStateFailed RunCommandState = "Failed"
+	// RunCommandStateTimedOut means the script did not finish within the timeout.
+	RunCommandStateTimedOut RunCommandState = "TimedOut"
+)
+
+type (
+	// AzureMachineRunCommandSpec defines the desired state of AzureMachineRunCommand.
+	AzureMachineRunCommandSpec struct {
+		// ClusterName is the name of the Cluster the targeted machines belong to.
+		// +kubebuilder:validation:MinLength=1
+		ClusterName string `json:"clusterName"`
+
+		// TargetKind is the kind of the machines the script is run on. Defaults to AzureMachine.
+		// +kubebuilder:validation:Enum=AzureMachine;AzureMachinePoolMachine
+		// +kubebuilder:default=AzureMachine
+		// +optional
+		TargetKind RunCommandTargetKind `json:"targetKind,omitempty"`
+
+		// MachineNames is the list of names of the machines the script is run on.
+		// Exactly one of MachineNames and Selector must be set.
+		// +optional
+		MachineNames []string `json:"machineNames,omitempty"`
+
+		// Selector selects the machines of the cluster the script is run on by label.
+		// Exactly one of MachineNames and Selector must be set.
+		// +optional
+		Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+		// Script is the content of the script to run on each machine, using the Azure managed Run Command.
+		// Shell scripts are run on Linux machines and PowerShell scripts on Windows machines.
+		// +kubebuilder:validation:MinLength=1
+		Script string `json:"script"`
+
+		// Timeout is the maximum amount of time the script is allowed to run on each machine before it is reported as
+		// timed out. Defaults to 10m, and cannot exceed 90m.
+		// +optional
+		Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+		// MaxConcurrency is the maximum number of machines the script runs on at the same time. Defaults to 1.
+		// +kubebuilder:validation:Minimum=1
+		// +kubebuilder:default=1
+		// +optional
+		MaxConcurrency int32 `json:"maxConcurrency,omitempty"`
+	}
+
+	// MachineRunCommandStatus reports the result of an AzureMachineRunCommand on a single machine.
+	MachineRunCommandStatus struct {
+		// Name is the name of the targeted AzureMachine or AzureMachinePoolMachine.
+		Name string `json:"name"`
+
+		// ProviderID is the provider ID of the targeted machine.
+		ProviderID string `json:"providerID"`
+
+		// State is the state of the script on the machine.
+		State RunCommandState `json:"state"`
+
+		// ExitCode is the exit code of the script.
+		// +optional
+		ExitCode *int32 `json:"exitCode,omitempty"`
+
+		// Output is the tail of the standard output of the script.
+		// +optional
+		Output string `json:"output,omitempty"`
+
+		// Error is the tail of the standard error of the script, or the reason the script could not be run.
+		// +optional
+		Error string `json:"error,omitempty"`
+
+		// StartTime is the time the script started running on the machine.
+		// +optional
+		StartTime *metav1.Time `json:"startTime,omitempty"`
+
+		// EndTime is the time the script finished running on the machine.
+		// +optional
+		EndTime *metav1.Time `json:"endTime,omitempty"`
+	}
+
+	// AzureMachineRunCommandStatus defines the observed state of AzureMachineRunCommand.
+	AzureMachineRunCommandStatus struct {
+		// Machines reports the result of the script on each targeted machine. The targeted machines are resolved
+		// once, on the first reconciliation.
+		// +optional
+		Machines []MachineRunCommandStatus `json:"machines,omitempty"`
+
+		// TargetsResolved is true once the targeted machines have been resolved, even if no machine matched.
+		// +optional
+		TargetsResolved bool `json:"targetsResolved,omitempty"`
+
+		// Conditions defines current service state of the AzureMachineRunCommand.
+		// +optional
+		Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+		// LongRunningOperationStates saves the state for Azure long running operations so they can be continued on the
+		// next reconciliation loop.
+		// +optional
+		LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+	}
+
+	// +kubebuilder:object:root=true
+	// +kubebuilder:subresource:status
+	// +kubebuilder:resource:path=azuremachineruncommands,scope=Namespaced,categories=cluster-api,shortName=amrc
+	// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster to which the targeted machines belong"
+	// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='RunCommandCompleted')].status",description="Flag indicating the script finished running on all targeted machines"
+	// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='RunCommandCompleted')].reason"
+	// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of this AzureMachineRunCommand"
+	// +kubebuilder:storageversion
+
+	// AzureMachineRunCommand is the Schema for the azuremachineruncommands API.
+	AzureMachineRunCommand struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata,omitempty"`
+
+		Spec   AzureMachineRunCommandSpec   `json:"spec,omitempty"`
+		Status AzureMachineRunCommandStatus `json:"status,omitempty"`
+	}
+
+	// +kubebuilder:object:root=true
+
+	// AzureMachineRunCommandList contains a list of AzureMachineRunCommands.
+	AzureMachineRunCommandList struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata,omitempty"`
+		Items           []AzureMachineRunCommand `json:"items"`
+	}
+)
+
+// GetConditions returns the list of conditions for an AzureMachineRunCommand API object.
+func (amrc *AzureMachineRunCommand) GetConditions() clusterv1.Conditions {
+	return amrc.Status.Conditions
+}
+
+// SetConditions will set the given conditions on an AzureMachineRunCommand object.
+func (amrc *AzureMachineRunCommand) SetConditions(conditions clusterv1.Conditions) {
+	amrc.Status.Conditions = conditions
+}
+
+// GetFutures returns the list of long running operation states for an AzureMachineRunCommand API object.
+func (amrc *AzureMachineRunCommand) GetFutures() infrav1.Futures {
+	return amrc.Status.LongRunningOperationStates
+}
+
+// SetFutures will set the given long running operation states on an AzureMachineRunCommand object.
+func (amrc *AzureMachineRunCommand) SetFutures(futures infrav1.Futures) {
+	amrc.Status.LongRunningOperationStates = futures
+}
+
+// IsFinished returns true if the state is terminal.
+func (s RunCommandState) IsFinished() bool {
+	switch s {
+	case RunCommandStateSucceeded, RunCommandStateFailed, RunCommandStateTimedOut:
+		return true
+	}
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureMachineRunCommand{}, &AzureMachineRunCommandList{})
+}
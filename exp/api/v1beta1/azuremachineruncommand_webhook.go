@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager sets up and registers the webhook with the manager.
+func (amrc *AzureMachineRunCommand) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(amrc).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachineruncommand,mutating=false,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=azuremachineruncommands,versions=v1beta1,name=validation.azuremachineruncommand.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.Validator = &AzureMachineRunCommand{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (amrc *AzureMachineRunCommand) ValidateCreate() error {
+	// NOTE: AzureMachineRunCommand is behind the MachineRunCommand feature gate flag; the webhook
+	// must prevent creating new objects in case the feature flag is disabled.
+	if !feature.Gates.Enabled(feature.MachineRunCommand) {
+		return field.Forbidden(
+			field.NewPath("spec"),
+			"can be set only if the MachineRunCommand feature flag is enabled",
+		)
+	}
+
+	if errs := amrc.validateSpec(); len(errs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("AzureMachineRunCommand").GroupKind(), amrc.Name, errs)
+	}
+
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (amrc *AzureMachineRunCommand) ValidateUpdate(oldRaw runtime.Object) error {
+	old, ok := oldRaw.(*AzureMachineRunCommand)
+	if !ok {
+		return errors.New("expected an AzureMachineRunCommand")
+	}
+
+	// The script has already been started on some of the machines, so the spec cannot be changed.
+	if err := webhookutils.ValidateImmutable(field.NewPath("spec"), old.Spec, amrc.Spec); err != nil {
+		return apierrors.NewInvalid(GroupVersion.WithKind("AzureMachineRunCommand").GroupKind(), amrc.Name, field.ErrorList{err})
+	}
+
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (amrc *AzureMachineRunCommand) ValidateDelete() error {
+	return nil
+}
+
+func (amrc *AzureMachineRunCommand) validateSpec() field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if amrc.Spec.ClusterName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("clusterName"), "clusterName is required"))
+	}
+
+	if amrc.Spec.Script == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("script"), "script is required"))
+	}
+
+	switch {
+	case len(amrc.Spec.MachineNames) == 0 && amrc.Spec.Selector == nil:
+		allErrs = append(allErrs, field.Required(specPath, "one of machineNames or selector must be set"))
+	case len(amrc.Spec.MachineNames) > 0 && amrc.Spec.Selector != nil:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("selector"), "selector cannot be set together with machineNames"))
+	case amrc.Spec.Selector != nil:
+		if _, err := metav1.LabelSelectorAsSelector(amrc.Spec.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("selector"), amrc.Spec.Selector, err.Error()))
+		}
+	}
+
+	if amrc.Spec.Timeout != nil && (amrc.Spec.Timeout.Duration <= 0 || amrc.Spec.Timeout.Duration > MaxRunCommandTimeout) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("timeout"), amrc.Spec.Timeout.Duration.String(),
+			"timeout must be greater than 0 and at most "+MaxRunCommandTimeout.String()))
+	}
+
+	if amrc.Spec.MaxConcurrency < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxConcurrency"), amrc.Spec.MaxConcurrency, "maxConcurrency must be at least 1"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+)
+
+func TestAzureMachineRunCommand_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		featureEnabled bool
+		runCommand     *AzureMachineRunCommand
+		wantErr        bool
+	}{
+		{
+			name:           "valid run command targeting machines by name",
+			featureEnabled: true,
+			runCommand:     createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {}),
+			wantErr:        false,
+		},
+		{
+			name:           "valid run command targeting machines by selector",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.MachineNames = nil
+				rc.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}}
+			}),
+			wantErr: false,
+		},
+		{
+			name:           "feature gate disabled",
+			featureEnabled: false,
+			runCommand:     createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {}),
+			wantErr:        true,
+		},
+		{
+			name:           "missing script",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.Script = ""
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "no targeted machines",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.MachineNames = nil
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "both machine names and selector",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}}
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "invalid selector",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.MachineNames = nil
+				rc.Spec.Selector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "role", Operator: "Foo"}}}
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "timeout too long",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.Timeout = &metav1.Duration{Duration: 2 * time.Hour}
+			}),
+			wantErr: true,
+		},
+		{
+			name:           "negative timeout",
+			featureEnabled: true,
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.Timeout = &metav1.Duration{Duration: -time.Minute}
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachineRunCommand, tc.featureEnabled)()
+			err := tc.runCommand.ValidateCreate()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachineRunCommand_ValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldRunCommand *AzureMachineRunCommand
+		runCommand    *AzureMachineRunCommand
+		wantErr       bool
+	}{
+		{
+			name:          "unchanged spec",
+			oldRunCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {}),
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Status.Machines = []MachineRunCommandStatus{{Name: "machine-1", State: RunCommandStateRunning}}
+			}),
+			wantErr: false,
+		},
+		{
+			name:          "changed script",
+			oldRunCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {}),
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.Script = "rm -rf /"
+			}),
+			wantErr: true,
+		},
+		{
+			name:          "changed machine names",
+			oldRunCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {}),
+			runCommand: createAzureMachineRunCommand(func(rc *AzureMachineRunCommand) {
+				rc.Spec.MachineNames = append(rc.Spec.MachineNames, "machine-3")
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.runCommand.ValidateUpdate(tc.oldRunCommand)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func createAzureMachineRunCommand(mutate func(*AzureMachineRunCommand)) *AzureMachineRunCommand {
+	rc := &AzureMachineRunCommand{
+		ObjectMeta: metav1.ObjectMeta{Name: "collect-logs", Namespace: "default"},
+		Spec: AzureMachineRunCommandSpec{
+			ClusterName:    "my-cluster",
+			TargetKind:     RunCommandTargetAzureMachine,
+			MachineNames:   []string{"machine-1", "machine-2"},
+			Script:         "journalctl -u kubelet --no-pager | tail -n 50",
+			Timeout:        &metav1.Duration{Duration: 5 * time.Minute},
+			MaxConcurrency: 1,
+		},
+	}
+	mutate(rc)
+	return rc
+}
@@ -29,6 +29,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcehealth"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesetvms"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
@@ -62,8 +63,9 @@ type (
 	}
 
 	azureMachinePoolMachineReconciler struct {
-		Scope              *scope.MachinePoolMachineScope
-		scalesetVMsService *scalesetvms.Service
+		Scope                 *scope.MachinePoolMachineScope
+		scalesetVMsService    *scalesetvms.Service
+		resourceHealthService *resourcehealth.Service
 	}
 )
 
@@ -288,6 +290,18 @@ func (ampmr *AzureMachinePoolMachineController) reconcileNormal(ctx context.Cont
 
 	log.V(2).Info(fmt.Sprintf("Scale Set VM is %s", state), "id", machineScope.ProviderID())
 
+	// A scale set VM that stays unavailable because of a platform-initiated event is marked as failed, so that it can be
+	// remediated.
+	remediate, resourceHealthRequeueAfter := machineScope.ResourceHealthRemediation()
+	if remediate {
+		cond := conditions.Get(machineScope.AzureMachinePoolMachine, infrav1.AzureResourceAvailableCondition)
+		err := errors.Errorf("scale set VM has been unavailable since %s because of a platform-initiated event: %s", cond.LastTransitionTime.UTC().Format(time.RFC3339), cond.Message)
+		ampmr.Recorder.Eventf(machineScope.AzureMachinePoolMachine, corev1.EventTypeWarning, "PlatformUnavailable", err.Error())
+		machineScope.SetFailureReason(capierrors.UpdateMachineError)
+		machineScope.SetFailureMessage(err)
+		return reconcile.Result{}, nil
+	}
+
 	bootstrappingCondition := conditions.Get(machineScope.AzureMachinePoolMachine, infrav1.BootstrapSucceededCondition)
 	if bootstrappingCondition != nil && bootstrappingCondition.Reason == infrav1.BootstrapFailedReason {
 		return reconcile.Result{}, nil
@@ -301,7 +315,7 @@ func (ampmr *AzureMachinePoolMachineController) reconcileNormal(ctx context.Cont
 		}, nil
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: resourceHealthRequeueAfter}, nil
 }
 
 func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (_ reconcile.Result, reterr error) {
@@ -347,8 +361,9 @@ func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Cont
 
 func newAzureMachinePoolMachineReconciler(scope *scope.MachinePoolMachineScope) azure.Reconciler {
 	return &azureMachinePoolMachineReconciler{
-		Scope:              scope,
-		scalesetVMsService: scalesetvms.NewService(scope),
+		Scope:                 scope,
+		scalesetVMsService:    scalesetvms.NewService(scope),
+		resourceHealthService: resourcehealth.NewMachineService(scope),
 	}
 }
 
@@ -361,6 +376,10 @@ func (r *azureMachinePoolMachineReconciler) Reconcile(ctx context.Context) error
 		return errors.Wrap(err, "failed to reconcile scalesetVMs")
 	}
 
+	if err := r.resourceHealthService.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile VMSS VM resource health")
+	}
+
 	if err := r.Scope.UpdateNodeStatus(ctx); err != nil {
 		return errors.Wrap(err, "failed to update VMSS VM node status")
 	}
@@ -0,0 +1,264 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/runcommands"
+	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// runCommandRequeueAfter is how often an AzureMachineRunCommand is reconciled while the script is running.
+const runCommandRequeueAfter = 30 * time.Second
+
+// AzureMachineRunCommandReconciler reconciles AzureMachineRunCommand objects.
+type AzureMachineRunCommandReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// NewAzureMachineRunCommandReconciler returns a new AzureMachineRunCommandReconciler instance.
+func NewAzureMachineRunCommandReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureMachineRunCommandReconciler {
+	return &AzureMachineRunCommandReconciler{
+		Client:           client,
+		Recorder:         recorder,
+		ReconcileTimeout: reconcileTimeout,
+		WatchFilterValue: watchFilterValue,
+	}
+}
+
+// SetupWithManager initializes this controller with a manager.
+func (r *AzureMachineRunCommandReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options infracontroller.Options) error {
+	_, log, done := tele.StartSpanWithLogger(ctx,
+		"controllers.AzureMachineRunCommandReconciler.SetupWithManager",
+		tele.KVP("controller", "AzureMachineRunCommand"),
+	)
+	defer done()
+
+	var rec reconcile.Reconciler = r
+	if options.Cache != nil {
+		rec = coalescing.NewReconciler(r, options.Cache, log)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options.Options).
+		For(&infrav1exp.AzureMachineRunCommand{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		Complete(rec)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachineruncommands,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachineruncommands/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachines;azuremachinepoolmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+
+// Reconcile idempotently runs the script of an AzureMachineRunCommand on the targeted machines.
+func (r *AzureMachineRunCommandReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	ctx, log, done := tele.StartSpanWithLogger(
+		ctx,
+		"controllers.AzureMachineRunCommandReconciler.Reconcile",
+		tele.KVP("namespace", req.Namespace),
+		tele.KVP("name", req.Name),
+		tele.KVP("kind", "AzureMachineRunCommand"),
+	)
+	defer done()
+
+	runCommand := &infrav1exp.AzureMachineRunCommand{}
+	if err := r.Get(ctx, req.NamespacedName, runCommand); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetClusterByName(ctx, r.Client, runCommand.Namespace, runCommand.Spec.ClusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) && !runCommand.DeletionTimestamp.IsZero() {
+			// The cluster is gone, and so are the run commands of its machines.
+			controllerutil.RemoveFinalizer(runCommand, infrav1exp.AzureMachineRunCommandFinalizer)
+			return reconcile.Result{}, r.Update(ctx, runCommand)
+		}
+		log.Info("Cluster is not available yet")
+		return reconcile.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+
+	// Return early if the object or Cluster is paused.
+	if annotations.IsPaused(cluster, runCommand) {
+		log.Info("AzureMachineRunCommand or linked Cluster is marked as paused. Won't reconcile")
+		return reconcile.Result{}, nil
+	}
+
+	if cluster.Spec.InfrastructureRef == nil {
+		log.Info("Cluster has no infrastructure reference yet")
+		return reconcile.Result{}, nil
+	}
+
+	azureCluster := &infrav1.AzureCluster{}
+	azureClusterName := client.ObjectKey{
+		Namespace: runCommand.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Get(ctx, azureClusterName, azureCluster); err != nil {
+		log.Info("AzureCluster is not available yet")
+		return reconcile.Result{}, nil
+	}
+
+	// Create the cluster scope
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:       r.Client,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Create the run command scope
+	runCommandScope, err := scope.NewRunCommandScope(scope.RunCommandScopeParams{
+		Client:                 r.Client,
+		ClusterScope:           clusterScope,
+		AzureMachineRunCommand: runCommand,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	// Always close the scope when exiting this function so we can persist any AzureMachineRunCommand changes.
+	defer func() {
+		if err := runCommandScope.Close(ctx); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if !runCommand.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, runCommandScope)
+	}
+
+	return r.reconcileNormal(ctx, runCommandScope)
+}
+
+func (r *AzureMachineRunCommandReconciler) reconcileNormal(ctx context.Context, runCommandScope *scope.RunCommandScope) (reconcile.Result, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineRunCommandReconciler.reconcileNormal")
+	defer done()
+
+	log.Info("Reconciling AzureMachineRunCommand")
+
+	// If the AzureMachineRunCommand doesn't have our finalizer, add it.
+	if controllerutil.AddFinalizer(runCommandScope.AzureMachineRunCommand, infrav1exp.AzureMachineRunCommandFinalizer) {
+		// Register the finalizer immediately to avoid orphaning Azure resources on delete
+		if err := runCommandScope.PatchObject(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if runCommandScope.IsCompleted() {
+		return reconcile.Result{}, nil
+	}
+
+	if err := runCommandScope.ResolveTargets(ctx); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to resolve the targeted machines")
+	}
+
+	if len(runCommandScope.AzureMachineRunCommand.Status.Machines) == 0 {
+		// Machines created later are never targeted, so there is nothing left to do.
+		r.Recorder.Eventf(runCommandScope.AzureMachineRunCommand, corev1.EventTypeWarning, infrav1.NoMachinesFoundReason, "no provisioned machine matches the targeted machines")
+		return reconcile.Result{}, nil
+	}
+
+	if err := runcommands.New(runCommandScope).Reconcile(ctx); err != nil {
+		// Handle transient and terminal errors
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) {
+			if reconcileError.IsTerminal() {
+				log.Error(err, "failed to reconcile AzureMachineRunCommand", "name", runCommandScope.Name())
+				return reconcile.Result{}, nil
+			}
+
+			if reconcileError.IsTransient() {
+				log.V(4).Info("requeuing due to transient failure", "name", runCommandScope.Name(), "transient_error", err)
+				return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+			}
+
+			return reconcile.Result{}, errors.Wrap(err, "failed to reconcile AzureMachineRunCommand")
+		}
+
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile AzureMachineRunCommand")
+	}
+
+	if !runCommandScope.IsCompleted() {
+		return reconcile.Result{RequeueAfter: runCommandRequeueAfter}, nil
+	}
+
+	for _, m := range runCommandScope.AzureMachineRunCommand.Status.Machines {
+		if m.State != infrav1exp.RunCommandStateSucceeded {
+			r.Recorder.Eventf(runCommandScope.AzureMachineRunCommand, corev1.EventTypeWarning, infrav1.RunCommandFailedReason, "script %s on machine %s: %s", m.State, m.Name, m.Error)
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *AzureMachineRunCommandReconciler) reconcileDelete(ctx context.Context, runCommandScope *scope.RunCommandScope) (reconcile.Result, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineRunCommandReconciler.reconcileDelete")
+	defer done()
+
+	log.Info("Reconciling AzureMachineRunCommand delete")
+
+	if err := runcommands.New(runCommandScope).Delete(ctx); err != nil {
+		// Handle transient errors
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+			log.V(4).Info("requeuing due to transient failure", "name", runCommandScope.Name(), "transient_error", err)
+			return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+		}
+
+		return reconcile.Result{}, errors.Wrap(err, "error deleting AzureMachineRunCommand")
+	}
+
+	// Run commands are deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(runCommandScope.AzureMachineRunCommand, infrav1exp.AzureMachineRunCommandFinalizer)
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAzureMachineRunCommandReconciler_NoTargetedMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	os.Setenv(auth.ClientID, "fooClient")
+	os.Setenv(auth.ClientSecret, "fooSecret")
+	os.Setenv(auth.TenantID, "fooTenant")
+
+	scheme := runtime.NewScheme()
+	for _, addTo := range []func(s *runtime.Scheme) error{
+		clusterv1.AddToScheme,
+		infrav1.AddToScheme,
+		infrav1exp.AddToScheme,
+	} {
+		g.Expect(addTo(scheme)).To(Succeed())
+	}
+
+	azCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "azCluster1", Namespace: "default"},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "subID",
+			},
+		},
+	}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Name: azCluster.Name},
+		},
+	}
+	machine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine1",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		},
+	}
+	runCommand := &infrav1exp.AzureMachineRunCommand{
+		ObjectMeta: metav1.ObjectMeta{Name: "collect-logs", Namespace: "default"},
+		Spec: infrav1exp.AzureMachineRunCommandSpec{
+			ClusterName:  cluster.Name,
+			TargetKind:   infrav1exp.RunCommandTargetAzureMachine,
+			MachineNames: []string{machine.Name},
+			Script:       "echo hello",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, azCluster, machine, runCommand).Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewAzureMachineRunCommandReconciler(c, recorder, 30*time.Second, "")
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: runCommand.Name, Namespace: runCommand.Namespace}}
+
+	// The only targeted machine is not provisioned yet, so the run command completes without targets.
+	result, err := reconciler.Reconcile(context.TODO(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.NoMachinesFoundReason)))
+
+	g.Expect(c.Get(context.TODO(), req.NamespacedName, runCommand)).To(Succeed())
+	g.Expect(runCommand.Status.TargetsResolved).To(BeTrue())
+	g.Expect(runCommand.Status.Machines).To(BeEmpty())
+	g.Expect(conditions.IsFalse(runCommand, infrav1.RunCommandCompletedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(runCommand, infrav1.RunCommandCompletedCondition)).To(Equal(infrav1.NoMachinesFoundReason))
+
+	// Machines provisioned afterwards are not targeted, and the run command is not requeued.
+	machine.Spec.ProviderID = pointer.String("azure:///subscriptions/subID/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/machine1")
+	g.Expect(c.Update(context.TODO(), machine)).To(Succeed())
+	result, err = reconciler.Reconcile(context.TODO(), req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(c.Get(context.TODO(), req.NamespacedName, runCommand)).To(Succeed())
+	g.Expect(runCommand.Status.Machines).To(BeEmpty())
+	g.Expect(recorder.Events).NotTo(Receive())
+}
@@ -47,6 +47,17 @@ const (
 	// owner: @upxinxin
 	// alpha: v1.8
 	EdgeZone featuregate.Feature = "EdgeZone"
+
+	// MachineResourceHealth is the feature gate for reporting Azure Resource Health
+	// on the VMs of AzureMachines and AzureMachinePoolMachines.
+	// owner: @luthermonson
+	// alpha: v1.10
+	MachineResourceHealth featuregate.Feature = "MachineResourceHealth"
+
+	// MachineRunCommand is the feature gate for running scripts on cluster machines using AzureMachineRunCommands.
+	// owner: @luthermonson
+	// alpha: v1.10
+	MachineRunCommand featuregate.Feature = "MachineRunCommand"
 )
 
 func init() {
@@ -57,7 +68,9 @@ func init() {
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
-	AKS:               {Default: true, PreRelease: featuregate.GA, LockToDefault: true}, // Remove in 1.12
-	AKSResourceHealth: {Default: false, PreRelease: featuregate.Alpha},
-	EdgeZone:          {Default: false, PreRelease: featuregate.Alpha},
+	AKS:                   {Default: true, PreRelease: featuregate.GA, LockToDefault: true}, // Remove in 1.12
+	AKSResourceHealth:     {Default: false, PreRelease: featuregate.Alpha},
+	EdgeZone:              {Default: false, PreRelease: featuregate.Alpha},
+	MachineResourceHealth: {Default: false, PreRelease: featuregate.Alpha},
+	MachineRunCommand:     {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -48,9 +48,11 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -68,6 +70,7 @@ func init() {
 	_ = infrav1exp.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
 	_ = expv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
 	_ = kubeadmv1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 
@@ -282,6 +285,9 @@ func main() {
 		HealthProbeBindAddress:     healthAddr,
 		Port:                       webhookPort,
 		EventBroadcaster:           broadcaster,
+		// The IPAddresses allocated from the static IP pools of subnets are read from the API server, so that an address
+		// allocated by a previous reconcile is never missed and allocated twice.
+		ClientDisableCacheFor: []client.Object{&ipamv1.IPAddress{}},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -464,6 +470,18 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			os.Exit(1)
 		}
 	}
+
+	if feature.Gates.Enabled(feature.MachineRunCommand) {
+		if err := infrav1controllersexp.NewAzureMachineRunCommandReconciler(
+			mgr.GetClient(),
+			mgr.GetEventRecorderFor("azuremachineruncommand-reconciler"),
+			reconcileTimeout,
+			watchFilterValue,
+		).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AzureMachineRunCommand")
+			os.Exit(1)
+		}
+	}
 }
 
 func registerWebhooks(mgr manager.Manager) {
@@ -492,6 +510,11 @@ func registerWebhooks(mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&infrav1exp.AzureMachineRunCommand{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AzureMachineRunCommand")
+		os.Exit(1)
+	}
+
 	// NOTE: AzureManagedCluster is behind AKS feature gate flag; the webhook
 	// is going to prevent creating or updating new objects in case the feature flag is disabled
 	if err := (&infrav1.AzureManagedCluster{}).SetupWebhookWithManager(mgr); err != nil {
@@ -67,6 +67,15 @@ func getCloudConfig(environment azureautorest.Environment) cloud.Configuration {
 
 // GetAuthorizer returns an autorest.Authorizer-compatible object from MSAL.
 func GetAuthorizer(settings auth.EnvironmentSettings) (autorest.Authorizer, error) {
+	cred, err := GetTokenCredential(settings)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenCredentialAuthorizer(cred, settings.Environment.TokenAudience), nil
+}
+
+// GetTokenCredential returns an azcore.TokenCredential for the credentials found in the controller environment.
+func GetTokenCredential(settings auth.EnvironmentSettings) (azcore.TokenCredential, error) {
 	// azidentity uses different envvars for certificate authentication:
 	//  azidentity: AZURE_CLIENT_CERTIFICATE_{PATH,PASSWORD}
 	//  autorest: AZURE_CERTIFICATE_{PATH,PASSWORD}
@@ -83,18 +92,19 @@ func GetAuthorizer(settings auth.EnvironmentSettings) (autorest.Authorizer, erro
 			Cloud: getCloudConfig(settings.Environment),
 		},
 	}
-	cred, err := azidentity.NewDefaultAzureCredential(&options)
-	if err != nil {
-		return nil, err
-	}
+	return azidentity.NewDefaultAzureCredential(&options)
+}
 
+// NewTokenCredentialAuthorizer returns an autorest.Authorizer requesting tokens for the given audience from an
+// azcore.TokenCredential.
+func NewTokenCredentialAuthorizer(cred azcore.TokenCredential, tokenAudience string) autorest.Authorizer {
 	// We must use TokenAudience for StackCloud, otherwise we get an
 	// AADSTS500011 error from the API
-	scope := settings.Environment.TokenAudience
+	scope := tokenAudience
 	if !strings.HasSuffix(scope, "/.default") {
 		scope += "/.default"
 	}
-	return azidext.NewTokenCredentialAdapter(cred, []string{scope}), nil
+	return azidext.NewTokenCredentialAdapter(cred, []string{scope})
 }
 
 // FindParentMachinePool finds the parent MachinePool for the AzureMachinePool.
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cidr allocates non-overlapping CIDR blocks from an address space.
+package cidr
+
+import (
+	"math/big"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// ErrExhausted is returned when no free CIDR block of the requested size is left in the address space.
+var ErrExhausted = errors.New("address space exhausted")
+
+// Allocate returns the first CIDR block with the given prefix length that is contained in one of the pools
+// and does not overlap with any of the used CIDR blocks. Only the pools and used CIDR blocks of the requested
+// IP family are considered.
+func Allocate(pools, used []string, prefixLength int, ipv6 bool) (string, error) {
+	usedNets := make([]*net.IPNet, 0, len(used))
+	for _, cidr := range used {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse used CIDR block %s", cidr)
+		}
+		if isIPv6(ipNet) == ipv6 {
+			usedNets = append(usedNets, ipNet)
+		}
+	}
+
+	for _, pool := range pools {
+		_, poolNet, err := net.ParseCIDR(pool)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse CIDR block %s", pool)
+		}
+		if isIPv6(poolNet) != ipv6 {
+			continue
+		}
+		if allocated := allocateFromPool(poolNet, usedNets, prefixLength); allocated != nil {
+			return allocated.String(), nil
+		}
+	}
+
+	return "", errors.Wrapf(ErrExhausted, "no free /%d CIDR block left in %v", prefixLength, pools)
+}
+
+// allocateFromPool returns the first free block of the given prefix length in the pool, or nil if there is none.
+func allocateFromPool(pool *net.IPNet, used []*net.IPNet, prefixLength int) *net.IPNet {
+	ones, bits := pool.Mask.Size()
+	if prefixLength < ones || prefixLength > bits {
+		return nil
+	}
+
+	start := ipToInt(pool.IP)
+	end := new(big.Int).Add(start, blockSize(ones, bits))
+	size := blockSize(prefixLength, bits)
+
+	for candidate := start; candidate.Cmp(end) < 0; {
+		block := &net.IPNet{IP: intToIP(candidate, bits), Mask: net.CIDRMask(prefixLength, bits)}
+		overlap := firstOverlap(block, used)
+		if overlap == nil {
+			return block
+		}
+		// Skip past the overlapping block, staying aligned on the requested block size.
+		overlapOnes, _ := overlap.Mask.Size()
+		next := new(big.Int).Add(ipToInt(overlap.IP), blockSize(overlapOnes, bits))
+		if next.Cmp(candidate) <= 0 {
+			next = new(big.Int).Add(candidate, size)
+		}
+		candidate = alignUp(next, size)
+	}
+	return nil
+}
+
+// firstOverlap returns the first used CIDR block that overlaps with the given block, or nil if there is none.
+func firstOverlap(block *net.IPNet, used []*net.IPNet) *net.IPNet {
+	for _, u := range used {
+		if block.Contains(u.IP) || u.Contains(block.IP) {
+			return u
+		}
+	}
+	return nil
+}
+
+func isIPv6(ipNet *net.IPNet) bool {
+	return ipNet.IP.To4() == nil
+}
+
+func blockSize(prefixLength, bits int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+}
+
+func alignUp(x, size *big.Int) *big.Int {
+	remainder := new(big.Int).Mod(x, size)
+	if remainder.Sign() == 0 {
+		return x
+	}
+	return new(big.Int).Add(new(big.Int).Sub(x, remainder), size)
+}
+
+func ipToInt(ip net.IP) *big.Int {
+	if v4 := ip.To4(); v4 != nil {
+		return new(big.Int).SetBytes(v4)
+	}
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+func intToIP(x *big.Int, bits int) net.IP {
+	ip := make(net.IP, bits/8)
+	x.FillBytes(ip)
+	return ip
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidr
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name         string
+		pools        []string
+		used         []string
+		prefixLength int
+		ipv6         bool
+		want         string
+		wantErr      error
+	}{
+		{
+			name:         "empty address space",
+			pools:        []string{"10.0.0.0/8"},
+			prefixLength: 16,
+			want:         "10.0.0.0/16",
+		},
+		{
+			name:         "skips used blocks",
+			pools:        []string{"10.0.0.0/8"},
+			used:         []string{"10.0.0.0/16", "10.1.0.0/16"},
+			prefixLength: 16,
+			want:         "10.2.0.0/16",
+		},
+		{
+			name:         "fills gaps between used blocks",
+			pools:        []string{"10.0.0.0/16"},
+			used:         []string{"10.0.0.0/24", "10.0.2.0/24"},
+			prefixLength: 24,
+			want:         "10.0.1.0/24",
+		},
+		{
+			name:         "stays aligned after a smaller used block",
+			pools:        []string{"10.0.0.0/16"},
+			used:         []string{"10.0.0.0/28"},
+			prefixLength: 24,
+			want:         "10.0.1.0/24",
+		},
+		{
+			name:         "skips a larger used block",
+			pools:        []string{"10.0.0.0/8"},
+			used:         []string{"10.0.0.0/9"},
+			prefixLength: 24,
+			want:         "10.128.0.0/24",
+		},
+		{
+			name:         "moves on to the next pool",
+			pools:        []string{"10.0.0.0/24", "172.16.0.0/16"},
+			used:         []string{"10.0.0.0/24"},
+			prefixLength: 24,
+			want:         "172.16.0.0/24",
+		},
+		{
+			name:         "ignores pools smaller than the requested block",
+			pools:        []string{"10.0.0.0/24", "172.16.0.0/16"},
+			prefixLength: 20,
+			want:         "172.16.0.0/20",
+		},
+		{
+			name:         "ignores pools and used blocks of the other IP family",
+			pools:        []string{"2001:1234:5678:9a00::/56", "10.0.0.0/16"},
+			used:         []string{"2001:1234:5678:9a00::/64"},
+			prefixLength: 24,
+			want:         "10.0.0.0/24",
+		},
+		{
+			name:         "IPv6",
+			pools:        []string{"10.0.0.0/16", "2001:1234:5678:9a00::/56"},
+			used:         []string{"10.0.0.0/24", "2001:1234:5678:9a00::/64"},
+			prefixLength: 64,
+			ipv6:         true,
+			want:         "2001:1234:5678:9a01::/64",
+		},
+		{
+			name:         "exhausted",
+			pools:        []string{"10.0.0.0/23"},
+			used:         []string{"10.0.0.0/24", "10.0.1.0/25"},
+			prefixLength: 24,
+			wantErr:      ErrExhausted,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			got, err := Allocate(tc.pools, tc.used, tc.prefixLength, tc.ipv6)
+			if tc.wantErr != nil {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(got).To(Equal(tc.want))
+			}
+		})
+	}
+}